@@ -2,14 +2,17 @@ package cli
 
 import (
 	"context"
+	"os"
 
 	"github.com/kuzxnia/loadbot/lbot"
 	"github.com/kuzxnia/loadbot/lbot/agent"
 	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
 )
 
 func StartAgent(
 	context context.Context, config *lbot.AgentRequest, watchConfigFile bool, stdin bool, configFile string,
+	ephemeralMongoImage string,
 ) (err error) {
 	var requestConfig *lbot.ConfigRequest
 
@@ -55,12 +58,109 @@ func StartAgent(
 	if lo.IsNotEmpty(config.MetricsExportPort) {
 		requestConfig.Agent.MetricsExportPort = config.MetricsExportPort
 	}
+	if lo.IsNotEmpty(config.MetricsExportTLSCertFile) {
+		requestConfig.Agent.MetricsExportTLSCertFile = config.MetricsExportTLSCertFile
+	}
+	if lo.IsNotEmpty(config.MetricsExportTLSKeyFile) {
+		requestConfig.Agent.MetricsExportTLSKeyFile = config.MetricsExportTLSKeyFile
+	}
+	if lo.IsNotEmpty(config.MetricsExportUsername) {
+		requestConfig.Agent.MetricsExportUsername = config.MetricsExportUsername
+	}
+	if lo.IsNotEmpty(config.MetricsExportPassword) {
+		requestConfig.Agent.MetricsExportPassword = config.MetricsExportPassword
+	}
+	if lo.IsNotEmpty(config.MetricsExportBearerToken) {
+		requestConfig.Agent.MetricsExportBearerToken = config.MetricsExportBearerToken
+	}
+	if config.EstimateAtlasCost {
+		requestConfig.Agent.EstimateAtlasCost = config.EstimateAtlasCost
+	}
+	if config.EventsNdjson {
+		requestConfig.Agent.EventsNdjson = config.EventsNdjson
+	}
+	if lo.IsNotEmpty(config.ArtifactsDir) {
+		requestConfig.Agent.ArtifactsDir = config.ArtifactsDir
+	}
+	if config.MaxCpuPercent > 0 {
+		requestConfig.Agent.MaxCpuPercent = config.MaxCpuPercent
+	}
+	if config.MaxNetworkBytesPerSecond > 0 {
+		requestConfig.Agent.MaxNetworkBytesPerSecond = config.MaxNetworkBytesPerSecond
+	}
+	if len(config.Plugins) > 0 {
+		requestConfig.Agent.Plugins = append(requestConfig.Agent.Plugins, config.Plugins...)
+	}
+	if lo.IsNotEmpty(config.Proxy) {
+		requestConfig.Agent.Proxy = config.Proxy
+	}
+	if lo.IsNotEmpty(config.SshKeyFile) {
+		requestConfig.Agent.SshKeyFile = config.SshKeyFile
+	}
+	if lo.IsNotEmpty(config.SshKnownHostsFile) {
+		requestConfig.Agent.SshKnownHostsFile = config.SshKnownHostsFile
+	}
+	if config.RetentionMaxRuns > 0 {
+		requestConfig.Agent.RetentionMaxRuns = config.RetentionMaxRuns
+	}
+	if config.RetentionMaxDiskBytes > 0 {
+		requestConfig.Agent.RetentionMaxDiskBytes = config.RetentionMaxDiskBytes
+	}
+	if lo.IsNotEmpty(config.RetentionMaxAge) {
+		requestConfig.Agent.RetentionMaxAge = config.RetentionMaxAge
+	}
+	if config.Protected {
+		requestConfig.Agent.Protected = config.Protected
+	}
+	if len(config.TargetAllowlist) > 0 {
+		requestConfig.Agent.TargetAllowlist = append(requestConfig.Agent.TargetAllowlist, config.TargetAllowlist...)
+	}
+	if len(config.TargetDenylist) > 0 {
+		requestConfig.Agent.TargetDenylist = append(requestConfig.Agent.TargetDenylist, config.TargetDenylist...)
+	}
+	if config.ConfirmAboveDocuments > 0 {
+		requestConfig.Agent.ConfirmAboveDocuments = config.ConfirmAboveDocuments
+	}
+	if config.ConfirmAboveWrittenBytes > 0 {
+		requestConfig.Agent.ConfirmAboveWrittenBytes = config.ConfirmAboveWrittenBytes
+	}
+	if config.ConfirmAboveEgressBytes > 0 {
+		requestConfig.Agent.ConfirmAboveEgressBytes = config.ConfirmAboveEgressBytes
+	}
+	if config.RunHistorySize > 0 {
+		requestConfig.Agent.RunHistorySize = config.RunHistorySize
+	}
+	if lo.IsNotEmpty(config.RunHistoryFile) {
+		requestConfig.Agent.RunHistoryFile = config.RunHistoryFile
+	}
+	if len(config.MetricsExportLabels) > 0 {
+		requestConfig.Agent.MetricsExportLabels = lo.Assign(requestConfig.Agent.MetricsExportLabels, config.MetricsExportLabels)
+	}
+
+	if ephemeralMongoImage != "" {
+		ephemeralMongo, err := StartEphemeralMongo(ephemeralMongoImage)
+		if err != nil {
+			return err
+		}
+		defer ephemeralMongo.Stop()
+		requestConfig.ConnectionString = ephemeralMongo.ConnectionString
+	}
 
 	cfg := lbot.NewConfig(requestConfig)
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
 	loadbot, err := lbot.NewLbot(context, cfg)
 	if err != nil {
 		return err
 	}
+	if cfg.Agent.EventsNdjson {
+		// stdout is reserved for events, everything printed or logged to stdout goes to stderr
+		events := os.Stdout
+		os.Stdout = os.Stderr
+		log.SetOutput(os.Stderr)
+		loadbot.SetEventWriter(lbot.NewEventWriter(events))
+	}
 	agent := agent.NewAgent(context, loadbot)
 	if requestConfig != nil {
 		if watchConfigFile {
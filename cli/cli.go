@@ -2,10 +2,14 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"runtime"
 	"time"
 
+	"github.com/fatih/color"
 	"github.com/kuzxnia/loadbot/cli/workload"
 	"github.com/kuzxnia/loadbot/lbot"
+	"github.com/kuzxnia/loadbot/lbot/config"
 	"github.com/kuzxnia/loadbot/lbot/proto"
 	"github.com/kuzxnia/loadbot/lbot/resourcemanager"
 	log "github.com/sirupsen/logrus"
@@ -15,6 +19,7 @@ import (
 
 func New(version string, commit string, date string) *cobra.Command {
 	cobra.EnableCommandSorting = false
+	lbot.Version = version
 	cmd := cobra.Command{
 		Use:     "loadbot",
 		Short:   "A command-line database workload driver ",
@@ -27,11 +32,18 @@ func New(version string, commit string, date string) *cobra.Command {
 	cmd.AddCommand(provideOrchiestrationCommands()...)
 	cmd.AddGroup(&OrchiestrationGroup)
 	cmd.Root().CompletionOptions.HiddenDefaultCmd = true
+	cmd.PersistentFlags().BoolVar(&noColor, NoColor, false, "Disable colored output, also disabled by NO_COLOR or when output isn't terminal")
+	cobra.OnInitialize(func() {
+		if noColor {
+			color.NoColor = true
+		}
+	})
 
 	return &cmd
 }
 
 var (
+	noColor                    bool
 	Conn                       *grpc.ClientConn
 	DefaultProgressInterval, _ = time.ParseDuration("200ms")
 )
@@ -50,19 +62,85 @@ const (
 	CommandProgressWorkload       = "progress"
 	CommandConfigWorkload         = "config"
 	CommandGenerateConfigWorkload = "generate-config"
+	CommandPreviewWorkload        = "preview"
+	CommandBenchGenerator         = "bench-generator"
+	CommandRecordWorkload         = "record"
+	CommandImportAdvisor          = "import-advisor"
+	CommandImportSchema           = "import-schema"
+	CommandExportWorkload         = "export"
+	CommandAlertRules             = "alert-rules"
+	CommandSmoke                  = "smoke"
+	CommandSweep                  = "sweep"
+	CommandResults                = "results"
+	CommandHistory                = "history"
+	CommandCompare                = "compare"
+	CommandAnnotate               = "annotate"
+	CommandAnnotations            = "annotations"
+	CommandApprove                = "approve"
+	CommandApprovals              = "approvals"
+	CommandResetStats             = "reset-stats"
+	CommandLogLevel               = "log-level"
+	CommandTemplate               = "template"
+	CommandTemplatePush           = "push"
+	CommandTemplatePull           = "pull"
 
 	// config args
 	ConfigFile = "config-file"
 	AgentUri   = "agent-uri"
+	Token      = "token"
 	Interval   = "interval"
 	StdIn      = "stdin"
+	Samples     = "samples"
+	Duration    = "duration"
+	Concurrency = "concurrency"
+	Database    = "database"
+	Output      = "output"
+	ConnectionString = "connection-string"
+	Once             = "once"
+	Wait             = "wait"
+	MaxErrorRate     = "max-error-rate"
+	GracePeriod      = "grace-period"
+	Force            = "force"
+	Restart          = "restart"
+	Schedule         = "schedule"
+	Schedules        = "schedules"
+	Yes              = "yes"
+	NoColor          = "no-color"
+	Download         = "download"
+	Mask             = "mask"
+	MaskKey          = "mask-key"
+	Credentials      = "credentials"
+	Job              = "job"
+	Connections      = "connections"
+	AlertErrorRate      = "error-rate"
+	AlertAgentCPU       = "agent-cpu"
+	AlertReplicationLag = "replication-lag"
+	Uri                 = "uri"
+	Timeout             = "timeout"
+	Alpha               = "alpha"
+	MaxSamples          = "max-samples"
+	At                  = "at"
+	Top                 = "top"
+	Window              = "window"
+	Model               = "model"
+	Username            = "username"
+	Password            = "password"
+	PlainHttp           = "plain-http"
+	Limit               = "limit"
+	RunId               = "run"
 )
 
 func provideWorkloadCommands() []*cobra.Command {
 	persistentPreRunE := func(cmd *cobra.Command, args []string) (err error) {
 		f := cmd.Flags()
 		agentUri, _ := f.GetString(AgentUri)
-		Conn, err = grpc.Dial(agentUri, grpc.WithInsecure())
+		token, _ := f.GetString(Token)
+		proxyOptions, err := ProxyDialOptions(f)
+		if err != nil {
+			log.Fatal("Found errors trying to connect to loadbot-agent:", err)
+			return
+		}
+		Conn, err = grpc.Dial(agentUri, append(DialOptions(token), proxyOptions...)...)
 		// valiedate connection
 		if err != nil {
 			log.Fatal("Found errors trying to connect to loadbot-agent:", err)
@@ -85,16 +163,61 @@ func provideWorkloadCommands() []*cobra.Command {
 
 			progress, _ := flags.GetBool("progress")
 			interval, _ := flags.GetDuration(Interval)
+			wait, _ := flags.GetBool(Wait)
+			maxErrorRate, _ := flags.GetFloat64(MaxErrorRate)
+			restart, _ := flags.GetBool(Restart)
+			schedule, _ := flags.GetString(Schedule)
+			at, _ := flags.GetString(At)
+			yes, _ := flags.GetBool(Yes)
+			configFile, _ := flags.GetString(ConfigFile)
 
+			if configFile != "" {
+				if progress || restart || schedule != "" || at != "" {
+					return fmt.Errorf("workload with own config can't be combined with --progress, --restart, --schedule or --at")
+				}
+				content, err := os.ReadFile(configFile)
+				if err != nil {
+					return fmt.Errorf("reading config file %s failed: %w", configFile, err)
+				}
+				// agent estimates footprint of own config and fails unconfirmed start exceeding its thresholds
+				request := proto.StartRequest{Config: string(content), Confirmed: yes}
+				if wait {
+					return workload.StartWorkloadAndWait(Conn, &request, interval, maxErrorRate)
+				}
+				return workload.StartWorkload(Conn, &request)
+			}
+			if err = workload.ConfirmFootprint(Conn, yes); err != nil {
+				return err
+			}
+			if schedule != "" || at != "" {
+				if wait || progress {
+					return fmt.Errorf("scheduled start can't be combined with --wait or --progress")
+				}
+				request := proto.StartRequest{Restart: restart, Schedule: schedule, Confirmed: yes}
+				if at != "" {
+					startAt, err := workload.ParseStartTime(at, time.Now())
+					if err != nil {
+						return err
+					}
+					request.StartAt = startAt.Format(time.RFC3339)
+				}
+				return workload.StartWorkload(Conn, &request)
+			}
+			if wait {
+				return workload.StartWorkloadAndWait(Conn, &proto.StartRequest{Restart: restart, Confirmed: yes}, interval, maxErrorRate)
+			}
 			if progress {
 				request := proto.StartWithProgressRequest{
 					RefreshInterval: interval.String(),
+					Confirmed:       yes,
 				}
 				return workload.StartWorkloadWithProgress(Conn, &request)
 			} else {
 				// todo: switch to local model aka cli.StartRequest
 				request := proto.StartRequest{
-					Watch: false,
+					Watch:     false,
+					Restart:   restart,
+					Confirmed: yes,
 				}
 
 				return workload.StartWorkload(Conn, &request)
@@ -105,8 +228,17 @@ func provideWorkloadCommands() []*cobra.Command {
 	startCommandFlags := startCommand.Flags()
 	startCommandFlags.BoolP("progress", "p", false, "Show progress of stress test")
 	startCommandFlags.DurationP(Interval, "i", DefaultProgressInterval, "Progress refresh interval")
+	startCommandFlags.Bool(Restart, false, "stop active run before starting new one")
+	startCommandFlags.Bool(Wait, false, "block until workload finishes and print summary, exit code is non-zero when assertions fail")
+	startCommandFlags.Float64(MaxErrorRate, -1, "with --wait fail when any job error rate is above this value, negative disables check")
+	startCommandFlags.String(Schedule, "", "cron expression (UTC), agent starts workload at every matching time, ex. \"0 2 * * *\"")
+	startCommandFlags.String(At, "", "agent starts workload once at this time, RFC 3339 or HH:MM (next occurrence, UTC like --schedule)")
+	startCommandFlags.BoolP(Yes, "y", false, "start even if estimated footprint exceeds agent thresholds")
+	startCommandFlags.StringP(ConfigFile, "f", "", "run workload from this config file alongside other runs instead of agent jobs")
 	// todo: add parent command and inherit this flag
 	startCommandFlags.StringP(AgentUri, "u", "127.0.0.1:1234", "loadbot agent uri (default: 127.0.0.1:1234)")
+	startCommandFlags.String(Token, "", "loadbot agent api token")
+	addProxyFlags(startCommandFlags, "agent")
 
 	stopCommand := cobra.Command{
 		Use:               CommandStopWorkload,
@@ -115,15 +247,30 @@ func provideWorkloadCommands() []*cobra.Command {
 		PersistentPreRunE: persistentPreRunE,
 		PersistentPostRun: persistentPostRun,
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			flags := cmd.Flags()
+			gracePeriod, _ := flags.GetDuration(GracePeriod)
+			force, _ := flags.GetBool(Force)
+			schedules, _ := flags.GetBool(Schedules)
+			runId, _ := flags.GetString(RunId)
+
 			// todo: switch to local model aka cli.StartRequest
-			request := proto.StopRequest{}
+			request := proto.StopRequest{Force: force, Schedules: schedules, RunId: runId}
+			if flags.Changed(GracePeriod) {
+				request.GracePeriod = gracePeriod.String()
+			}
 			// response model could have worlkload id?
 
 			return workload.StopWorkload(Conn, &request)
 		},
 	}
 	stopCommandFlags := stopCommand.Flags()
+	stopCommandFlags.Duration(GracePeriod, config.DefaultStopGracePeriod, "time to wait for in-flight operations before cancelling them")
+	stopCommandFlags.Bool(Force, false, "cancel in-flight operations immediately")
+	stopCommandFlags.Bool(Schedules, false, "cancel scheduled starts as well")
+	stopCommandFlags.String(RunId, "", "stop only this run, all active runs by default")
 	stopCommandFlags.StringP(AgentUri, "u", "127.0.0.1:1234", "loadbot agent uri (default: 127.0.0.1:1234)")
+	stopCommandFlags.String(Token, "", "loadbot agent api token")
+	addProxyFlags(stopCommandFlags, "agent")
 
 	watchCommand := cobra.Command{
 		Use:               CommandWatchWorkload,
@@ -132,18 +279,19 @@ func provideWorkloadCommands() []*cobra.Command {
 		PersistentPreRunE: persistentPreRunE,
 		PersistentPostRun: persistentPostRun,
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
-			// building parameters for stop
-			// check for params
+			runId, _ := cmd.Flags().GetString(RunId)
 
 			// todo: switch to local model aka cli.StartRequest
-			request := proto.WatchRequest{}
-			// response model could have worlkload id?
+			request := proto.WatchRequest{RunId: runId}
 
 			return workload.WatchWorkload(Conn, &request)
 		},
 	}
 	watchCommandFlags := watchCommand.Flags()
+	watchCommandFlags.String(RunId, "", "watch only this run, all active runs by default")
 	watchCommandFlags.StringP(AgentUri, "u", "127.0.0.1:1234", "loadbot agent uri (default: 127.0.0.1:1234)")
+	watchCommandFlags.String(Token, "", "loadbot agent api token")
+	addProxyFlags(watchCommandFlags, "agent")
 
 	progressCommand := cobra.Command{
 		Use:               CommandProgressWorkload,
@@ -154,9 +302,16 @@ func provideWorkloadCommands() []*cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			flags := cmd.Flags()
 			interval, _ := flags.GetDuration(Interval)
+			once, _ := flags.GetBool(Once)
+			runId, _ := flags.GetString(RunId)
+
+			if once {
+				return workload.WorkloadProgressSnapshot(Conn, runId)
+			}
 
 			request := proto.ProgressRequest{
 				RefreshInterval: interval.String(),
+				RunId:           runId,
 			}
 
 			return workload.WorkloadProgress(Conn, &request)
@@ -164,7 +319,11 @@ func provideWorkloadCommands() []*cobra.Command {
 	}
 	progressCommandFlags := progressCommand.Flags()
 	progressCommandFlags.DurationP(Interval, "i", DefaultProgressInterval, "Progress refresh interval")
+	progressCommandFlags.Bool(Once, false, "print single json snapshot of all jobs and exit")
+	progressCommandFlags.String(RunId, "", "show only jobs of this run, all runs by default")
 	progressCommandFlags.StringP(AgentUri, "u", "127.0.0.1:1234", "loadbot agent uri (default: 127.0.0.1:1234)")
+	progressCommandFlags.String(Token, "", "loadbot agent api token")
+	addProxyFlags(progressCommandFlags, "agent")
 
 	configCommand := cobra.Command{
 		Use:               CommandConfigWorkload,
@@ -193,6 +352,8 @@ func provideWorkloadCommands() []*cobra.Command {
 	configCommandFlags.StringP(ConfigFile, "f", "", "file with workload configuration")
 	configCommandFlags.Bool(StdIn, false, "get workload configuration from stdin")
 	configCommandFlags.StringP(AgentUri, "u", "127.0.0.1:1234", "loadbot agent uri (default: 127.0.0.1:1234)")
+	configCommandFlags.String(Token, "", "loadbot agent api token")
+	addProxyFlags(configCommandFlags, "agent")
 
 	generateConfigCommand := cobra.Command{
 		Use:   CommandGenerateConfigWorkload,
@@ -202,7 +363,476 @@ func provideWorkloadCommands() []*cobra.Command {
 		},
 	}
 
-	return []*cobra.Command{&startCommand, &stopCommand, &configCommand, &generateConfigCommand, &progressCommand}
+	previewCommand := cobra.Command{
+		Use:     CommandPreviewWorkload,
+		Short:   "Print sample generated documents, filters and updates without touching database",
+		GroupID: WorkloadGroup.ID,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			flags := cmd.Flags()
+			configFile, _ := flags.GetString(ConfigFile)
+			stdin, _ := flags.GetBool(StdIn)
+			samples, _ := flags.GetInt(Samples)
+
+			if configFile == "" && stdin == false {
+				return fmt.Errorf("config file or stdin is required")
+			}
+
+			config, err := ParseConfigFile(configFile, stdin)
+			if err != nil {
+				return err
+			}
+
+			return workload.PreviewWorkload(config, samples)
+		},
+	}
+	previewCommandFlags := previewCommand.Flags()
+	previewCommandFlags.StringP(ConfigFile, "f", "", "file with workload configuration")
+	previewCommandFlags.Bool(StdIn, false, "get workload configuration from stdin")
+	previewCommandFlags.IntP(Samples, "n", 3, "number of samples generated per job")
+
+	benchGeneratorCommand := cobra.Command{
+		Use:     CommandBenchGenerator,
+		Short:   "Measure data generator throughput on this machine",
+		GroupID: WorkloadGroup.ID,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			flags := cmd.Flags()
+			configFile, _ := flags.GetString(ConfigFile)
+			stdin, _ := flags.GetBool(StdIn)
+			duration, _ := flags.GetDuration(Duration)
+			concurrency, _ := flags.GetInt(Concurrency)
+
+			if configFile == "" && stdin == false {
+				return fmt.Errorf("config file or stdin is required")
+			}
+
+			config, err := ParseConfigFile(configFile, stdin)
+			if err != nil {
+				return err
+			}
+
+			return workload.BenchGenerator(config, duration, concurrency)
+		},
+	}
+	benchGeneratorCommandFlags := benchGeneratorCommand.Flags()
+	benchGeneratorCommandFlags.StringP(ConfigFile, "f", "", "file with workload configuration")
+	benchGeneratorCommandFlags.Bool(StdIn, false, "get workload configuration from stdin")
+	benchGeneratorCommandFlags.DurationP(Duration, "d", 3*time.Second, "duration of single measurement")
+	benchGeneratorCommandFlags.IntP(Concurrency, "c", runtime.NumCPU(), "number of generating goroutines")
+
+	exportCommand := cobra.Command{
+		Use:               CommandExportWorkload,
+		Short:             "Export effective config, schemas and run history as tar.gz bundle",
+		GroupID:           WorkloadGroup.ID,
+		PersistentPreRunE: persistentPreRunE,
+		PersistentPostRun: persistentPostRun,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			output, _ := cmd.Flags().GetString(Output)
+			credentials, _ := cmd.Flags().GetBool(Credentials)
+
+			return workload.ExportWorkload(Conn, output, credentials)
+		},
+	}
+	exportCommandFlags := exportCommand.Flags()
+	exportCommandFlags.StringP(Output, "o", "loadbot-workload.tar.gz", "bundle file")
+	exportCommandFlags.Bool(Credentials, false, "keep user and password of connection strings in exported config")
+	exportCommandFlags.StringP(AgentUri, "u", "127.0.0.1:1234", "loadbot agent uri (default: 127.0.0.1:1234)")
+	exportCommandFlags.String(Token, "", "loadbot agent api token")
+	addProxyFlags(exportCommandFlags, "agent")
+
+	alertRulesCommand := cobra.Command{
+		Use:     CommandAlertRules,
+		Short:   "Print prometheus alerting rules for unattended runs",
+		GroupID: WorkloadGroup.ID,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			flags := cmd.Flags()
+			output, _ := flags.GetString(Output)
+			errorRate, _ := flags.GetFloat64(AlertErrorRate)
+			agentCPU, _ := flags.GetFloat64(AlertAgentCPU)
+			replicationLag, _ := flags.GetDuration(AlertReplicationLag)
+
+			return workload.PrintAlertRules(output, errorRate, agentCPU, replicationLag)
+		},
+	}
+	alertRulesCommandFlags := alertRulesCommand.Flags()
+	alertRulesCommandFlags.StringP(Output, "o", "", "rules file, printed to stdout if not set")
+	alertRulesCommandFlags.Float64(AlertErrorRate, 0.05, "error rate alert threshold")
+	alertRulesCommandFlags.Float64(AlertAgentCPU, 0.9, "agent cpu usage alert threshold, fraction of GOMAXPROCS")
+	alertRulesCommandFlags.Duration(AlertReplicationLag, 10*time.Second, "replication lag alert threshold, requires mongodb_exporter")
+
+	smokeCommand := cobra.Command{
+		Use:     CommandSmoke,
+		Short:   "Run write/read/update/delete cycle to validate connectivity and permissions",
+		GroupID: WorkloadGroup.ID,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			flags := cmd.Flags()
+			uri, _ := flags.GetString(Uri)
+			database, _ := flags.GetString(Database)
+			timeout, _ := flags.GetDuration(Timeout)
+
+			if uri == "" {
+				return fmt.Errorf("uri is required")
+			}
+
+			return workload.Smoke(uri, database, timeout)
+		},
+	}
+	smokeCommandFlags := smokeCommand.Flags()
+	smokeCommandFlags.String(Uri, "", "connection string to tested cluster")
+	smokeCommandFlags.String(Database, "loadbot_smoke", "database where temporary collection is created")
+	smokeCommandFlags.Duration(Timeout, 30*time.Second, "timeout of whole smoke test")
+
+	recordCommand := cobra.Command{
+		Use:     CommandRecordWorkload,
+		Short:   "Record application traffic and generate workload config approximating it",
+		GroupID: WorkloadGroup.ID,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			flags := cmd.Flags()
+			connectionString, _ := flags.GetString(ConnectionString)
+			database, _ := flags.GetString(Database)
+			duration, _ := flags.GetDuration(Duration)
+			output, _ := flags.GetString(Output)
+			maskRules, _ := flags.GetStringArray(Mask)
+			maskKey, _ := flags.GetString(MaskKey)
+
+			if connectionString == "" || database == "" {
+				return fmt.Errorf("connection string and database are required")
+			}
+			masker, err := workload.ParseMaskRules(maskRules, maskKey)
+			if err != nil {
+				return err
+			}
+
+			return workload.RecordWorkload(connectionString, database, duration, output, masker)
+		},
+	}
+	recordCommandFlags := recordCommand.Flags()
+	recordCommandFlags.StringP(ConnectionString, "c", "", "connection string to recorded database")
+	recordCommandFlags.String(Database, "", "recorded database, profiler is enabled on it while recording")
+	recordCommandFlags.DurationP(Duration, "d", time.Minute, "recording duration")
+	recordCommandFlags.StringP(Output, "o", "", "file where generated config is saved, printed to stdout if not set")
+	recordCommandFlags.StringArray(Mask, nil, "masking rule field=keep|hash|truncate:<length>|drop|#<generator>, ex. --mask email=#email --mask ssn=hash")
+	recordCommandFlags.String(MaskKey, "", "key of hash masking rule, keep it secret to make hashes comparable between recordings, random if not set")
+
+	importAdvisorCommand := cobra.Command{
+		Use:     CommandImportAdvisor + " <report.json>",
+		Short:   "Generate workload config from Atlas Performance Advisor query shapes or slow query logs",
+		GroupID: WorkloadGroup.ID,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			flags := cmd.Flags()
+			connectionString, _ := flags.GetString(ConnectionString)
+			database, _ := flags.GetString(Database)
+			top, _ := flags.GetInt(Top)
+			window, _ := flags.GetDuration(Window)
+			duration, _ := flags.GetDuration(Duration)
+			output, _ := flags.GetString(Output)
+			maskRules, _ := flags.GetStringArray(Mask)
+			maskKey, _ := flags.GetString(MaskKey)
+
+			if connectionString == "" || database == "" {
+				return fmt.Errorf("connection string and database are required")
+			}
+			if top <= 0 {
+				return fmt.Errorf("top must be greater than 0")
+			}
+			if window <= 0 {
+				return fmt.Errorf("window must be greater than 0")
+			}
+			masker, err := workload.ParseMaskRules(maskRules, maskKey)
+			if err != nil {
+				return err
+			}
+
+			return workload.ImportAdvisorReport(args[0], connectionString, database, top, window, duration, output, masker)
+		},
+	}
+	importAdvisorCommandFlags := importAdvisorCommand.Flags()
+	importAdvisorCommandFlags.StringP(ConnectionString, "c", "", "connection string to test cluster")
+	importAdvisorCommandFlags.String(Database, "", "database of reproduced queries, queries of other databases are skipped")
+	importAdvisorCommandFlags.Int(Top, workload.DefaultAdvisorTop, "number of most frequent query shapes reproduced")
+	importAdvisorCommandFlags.Duration(Window, workload.DefaultAdvisorWindow, "period covered by report, observed counts are spread over it")
+	importAdvisorCommandFlags.DurationP(Duration, "d", 10*time.Minute, "duration of generated jobs")
+	importAdvisorCommandFlags.StringP(Output, "o", "", "file where generated config is saved, printed to stdout if not set")
+	importAdvisorCommandFlags.StringArray(Mask, nil, "masking rule field=keep|hash|truncate:<length>|drop|#<generator>, ex. --mask email=#email --mask ssn=hash")
+	importAdvisorCommandFlags.String(MaskKey, "", "key of hash masking rule, keep it secret to make hashes comparable between recordings, random if not set")
+
+	importSchemaCommand := cobra.Command{
+		Use:     CommandImportSchema + " <schema.json>",
+		Short:   "Generate schemas and write jobs from JSON Schema, MongoDB validator or OpenAPI models",
+		GroupID: WorkloadGroup.ID,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			flags := cmd.Flags()
+			connectionString, _ := flags.GetString(ConnectionString)
+			database, _ := flags.GetString(Database)
+			models, _ := flags.GetStringArray(Model)
+			duration, _ := flags.GetDuration(Duration)
+			output, _ := flags.GetString(Output)
+
+			if database == "" {
+				return fmt.Errorf("database is required")
+			}
+
+			return workload.ImportJsonSchema(args[0], models, connectionString, database, duration, output)
+		},
+	}
+	importSchemaCommandFlags := importSchemaCommand.Flags()
+	importSchemaCommandFlags.StringP(ConnectionString, "c", "", "connection string to test cluster")
+	importSchemaCommandFlags.String(Database, "", "database of generated schemas")
+	importSchemaCommandFlags.StringArray(Model, nil, "imported model, ex. --model Order --model Customer, all OpenAPI components (or whole document) if not set")
+	importSchemaCommandFlags.DurationP(Duration, "d", 10*time.Minute, "duration of generated jobs")
+	importSchemaCommandFlags.StringP(Output, "o", "", "file where generated config is saved, printed to stdout if not set")
+
+	sweepCommand := cobra.Command{
+		Use:               CommandSweep,
+		Short:             "Run job at increasing connection counts and save throughput-vs-concurrency curve",
+		GroupID:           WorkloadGroup.ID,
+		PersistentPreRunE: persistentPreRunE,
+		PersistentPostRun: persistentPostRun,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			flags := cmd.Flags()
+			configFile, _ := flags.GetString(ConfigFile)
+			stdin, _ := flags.GetBool(StdIn)
+			jobName, _ := flags.GetString(Job)
+			connections, _ := flags.GetUintSlice(Connections)
+			interval, _ := flags.GetDuration(Interval)
+			output, _ := flags.GetString(Output)
+
+			config, err := ParseConfigFile(configFile, stdin)
+			if err != nil {
+				return err
+			}
+
+			return workload.SweepConcurrency(Conn, config, jobName, connections, interval, output)
+		},
+	}
+	sweepCommandFlags := sweepCommand.Flags()
+	sweepCommandFlags.StringP(ConfigFile, "f", "", "file with workload configuration")
+	sweepCommandFlags.Bool(StdIn, false, "get workload configuration from stdin")
+	sweepCommandFlags.String(Job, "", "name of swept job, required when config has more jobs")
+	sweepCommandFlags.UintSlice(Connections, workload.DefaultSweepConnections, "connection counts job is run with")
+	sweepCommandFlags.DurationP(Interval, "i", DefaultProgressInterval, "progress polling interval")
+	sweepCommandFlags.StringP(Output, "o", "", "file where sweep result is saved, printed to stdout if not set")
+	sweepCommandFlags.StringP(AgentUri, "u", "127.0.0.1:1234", "loadbot agent uri (default: 127.0.0.1:1234)")
+	sweepCommandFlags.String(Token, "", "loadbot agent api token")
+	addProxyFlags(sweepCommandFlags, "agent")
+
+	resultsCommand := cobra.Command{
+		Use:               CommandResults + " <run-id>",
+		Short:             "List or download artifacts (raw latency logs, job reports) saved by agent for run",
+		GroupID:           WorkloadGroup.ID,
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		PersistentPostRun: persistentPostRun,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			flags := cmd.Flags()
+			download, _ := flags.GetBool(Download)
+			output, _ := flags.GetString(Output)
+
+			return workload.RunResults(Conn, args[0], download, output)
+		},
+	}
+	resultsCommandFlags := resultsCommand.Flags()
+	resultsCommandFlags.Bool(Download, false, "download artifacts instead of listing them")
+	resultsCommandFlags.StringP(Output, "o", "", "directory where artifacts are downloaded, run id if not set")
+	resultsCommandFlags.StringP(AgentUri, "u", "127.0.0.1:1234", "loadbot agent uri (default: 127.0.0.1:1234)")
+	resultsCommandFlags.String(Token, "", "loadbot agent api token")
+	addProxyFlags(resultsCommandFlags, "agent")
+
+	historyCommand := cobra.Command{
+		Use:               CommandHistory + " [run-id]",
+		Short:             "List runs ended on agent or show summary of one of them",
+		GroupID:           WorkloadGroup.ID,
+		Args:              cobra.MaximumNArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		PersistentPostRun: persistentPostRun,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if len(args) == 1 {
+				return workload.RunDetails(Conn, args[0])
+			}
+			limit, _ := cmd.Flags().GetUint32(Limit)
+
+			return workload.RunHistory(Conn, limit)
+		},
+	}
+	historyCommandFlags := historyCommand.Flags()
+	historyCommandFlags.Uint32(Limit, 20, "list only this many newest runs, 0 lists all runs kept by agent")
+	historyCommandFlags.StringP(AgentUri, "u", "127.0.0.1:1234", "loadbot agent uri (default: 127.0.0.1:1234)")
+	historyCommandFlags.String(Token, "", "loadbot agent api token")
+	addProxyFlags(historyCommandFlags, "agent")
+
+	compareCommand := cobra.Command{
+		Use:     CommandCompare + " <baseline-latency.csv> <candidate-latency.csv>",
+		Short:   "Compare latency logs of two runs with significance tests",
+		GroupID: WorkloadGroup.ID,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			flags := cmd.Flags()
+			alpha, _ := flags.GetFloat64(Alpha)
+			maxSamples, _ := flags.GetInt(MaxSamples)
+
+			if alpha <= 0 || alpha >= 1 {
+				return fmt.Errorf("alpha must be between 0 and 1")
+			}
+			if maxSamples <= 0 {
+				return fmt.Errorf("max samples must be greater than 0")
+			}
+
+			return workload.CompareLatencies(args[0], args[1], alpha, maxSamples)
+		},
+	}
+	compareCommandFlags := compareCommand.Flags()
+	compareCommandFlags.Float64(Alpha, workload.DefaultCompareAlpha, "significance level, confidence intervals are 1-alpha")
+	compareCommandFlags.Int(MaxSamples, workload.DefaultCompareMaxSamples, "latencies randomly sampled from every log for statistics")
+
+	annotateCommand := cobra.Command{
+		Use:               CommandAnnotate + " <run-id> <text>",
+		Short:             "Attach note to run, shown in results, job reports and exports",
+		GroupID:           WorkloadGroup.ID,
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: persistentPreRunE,
+		PersistentPostRun: persistentPostRun,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			at, _ := cmd.Flags().GetString(At)
+
+			return workload.AnnotateRun(Conn, args[0], args[1], at)
+		},
+	}
+	annotateCommandFlags := annotateCommand.Flags()
+	annotateCommandFlags.String(At, "", "time note refers to, RFC 3339 or HH:MM of today, now if not set")
+	annotateCommandFlags.StringP(AgentUri, "u", "127.0.0.1:1234", "loadbot agent uri (default: 127.0.0.1:1234)")
+	annotateCommandFlags.String(Token, "", "loadbot agent api token")
+	addProxyFlags(annotateCommandFlags, "agent")
+
+	annotationsCommand := cobra.Command{
+		Use:               CommandAnnotations + " <run-id>",
+		Short:             "List notes attached to run",
+		GroupID:           WorkloadGroup.ID,
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		PersistentPostRun: persistentPostRun,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			return workload.ListRunAnnotations(Conn, args[0])
+		},
+	}
+	annotationsCommandFlags := annotationsCommand.Flags()
+	annotationsCommandFlags.StringP(AgentUri, "u", "127.0.0.1:1234", "loadbot agent uri (default: 127.0.0.1:1234)")
+	annotationsCommandFlags.String(Token, "", "loadbot agent api token")
+	addProxyFlags(annotationsCommandFlags, "agent")
+
+	approveCommand := cobra.Command{
+		Use:               CommandApprove + " <approval-id>",
+		Short:             "Approve start queued on protected agent, requires another token than start",
+		GroupID:           WorkloadGroup.ID,
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		PersistentPostRun: persistentPostRun,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			return workload.ApproveStart(Conn, args[0])
+		},
+	}
+	approveCommandFlags := approveCommand.Flags()
+	approveCommandFlags.StringP(AgentUri, "u", "127.0.0.1:1234", "loadbot agent uri (default: 127.0.0.1:1234)")
+	approveCommandFlags.String(Token, "", "loadbot agent api token")
+	addProxyFlags(approveCommandFlags, "agent")
+
+	approvalsCommand := cobra.Command{
+		Use:               CommandApprovals,
+		Short:             "List starts of protected agent waiting for approval",
+		GroupID:           WorkloadGroup.ID,
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: persistentPreRunE,
+		PersistentPostRun: persistentPostRun,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			return workload.ListApprovals(Conn)
+		},
+	}
+	approvalsCommandFlags := approvalsCommand.Flags()
+	approvalsCommandFlags.StringP(AgentUri, "u", "127.0.0.1:1234", "loadbot agent uri (default: 127.0.0.1:1234)")
+	approvalsCommandFlags.String(Token, "", "loadbot agent api token")
+	addProxyFlags(approvalsCommandFlags, "agent")
+
+	resetStatsCommand := cobra.Command{
+		Use:               CommandResetStats,
+		Short:             "Zero counters of running workload, starting new measurement window",
+		GroupID:           WorkloadGroup.ID,
+		PersistentPreRunE: persistentPreRunE,
+		PersistentPostRun: persistentPostRun,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			jobName, _ := cmd.Flags().GetString(Job)
+
+			return workload.ResetStats(Conn, jobName)
+		},
+	}
+	resetStatsCommandFlags := resetStatsCommand.Flags()
+	resetStatsCommandFlags.String(Job, "", "name of job to reset, all running jobs if not set")
+	resetStatsCommandFlags.StringP(AgentUri, "u", "127.0.0.1:1234", "loadbot agent uri (default: 127.0.0.1:1234)")
+	resetStatsCommandFlags.String(Token, "", "loadbot agent api token")
+	addProxyFlags(resetStatsCommandFlags, "agent")
+
+	logLevelCommand := cobra.Command{
+		Use:               CommandLogLevel + " [trace|debug|info|warn|error]",
+		Short:             "Change logging verbosity of running agent, prints current level without argument",
+		GroupID:           AgentGroup.ID,
+		Args:              cobra.MaximumNArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		PersistentPostRun: persistentPostRun,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			level := ""
+			if len(args) > 0 {
+				level = args[0]
+			}
+
+			return workload.SetLogLevel(Conn, level)
+		},
+	}
+	logLevelCommandFlags := logLevelCommand.Flags()
+	logLevelCommandFlags.StringP(AgentUri, "u", "127.0.0.1:1234", "loadbot agent uri (default: 127.0.0.1:1234)")
+	logLevelCommandFlags.String(Token, "", "loadbot agent api token")
+	addProxyFlags(logLevelCommandFlags, "agent")
+
+	templateCommand := cobra.Command{
+		Use:     CommandTemplate,
+		Short:   "Share workload configs as templates in OCI registry or git repository",
+		GroupID: WorkloadGroup.ID,
+	}
+	templatePushCommand := cobra.Command{
+		Use:   CommandTemplatePush + " <oci://registry/repository:tag|git+repository-url#path>",
+		Short: "Publish workload config as template",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			configFile, _ := cmd.Flags().GetString(ConfigFile)
+			if configFile == "" {
+				return fmt.Errorf("config file is required")
+			}
+
+			return workload.PushTemplateFile(args[0], configFile, templateOptions(cmd.Flags()))
+		},
+	}
+	templatePushCommandFlags := templatePushCommand.Flags()
+	templatePushCommandFlags.StringP(ConfigFile, "f", "", "file with workload configuration")
+	addTemplateFlags(templatePushCommandFlags)
+	templatePullCommand := cobra.Command{
+		Use:   CommandTemplatePull + " <oci://registry/repository:tag|git+repository-url#path>",
+		Short: "Fetch template as workload config recording its digest",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			output, _ := cmd.Flags().GetString(Output)
+
+			return workload.PullTemplateFile(args[0], output, templateOptions(cmd.Flags()))
+		},
+	}
+	templatePullCommandFlags := templatePullCommand.Flags()
+	templatePullCommandFlags.StringP(Output, "o", "", "file where config is saved, printed to stdout if not set")
+	addTemplateFlags(templatePullCommandFlags)
+	templateCommand.AddCommand(&templatePushCommand, &templatePullCommand)
+
+	return []*cobra.Command{
+		&startCommand, &stopCommand, &configCommand, &generateConfigCommand, &previewCommand, &benchGeneratorCommand,
+		&smokeCommand, &recordCommand, &importAdvisorCommand, &importSchemaCommand, &exportCommand, &alertRulesCommand, &progressCommand, &watchCommand, &sweepCommand,
+		&resultsCommand, &historyCommand, &compareCommand, &annotateCommand, &annotationsCommand, &approveCommand, &approvalsCommand,
+		&resetStatsCommand, &logLevelCommand, &templateCommand,
+	}
 }
 
 var AgentGroup = cobra.Group{
@@ -220,6 +850,34 @@ const (
 	MetricsExportUrl             = "metrics_export_url"
 	MetricsExportIntervalSeconds = "metrics_export_interval_seconds"
 	MetricsExportPort            = "metrics_export_port"
+	MetricsExportTLSCertFile     = "metrics_export_tls_cert_file"
+	MetricsExportTLSKeyFile      = "metrics_export_tls_key_file"
+	MetricsExportUsername        = "metrics_export_username"
+	MetricsExportPassword        = "metrics_export_password"
+	MetricsExportBearerToken     = "metrics_export_bearer_token"
+	MetricsExportLabels          = "metrics_export_labels"
+	EstimateAtlasCost            = "estimate_atlas_cost"
+	EventsNdjson                 = "events-ndjson"
+	ArtifactsDir                 = "artifacts-dir"
+	MaxCpuPercent                = "max-cpu-percent"
+	MaxNetworkBytesPerSecond     = "max-network-bytes-per-second"
+	Plugin                       = "plugin"
+	Proxy                        = "proxy"
+	SshKeyFile                   = "ssh-key-file"
+	SshKnownHostsFile            = "ssh-known-hosts-file"
+	RetentionMaxRuns             = "retention-max-runs"
+	RetentionMaxDiskBytes        = "retention-max-disk-bytes"
+	RetentionMaxAge              = "retention-max-age"
+	Protected                    = "protected"
+	TargetAllowlist              = "target-allowlist"
+	TargetDenylist               = "target-denylist"
+	ConfirmAboveDocuments        = "confirm-above-documents"
+	ConfirmAboveWrittenBytes     = "confirm-above-written-bytes"
+	ConfirmAboveEgressBytes      = "confirm-above-egress-bytes"
+	RunHistorySize               = "run-history-size"
+	RunHistoryFile               = "run-history-file"
+	WithEphemeralMongo           = "with-ephemeral-mongo"
+	EphemeralMongoImage          = "ephemeral-mongo-image"
 )
 
 func provideAgentCommand() *cobra.Command {
@@ -236,6 +894,32 @@ func provideAgentCommand() *cobra.Command {
 			metricsExportUrl, _ := flags.GetString(MetricsExportUrl)
 			metricsExportIntervalSeconds, _ := flags.GetUint64(MetricsExportIntervalSeconds)
 			metricsExportPort, _ := flags.GetString(MetricsExportPort)
+			metricsExportTLSCertFile, _ := flags.GetString(MetricsExportTLSCertFile)
+			metricsExportTLSKeyFile, _ := flags.GetString(MetricsExportTLSKeyFile)
+			metricsExportUsername, _ := flags.GetString(MetricsExportUsername)
+			metricsExportPassword, _ := flags.GetString(MetricsExportPassword)
+			metricsExportBearerToken, _ := flags.GetString(MetricsExportBearerToken)
+			metricsExportLabels, _ := flags.GetStringToString(MetricsExportLabels)
+			estimateAtlasCost, _ := flags.GetBool(EstimateAtlasCost)
+			eventsNdjson, _ := flags.GetBool(EventsNdjson)
+			artifactsDir, _ := flags.GetString(ArtifactsDir)
+			maxCpuPercent, _ := flags.GetFloat64(MaxCpuPercent)
+			maxNetworkBytesPerSecond, _ := flags.GetUint64(MaxNetworkBytesPerSecond)
+			plugins, _ := flags.GetStringSlice(Plugin)
+			proxy, _ := flags.GetString(Proxy)
+			sshKeyFile, _ := flags.GetString(SshKeyFile)
+			sshKnownHostsFile, _ := flags.GetString(SshKnownHostsFile)
+			retentionMaxRuns, _ := flags.GetUint64(RetentionMaxRuns)
+			retentionMaxDiskBytes, _ := flags.GetUint64(RetentionMaxDiskBytes)
+			retentionMaxAge, _ := flags.GetString(RetentionMaxAge)
+			protected, _ := flags.GetBool(Protected)
+			targetAllowlist, _ := flags.GetStringSlice(TargetAllowlist)
+			targetDenylist, _ := flags.GetStringSlice(TargetDenylist)
+			confirmAboveDocuments, _ := flags.GetUint64(ConfirmAboveDocuments)
+			confirmAboveWrittenBytes, _ := flags.GetUint64(ConfirmAboveWrittenBytes)
+			confirmAboveEgressBytes, _ := flags.GetUint64(ConfirmAboveEgressBytes)
+			runHistorySize, _ := flags.GetUint64(RunHistorySize)
+			runHistoryFile, _ := flags.GetString(RunHistoryFile)
 
 			agentConfig := &lbot.AgentRequest{
 				Name:                         name,
@@ -243,13 +927,44 @@ func provideAgentCommand() *cobra.Command {
 				MetricsExportUrl:             metricsExportUrl,
 				MetricsExportIntervalSeconds: metricsExportIntervalSeconds,
 				MetricsExportPort:            metricsExportPort,
+				MetricsExportTLSCertFile:     metricsExportTLSCertFile,
+				MetricsExportTLSKeyFile:      metricsExportTLSKeyFile,
+				MetricsExportUsername:        metricsExportUsername,
+				MetricsExportPassword:        metricsExportPassword,
+				MetricsExportBearerToken:     metricsExportBearerToken,
+				MetricsExportLabels:          metricsExportLabels,
+				EstimateAtlasCost:            estimateAtlasCost,
+				EventsNdjson:                 eventsNdjson,
+				ArtifactsDir:                 artifactsDir,
+				MaxCpuPercent:                maxCpuPercent,
+				MaxNetworkBytesPerSecond:     maxNetworkBytesPerSecond,
+				Plugins:                      plugins,
+				Proxy:                        proxy,
+				SshKeyFile:                   sshKeyFile,
+				SshKnownHostsFile:            sshKnownHostsFile,
+				RetentionMaxRuns:             retentionMaxRuns,
+				RetentionMaxDiskBytes:        retentionMaxDiskBytes,
+				RetentionMaxAge:              retentionMaxAge,
+				Protected:                    protected,
+				TargetAllowlist:              targetAllowlist,
+				TargetDenylist:               targetDenylist,
+				ConfirmAboveDocuments:        confirmAboveDocuments,
+				ConfirmAboveWrittenBytes:     confirmAboveWrittenBytes,
+				ConfirmAboveEgressBytes:      confirmAboveEgressBytes,
+				RunHistorySize:               runHistorySize,
+				RunHistoryFile:               runHistoryFile,
 			}
 
 			configFile, _ := flags.GetString(ConfigFile)
 			stdin, _ := flags.GetBool(StdIn)
 
+			ephemeralMongoImage := ""
+			if withEphemeralMongo, _ := flags.GetBool(WithEphemeralMongo); withEphemeralMongo {
+				ephemeralMongoImage, _ = flags.GetString(EphemeralMongoImage)
+			}
+
 			return StartAgent(
-				cmd.Context(), agentConfig, watchConfigFileChanges, stdin, configFile,
+				cmd.Context(), agentConfig, watchConfigFileChanges, stdin, configFile, ephemeralMongoImage,
 			)
 		},
 	}
@@ -263,6 +978,32 @@ func provideAgentCommand() *cobra.Command {
 	flags.String(MetricsExportUrl, "", "Prometheus export url used for pushing metrics")
 	flags.Uint64(MetricsExportIntervalSeconds, 0, "Prometheus export push interval")
 	flags.String(MetricsExportPort, "", "Expose metrics on port instead pushing to prometheus")
+	flags.String(MetricsExportTLSCertFile, "", "TLS certificate file used for serving metrics over https")
+	flags.String(MetricsExportTLSKeyFile, "", "TLS private key file used for serving metrics over https")
+	flags.String(MetricsExportUsername, "", "Basic auth username required for reading metrics")
+	flags.String(MetricsExportPassword, "", "Basic auth password required for reading metrics")
+	flags.String(MetricsExportBearerToken, "", "Bearer token required for reading metrics")
+	flags.StringToString(MetricsExportLabels, nil, "Additional labels/grouping keys added to pushed metrics (ex. run=baseline,cluster=rs0)")
+	flags.Bool(EstimateAtlasCost, false, "Log atlas tier recommendation and approximate cost after every finished job")
+	flags.Bool(EventsNdjson, false, "Emit newline-delimited json events (run started, job stats, errors, run finished) on stdout, other output goes to stderr")
+	flags.String(ArtifactsDir, "", "Save raw latency logs and job reports of every run in this directory, download them with 'results'")
+	flags.Float64(MaxCpuPercent, 0, "Throttle operations when agent CPU usage is above this percent (100 is one core)")
+	flags.Uint64(MaxNetworkBytesPerSecond, 0, "Throttle operations when agent sends more bytes per second to database")
+	flags.StringSlice(Plugin, nil, "Go plugin (.so) registering custom job types, can be repeated")
+	flags.Uint64(RetentionMaxRuns, 0, "Keep only this many newest finished runs in run history and artifacts")
+	flags.Uint64(RetentionMaxDiskBytes, 0, "Prune artifacts of oldest finished runs when artifacts dir grows above this size")
+	flags.String(RetentionMaxAge, "", "Prune finished runs older than this duration from run history and artifacts (ex. 168h)")
+	flags.Bool(Protected, false, "Queue start requests until approved with 'approve' by another api token")
+	flags.StringSlice(TargetAllowlist, nil, "Hosts (globs, ex. *.staging.example.com) configs may point at, other targets are rejected, can be repeated")
+	flags.StringSlice(TargetDenylist, nil, "Hosts (globs, ex. *.prod.example.com) configs can't point at, can be repeated")
+	flags.Uint64(ConfirmAboveDocuments, 0, "Require 'start --yes' when run is estimated to insert more documents")
+	flags.Uint64(ConfirmAboveWrittenBytes, 0, "Require 'start --yes' when run is estimated to write more bytes")
+	flags.Uint64(ConfirmAboveEgressBytes, 0, "Require 'start --yes' when run is estimated to send more bytes to database")
+	flags.Uint64(RunHistorySize, 0, "Keep summaries of this many newest ended runs, list them with 'history' (default 100)")
+	flags.String(RunHistoryFile, "", "Save run summaries in this file, so history survives agent restart")
+	addProxyFlags(flags, "database")
+	flags.Bool(WithEphemeralMongo, false, "Start disposable mongo container (requires docker) and use it instead of connection string")
+	flags.String(EphemeralMongoImage, DefaultEphemeralMongoImage, "Docker image used for ephemeral mongo")
 
 	return &startAgentCommand
 }
@@ -272,6 +1013,8 @@ const (
 	CommandUpgrade   = "upgrade"
 	CommandUnInstall = "uninstall"
 	CommandList      = "list"
+	CommandFleet     = "fleet"
+	CommandStatus    = "status"
 
 	// if not set will install localy without k8s
 	FlagSourceKubeconfig = "k8s-config"
@@ -459,6 +1202,9 @@ func provideOrchiestrationCommands() []*cobra.Command {
 				ResourceManagerConfig: rsm,
 			}
 
+			if connectionString, _ := flags.GetString(ConnectionString); connectionString != "" {
+				return ListAgents(connectionString)
+			}
 			return ListResources(&request)
 		},
 	}
@@ -468,8 +1214,26 @@ func provideOrchiestrationCommands() []*cobra.Command {
 	lflags.StringP(FlagSourceContext, "c", "", "context in the kubeconfig file of the source PVC")
 	lflags.StringP(FlagSourceNamespace, "n", "", "namespace of the source PVC")
 	lflags.DurationP(FlagHelmTimeout, "t", 1*time.Minute, "install/uninstall timeout for helm releases")
+	lflags.String(ConnectionString, "", "list agents registered in database with their liveness instead of helm releases")
+
+	fleetCommand := cobra.Command{
+		Use:     CommandFleet,
+		Short:   "Manage fleet of agents sharing internal database",
+		GroupID: OrchiestrationGroup.ID,
+	}
+	fleetStatusCommand := cobra.Command{
+		Use:   CommandStatus,
+		Short: "Print overview of all registered agents: version, uptime, running jobs and configured cluster",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			connectionString, _ := cmd.Flags().GetString(ConnectionString)
+
+			return FleetStatus(connectionString)
+		},
+	}
+	fleetStatusCommand.Flags().String(ConnectionString, "", "connection string to database where agents are registered")
+	fleetCommand.AddCommand(&fleetStatusCommand)
 
-	return []*cobra.Command{&installationCommand, &unInstallationCommand, &upgradeCommand, &listCommand}
+	return []*cobra.Command{&installationCommand, &unInstallationCommand, &upgradeCommand, &listCommand, &fleetCommand}
 }
 
 func ParseConfigFile(path string, fromStdIn bool) (config *lbot.ConfigRequest, err error) {
@@ -0,0 +1,10 @@
+package cli
+
+import (
+	"github.com/kuzxnia/loadbot/lbot/client"
+	"google.golang.org/grpc"
+)
+
+func DialOptions(token string) []grpc.DialOption {
+	return client.DialOptions(token)
+}
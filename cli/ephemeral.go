@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	DefaultEphemeralMongoImage = "mongo:7"
+	ephemeralMongoStartTimeout = 60 * time.Second
+)
+
+// EphemeralMongo is disposable single node replica set started in docker container,
+// container is removed on stop
+type EphemeralMongo struct {
+	containerId      string
+	ConnectionString string
+}
+
+func StartEphemeralMongo(image string) (*EphemeralMongo, error) {
+	log.Infof("Starting ephemeral mongo from %s image", image)
+	containerId, err := docker("run", "-d", "--rm", "-p", "127.0.0.1::27017", image, "--replSet", "rs0", "--bind_ip_all")
+	if err != nil {
+		return nil, fmt.Errorf("starting ephemeral mongo container failed: %w", err)
+	}
+	mongo := &EphemeralMongo{containerId: containerId}
+
+	// output format: 127.0.0.1:49153
+	address, err := docker("port", containerId, "27017/tcp")
+	if err != nil {
+		mongo.Stop()
+		return nil, fmt.Errorf("reading ephemeral mongo port failed: %w", err)
+	}
+	mongo.ConnectionString = fmt.Sprintf("mongodb://%s/?directConnection=true", strings.Split(address, "\n")[0])
+
+	if err = mongo.initReplicaSet(); err != nil {
+		mongo.Stop()
+		return nil, err
+	}
+	log.Infof("Ephemeral mongo started on %s", mongo.ConnectionString)
+	return mongo, nil
+}
+
+// initReplicaSet waits until mongod accepts connections and initiates replica set,
+// replica set is needed for transactions and change streams
+func (m *EphemeralMongo) initReplicaSet() (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), ephemeralMongoStartTimeout)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(m.ConnectionString))
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(context.Background())
+
+	for {
+		err = client.Database("admin").RunCommand(ctx, bson.D{{Key: "replSetInitiate", Value: bson.D{}}}).Err()
+		if err == nil || strings.Contains(err.Error(), "already initialized") {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("ephemeral mongo replica set initialization failed: %w", err)
+		case <-time.After(time.Second):
+		}
+	}
+
+	for {
+		var status struct {
+			IsWritablePrimary bool `bson:"isWritablePrimary"`
+		}
+		err = client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&status)
+		if err == nil && status.IsWritablePrimary {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("ephemeral mongo did not become primary: %w", ctx.Err())
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+func (m *EphemeralMongo) Stop() error {
+	log.Info("Stopping ephemeral mongo")
+	_, err := docker("stop", m.containerId)
+	return err
+}
+
+// docker runs docker command and returns its stdout, stderr (ex. image pull progress or warnings) is only
+// part of error
+func docker(args ...string) (string, error) {
+	var stderr bytes.Buffer
+	command := exec.Command("docker", args...)
+	command.Stderr = &stderr
+	output, err := command.Output()
+	if err != nil {
+		return "", fmt.Errorf("docker %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
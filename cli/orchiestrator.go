@@ -3,9 +3,15 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/kuzxnia/loadbot/lbot"
+	"github.com/kuzxnia/loadbot/lbot/database"
 	"github.com/kuzxnia/loadbot/lbot/resourcemanager"
+	"github.com/samber/lo"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -68,3 +74,92 @@ func ListResources(request *resourcemanager.ListRequest) (err error) {
 
 	return nil
 }
+
+// ListAgents prints agents registered in internal database of cluster with their liveness,
+// agent is alive when sent heartbeat recently
+func ListAgents(connectionString string) (err error) {
+	client, err := database.NewInternalMongoClient(connectionString)
+	if err != nil {
+		return fmt.Errorf("Connecting to database failed: %w", err)
+	}
+	defer client.Disconnect()
+
+	ct, err := client.ClusterTime()
+	if err != nil {
+		return err
+	}
+	now := ct.Time()
+	agents, err := client.GetAgentStatuses(context.TODO())
+	if err != nil {
+		return err
+	}
+	if len(agents) == 0 {
+		fmt.Println("There are no registered agents")
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "AGENT\tHOST\tSTATUS\tLAST SEEN\tRUNNING JOBS")
+	for _, agent := range agents {
+		fmt.Fprintf(
+			writer, "%s\t%s\t%s\t%s ago\t%d\n",
+			lo.If(agent.Name != "", agent.Name).Else(agent.Id.Hex()),
+			lo.If(agent.Host != "", agent.Host).Else("-"),
+			lo.If(agent.IsAlive(now), "alive").Else("dead"),
+			now.Sub(agent.Heartbeat.Time()).Round(time.Second),
+			agent.RunningWorkers,
+		)
+	}
+	return writer.Flush()
+}
+
+// FleetStatus prints overview of all agents registered in internal database: version, uptime,
+// running jobs and cluster they are configured with
+func FleetStatus(connectionString string) (err error) {
+	client, err := database.NewInternalMongoClient(connectionString)
+	if err != nil {
+		return fmt.Errorf("Connecting to database failed: %w", err)
+	}
+	defer client.Disconnect()
+
+	ct, err := client.ClusterTime()
+	if err != nil {
+		return err
+	}
+	now := ct.Time()
+	agents, err := client.GetAgentStatuses(context.TODO())
+	if err != nil {
+		return err
+	}
+	if len(agents) == 0 {
+		fmt.Println("There are no registered agents")
+		return nil
+	}
+
+	alive, running := 0, 0
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "AGENT\tHOST\tVERSION\tSTATUS\tUPTIME\tLAST SEEN\tCLUSTER\tJOBS")
+	for _, agent := range agents {
+		isAlive := agent.IsAlive(now)
+		if isAlive {
+			alive++
+			running += agent.RunningWorkers
+		}
+		fmt.Fprintf(
+			writer, "%s\t%s\t%s\t%s\t%s\t%s ago\t%s\t%s\n",
+			lo.If(agent.Name != "", agent.Name).Else(agent.Id.Hex()),
+			lo.If(agent.Host != "", agent.Host).Else("-"),
+			lo.If(agent.Version != "", agent.Version).Else("-"),
+			lo.If(isAlive, "alive").Else("dead"),
+			agent.Uptime().Round(time.Second),
+			now.Sub(agent.Heartbeat.Time()).Round(time.Second),
+			lo.If(agent.Cluster != "", agent.Cluster).Else("-"),
+			lo.If(len(agent.Jobs) > 0, strings.Join(agent.Jobs, ",")).Else("-"),
+		)
+	}
+	if err = writer.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("\n%d agents, %d alive, %d running jobs\n", len(agents), alive, running)
+	return nil
+}
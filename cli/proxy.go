@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"context"
+	"net"
+
+	"github.com/kuzxnia/loadbot/lbot/database"
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+)
+
+func addProxyFlags(flags *pflag.FlagSet, target string) {
+	flags.String(Proxy, "", "Reach "+target+" through socks5://[user:password@]host[:port] proxy or ssh://user@host[:port] jump host")
+	flags.String(SshKeyFile, "", "Private key of ssh jump host, ssh agent (SSH_AUTH_SOCK) is used as well")
+	flags.String(SshKnownHostsFile, "", "Known hosts file verifying ssh jump host (default ~/.ssh/known_hosts)")
+}
+
+// ProxyDialOptions routes agent connection through proxy set with flags, no options when proxy is not set
+func ProxyDialOptions(flags *pflag.FlagSet) ([]grpc.DialOption, error) {
+	proxy, _ := flags.GetString(Proxy)
+	sshKeyFile, _ := flags.GetString(SshKeyFile)
+	sshKnownHostsFile, _ := flags.GetString(SshKnownHostsFile)
+	dialer, err := database.NewProxyDialer(proxy, sshKeyFile, sshKnownHostsFile)
+	if err != nil || dialer == nil {
+		return nil, err
+	}
+	return []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "tcp", address)
+		}),
+	}, nil
+}
@@ -0,0 +1,20 @@
+package cli
+
+import (
+	"github.com/kuzxnia/loadbot/cli/workload"
+	"github.com/spf13/pflag"
+)
+
+func addTemplateFlags(flags *pflag.FlagSet) {
+	flags.String(Username, "", "Registry username, docker credentials are used when not set")
+	flags.String(Password, "", "Registry password")
+	flags.Bool(PlainHttp, false, "Use plain http connection to registry")
+}
+
+// templateOptions reads registry access of template commands, git templates use git credentials
+func templateOptions(flags *pflag.FlagSet) workload.TemplateOptions {
+	username, _ := flags.GetString(Username)
+	password, _ := flags.GetString(Password)
+	plainHttp, _ := flags.GetBool(PlainHttp)
+	return workload.TemplateOptions{Username: username, Password: password, PlainHTTP: plainHttp}
+}
@@ -0,0 +1,189 @@
+package workload
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	DefaultAdvisorTop    = 10
+	DefaultAdvisorWindow = 24 * time.Hour
+)
+
+// ImportAdvisorReport generates workload config reproducing top query shapes of Atlas Performance Advisor output
+// at their observed frequencies, counts are spread over window which report covers (24h by default in Atlas).
+// Supported are suggested indexes response (query shapes with counts) and slow query logs response or
+// raw mongod log (JSON lines), queries of other databases are skipped.
+func ImportAdvisorReport(
+	path string, connectionString string, database string, top int, window time.Duration, duration time.Duration, output string, masker *Masker,
+) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	recorder := NewOperationRecorder(masker)
+	if err = ReadAdvisorReport(data, database, recorder); err != nil {
+		return fmt.Errorf("Reading performance advisor report failed: %w", err)
+	}
+	recorder.Keep(top)
+	cfg := recorder.Config(connectionString, database, window)
+	for _, job := range cfg.Jobs {
+		job.Duration = duration
+	}
+	recorder.PrintSummary(window)
+
+	data, err = json.MarshalIndent(cfg, "", "\t")
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		fmt.Println(string(data))
+		return nil
+	}
+	if err = os.WriteFile(output, data, 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Config saved to %s\n", output)
+	return nil
+}
+
+// ReadAdvisorReport records query shapes of database from performance advisor output into recorder
+func ReadAdvisorReport(data []byte, database string, recorder *OperationRecorder) error {
+	data = bytes.TrimSpace(data)
+	var report struct {
+		Shapes      []json.RawMessage `json:"shapes"`
+		SlowQueries []struct {
+			Line      string `json:"line"`
+			Namespace string `json:"namespace"`
+		} `json:"slowQueries"`
+	}
+	// log lines are json objects too, but without shapes and slowQueries fields
+	if json.Unmarshal(data, &report) == nil && (report.Shapes != nil || report.SlowQueries != nil) {
+		for _, raw := range report.Shapes {
+			if err := recordAdvisorShape(raw, database, recorder); err != nil {
+				return err
+			}
+		}
+		for i, query := range report.SlowQueries {
+			if err := recordLogLine([]byte(query.Line), database, recorder); err != nil {
+				return fmt.Errorf("slow query %d: %w", i+1, err)
+			}
+		}
+		return nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if err := recordLogLine(scanner.Bytes(), database, recorder); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// recordAdvisorShape records suggested indexes query shape, ex.
+// {"namespace": "shop.orders", "count": 120, "operations": [{"predicates": [{"find": {"user": "u1"}}]}]}
+func recordAdvisorShape(raw json.RawMessage, database string, recorder *OperationRecorder) error {
+	var shape bson.M
+	if err := bson.UnmarshalExtJSON(raw, false, &shape); err != nil {
+		return fmt.Errorf("invalid query shape: %w", err)
+	}
+	ns, _ := shape["namespace"].(string)
+	operations, _ := shape["operations"].(bson.A)
+	if !inDatabase(ns, database) || len(operations) == 0 {
+		return nil
+	}
+	count := uint64(toFloat(shape["count"]))
+	if count == 0 {
+		return nil
+	}
+	// operations of shape differ only in values, first one represents shape
+	operation, _ := operations[0].(bson.M)
+	predicates, _ := operation["predicates"].(bson.A)
+	for _, predicate := range predicates {
+		predicate, _ := predicate.(bson.M)
+		for kind, filter := range predicate {
+			switch kind {
+			case "find":
+				recorder.RecordCount(bson.M{"ns": ns, "op": "query", "command": bson.M{"filter": filter}}, count)
+			case "update":
+				recorder.RecordCount(bson.M{"ns": ns, "op": "update", "command": bson.M{"q": filter}}, count)
+			case "remove", "delete":
+				recorder.RecordCount(bson.M{"ns": ns, "op": "remove", "command": bson.M{"q": filter}}, count)
+			default:
+				recorder.RecordCount(bson.M{"ns": ns, "op": kind, "command": bson.M{}}, count)
+			}
+		}
+	}
+	return nil
+}
+
+// recordLogLine records command of structured mongod log line (slow query), other log lines are skipped
+func recordLogLine(line []byte, database string, recorder *OperationRecorder) error {
+	if len(bytes.TrimSpace(line)) == 0 {
+		return nil
+	}
+	var entry struct {
+		Attr bson.M `bson:"attr"`
+	}
+	if err := bson.UnmarshalExtJSON(line, false, &entry); err != nil {
+		return fmt.Errorf("invalid log line: %w", err)
+	}
+	ns, _ := entry.Attr["ns"].(string)
+	command, _ := entry.Attr["command"].(bson.M)
+	if command == nil || !inDatabase(ns, database) {
+		return nil
+	}
+	recorder.Record(profileEntry(ns, command))
+	return nil
+}
+
+// profileEntry converts logged command to system.profile like entry, update and delete commands
+// are recorded by their first statement
+func profileEntry(ns string, command bson.M) bson.M {
+	entry := bson.M{"ns": ns, "op": "command", "command": command}
+	switch {
+	case command["find"] != nil:
+		entry["op"] = "query"
+	case command["insert"] != nil:
+		entry["op"] = "insert"
+	case command["update"] != nil && command["updates"] != nil:
+		entry["op"] = "update"
+		if updates, _ := command["updates"].(bson.A); len(updates) > 0 {
+			entry["command"] = updates[0]
+		}
+	case command["delete"] != nil:
+		entry["op"] = "remove"
+		if deletes, _ := command["deletes"].(bson.A); len(deletes) > 0 {
+			entry["command"] = deletes[0]
+		}
+	case command["getMore"] != nil:
+		entry["op"] = "getmore"
+	}
+	return entry
+}
+
+func inDatabase(ns string, database string) bool {
+	db, _, found := strings.Cut(ns, ".")
+	return found && db == database
+}
+
+func toFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
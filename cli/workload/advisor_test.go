@@ -0,0 +1,49 @@
+package workload
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadAdvisorShapes(t *testing.T) {
+	report := []byte(`{"shapes": [
+		{"namespace": "shop.orders", "count": 7200, "operations": [{"predicates": [{"find": {"user": "u1", "total": {"$gt": 10}}}]}]},
+		{"namespace": "shop.users", "count": 3600, "operations": [{"predicates": [{"update": {"email": "a@b.c"}}]}]},
+		{"namespace": "shop.orders", "count": 360, "operations": [{"predicates": [{"find": {"status": "new"}}]}]},
+		{"namespace": "other.orders", "count": 99999, "operations": [{"predicates": [{"find": {"user": "u1"}}]}]}
+	]}`)
+
+	recorder := NewOperationRecorder(nil)
+	assert.NoError(t, ReadAdvisorReport(report, "shop", recorder))
+	recorder.Keep(2)
+	cfg := recorder.Config("mongodb://localhost", "shop", time.Hour)
+
+	assert.Len(t, cfg.Jobs, 2)
+	assert.Equal(t, "read", cfg.Jobs[0].Type)
+	assert.Equal(t, "orders", cfg.Jobs[0].Collection)
+	assert.Equal(t, uint64(2), cfg.Jobs[0].Pace)
+	assert.Equal(t, map[string]interface{}{"user": "#string", "total": map[string]interface{}{"$gt": int32(10)}}, cfg.Jobs[0].Filter)
+	assert.Equal(t, "update", cfg.Jobs[1].Type)
+	assert.Equal(t, uint64(1), cfg.Jobs[1].Pace)
+	assert.Equal(t, 1, recorder.dropped)
+}
+
+func TestReadAdvisorSlowQueryLogs(t *testing.T) {
+	report := []byte(`{"slowQueries": [
+		{"namespace": "shop.orders", "line": "{\"t\":{\"$date\":\"2024-01-01T00:00:00.000Z\"},\"msg\":\"Slow query\",\"attr\":{\"type\":\"command\",\"ns\":\"shop.orders\",\"command\":{\"find\":\"orders\",\"filter\":{\"user\":\"u1\"}}}}"},
+		{"namespace": "shop.orders", "line": "{\"msg\":\"Slow query\",\"attr\":{\"type\":\"command\",\"ns\":\"shop.orders\",\"command\":{\"find\":\"orders\",\"filter\":{\"user\":\"u2\"}}}}"},
+		{"namespace": "shop.orders", "line": "{\"msg\":\"Slow query\",\"attr\":{\"type\":\"command\",\"ns\":\"shop.orders\",\"command\":{\"delete\":\"orders\",\"deletes\":[{\"q\":{\"status\":\"done\"},\"limit\":1}]}}}"}
+	]}`)
+
+	recorder := NewOperationRecorder(nil)
+	assert.NoError(t, ReadAdvisorReport(report, "shop", recorder))
+	cfg := recorder.Config("mongodb://localhost", "shop", time.Second)
+
+	assert.Len(t, cfg.Jobs, 2)
+	assert.Equal(t, "read", cfg.Jobs[0].Type)
+	assert.Equal(t, uint64(2), cfg.Jobs[0].Pace)
+	assert.Equal(t, "delete", cfg.Jobs[1].Type)
+	assert.Equal(t, map[string]interface{}{"status": "#string"}, cfg.Jobs[1].Filter)
+}
@@ -0,0 +1,72 @@
+package workload
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+	"time"
+)
+
+// keep in sync with helm/workload/templates/prometheusrule.yaml
+const alertRulesTemplate = `groups:
+  - name: loadbot
+    rules:
+      - alert: LoadbotErrorRateSpike
+        expr: |
+          sum by (job, run_id, instance) (rate(requests_error[5m]))
+            / sum by (job, run_id, instance) (rate(requests_total[5m])) > {{ .ErrorRate }}
+        for: 2m
+        labels:
+          severity: warning
+        annotations:
+          summary: "Loadbot job {{ "{{ $labels.job }}" }} error rate above {{ .ErrorRate }}"
+      - alert: LoadbotAgentSaturated
+        expr: rate(process_cpu_seconds_total[5m]) / go_gomaxprocs > {{ .AgentCPU }}
+        for: 5m
+        labels:
+          severity: warning
+        annotations:
+          summary: "Loadbot agent {{ "{{ $labels.instance }}" }} is CPU saturated, results may be limited by agent not database"
+      - alert: LoadbotReplicationLagDuringRun
+        expr: |
+          max(mongodb_mongod_replset_member_replication_lag) > {{ .ReplicationLagSeconds }}
+            and on() sum(rate(requests_total[5m])) > 0
+        for: 2m
+        labels:
+          severity: critical
+        annotations:
+          summary: "Replication lag above {{ .ReplicationLag }} while loadbot workload is running"
+`
+
+type alertRulesParams struct {
+	ErrorRate             float64
+	AgentCPU              float64
+	ReplicationLag        time.Duration
+	ReplicationLagSeconds float64
+}
+
+// PrintAlertRules writes prometheus alerting rules for loadbot metrics to output, stdout if empty,
+// replication lag rule requires mongodb_exporter metrics
+func PrintAlertRules(output string, errorRate float64, agentCPU float64, replicationLag time.Duration) (err error) {
+	tmpl := template.Must(template.New("rules").Parse(alertRulesTemplate))
+	params := alertRulesParams{
+		ErrorRate:             errorRate,
+		AgentCPU:              agentCPU,
+		ReplicationLag:        replicationLag,
+		ReplicationLagSeconds: replicationLag.Seconds(),
+	}
+
+	if output == "" {
+		return tmpl.Execute(os.Stdout, params)
+	}
+	file, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err = tmpl.Execute(file, params); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Alert rules saved to %s\n", output)
+	return nil
+}
@@ -0,0 +1,69 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"google.golang.org/grpc"
+)
+
+// AnnotateRun attaches free-form note to stored run, at is RFC 3339 time or HH:MM of today (local time)
+// note refers to, time of call if empty
+func AnnotateRun(conn grpc.ClientConnInterface, runId string, text string, at string) error {
+	request := &proto.AddAnnotationRequest{RunId: runId, Text: text}
+	if at != "" {
+		parsed, err := ParseAnnotationTime(at, time.Now())
+		if err != nil {
+			return err
+		}
+		request.At = parsed.Format(time.RFC3339)
+	}
+
+	client := proto.NewAnnotationServiceClient(conn)
+	annotation, err := client.AddAnnotation(context.TODO(), request)
+	if err != nil {
+		return fmt.Errorf("Annotating run failed: %w", err)
+	}
+	fmt.Printf("📝 Annotated run %s at %s\n", annotation.RunId, annotation.At)
+	return nil
+}
+
+// ListRunAnnotations prints notes attached to run
+func ListRunAnnotations(conn grpc.ClientConnInterface, runId string) error {
+	annotations, err := runAnnotations(conn, runId)
+	if err != nil {
+		return fmt.Errorf("Listing run annotations failed: %w", err)
+	}
+	printAnnotations(annotations)
+	return nil
+}
+
+func runAnnotations(conn grpc.ClientConnInterface, runId string) ([]*proto.Annotation, error) {
+	client := proto.NewAnnotationServiceClient(conn)
+	response, err := client.ListAnnotations(context.TODO(), &proto.ListAnnotationsRequest{RunId: runId})
+	if err != nil {
+		return nil, err
+	}
+	return response.Annotations, nil
+}
+
+func printAnnotations(annotations []*proto.Annotation) {
+	for _, annotation := range annotations {
+		fmt.Printf("%s\t%s\n", annotation.At, annotation.Text)
+	}
+}
+
+// ParseAnnotationTime parses RFC 3339 time or HH:MM / HH:MM:SS clock time of now's day
+func ParseAnnotationTime(value string, now time.Time) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if clock, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, now.Location()), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid annotation time %q, expected RFC 3339 time or HH:MM", value)
+}
@@ -0,0 +1,28 @@
+package workload
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAnnotationTime(t *testing.T) {
+	location := time.FixedZone("CET", 3600)
+	now := time.Date(2024, 5, 2, 18, 0, 0, 0, location)
+
+	parsed, err := ParseAnnotationTime("14:32", now)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 5, 2, 14, 32, 0, 0, location), parsed)
+
+	parsed, err = ParseAnnotationTime("14:32:10", now)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 5, 2, 14, 32, 10, 0, location), parsed)
+
+	parsed, err = ParseAnnotationTime("2024-05-01T10:00:00Z", now)
+	assert.Nil(t, err)
+	assert.True(t, parsed.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)))
+
+	_, err = ParseAnnotationTime("yesterday", now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,50 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ApproveStart starts workload queued on protected agent, token must differ from the one start was requested with
+func ApproveStart(conn grpc.ClientConnInterface, approvalId string) error {
+	client := proto.NewApprovalServiceClient(conn)
+	response, err := client.Approve(context.TODO(), &proto.ApproveRequest{ApprovalId: approvalId})
+	if err != nil {
+		return fmt.Errorf("approving start failed: %w", err)
+	}
+	printStartedJobs(response)
+	return nil
+}
+
+// ListApprovals prints pending starts of protected agent caller can approve
+func ListApprovals(conn grpc.ClientConnInterface) error {
+	client := proto.NewApprovalServiceClient(conn)
+	response, err := client.ListApprovals(context.TODO(), &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("listing pending approvals failed: %w", err)
+	}
+	if len(response.Approvals) == 0 {
+		fmt.Println("There are no starts waiting for approval")
+		return nil
+	}
+	for _, approval := range response.Approvals {
+		fmt.Printf("%s\ttenant %q\trequested %s\texpires %s", approval.Id, approval.Tenant, approval.RequestedAt, approval.ExpiresAt)
+		if approval.Restart {
+			fmt.Print("\trestart")
+		}
+		fmt.Println()
+		fmt.Printf("   targets: %s\n", strings.Join(approval.Targets, ", "))
+		fmt.Printf("   jobs:    %s\n", strings.Join(approval.Jobs, ", "))
+		fmt.Printf("   config:  sha256:%s\n", approval.ConfigHash)
+	}
+	return nil
+}
+
+func printPendingApproval(approvalId string) {
+	fmt.Printf("⏸️  Agent is protected, start waits for 'approve %s' with another token\n", approvalId)
+}
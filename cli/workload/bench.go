@@ -0,0 +1,90 @@
+package workload
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot"
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/schema"
+	"github.com/samber/lo"
+)
+
+// BenchGenerator measures how many documents and templates per second generator can produce on this machine,
+// every job is measured separately with concurrency goroutines
+func BenchGenerator(parsedConfig *lbot.ConfigRequest, duration time.Duration, concurrency int) error {
+	if parsedConfig.Agent == nil {
+		parsedConfig.Agent = &lbot.AgentRequest{}
+	}
+	cfg := lbot.NewConfig(parsedConfig)
+
+	fmt.Printf("🚀 Benchmarking generator for %s with %d goroutines per measurement\n", duration, concurrency)
+	for _, job := range cfg.Jobs {
+		if job.Type == string(config.Sleep) || job.Type == string(config.DropCollection) {
+			continue
+		}
+		jobSchema := cfg.GetSchema(job.Schema)
+		fmt.Printf("%s (%s)\n", lo.If(job.Name != "", job.Name).Else("-"), job.Type)
+
+		rate, err := benchGenerate(duration, concurrency, func(generator schema.DataGenerator) error {
+			_, err := generator.Generate()
+			return err
+		}, jobSchema, job)
+		printBenchResult("documents", rate, err)
+
+		templates := map[string]interface{}{"filters": job.Filter, "updates": job.Update}
+		for _, name := range []string{"filters", "updates"} {
+			template := templates[name]
+			if lo.IsNil(template) {
+				continue
+			}
+			rate, err := benchGenerate(duration, concurrency, func(generator schema.DataGenerator) error {
+				_, err := generator.GenerateFromTemplate(template)
+				return err
+			}, jobSchema, job)
+			printBenchResult(name, rate, err)
+		}
+	}
+	return nil
+}
+
+func benchGenerate(
+	duration time.Duration, concurrency int, generate func(schema.DataGenerator) error, jobSchema *config.Schema, job *config.Job,
+) (float64, error) {
+	var generated uint64
+	var wg sync.WaitGroup
+	var firstError atomic.Value
+
+	deadline := time.Now().Add(duration)
+	startTime := time.Now()
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			generator := schema.NewDataGenerator(jobSchema, int(job.DataSize))
+			for time.Now().Before(deadline) {
+				if err := generate(generator); err != nil {
+					firstError.CompareAndSwap(nil, err)
+					return
+				}
+				atomic.AddUint64(&generated, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err, ok := firstError.Load().(error); ok {
+		return 0, err
+	}
+	return float64(generated) / time.Since(startTime).Seconds(), nil
+}
+
+func printBenchResult(name string, rate float64, err error) {
+	if err != nil {
+		fmt.Printf("   %-10s generation failed: %s\n", name, err)
+		return
+	}
+	fmt.Printf("   %-10s %12.0f/s\n", name, rate)
+}
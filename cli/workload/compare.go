@@ -0,0 +1,245 @@
+package workload
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultCompareAlpha      = 0.05
+	DefaultCompareMaxSamples = 20000
+	BootstrapIterations      = 1000
+)
+
+// LatencySamples holds latencies (ms) of successful operations read from latency log,
+// uniformly sampled when log has more operations than max samples
+type LatencySamples struct {
+	Path       string
+	Operations uint64
+	Errors     uint64
+	Latencies  []float64 // sorted
+}
+
+// CompareLatencies compares raw latency logs of two runs (downloaded with 'results'),
+// besides percentile deltas reports Mann-Whitney U test and bootstrap confidence intervals
+// of median and p99 difference, so noise isn't mistaken for regression
+func CompareLatencies(baselinePath string, candidatePath string, alpha float64, maxSamples int) error {
+	rng := rand.New(rand.NewSource(1))
+	baseline, err := ReadLatencyLog(baselinePath, maxSamples, rng)
+	if err != nil {
+		return fmt.Errorf("Reading baseline latency log failed: %w", err)
+	}
+	candidate, err := ReadLatencyLog(candidatePath, maxSamples, rng)
+	if err != nil {
+		return fmt.Errorf("Reading candidate latency log failed: %w", err)
+	}
+	if len(baseline.Latencies) == 0 || len(candidate.Latencies) == 0 {
+		return fmt.Errorf("latency logs have no successful operations to compare")
+	}
+
+	fmt.Printf("📊 Comparing %s (%d operations, %d errors) with %s (%d operations, %d errors)\n",
+		baseline.Path, baseline.Operations, baseline.Errors, candidate.Path, candidate.Operations, candidate.Errors)
+	if uint64(len(baseline.Latencies)) < baseline.Operations-baseline.Errors || uint64(len(candidate.Latencies)) < candidate.Operations-candidate.Errors {
+		fmt.Printf("   statistics computed on %d random samples of every run\n", maxSamples)
+	}
+	fmt.Printf("%8s %12s %12s %10s\n", "", "baseline", "candidate", "delta")
+	for _, row := range []struct {
+		name     string
+		quantile float64
+	}{{"p50", 0.5}, {"p90", 0.9}, {"p95", 0.95}, {"p99", 0.99}} {
+		b, c := Quantile(baseline.Latencies, row.quantile), Quantile(candidate.Latencies, row.quantile)
+		fmt.Printf("%8s %10.3fms %10.3fms %+9.1f%%\n", row.name, b, c, (c-b)/b*100)
+	}
+	b, c := mean(baseline.Latencies), mean(candidate.Latencies)
+	fmt.Printf("%8s %10.3fms %10.3fms %+9.1f%%\n", "mean", b, c, (c-b)/b*100)
+
+	test := MannWhitneyU(baseline.Latencies, candidate.Latencies)
+	fmt.Printf("\nMann-Whitney U test: U=%.0f z=%.2f p=%.4g - %s (alpha %.3g)\n",
+		test.U, test.Z, test.P, significance(test.P < alpha), alpha)
+	fmt.Printf("   probability that candidate operation is slower than baseline one: %.3f\n", test.Effect)
+
+	confidence := 1 - alpha
+	for _, row := range []struct {
+		name     string
+		quantile float64
+	}{{"median", 0.5}, {"p99", 0.99}} {
+		low, high := BootstrapQuantileDifference(baseline.Latencies, candidate.Latencies, row.quantile, BootstrapIterations, confidence, rng)
+		fmt.Printf("Bootstrap %.0f%% CI of %s difference: [%+.3fms, %+.3fms] - %s\n",
+			confidence*100, row.name, low, high, significance(low > 0 || high < 0))
+	}
+	return nil
+}
+
+func significance(significant bool) string {
+	if significant {
+		return "difference is significant"
+	}
+	return "difference is not significant"
+}
+
+// ReadLatencyLog reads latency log artifact (start_ms,latency_us,error), failed operations are only counted,
+// reservoir sampling keeps at most maxSamples latencies
+func ReadLatencyLog(path string, maxSamples int, rng *rand.Rand) (*LatencySamples, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	samples := &LatencySamples{Path: path}
+	var successful int
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Split(scanner.Text(), ",")
+		if line == 1 && fields[0] == "start_ms" {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d: expected start_ms,latency_us,error, got %q", line, scanner.Text())
+		}
+		samples.Operations++
+		if fields[2] == "true" {
+			samples.Errors++
+			continue
+		}
+		latency, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid latency: %w", line, err)
+		}
+		latency /= 1000
+
+		successful++
+		if len(samples.Latencies) < maxSamples {
+			samples.Latencies = append(samples.Latencies, latency)
+		} else if i := rng.Intn(successful); i < maxSamples {
+			samples.Latencies[i] = latency
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.Float64s(samples.Latencies)
+	return samples, nil
+}
+
+// Quantile returns linearly interpolated quantile of sorted values
+func Quantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	position := q * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	if lower+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lower] + (position-float64(lower))*(sorted[lower+1]-sorted[lower])
+}
+
+func mean(values []float64) float64 {
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+// MannWhitneyResult is result of two-sided Mann-Whitney U test with normal approximation,
+// Effect is probability that candidate value is greater than baseline value (ties count half)
+type MannWhitneyResult struct {
+	U      float64
+	Z      float64
+	P      float64
+	Effect float64
+}
+
+// MannWhitneyU tests whether baseline and candidate latencies come from the same distribution,
+// it makes no assumption of normality which latencies never meet
+func MannWhitneyU(baseline []float64, candidate []float64) MannWhitneyResult {
+	n1, n2 := float64(len(baseline)), float64(len(candidate))
+	type sample struct {
+		value     float64
+		candidate bool
+	}
+	all := make([]sample, 0, len(baseline)+len(candidate))
+	for _, value := range baseline {
+		all = append(all, sample{value: value})
+	}
+	for _, value := range candidate {
+		all = append(all, sample{value: value, candidate: true})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].value < all[j].value })
+
+	// tied values get average rank, ties reduce variance of U
+	var candidateRanks, ties float64
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].value == all[i].value {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if all[k].candidate {
+				candidateRanks += rank
+			}
+		}
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+
+	n := n1 + n2
+	u := candidateRanks - n2*(n2+1)/2
+	mu := n1 * n2 / 2
+	sigma := math.Sqrt(n1 * n2 / 12 * ((n + 1) - ties/(n*(n-1))))
+	result := MannWhitneyResult{U: u, P: 1, Effect: u / (n1 * n2)}
+	if sigma > 0 {
+		// continuity correction
+		result.Z = (u - mu - math.Copysign(0.5, u-mu)) / sigma
+		if math.Abs(u-mu) < 0.5 {
+			result.Z = 0
+		}
+		result.P = math.Erfc(math.Abs(result.Z) / math.Sqrt2)
+	}
+	return result
+}
+
+// BootstrapQuantileDifference returns percentile bootstrap confidence interval of
+// quantile(candidate) - quantile(baseline), both latencies have to be sorted
+func BootstrapQuantileDifference(
+	baseline []float64, candidate []float64, q float64, iterations int, confidence float64, rng *rand.Rand,
+) (low float64, high float64) {
+	differences := make([]float64, iterations)
+	baselineResample := make([]float64, len(baseline))
+	candidateResample := make([]float64, len(candidate))
+	baselineCounts, candidateCounts := make([]int, len(baseline)), make([]int, len(candidate))
+	for i := range differences {
+		resample(baselineResample, baseline, baselineCounts, rng)
+		resample(candidateResample, candidate, candidateCounts, rng)
+		differences[i] = Quantile(candidateResample, q) - Quantile(baselineResample, q)
+	}
+	sort.Float64s(differences)
+	tail := (1 - confidence) / 2
+	return Quantile(differences, tail), Quantile(differences, 1-tail)
+}
+
+// resample draws values with replacement into destination, values are sorted so counting
+// drawn indices gives sorted resample without sorting
+func resample(destination []float64, values []float64, counts []int, rng *rand.Rand) {
+	clear(counts)
+	for range destination {
+		counts[rng.Intn(len(values))]++
+	}
+	position := 0
+	for i, count := range counts {
+		for ; count > 0; count-- {
+			destination[position] = values[i]
+			position++
+		}
+	}
+}
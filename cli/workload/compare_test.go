@@ -0,0 +1,62 @@
+package workload
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuantile(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5}
+	assert.Equal(t, 1.0, Quantile(values, 0))
+	assert.Equal(t, 3.0, Quantile(values, 0.5))
+	assert.Equal(t, 4.5, Quantile(values, 0.875))
+	assert.Equal(t, 5.0, Quantile(values, 1))
+	assert.Equal(t, 0.0, Quantile(nil, 0.5))
+}
+
+func TestMannWhitneyU(t *testing.T) {
+	result := MannWhitneyU([]float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10})
+	assert.Equal(t, 25.0, result.U)
+	assert.Equal(t, 1.0, result.Effect)
+	assert.InDelta(t, 2.507, result.Z, 0.001)
+	assert.InDelta(t, 0.0122, result.P, 0.0001)
+
+	// identical distributions
+	result = MannWhitneyU([]float64{1, 2, 3, 3}, []float64{1, 2, 3, 3})
+	assert.Equal(t, 0.5, result.Effect)
+	assert.Equal(t, 1.0, result.P)
+}
+
+func TestBootstrapQuantileDifference(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	baseline := make([]float64, 1000)
+	candidate := make([]float64, 1000)
+	for i := range baseline {
+		baseline[i] = float64(i) / 100
+		candidate[i] = float64(i)/100 + 2
+	}
+	low, high := BootstrapQuantileDifference(baseline, candidate, 0.5, 200, 0.95, rng)
+	assert.Less(t, low, 2.0)
+	assert.Greater(t, high, 2.0)
+	assert.Greater(t, low, 0.0)
+}
+
+func TestReadLatencyLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orders-latency.csv")
+	err := os.WriteFile(path, []byte("start_ms,latency_us,error\n1,3000,false\n2,1000,false\n3,99000,true\n4,2000,false\n"), 0o644)
+	assert.Nil(t, err)
+
+	samples, err := ReadLatencyLog(path, 10, rand.New(rand.NewSource(1)))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(4), samples.Operations)
+	assert.Equal(t, uint64(1), samples.Errors)
+	assert.Equal(t, []float64{1, 2, 3}, samples.Latencies)
+
+	samples, err = ReadLatencyLog(path, 2, rand.New(rand.NewSource(1)))
+	assert.Nil(t, err)
+	assert.Len(t, samples.Latencies, 2)
+}
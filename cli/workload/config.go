@@ -30,7 +30,7 @@ import (
 // checks if process is running in local system
 // here should be cli config request - not lbot one
 func SetWorkloadConfig(conn grpc.ClientConnInterface, parsedConfig *lbot.ConfigRequest) (err error) {
-	requestConfig := BuildConfigRequest(parsedConfig)
+	requestConfig := lbot.NewProtoConfigRequest(parsedConfig)
 
 	fmt.Println("🚀 Setting new config")
 
@@ -193,50 +193,20 @@ func GetDurationInput(label string, defaultValue string) *time.Duration {
 	return &resultDuration
 }
 
-func BuildConfigRequest(request *lbot.ConfigRequest) *proto.ConfigRequest {
-	cfg := &proto.ConfigRequest{
-		ConnectionString: request.ConnectionString,
-		Agent: &proto.AgentRequest{
-			Name:                         request.Agent.Name,
-			Port:                         request.Agent.Port,
-			MetricsExportUrl:             request.Agent.MetricsExportUrl,
-			MetricsExportIntervalSeconds: request.Agent.MetricsExportIntervalSeconds,
-			MetricsExportPort:            request.Agent.MetricsExportPort,
-		},
-		Jobs:    make([]*proto.JobRequest, len(request.Jobs)),
-		Schemas: make([]*proto.SchemaRequest, len(request.Schemas)),
-		Debug:   request.Debug,
-	}
-	for i, job := range request.Jobs {
-		cfg.Jobs[i] = &proto.JobRequest{
-			Name:        job.Name,
-			Database:    job.Database,
-			Collection:  job.Collection,
-			Type:        job.Type,
-			Schema:      job.Schema,
-			Connections: job.Connections,
-			Pace:        job.Pace,
-			DataSize:    job.DataSize,
-			BatchSize:   job.BatchSize,
-			Duration:    job.Duration.String(),
-			Operations:  job.Operations,
-			Timeout:     job.Timeout.String(),
-			// todo: setup filters and schema inside
-			// Filter:          job.Filter,
-		}
+// todo: command for setting only one field
+// ex. --set=cos.tam.tam=2
+
+func ExportWorkload(conn grpc.ClientConnInterface, output string, credentials bool) (err error) {
+	client := proto.NewConfigServiceClient(conn)
+	response, err := client.ExportConfig(context.TODO(), &proto.ExportRequest{Credentials: credentials})
+	if err != nil {
+		return fmt.Errorf("Exporting workload failed: %w", err)
 	}
-	for i, schema := range request.Schemas {
-		cfg.Schemas[i] = &proto.SchemaRequest{
-			Name:       schema.Name,
-			Database:   schema.Database,
-			Collection: schema.Collection,
-			// Schema:     schema.Schema,
-			Save: schema.Save,
-		}
+
+	if err = os.WriteFile(output, response.Bundle, 0o644); err != nil {
+		return err
 	}
+	fmt.Printf("✅ Workload exported to %s\n", output)
 
-	return cfg
+	return
 }
-
-// todo: command for setting only one field
-// ex. --set=cos.tam.tam=2
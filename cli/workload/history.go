@@ -0,0 +1,102 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"google.golang.org/grpc"
+)
+
+// RunHistory prints summaries of runs ended on agent, newest first, limit 0 prints all kept runs
+func RunHistory(conn grpc.ClientConnInterface, limit uint32) error {
+	response, err := proto.NewRunHistoryServiceClient(conn).ListRuns(context.TODO(), &proto.ListRunsRequest{Limit: limit})
+	if err != nil {
+		return fmt.Errorf("Listing run history failed: %w", err)
+	}
+	if len(response.Runs) == 0 {
+		fmt.Println("There are no ended runs in agent history")
+		return nil
+	}
+	summary := HistorySummary(response.Runs)
+	summary.Print()
+	return nil
+}
+
+// RunDetails prints summary of ended run with stats of its jobs
+func RunDetails(conn grpc.ClientConnInterface, runId string) error {
+	run, err := proto.NewRunHistoryServiceClient(conn).GetRun(context.TODO(), &proto.GetRunRequest{RunId: runId})
+	if err != nil {
+		return fmt.Errorf("Getting run %s failed: %w", runId, err)
+	}
+	summary := RunSummary(run)
+	summary.Print()
+	return nil
+}
+
+// HistorySummary builds table of ended runs, one row per run
+func HistorySummary(runs []*proto.RunSummary) Summary {
+	summary := Summary{
+		Columns: []SummaryColumn{
+			{Name: "RUN"}, {Name: "STATUS"}, {Name: "STARTED", DropOrder: 3}, {Name: "DURATION", Numeric: true, DropOrder: 2},
+			{Name: "JOBS", Numeric: true, DropOrder: 4}, {Name: "REQUESTS", Numeric: true}, {Name: "ERRORS", Numeric: true},
+			{Name: "ERROR RATE", Numeric: true}, {Name: "RPS", Numeric: true, DropOrder: 1},
+		},
+	}
+	for _, run := range runs {
+		summary.Rows = append(summary.Rows, SummaryRow{
+			Cells: []string{
+				run.RunId, run.Status, formatRunTime(run.StartedAt), HumanizeSeconds(run.Duration), fmt.Sprint(len(run.Jobs)),
+				HumanizeCount(run.Requests), HumanizeCount(run.Errors), HumanizeRate(errorRate(run.Requests, run.Errors)),
+				HumanizeCount(run.Rps),
+			},
+		})
+	}
+	return summary
+}
+
+// RunSummary builds table of jobs of ended run with run totals
+func RunSummary(run *proto.RunSummary) Summary {
+	summary := Summary{
+		Title: fmt.Sprintf("📜 Run %s %s at %s, took %s", run.RunId, run.Status, formatRunTime(run.EndedAt), HumanizeSeconds(run.Duration)),
+		Columns: []SummaryColumn{
+			{Name: "JOB"}, {Name: "TYPE", DropOrder: 2}, {Name: "REQUESTS", Numeric: true}, {Name: "ERRORS", Numeric: true},
+			{Name: "ERROR RATE", Numeric: true}, {Name: "RPS", Numeric: true, DropOrder: 1},
+			{Name: "LATENCY", Numeric: true, DropOrder: 4}, {Name: "DURATION", Numeric: true, DropOrder: 3}, {Name: "STOP REASON"},
+		},
+		Totals: []string{
+			"total", "", HumanizeCount(run.Requests), HumanizeCount(run.Errors), HumanizeRate(errorRate(run.Requests, run.Errors)),
+			HumanizeCount(run.Rps), formatLatency(run.AverageLatencyMs), HumanizeSeconds(run.Duration), "",
+		},
+	}
+	for _, job := range run.Jobs {
+		summary.Rows = append(summary.Rows, SummaryRow{
+			Cells: []string{
+				job.JobName, job.JobType, HumanizeCount(job.Requests), HumanizeCount(job.Errors),
+				HumanizeRate(errorRate(job.Requests, job.Errors)), HumanizeCount(job.Rps), formatLatency(job.AverageLatencyMs),
+				HumanizeSeconds(job.Duration), job.StopReason,
+			},
+		})
+	}
+	if run.Template != "" {
+		summary.Title += ", template " + run.Template
+	}
+	return summary
+}
+
+// formatRunTime formats RFC 3339 time of run in local time
+func formatRunTime(value string) string {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return parsed.Local().Format("2006-01-02 15:04:05")
+}
+
+func formatLatency(latencyMs float64) string {
+	if latencyMs == 0 {
+		return SummaryNone
+	}
+	return fmt.Sprintf("%.2fms", latencyMs)
+}
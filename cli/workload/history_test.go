@@ -0,0 +1,41 @@
+package workload
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistorySummary(t *testing.T) {
+	summary := HistorySummary([]*proto.RunSummary{
+		{RunId: "run2", Status: "stopped", Duration: 30, Requests: 1000, Errors: 10, Rps: 33, Jobs: []*proto.RunJobSummary{{}, {}}},
+		{RunId: "run1", Status: "finished", Duration: 90, Requests: 9000, Rps: 100},
+	})
+	assert.Len(t, summary.Rows, 2)
+	assert.Equal(t, []string{"run2", "stopped", "", "30s", "2", "1.0k", "10", "1.00%", "33"}, summary.Rows[0].Cells)
+	assert.Equal(t, "0%", summary.Rows[1].Cells[7])
+}
+
+func TestRunSummary(t *testing.T) {
+	color.NoColor = true
+	summary := RunSummary(&proto.RunSummary{
+		RunId: "run1", Status: "finished", EndedAt: "not a time", Duration: 100, Requests: 12000, Errors: 205, Rps: 120,
+		AverageLatencyMs: 1.5, Template: "registry.example.com/orders:v1",
+		Jobs: []*proto.RunJobSummary{
+			{JobName: "inserts", JobType: "write", Requests: 10000, Errors: 5, Rps: 100, AverageLatencyMs: 1.25, Duration: 100, StopReason: "duration"},
+			{JobName: "reads", JobType: "read", Requests: 2000, Errors: 200, Rps: 20, Duration: 90, StopReason: "grace_period_exceeded"},
+		},
+	})
+	assert.Equal(t, "📜 Run run1 finished at not a time, took 1m40s, template registry.example.com/orders:v1", summary.Title)
+
+	var output bytes.Buffer
+	summary.Render(&output, 0)
+	lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+	assert.Equal(t, "   inserts  write     10.0k       5       0.05%  100   1.25ms     1m40s  duration", strings.TrimRight(lines[3], " "))
+	assert.Equal(t, "   reads    read       2.0k     200      10.00%   20        -     1m30s  grace_period_exceeded", lines[4])
+	assert.Equal(t, "   total              12.0k     205       1.71%  120   1.50ms     1m40s", strings.TrimRight(lines[6], " "))
+}
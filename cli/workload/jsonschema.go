@@ -0,0 +1,373 @@
+package workload
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/kuzxnia/loadbot/lbot"
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/schema"
+	"github.com/samber/lo"
+)
+
+// maximal depth of nested $ref, deeper (recursive) references are skipped
+const maxSchemaRefDepth = 8
+
+// ImportJsonSchema generates workload config with loadbot schema and write job for every model of JSON Schema,
+// MongoDB $jsonSchema validator or OpenAPI document (models of components.schemas), saving the config to output
+func ImportJsonSchema(path string, models []string, connectionString string, database string, duration time.Duration, output string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var document map[string]interface{}
+	if err = json.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("Reading JSON Schema failed: %w", err)
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	templates, err := ConvertJsonSchema(document, models, name)
+	if err != nil {
+		return fmt.Errorf("Converting JSON Schema failed: %w", err)
+	}
+
+	cfg := &lbot.ConfigRequest{ConnectionString: connectionString}
+	for _, model := range lo.Keys(templates) {
+		cfg.Schemas = append(cfg.Schemas, &lbot.SchemaRequest{
+			Name: model, Database: database, Collection: model, Schema: templates[model],
+		})
+	}
+	sort.Slice(cfg.Schemas, func(i, j int) bool { return cfg.Schemas[i].Name < cfg.Schemas[j].Name })
+	for _, s := range cfg.Schemas {
+		cfg.Jobs = append(cfg.Jobs, &lbot.JobRequest{
+			Name:        s.Name + " write",
+			Type:        string(config.Write),
+			Schema:      s.Name,
+			Connections: recordedConnections,
+			Duration:    duration,
+		})
+		fmt.Fprintf(os.Stderr, "   %-20s %3d fields\n", s.Name, len(s.Schema))
+	}
+
+	data, err = json.MarshalIndent(cfg, "", "\t")
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		fmt.Println(string(data))
+		return nil
+	}
+	if err = os.WriteFile(output, data, 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "✅ Config saved to %s\n", output)
+	return nil
+}
+
+// ConvertJsonSchema converts models of document into loadbot document templates by model name. Models are
+// looked up in components.schemas (OpenAPI), $defs and definitions, without models all OpenAPI components
+// are converted, or document itself named after its title or fallback name
+func ConvertJsonSchema(document map[string]interface{}, models []string, fallbackName string) (map[string]map[string]interface{}, error) {
+	// collection validator, ex. output of db.getCollectionInfos()[0].options.validator
+	if validator, ok := document["$jsonSchema"].(map[string]interface{}); ok {
+		document = validator
+	}
+	definitions := schemaDefinitions(document)
+	if len(models) == 0 {
+		if components, ok := document["components"].(map[string]interface{}); ok && components["schemas"] != nil {
+			models = lo.Keys(definitions)
+		}
+	}
+	if len(models) == 0 {
+		name, _ := document["title"].(string)
+		definitions[lo.Ternary(name != "", name, fallbackName)] = document
+		models = []string{lo.Ternary(name != "", name, fallbackName)}
+	}
+
+	converter := &jsonSchemaConverter{root: document}
+	templates := make(map[string]map[string]interface{})
+	for _, model := range models {
+		definition, ok := definitions[model].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("model %s not found, available: %s", model, strings.Join(lo.Keys(definitions), ", "))
+		}
+		template, err := converter.convert(definition, "", 0)
+		if err != nil {
+			return nil, fmt.Errorf("model %s: %w", model, err)
+		}
+		object, ok := template.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("model %s is not an object", model)
+		}
+		templates[model] = object
+	}
+	return templates, nil
+}
+
+func schemaDefinitions(document map[string]interface{}) map[string]interface{} {
+	definitions := make(map[string]interface{})
+	if components, ok := document["components"].(map[string]interface{}); ok {
+		if schemas, ok := components["schemas"].(map[string]interface{}); ok {
+			for name, definition := range schemas {
+				definitions[name] = definition
+			}
+		}
+	}
+	for _, key := range []string{"definitions", "$defs"} {
+		if schemas, ok := document[key].(map[string]interface{}); ok {
+			for name, definition := range schemas {
+				definitions[name] = definition
+			}
+		}
+	}
+	return definitions
+}
+
+type jsonSchemaConverter struct {
+	root map[string]interface{}
+}
+
+// convert returns template of schema node, field is name of converted property used to pick generator
+// of plain strings, ex. email or first_name
+func (c *jsonSchemaConverter) convert(node map[string]interface{}, field string, depth int) (interface{}, error) {
+	if ref, ok := node["$ref"].(string); ok {
+		if depth >= maxSchemaRefDepth {
+			return nil, nil
+		}
+		resolved, err := c.resolve(ref)
+		if err != nil {
+			return nil, err
+		}
+		return c.convert(resolved, field, depth+1)
+	}
+	if value, ok := node["const"]; ok {
+		return value, nil
+	}
+	if values, ok := node["enum"].([]interface{}); ok && len(values) > 0 {
+		return values[0], nil
+	}
+	if parts, ok := node["allOf"].([]interface{}); ok {
+		return c.convertAllOf(node, parts, field, depth)
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		// first non-null variant
+		if variants, ok := node[key].([]interface{}); ok {
+			for _, variant := range variants {
+				if variant, ok := variant.(map[string]interface{}); ok && schemaType(variant) != "null" {
+					return c.convert(variant, field, depth)
+				}
+			}
+		}
+	}
+
+	switch schemaType(node) {
+	case "object":
+		return c.convertProperties(node, depth)
+	case "array":
+		items, _ := node["items"].(map[string]interface{})
+		item, err := c.convert(items, field, depth)
+		if err != nil {
+			return nil, err
+		}
+		minItems, _ := node["minItems"].(float64)
+		return lo.Times(max(1, int(minItems)), func(int) interface{} { return item }), nil
+	case "string":
+		return stringGenerator(node, field), nil
+	case "integer", "int":
+		return integerGenerator(node, "#int32", math.MaxInt32), nil
+	case "long":
+		return integerGenerator(node, "#int64", math.MaxInt64), nil
+	case "number", "double":
+		return numberGenerator(node, "#double"), nil
+	case "decimal":
+		return numberGenerator(node, "#decimal128"), nil
+	case "date":
+		return "#date", nil
+	case "objectId":
+		return "#object_id", nil
+	case "boolean", "bool":
+		return true, nil
+	case "null", "":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s of field %s", schemaType(node), field)
+	}
+}
+
+func (c *jsonSchemaConverter) convertProperties(node map[string]interface{}, depth int) (map[string]interface{}, error) {
+	template := make(map[string]interface{})
+	properties, _ := node["properties"].(map[string]interface{})
+	for name, property := range properties {
+		property, ok := property.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		value, err := c.convert(property, name, depth)
+		if err != nil {
+			return nil, err
+		}
+		template[name] = value
+	}
+	return template, nil
+}
+
+// convertAllOf merges properties of all parts, ex. OpenAPI model extending other model
+func (c *jsonSchemaConverter) convertAllOf(node map[string]interface{}, parts []interface{}, field string, depth int) (interface{}, error) {
+	template := make(map[string]interface{})
+	for _, part := range append(append([]interface{}{}, parts...), lo.OmitByKeys(node, []string{"allOf"})) {
+		part, ok := part.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		value, err := c.convert(part, field, depth)
+		if err != nil {
+			return nil, err
+		}
+		if object, ok := value.(map[string]interface{}); ok {
+			for name, property := range object {
+				template[name] = property
+			}
+		} else if value != nil && len(parts) == 1 {
+			// single part restricted by sibling keywords
+			return value, nil
+		}
+	}
+	return template, nil
+}
+
+// resolve returns node of local reference, ex. #/components/schemas/Address or #/$defs/address
+func (c *jsonSchemaConverter) resolve(ref string) (map[string]interface{}, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, errors.New("only local references are supported, got: " + ref)
+	}
+	var node interface{} = c.root
+	for _, token := range strings.Split(strings.TrimPrefix(strings.TrimPrefix(ref, "#"), "/"), "/") {
+		if token == "" {
+			continue
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		object, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("invalid reference " + ref)
+		}
+		if node, ok = object[token]; !ok {
+			return nil, errors.New("invalid reference " + ref)
+		}
+	}
+	resolved, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("invalid reference " + ref)
+	}
+	return resolved, nil
+}
+
+// schemaType returns first non-null type of node, bsonType of MongoDB validators takes precedence,
+// nodes with properties or items are objects and arrays without explicit type
+func schemaType(node map[string]interface{}) string {
+	for _, key := range []string{"bsonType", "type"} {
+		switch value := node[key].(type) {
+		case string:
+			return value
+		case []interface{}:
+			for _, variant := range value {
+				if variant, ok := variant.(string); ok && variant != "null" {
+					return variant
+				}
+			}
+			return "null"
+		}
+	}
+	if node["properties"] != nil {
+		return "object"
+	}
+	if node["items"] != nil {
+		return "array"
+	}
+	return ""
+}
+
+var stringFormatGenerators = map[string]string{
+	"email":     "#email",
+	"date-time": "#date",
+	"date":      "#date",
+	"uuid":      "#id",
+	"password":  "#password",
+}
+
+// stringGenerator picks generator by string format, then by field name, ex. first_name or firstName
+func stringGenerator(node map[string]interface{}, field string) string {
+	format, _ := node["format"].(string)
+	if generator, ok := stringFormatGenerators[format]; ok {
+		return generator
+	}
+	generator := "#" + snakeCase(field)
+	if _, ok := schema.DefaultGeneratorFieldMapper.FieldTypeMapper[generator]; ok && generator != "#string" {
+		return generator
+	}
+	if maxLength, _ := node["maxLength"].(float64); maxLength > 200 {
+		return "#paragraph"
+	}
+	return "#string"
+}
+
+// integerGenerator returns typed generator, bounds are set when schema sets at least one of them
+func integerGenerator(node map[string]interface{}, generator string, maxValue int64) string {
+	if format, _ := node["format"].(string); format == "int64" {
+		generator, maxValue = "#int64", math.MaxInt64
+	}
+	low, hasLow := schemaBound(node, "minimum", "exclusiveMinimum", 1)
+	high, hasHigh := schemaBound(node, "maximum", "exclusiveMaximum", -1)
+	if !hasLow && !hasHigh {
+		return generator
+	}
+	// float of max int64 rounds above it
+	upper := maxValue
+	if hasHigh && math.Floor(high) < float64(maxValue) {
+		upper = int64(math.Floor(high))
+	}
+	return fmt.Sprintf("%s(%d,%d)", generator, int64(math.Ceil(low)), upper)
+}
+
+// numberGenerator returns typed generator, bounds are set only when schema sets both of them
+func numberGenerator(node map[string]interface{}, generator string) string {
+	low, hasLow := schemaBound(node, "minimum", "exclusiveMinimum", 0)
+	high, hasHigh := schemaBound(node, "maximum", "exclusiveMaximum", 0)
+	if !hasLow || !hasHigh {
+		return generator
+	}
+	return fmt.Sprintf("%s(%g,%g)", generator, low, high)
+}
+
+// schemaBound returns inclusive bound, numeric exclusive bound (draft 6+) is moved by step
+func schemaBound(node map[string]interface{}, inclusive string, exclusive string, step float64) (float64, bool) {
+	if value, ok := node[exclusive].(float64); ok {
+		return value + step, true
+	}
+	value, ok := node[inclusive].(float64)
+	if ok && node[exclusive] == true {
+		value += step
+	}
+	return value, ok
+}
+
+// snakeCase converts camelCase field name to snake_case, ex. firstName to first_name
+func snakeCase(name string) string {
+	var result strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				result.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		result.WriteRune(r)
+	}
+	return result.String()
+}
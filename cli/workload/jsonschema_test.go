@@ -0,0 +1,122 @@
+package workload
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot"
+	"github.com/stretchr/testify/assert"
+)
+
+func parseJsonSchema(t *testing.T, document string) map[string]interface{} {
+	var parsed map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(document), &parsed))
+	return parsed
+}
+
+func TestConvertJsonSchema(t *testing.T) {
+	document := parseJsonSchema(t, `{
+		"title": "user",
+		"type": "object",
+		"properties": {
+			"firstName": {"type": "string"},
+			"email": {"type": "string", "format": "email"},
+			"bio": {"type": "string", "maxLength": 1000},
+			"age": {"type": "integer", "minimum": 18, "exclusiveMaximum": 120},
+			"score": {"type": ["number", "null"], "minimum": 0, "maximum": 5},
+			"status": {"enum": ["active", "blocked"]},
+			"tags": {"type": "array", "items": {"type": "string"}, "minItems": 2},
+			"address": {"$ref": "#/$defs/address"},
+			"verified": {"type": "boolean"}
+		},
+		"$defs": {
+			"address": {"properties": {"city": {"type": "string"}, "created_at": {"type": "string", "format": "date-time"}}}
+		}
+	}`)
+
+	templates, err := ConvertJsonSchema(document, nil, "users")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"firstName": "#first_name",
+		"email":     "#email",
+		"bio":       "#paragraph",
+		"age":       "#int32(18,119)",
+		"score":     "#double(0,5)",
+		"status":    "active",
+		"tags":      []interface{}{"#string", "#string"},
+		"address":   map[string]interface{}{"city": "#string", "created_at": "#date"},
+		"verified":  true,
+	}, templates["user"])
+
+	// definitions are models too
+	templates, err = ConvertJsonSchema(document, []string{"address"}, "users")
+	assert.NoError(t, err)
+	assert.Equal(t, "#string", templates["address"]["city"])
+
+	_, err = ConvertJsonSchema(document, []string{"order"}, "users")
+	assert.Error(t, err)
+}
+
+func TestConvertOpenApiSchemas(t *testing.T) {
+	document := parseJsonSchema(t, `{
+		"openapi": "3.0.0",
+		"components": {"schemas": {
+			"Entity": {"type": "object", "properties": {"id": {"type": "string", "format": "uuid"}}},
+			"Order": {"allOf": [{"$ref": "#/components/schemas/Entity"}, {"properties": {
+				"quantity": {"type": "integer", "format": "int64", "minimum": 1},
+				"customer": {"oneOf": [{"type": "null"}, {"$ref": "#/components/schemas/Customer"}]}
+			}}]},
+			"Customer": {"type": "object", "properties": {"name": {"type": "string"}, "referrer": {"$ref": "#/components/schemas/Customer"}}}
+		}}
+	}`)
+
+	templates, err := ConvertJsonSchema(document, nil, "api")
+	assert.NoError(t, err)
+	assert.Len(t, templates, 3)
+	assert.Equal(t, "#id", templates["Order"]["id"])
+	assert.Equal(t, "#int64(1,9223372036854775807)", templates["Order"]["quantity"])
+	customer := templates["Order"]["customer"].(map[string]interface{})
+	assert.Equal(t, "#name", customer["name"])
+
+	// recursive reference ends after max depth
+	depth := 0
+	for ; customer != nil; depth++ {
+		customer, _ = customer["referrer"].(map[string]interface{})
+	}
+	assert.Equal(t, maxSchemaRefDepth, depth)
+}
+
+func TestConvertMongoValidator(t *testing.T) {
+	document := parseJsonSchema(t, `{"$jsonSchema": {
+		"bsonType": "object",
+		"properties": {
+			"_id": {"bsonType": "objectId"},
+			"total": {"bsonType": "decimal", "minimum": 0, "maximum": 1000},
+			"items": {"bsonType": "long"},
+			"created": {"bsonType": "date"}
+		}
+	}}`)
+
+	templates, err := ConvertJsonSchema(document, nil, "orders")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"_id": "#object_id", "total": "#decimal128(0,1000)", "items": "#int64", "created": "#date",
+	}, templates["orders"])
+}
+
+func TestImportJsonSchemaConfigParses(t *testing.T) {
+	dir := t.TempDir()
+	path, output := filepath.Join(dir, "users.json"), filepath.Join(dir, "config.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"properties": {"email": {"type": "string", "format": "email"}}}`), 0o644))
+
+	assert.NoError(t, ImportJsonSchema(path, nil, "mongodb://localhost:27017", "app", time.Minute, output))
+	cfg, err := lbot.ParseConfigFile(output)
+	assert.NoError(t, err)
+	assert.Len(t, cfg.Jobs, 1)
+	assert.Equal(t, "users", cfg.Jobs[0].Schema)
+	assert.Equal(t, time.Minute, cfg.Jobs[0].Duration)
+	assert.Equal(t, "#email", cfg.Schemas[0].Schema["email"])
+}
@@ -0,0 +1,25 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"google.golang.org/grpc"
+)
+
+// SetLogLevel changes logging verbosity of running agent, empty level prints current one
+func SetLogLevel(conn grpc.ClientConnInterface, level string) error {
+	client := proto.NewLogServiceClient(conn)
+	response, err := client.SetLogLevel(context.TODO(), &proto.SetLogLevelRequest{Level: level})
+	if err != nil {
+		return fmt.Errorf("Setting log level failed: %w", err)
+	}
+
+	if level == "" {
+		fmt.Printf("Agent log level: %s\n", response.Level)
+	} else {
+		fmt.Printf("🔧 Agent log level changed from %s to %s\n", response.PreviousLevel, response.Level)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package workload
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	MaskKeep     = "keep"     // keep recorded value
+	MaskHash     = "hash"     // replace value with its keyed hash (HMAC-SHA256) prefix, equal values stay equal
+	MaskTruncate = "truncate" // keep first n characters of string value, truncate:n
+	MaskDrop     = "drop"     // remove field
+	// rule starting with # is generator used instead of value, ex. #email
+)
+
+// hashed values are shortened, collisions don't matter for workload shape
+const maskHashLength = 16
+
+type MaskRule struct {
+	Kind      string
+	Length    int
+	Generator string
+}
+
+// Masker anonymizes recorded documents, rules are matched by field path (ex. "address.city") or field name,
+// values without rule are replaced by generators of their type (see valueShape), nil masker has no rules
+type Masker struct {
+	rules map[string]MaskRule
+	// key of hash rule, values can't be recovered by hashing guessed ones without it
+	key []byte
+}
+
+// ParseMaskRules parses field=rule pairs, ex. "email=#email", "ssn=hash", "status=keep", "notes=truncate:8", "token=drop",
+// hash rule is keyed with key, random one when empty, so hashes are comparable only within one recording
+func ParseMaskRules(rules []string, key string) (*Masker, error) {
+	masker := &Masker{rules: make(map[string]MaskRule, len(rules)), key: []byte(key)}
+	if key == "" {
+		masker.key = make([]byte, sha256.Size)
+		if _, err := rand.Read(masker.key); err != nil {
+			return nil, fmt.Errorf("generating mask key failed: %w", err)
+		}
+	}
+	for _, rule := range rules {
+		field, value, found := strings.Cut(rule, "=")
+		if !found || field == "" || value == "" {
+			return nil, fmt.Errorf("invalid mask rule %q, expected field=rule", rule)
+		}
+		kind, length, _ := strings.Cut(value, ":")
+		switch {
+		case strings.HasPrefix(value, "#"):
+			masker.rules[field] = MaskRule{Generator: value}
+		case kind == MaskTruncate:
+			n, err := strconv.Atoi(length)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid mask rule %q, expected truncate:<length>", rule)
+			}
+			masker.rules[field] = MaskRule{Kind: MaskTruncate, Length: n}
+		case value == MaskKeep || value == MaskHash || value == MaskDrop:
+			masker.rules[field] = MaskRule{Kind: value}
+		default:
+			return nil, fmt.Errorf("invalid mask rule %q, must be one of: keep, hash, truncate:<length>, drop, #<generator>", rule)
+		}
+	}
+	return masker, nil
+}
+
+// rule returns rule matching field path or, less specific, field name
+func (m *Masker) rule(path string) (MaskRule, bool) {
+	if m == nil || path == "" {
+		return MaskRule{}, false
+	}
+	if rule, ok := m.rules[path]; ok {
+		return rule, true
+	}
+	rule, ok := m.rules[path[strings.LastIndex(path, ".")+1:]]
+	return rule, ok
+}
+
+// Drops reports whether field is removed from recorded documents
+func (m *Masker) Drops(path string) bool {
+	rule, ok := m.rule(path)
+	return ok && rule.Kind == MaskDrop
+}
+
+// Mask applies rule matching path to value, matched is false when there is no rule
+func (m *Masker) Mask(path string, value interface{}) (masked interface{}, matched bool) {
+	rule, ok := m.rule(path)
+	if !ok {
+		return nil, false
+	}
+	switch rule.Kind {
+	case MaskKeep, MaskDrop:
+		return value, true
+	case MaskHash:
+		mac := hmac.New(sha256.New, m.key)
+		// type is part of hashed value, so 1 and "1" differ
+		fmt.Fprintf(mac, "%T:%v", value, value)
+		return hex.EncodeToString(mac.Sum(nil))[:maskHashLength], true
+	case MaskTruncate:
+		if s, ok := value.(string); ok && len([]rune(s)) > rule.Length {
+			return string([]rune(s)[:rule.Length]), true
+		}
+		return value, true
+	}
+	return rule.Generator, true
+}
@@ -0,0 +1,68 @@
+package workload
+
+import (
+	"fmt"
+
+	"github.com/kuzxnia/loadbot/lbot"
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/schema"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// PreviewWorkload prints generated sample documents, filters and updates for every job,
+// database is not touched
+func PreviewWorkload(parsedConfig *lbot.ConfigRequest, samples int) error {
+	if parsedConfig.Agent == nil {
+		parsedConfig.Agent = &lbot.AgentRequest{}
+	}
+	cfg := lbot.NewConfig(parsedConfig)
+
+	for _, job := range cfg.Jobs {
+		fmt.Printf("🔎 Job %s (%s)\n", lo.If(job.Name != "", job.Name).Else("-"), job.Type)
+		if job.Type == string(config.Sleep) || job.Type == string(config.DropCollection) {
+			fmt.Println("   nothing generated for this job type")
+			continue
+		}
+
+		// generator used directly, data provider hides generation errors
+		generator := schema.NewDataGenerator(cfg.GetSchema(job.Schema), int(job.DataSize))
+		templates := lo.OmitBy(map[string]interface{}{
+			"filter":        job.Filter,
+			"update":        job.Update,
+			"array_filters": job.ArrayFilters,
+			"sort":          job.Sort,
+			"lookup":        job.Lookup,
+		}, func(_ string, template interface{}) bool {
+			return lo.IsNil(template)
+		})
+		for i := 0; i < samples; i++ {
+			fmt.Printf("-- sample %d\n", i+1)
+			printPreview("document", lo.T2(generator.Generate()))
+			for _, name := range []string{"filter", "update", "array_filters", "sort", "lookup"} {
+				if template, ok := templates[name]; ok {
+					printPreview(name, lo.T2(generator.GenerateFromTemplate(template)))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func printPreview(name string, generated lo.Tuple2[interface{}, error]) {
+	value, err := generated.Unpack()
+	if err != nil {
+		fmt.Printf("   %s: generation failed: %s\n", name, err)
+		return
+	}
+	if values, ok := value.([]interface{}); ok {
+		// extended json encoder needs document on top level
+		value = bson.M{"values": values}
+	}
+	content, err := bson.MarshalExtJSONIndent(value, false, false, "   ", "  ")
+	if err != nil {
+		fmt.Printf("   %s: %v\n", name, value)
+		return
+	}
+	fmt.Printf("   %s: %s\n", name, content)
+}
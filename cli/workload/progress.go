@@ -2,15 +2,18 @@ package workload
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/cheggaaa/pb/v3"
 	"github.com/kuzxnia/loadbot/lbot/proto"
 	"github.com/samber/lo"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 func WorkloadProgress(conn grpc.ClientConnInterface, request *proto.ProgressRequest) (err error) {
@@ -49,8 +52,88 @@ func WorkloadProgress(conn grpc.ClientConnInterface, request *proto.ProgressRequ
 	return
 }
 
+// WorkloadProgressSnapshot prints single json snapshot of all jobs progress, meant for scripts, empty
+// run id means jobs of all runs
+func WorkloadProgressSnapshot(conn grpc.ClientConnInterface, runId string) (err error) {
+	snapshot, err := progressSnapshot(conn, runId)
+	if err != nil {
+		return err
+	}
+
+	jobs := make([]json.RawMessage, 0, len(snapshot))
+	for _, resp := range snapshot {
+		job, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(resp)
+		if err != nil {
+			return err
+		}
+		jobs = append(jobs, job)
+	}
+
+	data, err := json.MarshalIndent(map[string]interface{}{
+		"time": time.Now().Format(time.RFC3339), "jobs": jobs, "total": NewProgressTotal(snapshot),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+
+	return
+}
+
+func progressSnapshot(conn grpc.ClientConnInterface, runId string) ([]*proto.ProgressResponse, error) {
+	stream, err := proto.NewProgressProcessClient(conn).Run(context.TODO(), &proto.ProgressRequest{Once: true, RunId: runId})
+	if err != nil {
+		return nil, fmt.Errorf("getting progress failed: %w", err)
+	}
+
+	snapshot := make([]*proto.ProgressResponse, 0)
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return snapshot, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("getting progress failed: %w", err)
+		}
+		snapshot = append(snapshot, resp)
+	}
+}
+
+// ProgressTotal aggregates progress of all jobs, rps of jobs running in parallel adds up
+type ProgressTotal struct {
+	Jobs           int    `json:"jobs"`
+	Running        int    `json:"running"`
+	Requests       uint64 `json:"requests"`
+	Errors         uint64 `json:"errors"`
+	WriteConflicts uint64 `json:"write_conflicts"`
+	Rps            uint64 `json:"rps"`
+}
+
+func NewProgressTotal(jobs []*proto.ProgressResponse) *ProgressTotal {
+	total := &ProgressTotal{Jobs: len(jobs)}
+	for _, job := range jobs {
+		total.Requests += job.GetRequests()
+		total.Errors += job.GetErrors()
+		total.WriteConflicts += job.GetWriteConflicts()
+		if !job.GetIsFinished() {
+			total.Running++
+			total.Rps += job.GetRps()
+		}
+	}
+	return total
+}
+
+func (t *ProgressTotal) String() string {
+	total := fmt.Sprintf("Total of %d jobs: %d requests, %d errors", t.Jobs, t.Requests, t.Errors)
+	if t.Running > 0 {
+		total += fmt.Sprintf(", %d RPS of %d running jobs", t.Rps, t.Running)
+	}
+	return total
+}
+
 type ProgressBar struct {
 	bars map[string]*pb.ProgressBar
+	last map[string]*proto.ProgressResponse // latest progress of every job
 }
 
 // todo: no need to send request ops and request duration in every request
@@ -58,6 +141,7 @@ type ProgressBar struct {
 func NewProgressBar() *ProgressBar {
 	return &ProgressBar{
 		bars: make(map[string]*pb.ProgressBar),
+		last: make(map[string]*proto.ProgressResponse),
 	}
 }
 
@@ -79,6 +163,7 @@ func (b *ProgressBar) Init(resp *proto.ProgressResponse) {
 		value = int64(resp.GetRequestDuration())
 		tmpl += `{{ string . "duration"}}/{{ string . "requestDuration" }}S {{string . "rps" }}RPS {{string . "requests"}}REQ`
 	}
+	tmpl += `{{ string . "paused" }}`
 
 	bar := pb.New64(int64(value))
 	bar.SetTemplateString(tmpl)
@@ -96,6 +181,7 @@ func (b *ProgressBar) Start(resp *proto.ProgressResponse) {
 }
 
 func (b *ProgressBar) Update(resp *proto.ProgressResponse) {
+	b.last[resp.JobName] = resp
 	bar := b.bars[resp.JobName]
 	if resp.RequestDuration != 0 {
 		bar.SetCurrent(int64(resp.GetDuration()))
@@ -105,6 +191,7 @@ func (b *ProgressBar) Update(resp *proto.ProgressResponse) {
 	bar.Set("rps", int(resp.GetRps()))
 	bar.Set("requests", resp.GetRequests())
 	bar.Set("duration", resp.GetDuration())
+	bar.Set("paused", lo.Ternary(resp.GetPaused(), " PAUSED", ""))
 
 	bar.Write()
 
@@ -118,4 +205,7 @@ func (b *ProgressBar) Finish() {
 	for _, bar := range b.bars {
 		bar.Finish()
 	}
+	if len(b.last) > 1 {
+		fmt.Println(NewProgressTotal(lo.Values(b.last)))
+	}
 }
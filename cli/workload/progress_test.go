@@ -0,0 +1,21 @@
+package workload
+
+import (
+	"testing"
+
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProgressTotal(t *testing.T) {
+	total := NewProgressTotal([]*proto.ProgressResponse{
+		{JobName: "reads", Requests: 1000, Errors: 2, Rps: 100},
+		{JobName: "writes", Requests: 500, Errors: 1, WriteConflicts: 3, Rps: 50},
+		{JobName: "seed", Requests: 200, Rps: 400, IsFinished: true},
+	})
+	assert.Equal(t, &ProgressTotal{Jobs: 3, Running: 2, Requests: 1700, Errors: 3, WriteConflicts: 3, Rps: 150}, total)
+	assert.Equal(t, "Total of 3 jobs: 1700 requests, 3 errors, 150 RPS of 2 running jobs", total.String())
+
+	total.Running = 0
+	assert.Equal(t, "Total of 3 jobs: 1700 requests, 3 errors", total.String())
+}
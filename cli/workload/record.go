@@ -0,0 +1,329 @@
+package workload
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot"
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const recordedConnections = 10
+
+// RecordWorkload observes application traffic on database for duration and writes loadbot config approximating it.
+// Command monitoring sees only commands of its own client, so traffic of other clients is taken from
+// database profiler (level 2) enabled for the time of recording, previous profiling level is restored afterwards.
+func RecordWorkload(connectionString string, database string, duration time.Duration, output string, masker *Masker) (err error) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(connectionString))
+	if err != nil {
+		return fmt.Errorf("Connecting to database failed: %w", err)
+	}
+	defer client.Disconnect(context.Background())
+	db := client.Database(database)
+
+	var previous bson.M
+	if err = db.RunCommand(ctx, bson.D{{Key: "profile", Value: 2}, {Key: "slowms", Value: 0}}).Decode(&previous); err != nil {
+		return fmt.Errorf("Enabling profiler failed: %w", err)
+	}
+	defer db.RunCommand(context.Background(), bson.D{{Key: "profile", Value: previous["was"]}, {Key: "slowms", Value: previous["slowms"]}})
+
+	start := time.Now()
+	fmt.Printf("🎙️ Recording traffic on %s for %s, press Ctrl+C to finish earlier\n", database, duration)
+	select {
+	case <-ctx.Done():
+	case <-time.After(duration):
+	}
+	recorded := time.Since(start)
+
+	cursor, err := db.Collection("system.profile").Find(
+		context.Background(), bson.M{"ts": bson.M{"$gte": start}, "ns": bson.M{"$ne": database + ".system.profile"}},
+	)
+	if err != nil {
+		return fmt.Errorf("Reading profiler entries failed: %w", err)
+	}
+	var entries []bson.M
+	if err = cursor.All(context.Background(), &entries); err != nil {
+		return fmt.Errorf("Reading profiler entries failed: %w", err)
+	}
+
+	recorder := NewOperationRecorder(masker)
+	for _, entry := range entries {
+		recorder.Record(entry)
+	}
+	cfg := recorder.Config(connectionString, database, recorded)
+	recorder.PrintSummary(recorded)
+
+	data, err := json.MarshalIndent(cfg, "", "\t")
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		fmt.Println(string(data))
+		return nil
+	}
+	if err = os.WriteFile(output, data, 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Config saved to %s\n", output)
+	return nil
+}
+
+type recordedOperation struct {
+	collection string
+	jobType    string
+	filter     map[string]interface{}
+	update     map[string]interface{}
+	count      uint64
+	documents  uint64
+}
+
+// OperationRecorder groups profiled operations by collection, operation type and query shape
+type OperationRecorder struct {
+	masker      *Masker
+	operations  map[string]*recordedOperation
+	schemas     map[string]map[string]interface{}
+	unsupported map[string]uint64
+	dropped     int // less frequent shapes removed by Keep
+}
+
+func NewOperationRecorder(masker *Masker) *OperationRecorder {
+	return &OperationRecorder{
+		masker:      masker,
+		operations:  make(map[string]*recordedOperation),
+		schemas:     make(map[string]map[string]interface{}),
+		unsupported: make(map[string]uint64),
+	}
+}
+
+// Record adds single system.profile entry
+func (r *OperationRecorder) Record(entry bson.M) {
+	r.RecordCount(entry, 1)
+}
+
+// RecordCount adds system.profile entry executed count times
+func (r *OperationRecorder) RecordCount(entry bson.M, count uint64) {
+	ns, _ := entry["ns"].(string)
+	_, collection, found := strings.Cut(ns, ".")
+	command, _ := entry["command"].(bson.M)
+	if !found || command == nil {
+		return
+	}
+	op, _ := entry["op"].(string)
+
+	switch op {
+	case "insert":
+		documents, _ := command["documents"].(bson.A)
+		if len(documents) > 0 {
+			if document, ok := documents[0].(bson.M); ok && r.schemas[collection] == nil {
+				r.schemas[collection] = r.shape(document)
+			}
+		}
+		jobType := lo.If(len(documents) > 1, string(config.BulkWrite)).Else(string(config.Write))
+		r.add(collection, jobType, nil, nil, count, uint64(max(len(documents), 1)))
+	case "query":
+		filter, _ := command["filter"].(bson.M)
+		r.add(collection, string(config.Read), r.shape(filter), nil, count, 1)
+	case "update":
+		filter, _ := command["q"].(bson.M)
+		update, _ := command["u"].(bson.M)
+		r.add(collection, string(config.Update), r.shape(filter), r.shape(update), count, 1)
+	case "remove":
+		filter, _ := command["q"].(bson.M)
+		r.add(collection, string(config.Delete), r.shape(filter), nil, count, 1)
+	case "command":
+		if _, ok := command["findAndModify"]; ok {
+			filter, _ := command["query"].(bson.M)
+			if remove, _ := command["remove"].(bool); remove {
+				r.add(collection, string(config.FindOneAndDelete), r.shape(filter), nil, count, 1)
+			} else {
+				update, _ := command["update"].(bson.M)
+				r.add(collection, string(config.FindOneAndUpdate), r.shape(filter), r.shape(update), count, 1)
+			}
+			return
+		}
+		for name := range command {
+			r.unsupported[name] += count
+			break
+		}
+	default:
+		// getmore and killcursors are part of already counted queries
+		if op != "getmore" && op != "killcursors" {
+			r.unsupported[op] += count
+		}
+	}
+}
+
+func (r *OperationRecorder) shape(document bson.M) map[string]interface{} {
+	return MaskedShape(document, r.masker)
+}
+
+// add counts operation shape executed count times, every execution with given number of documents
+func (r *OperationRecorder) add(collection, jobType string, filter, update map[string]interface{}, count, documents uint64) {
+	operation, ok := r.operations[operationKey(collection, jobType, filter, update)]
+	if !ok {
+		operation = &recordedOperation{collection: collection, jobType: jobType, filter: filter, update: update}
+		r.operations[operationKey(collection, jobType, filter, update)] = operation
+	}
+	operation.count += count
+	operation.documents += documents * count
+}
+
+func operationKey(collection, jobType string, filter, update map[string]interface{}) string {
+	key, _ := json.Marshal([]interface{}{collection, jobType, filter, update})
+	return string(key)
+}
+
+// Keep removes all but top most frequent operation shapes, with schemas of collections left without operations
+func (r *OperationRecorder) Keep(top int) {
+	kept := make(map[string]bool)
+	for i, operation := range r.sortedOperations() {
+		if i < top {
+			kept[operation.collection] = true
+			continue
+		}
+		delete(r.operations, operationKey(operation.collection, operation.jobType, operation.filter, operation.update))
+		r.dropped++
+	}
+	for collection := range r.schemas {
+		if !kept[collection] {
+			delete(r.schemas, collection)
+		}
+	}
+}
+
+// Config builds workload config with one job per recorded operation shape, pace reflects observed rate
+func (r *OperationRecorder) Config(connectionString, database string, recorded time.Duration) *lbot.ConfigRequest {
+	cfg := &lbot.ConfigRequest{ConnectionString: connectionString}
+	for collection, schema := range r.schemas {
+		cfg.Schemas = append(cfg.Schemas, &lbot.SchemaRequest{
+			Name: collection, Database: database, Collection: collection, Schema: schema,
+		})
+	}
+	sort.Slice(cfg.Schemas, func(i, j int) bool { return cfg.Schemas[i].Name < cfg.Schemas[j].Name })
+
+	for i, operation := range r.sortedOperations() {
+		job := &lbot.JobRequest{
+			Name:        fmt.Sprintf("%s %s %d", operation.collection, operation.jobType, i+1),
+			Database:    database,
+			Collection:  operation.collection,
+			Type:        operation.jobType,
+			Connections: recordedConnections,
+			Pace:        uint64(math.Ceil(float64(operation.count) / recorded.Seconds())),
+			Duration:    recorded.Round(time.Second),
+			Filter:      operation.filter,
+			Update:      operation.update,
+		}
+		if operation.jobType == string(config.BulkWrite) {
+			job.BatchSize = operation.documents / operation.count
+		}
+		if r.schemas[operation.collection] != nil {
+			job.Schema = operation.collection
+		}
+		cfg.Jobs = append(cfg.Jobs, job)
+	}
+	return cfg
+}
+
+func (r *OperationRecorder) PrintSummary(recorded time.Duration) {
+	fmt.Printf("Recorded %s of traffic\n", recorded.Round(time.Millisecond))
+	for _, operation := range r.sortedOperations() {
+		fmt.Printf("   %-20s %-22s %8d ops\n", operation.collection, operation.jobType, operation.count)
+	}
+	if r.dropped > 0 {
+		fmt.Printf("   skipped %d less frequent operation shapes\n", r.dropped)
+	}
+	for name, count := range r.unsupported {
+		fmt.Printf("   skipped %d unsupported %s operations\n", count, name)
+	}
+}
+
+func (r *OperationRecorder) sortedOperations() []*recordedOperation {
+	operations := lo.Values(r.operations)
+	sort.Slice(operations, func(i, j int) bool {
+		if operations[i].count != operations[j].count {
+			return operations[i].count > operations[j].count
+		}
+		return operations[i].collection+operations[i].jobType < operations[j].collection+operations[j].jobType
+	})
+	return operations
+}
+
+// QueryShape replaces string values of document with generator placeholders, keeping field names,
+// operators and other literal values, ex. {"name": "john", "age": {"$gt": 18}} -> {"name": "#string", "age": {"$gt": 18}}
+func QueryShape(document bson.M) map[string]interface{} {
+	return MaskedShape(document, nil)
+}
+
+// MaskedShape is QueryShape with mask rules applied to values of matching fields
+func MaskedShape(document bson.M, masker *Masker) map[string]interface{} {
+	return documentShape(document, "", masker)
+}
+
+func documentShape(document bson.M, path string, masker *Masker) map[string]interface{} {
+	if document == nil {
+		return nil
+	}
+	shape := make(map[string]interface{}, len(document))
+	for key, value := range document {
+		fieldPath := path
+		// operators like $gt or $set are not part of field path
+		if !strings.HasPrefix(key, "$") {
+			fieldPath = strings.TrimPrefix(path+"."+key, ".")
+		}
+		if masker.Drops(fieldPath) {
+			continue
+		}
+		shape[key] = valueShape(value, fieldPath, masker)
+	}
+	return shape
+}
+
+// valueShape walks documents and arrays of every bson representation, values without mask rule are replaced
+// with generators of their type, only numbers and booleans (ex. operator arguments like {"$gt": 18}) are kept
+func valueShape(value interface{}, path string, masker *Masker) interface{} {
+	switch v := value.(type) {
+	case bson.M:
+		return documentShape(v, path, masker)
+	case map[string]interface{}:
+		return documentShape(v, path, masker)
+	case bson.D:
+		document := make(bson.M, len(v))
+		for _, element := range v {
+			document[element.Key] = element.Value
+		}
+		return documentShape(document, path, masker)
+	case bson.A:
+		return lo.Map(v, func(item interface{}, _ int) interface{} { return valueShape(item, path, masker) })
+	case []interface{}:
+		return lo.Map(v, func(item interface{}, _ int) interface{} { return valueShape(item, path, masker) })
+	}
+	if masked, matched := masker.Mask(path, value); matched {
+		return masked
+	}
+	switch value.(type) {
+	case primitive.ObjectID:
+		return "#object_id"
+	case primitive.DateTime, primitive.Timestamp, time.Time:
+		return "#date"
+	case primitive.Decimal128:
+		return "#decimal128"
+	case string, primitive.Binary, primitive.Regex, primitive.JavaScript, primitive.Symbol:
+		return "#string"
+	}
+	return value
+}
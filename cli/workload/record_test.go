@@ -0,0 +1,120 @@
+package workload
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestQueryShape(t *testing.T) {
+	shape := QueryShape(bson.M{
+		"name": "john",
+		"age":  bson.M{"$gt": int32(18)},
+		"tags": bson.A{"a", int32(1)},
+		"meta": bson.D{{Key: "source", Value: "web"}},
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"name": "#string",
+		"age":  map[string]interface{}{"$gt": int32(18)},
+		"tags": []interface{}{"#string", int32(1)},
+		"meta": map[string]interface{}{"source": "#string"},
+	}, shape)
+}
+
+func TestOperationRecorderConfig(t *testing.T) {
+	recorder := NewOperationRecorder(nil)
+	for i := 0; i < 20; i++ {
+		recorder.Record(bson.M{"op": "query", "ns": "shop.orders", "command": bson.M{"find": "orders", "filter": bson.M{"user": "u1"}}})
+	}
+	for i := 0; i < 5; i++ {
+		recorder.Record(bson.M{"op": "insert", "ns": "shop.orders", "command": bson.M{
+			"insert": "orders", "documents": bson.A{bson.M{"user": "u1"}, bson.M{"user": "u2"}},
+		}})
+	}
+	recorder.Record(bson.M{"op": "getmore", "ns": "shop.orders", "command": bson.M{"getMore": int64(1)}})
+
+	cfg := recorder.Config("mongodb://localhost", "shop", 10*time.Second)
+
+	assert.Len(t, cfg.Schemas, 1)
+	assert.Len(t, cfg.Jobs, 2)
+	assert.Equal(t, "read", cfg.Jobs[0].Type)
+	assert.Equal(t, uint64(2), cfg.Jobs[0].Pace)
+	assert.Equal(t, map[string]interface{}{"user": "#string"}, cfg.Jobs[0].Filter)
+	assert.Equal(t, "bulk_write", cfg.Jobs[1].Type)
+	assert.Equal(t, uint64(1), cfg.Jobs[1].Pace)
+	assert.Equal(t, uint64(2), cfg.Jobs[1].BatchSize)
+	assert.Equal(t, "orders", cfg.Jobs[1].Schema)
+
+	// recorded config is read back the way agent reads config files
+	data, err := json.Marshal(cfg)
+	assert.NoError(t, err)
+	parsed, err := lbot.ParseConfig(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "orders", parsed.Jobs[1].Schema)
+	assert.Equal(t, cfg.Jobs[0].Filter, parsed.Jobs[0].Filter)
+	assert.Equal(t, cfg.Jobs[1].Duration, parsed.Jobs[1].Duration)
+	assert.Equal(t, cfg.Schemas[0].Name, parsed.Schemas[0].Name)
+}
+
+func TestMaskedShape(t *testing.T) {
+	masker, err := ParseMaskRules([]string{
+		"email=#email", "ssn=hash", "status=keep", "address.city=truncate:2", "token=drop",
+	}, "agent secret")
+	assert.NoError(t, err)
+
+	shape := MaskedShape(bson.M{
+		"email":   "john@example.com",
+		"ssn":     int64(123456789),
+		"status":  bson.M{"$in": bson.A{"new", "paid"}},
+		"address": bson.M{"city": "Warsaw", "zip": "00-001"},
+		"token":   "secret",
+		"age":     int32(30),
+	}, masker)
+
+	assert.Equal(t, "#email", shape["email"])
+	assert.Len(t, shape["ssn"], maskHashLength)
+	assert.NotEqual(t, int64(123456789), shape["ssn"])
+	assert.Equal(t, map[string]interface{}{"$in": []interface{}{"new", "paid"}}, shape["status"])
+	assert.Equal(t, map[string]interface{}{"city": "Wa", "zip": "#string"}, shape["address"])
+	assert.NotContains(t, shape, "token")
+	assert.Equal(t, int32(30), shape["age"])
+
+	// hash is keyed, the same value hashed with other key differs
+	other, err := ParseMaskRules([]string{"ssn=hash"}, "other secret")
+	assert.NoError(t, err)
+	assert.Equal(t, shape["ssn"], MaskedShape(bson.M{"ssn": int64(123456789)}, masker)["ssn"])
+	assert.NotEqual(t, shape["ssn"], MaskedShape(bson.M{"ssn": int64(123456789)}, other)["ssn"])
+
+	_, err = ParseMaskRules([]string{"email=unknown"}, "")
+	assert.Error(t, err)
+}
+
+func TestMaskedShapeOfBsonTypes(t *testing.T) {
+	shape := MaskedShape(bson.M{
+		"_id":     primitive.NewObjectID(),
+		"created": primitive.NewDateTimeFromTime(time.Now()),
+		"total":   primitive.NewDecimal128(1, 0),
+		"avatar":  primitive.Binary{Data: []byte("png")},
+		"name":    primitive.Regex{Pattern: "^jo"},
+		"items":   []interface{}{map[string]interface{}{"sku": "A-1", "qty": int32(2)}},
+		"meta":    bson.D{{Key: "tags", Value: bson.A{"vip"}}},
+		"active":  true,
+	}, nil)
+
+	assert.Equal(t, map[string]interface{}{
+		"_id":     "#object_id",
+		"created": "#date",
+		"total":   "#decimal128",
+		"avatar":  "#string",
+		"name":    "#string",
+		"items":   []interface{}{map[string]interface{}{"sku": "#string", "qty": int32(2)}},
+		"meta":    map[string]interface{}{"tags": []interface{}{"#string"}},
+		"active":  true,
+	}, shape)
+}
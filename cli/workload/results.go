@@ -0,0 +1,72 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"google.golang.org/grpc"
+)
+
+// RunResults lists artifacts (raw latency logs, job reports) saved by agent for run,
+// with download they are saved in output directory
+func RunResults(conn grpc.ClientConnInterface, runId string, download bool, output string) error {
+	client := proto.NewArtifactServiceClient(conn)
+	response, err := client.ListArtifacts(context.TODO(), &proto.ListArtifactsRequest{RunId: runId})
+	if err != nil {
+		return fmt.Errorf("Listing run artifacts failed: %w", err)
+	}
+	if !download {
+		for _, artifact := range response.Artifacts {
+			fmt.Printf("%s\t%d bytes\n", artifact.Name, artifact.Size)
+		}
+		if annotations, err := runAnnotations(conn, runId); err == nil && len(annotations) > 0 {
+			fmt.Println("\nAnnotations:")
+			printAnnotations(annotations)
+		}
+		return nil
+	}
+
+	if output == "" {
+		output = runId
+	}
+	if err = os.MkdirAll(output, 0o755); err != nil {
+		return err
+	}
+	for _, artifact := range response.Artifacts {
+		path := filepath.Join(output, artifact.Name)
+		if err = downloadArtifact(client, runId, artifact.Name, path); err != nil {
+			return fmt.Errorf("Downloading artifact %s failed: %w", artifact.Name, err)
+		}
+		fmt.Printf("✅ Downloaded %s\n", path)
+	}
+	return nil
+}
+
+func downloadArtifact(client proto.ArtifactServiceClient, runId string, name string, path string) error {
+	stream, err := client.DownloadArtifact(context.TODO(), &proto.DownloadArtifactRequest{RunId: runId, Name: name})
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err = file.Write(chunk.Data); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package workload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+type smokeStep struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// Smoke runs tiny write/read/update/delete cycle on temporary collection and reports result of every step,
+// meant for validating connectivity and permissions of a new cluster
+func Smoke(uri string, database string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return fmt.Errorf("Connecting to database failed: %w", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	collection := client.Database(database).Collection(fmt.Sprintf("smoke_%d", time.Now().UnixNano()))
+	id := primitive.NewObjectID()
+	steps := []smokeStep{
+		{"ping", func(ctx context.Context) error {
+			return client.Ping(ctx, readpref.Primary())
+		}},
+		{"write", func(ctx context.Context) error {
+			_, err := collection.InsertOne(ctx, bson.M{"_id": id, "counter": 0, "created_at": time.Now()})
+			return err
+		}},
+		{"read", func(ctx context.Context) error {
+			return collection.FindOne(ctx, bson.M{"_id": id}).Err()
+		}},
+		{"update", func(ctx context.Context) error {
+			result, err := collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$inc": bson.M{"counter": 1}})
+			if err == nil && result.ModifiedCount != 1 {
+				err = errors.New("document not modified")
+			}
+			return err
+		}},
+		{"delete", func(ctx context.Context) error {
+			result, err := collection.DeleteOne(ctx, bson.M{"_id": id})
+			if err == nil && result.DeletedCount != 1 {
+				err = errors.New("document not deleted")
+			}
+			return err
+		}},
+		{"drop", func(ctx context.Context) error {
+			return collection.Drop(ctx)
+		}},
+	}
+
+	fmt.Printf("💨 Smoke testing %s.%s\n", database, collection.Name())
+	failed := 0
+	for _, step := range steps {
+		start := time.Now()
+		err := step.run(ctx)
+		latency := time.Since(start)
+		if err != nil {
+			failed++
+			fmt.Printf("   ❌ %-7s %10s  %s\n", step.name, latency.Round(time.Microsecond), err)
+			if step.name == "ping" {
+				// next steps would fail the same way
+				return fmt.Errorf("smoke test failed, cluster not reachable")
+			}
+			continue
+		}
+		fmt.Printf("   ✅ %-7s %10s\n", step.name, latency.Round(time.Microsecond))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("smoke test failed, %d of %d steps failed", failed, len(steps))
+	}
+	fmt.Println("✅ Smoke test passed")
+	return nil
+}
@@ -5,9 +5,16 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/kuzxnia/loadbot/lbot/worker"
+	"github.com/samber/lo"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 // checks if process is running in local system
@@ -19,16 +26,148 @@ func StartWorkload(conn grpc.ClientConnInterface, request *proto.StartRequest) (
 
 	client := proto.NewStartProcessClient(conn)
 
-	_, err = client.Run(context.TODO(), request)
+	response, err := client.Run(context.TODO(), request)
 	if err != nil {
 		return fmt.Errorf("starting stress test failed: %w", err)
 	}
+	if response.ApprovalId != "" {
+		printPendingApproval(response.ApprovalId)
+		return
+	}
 
-	fmt.Println("✅ Starting stress test succeeded")
+	printStartedJobs(response)
+	return
+}
+
+// ConfirmFootprint prints estimated footprint of workload and fails when it exceeds agent thresholds
+// and start is not confirmed, agents without estimates are skipped
+func ConfirmFootprint(conn grpc.ClientConnInterface, confirmed bool) error {
+	footprint, err := proto.NewStartProcessClient(conn).Estimate(context.TODO(), &emptypb.Empty{})
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("estimating footprint failed: %w", err)
+	}
+	fmt.Printf(
+		"📦 Estimated footprint: %d operations, %d documents, %s written, %s sent to database\n",
+		footprint.Operations, footprint.Documents, worker.FormatBytes(footprint.WrittenBytes), worker.FormatBytes(footprint.EgressBytes),
+	)
+	if len(footprint.UnboundedJobs) > 0 {
+		fmt.Printf("   not estimated (no operations limit or duration and rate): %s\n", strings.Join(footprint.UnboundedJobs, ", "))
+	}
+	if len(footprint.Exceeded) == 0 {
+		return nil
+	}
+	fmt.Printf("⚠️  Estimate exceeds agent thresholds: %s\n", strings.Join(footprint.Exceeded, ", "))
+	if !confirmed {
+		return fmt.Errorf("start not confirmed, rerun with --yes to start anyway")
+	}
+	return nil
+}
+
+// ParseStartTime parses RFC 3339 time or HH:MM / HH:MM:SS UTC clock time (like cron schedule) of its next
+// occurrence after now
+func ParseStartTime(value string, now time.Time) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	now = now.UTC()
+	parsed, err := ParseAnnotationTime(value, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid start time %q, expected RFC 3339 time or HH:MM", value)
+	}
+	if !parsed.After(now) {
+		parsed = parsed.AddDate(0, 0, 1)
+	}
+	return parsed, nil
+}
+
+func printStartedJobs(response *proto.StartResponse) {
+	if response.ScheduledAt != "" {
+		fmt.Printf("⏰ Stress test scheduled, first start at %s\n", response.ScheduledAt)
+		return
+	}
+	fmt.Printf("✅ Starting stress test succeeded, run %s\n", response.RunId)
+	for _, job := range response.GetJobs() {
+		fmt.Printf("   %s (%s) - command %s\n", job.JobName, job.JobType, job.CommandId)
+	}
+}
+
+// StartWorkloadAndWait starts workload and blocks until all started jobs finish, prints summary
+// and returns error when any job exceeded maxErrorRate (negative value disables the check)
+func StartWorkloadAndWait(conn grpc.ClientConnInterface, request *proto.StartRequest, interval time.Duration, maxErrorRate float64) (err error) {
+	fmt.Println("🚀 Starting stress test")
+
+	startTime := time.Now()
+	finished, err := runAndWait(conn, request, interval)
+	if err != nil {
+		return err
+	}
+
+	summary, failed := FinishedSummary(finished, time.Since(startTime), maxErrorRate)
+	summary.Print()
+	if failed > 0 {
+		return fmt.Errorf("%d jobs exceeded max error rate %.4f", failed, maxErrorRate)
+	}
 
 	return
 }
 
+// runAndWait starts workload and polls progress snapshots every interval until all started jobs finish, once run
+// has no running jobs and agent forgot them final stats come from run history
+func runAndWait(conn grpc.ClientConnInterface, request *proto.StartRequest, interval time.Duration) ([]*proto.ProgressResponse, error) {
+	response, err := proto.NewStartProcessClient(conn).Run(context.TODO(), request)
+	if err != nil {
+		return nil, fmt.Errorf("starting stress test failed: %w", err)
+	}
+	if response.ApprovalId != "" {
+		printPendingApproval(response.ApprovalId)
+		return nil, fmt.Errorf("agent is protected, start %s waits for approval and can't be awaited", response.ApprovalId)
+	}
+	fmt.Printf("✅ Starting stress test succeeded, run %s, waiting for completion\n", response.RunId)
+
+	started := lo.SliceToMap(response.GetJobs(), func(job *proto.StartedJob) (string, bool) { return job.CommandId, true })
+	for {
+		time.Sleep(interval)
+		snapshot, err := progressSnapshot(conn, response.RunId)
+		if err != nil {
+			return nil, err
+		}
+		jobs := lo.Filter(snapshot, func(job *proto.ProgressResponse, _ int) bool { return started[job.CommandId] })
+		commands := lo.Uniq(lo.Map(jobs, func(job *proto.ProgressResponse, _ int) string { return job.CommandId }))
+		if len(commands) == len(started) && lo.EveryBy(jobs, func(job *proto.ProgressResponse) bool { return job.IsFinished }) {
+			return jobs, nil
+		}
+		if lo.EveryBy(jobs, func(job *proto.ProgressResponse) bool { return job.IsFinished }) {
+			// jobs which never started (ex. skipped) or were forgotten by agent are known only to run history
+			summary, err := proto.NewRunHistoryServiceClient(conn).GetRun(context.TODO(), &proto.GetRunRequest{RunId: response.RunId})
+			if err == nil {
+				return summaryJobs(summary), nil
+			}
+		}
+	}
+}
+
+// summaryJobs returns final progress of jobs of ended run
+func summaryJobs(summary *proto.RunSummary) []*proto.ProgressResponse {
+	return lo.Map(summary.Jobs, func(job *proto.RunJobSummary, _ int) *proto.ProgressResponse {
+		return &proto.ProgressResponse{
+			RunId:            summary.RunId,
+			JobName:          job.JobName,
+			JobType:          job.JobType,
+			Requests:         job.Requests,
+			Errors:           job.Errors,
+			ErrorRate:        lo.Ternary(job.Requests > 0, float32(job.Errors)/float32(job.Requests), 0),
+			Rps:              job.Rps,
+			AverageLatencyMs: float32(job.AverageLatencyMs),
+			Duration:         job.Duration,
+			IsFinished:       true,
+			StopReason:       job.StopReason,
+		}
+	})
+}
+
 func StartWorkloadWithProgress(conn grpc.ClientConnInterface, request *proto.StartWithProgressRequest) (err error) {
 	// todo: mapowanie to proto
 	fmt.Println("🚀 Starting stress test")
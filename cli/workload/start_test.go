@@ -0,0 +1,34 @@
+package workload
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStartTime(t *testing.T) {
+	location := time.FixedZone("CET", 3600)
+	now := time.Date(2024, 5, 2, 18, 0, 0, 0, location)
+
+	// clock time is UTC like cron schedule, not local time
+	parsed, err := ParseStartTime("22:30", now)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 5, 2, 22, 30, 0, 0, time.UTC), parsed)
+	parsed, err = ParseStartTime("17:30", now)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 5, 2, 17, 30, 0, 0, time.UTC), parsed)
+
+	// passed clock time is next day
+	parsed, err = ParseStartTime("02:00", now)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 5, 3, 2, 0, 0, 0, time.UTC), parsed)
+
+	// RFC 3339 time is kept even if passed, agent rejects it
+	parsed, err = ParseStartTime("2024-05-01T10:00:00Z", now)
+	assert.Nil(t, err)
+	assert.True(t, parsed.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)))
+
+	_, err = ParseStartTime("tonight", now)
+	assert.Error(t, err)
+}
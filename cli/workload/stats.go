@@ -0,0 +1,28 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"google.golang.org/grpc"
+)
+
+// ResetStats zeroes counters of running jobs, job is name of job to reset or all jobs if empty,
+// prints stats of measurement window closed by reset
+func ResetStats(conn grpc.ClientConnInterface, job string) error {
+	client := proto.NewStatsServiceClient(conn)
+	response, err := client.ResetStats(context.TODO(), &proto.ResetStatsRequest{Job: job})
+	if err != nil {
+		return fmt.Errorf("Resetting stats failed: %w", err)
+	}
+
+	fmt.Println("🔄 Stats reset, previous window:")
+	for _, workload := range response.GetWorkloads() {
+		fmt.Printf(
+			"   %s (%s) - workload %s, requests: %d, errors: %d, duration: %ds\n",
+			workload.JobName, workload.JobType, workload.WorkloadId, workload.Requests, workload.Errors, workload.Duration,
+		)
+	}
+	return nil
+}
@@ -14,13 +14,20 @@ func StopWorkload(conn grpc.ClientConnInterface, request *proto.StopRequest) (er
 
 	client := proto.NewStopProcessClient(conn)
 
-	_, err = client.Run(context.TODO(), request)
+	response, err := client.Run(context.TODO(), request)
 	if err != nil {
 		log.Fatal("arith error:", err)
 		return
 	}
 
 	fmt.Println("✅ Stopping stress test succeeded")
+	if request.Schedules {
+		fmt.Printf("   cancelled %d scheduled starts\n", response.CancelledSchedules)
+	}
+	if len(response.GetWorkloads()) > 0 {
+		summary := StoppedSummary(response.GetWorkloads())
+		summary.Print()
+	}
 
 	return nil
 }
@@ -0,0 +1,306 @@
+package workload
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cheggaaa/pb/v3/termutil"
+	"github.com/fatih/color"
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/mattn/go-runewidth"
+	"github.com/samber/lo"
+)
+
+const (
+	SummaryPass = "PASS"
+	SummaryFail = "FAIL"
+	SummaryNone = "-"
+)
+
+// SummaryColumn is column of summary table, numeric columns are right aligned
+type SummaryColumn struct {
+	Name    string
+	Numeric bool
+	// columns are dropped from highest drop order when table is wider than terminal, 0 is never dropped
+	DropOrder int
+}
+
+type SummaryRow struct {
+	Cells  []string
+	Failed bool // row is printed red, ex. job failed assertion
+}
+
+// Summary is table of job results printed when workload finishes or stops, with totals row,
+// assertion outcomes and warnings
+type Summary struct {
+	Title    string
+	Columns  []SummaryColumn
+	Rows     []SummaryRow
+	Totals   []string // no totals row when empty
+	Warnings []string
+}
+
+var (
+	headerColor  = color.New(color.Bold)
+	totalsColor  = color.New(color.Bold)
+	failColor    = color.New(color.FgRed, color.Bold)
+	passColor    = color.New(color.FgGreen, color.Bold)
+	warningColor = color.New(color.FgYellow)
+)
+
+// Print prints summary to stdout fitted to terminal width, colored unless disabled with --no-color,
+// NO_COLOR or when stdout isn't terminal
+func (s *Summary) Print() {
+	s.Render(color.Output, TerminalWidth())
+}
+
+// Render writes summary to w, columns are dropped (by drop order) and first column is truncated
+// until table fits width, width 0 doesn't limit table
+func (s *Summary) Render(w io.Writer, width int) {
+	if s.Title != "" {
+		fmt.Fprintln(w, s.Title)
+	}
+	columns := s.fitColumns(width)
+	widths := s.columnWidths(columns)
+	if width > 0 {
+		if excess := tableWidth(widths) - width; excess > 0 {
+			// ellipsis needs at least one column
+			widths[0] = max(widths[0]-excess, min(widths[0], 4))
+		}
+	}
+
+	cells := make([]string, len(columns))
+	for i, column := range columns {
+		cells[i] = headerColor.Sprint(pad(s.Columns[column].Name, widths[i], s.Columns[column].Numeric))
+	}
+	fmt.Fprintln(w, "   "+strings.Join(cells, "  "))
+	separator := make([]string, len(columns))
+	for i := range columns {
+		separator[i] = strings.Repeat("─", widths[i])
+	}
+	fmt.Fprintln(w, "   "+strings.Join(separator, "  "))
+
+	for _, row := range s.Rows {
+		for i, column := range columns {
+			cell := pad(cellAt(row.Cells, column), widths[i], s.Columns[column].Numeric)
+			switch {
+			case row.Failed:
+				cell = failColor.Sprint(cell)
+			case cellAt(row.Cells, column) == SummaryPass:
+				cell = passColor.Sprint(cell)
+			}
+			cells[i] = cell
+		}
+		fmt.Fprintln(w, "   "+strings.Join(cells, "  "))
+	}
+	if len(s.Totals) > 0 && len(s.Rows) > 1 {
+		fmt.Fprintln(w, "   "+strings.Join(separator, "  "))
+		for i, column := range columns {
+			cells[i] = totalsColor.Sprint(pad(cellAt(s.Totals, column), widths[i], s.Columns[column].Numeric))
+		}
+		fmt.Fprintln(w, "   "+strings.Join(cells, "  "))
+	}
+	for _, warning := range s.Warnings {
+		fmt.Fprintln(w, warningColor.Sprint("⚠️  "+warning))
+	}
+}
+
+// fitColumns returns indexes of columns shown in table of width, dropping optional columns
+func (s *Summary) fitColumns(width int) []int {
+	columns := make([]int, len(s.Columns))
+	for i := range s.Columns {
+		columns[i] = i
+	}
+	for width > 0 && tableWidth(s.columnWidths(columns)) > width {
+		drop := -1
+		for i, column := range columns {
+			if order := s.Columns[column].DropOrder; order > 0 && (drop < 0 || order > s.Columns[columns[drop]].DropOrder) {
+				drop = i
+			}
+		}
+		if drop < 0 {
+			break
+		}
+		columns = append(columns[:drop], columns[drop+1:]...)
+	}
+	return columns
+}
+
+func (s *Summary) columnWidths(columns []int) []int {
+	widths := make([]int, len(columns))
+	for i, column := range columns {
+		widths[i] = runewidth.StringWidth(s.Columns[column].Name)
+		for _, row := range s.Rows {
+			widths[i] = max(widths[i], runewidth.StringWidth(cellAt(row.Cells, column)))
+		}
+		widths[i] = max(widths[i], runewidth.StringWidth(cellAt(s.Totals, column)))
+	}
+	return widths
+}
+
+// tableWidth is width of rendered row, with indent and column gaps
+func tableWidth(widths []int) int {
+	total := 3 + 2*max(len(widths)-1, 0)
+	for _, width := range widths {
+		total += width
+	}
+	return total
+}
+
+func cellAt(cells []string, i int) string {
+	if i < len(cells) {
+		return cells[i]
+	}
+	return ""
+}
+
+// pad aligns cell to width, truncating it with ellipsis when it's wider
+func pad(cell string, width int, right bool) string {
+	if runewidth.StringWidth(cell) > width {
+		cell = runewidth.Truncate(cell, width, "…")
+	}
+	if right {
+		return runewidth.FillLeft(cell, width)
+	}
+	return runewidth.FillRight(cell, width)
+}
+
+// TerminalWidth returns width of terminal from COLUMNS or stdout, 0 when stdout isn't terminal
+func TerminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	if width, err := termutil.TerminalWidth(); err == nil && width > 0 {
+		return width
+	}
+	return 0
+}
+
+// HumanizeCount formats count with metric suffix, ex. 12.3k
+func HumanizeCount(count uint64) string {
+	if count < 1000 {
+		return strconv.FormatUint(count, 10)
+	}
+	exponent := min(int(math.Log10(float64(count))/3), 6)
+	value := float64(count) / math.Pow(1000, float64(exponent))
+	if value >= 999.95 && exponent < 6 {
+		// 999999 would be rounded to 1000.0k
+		value, exponent = value/1000, exponent+1
+	}
+	return fmt.Sprintf("%.1f%c", value, "kMGTPE"[exponent-1])
+}
+
+// HumanizeSeconds formats seconds as duration, ex. 1h2m3s
+func HumanizeSeconds(seconds uint64) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
+
+// HumanizeRate formats error rate as percent
+func HumanizeRate(rate float64) string {
+	if rate == 0 {
+		return "0%"
+	}
+	if rate < 0.0001 {
+		return "<0.01%"
+	}
+	return fmt.Sprintf("%.2f%%", rate*100)
+}
+
+// FinishedSummary builds summary of finished jobs with max error rate assertion outcome of every job
+// (negative max error rate disables assertion), returns number of jobs which failed it. Total rps is total requests
+// over wall-clock time of run, jobs may run one after another so their rps can't be summed, when elapsed is 0
+// longest job duration is used
+func FinishedSummary(jobs []*proto.ProgressResponse, elapsed time.Duration, maxErrorRate float64) (summary Summary, failed int) {
+	summary = Summary{
+		Title: "🏁 Stress test finished",
+		Columns: []SummaryColumn{
+			{Name: "JOB"}, {Name: "TYPE", DropOrder: 2}, {Name: "REQUESTS", Numeric: true}, {Name: "ERRORS", Numeric: true},
+			{Name: "ERROR RATE", Numeric: true}, {Name: "RPS", Numeric: true, DropOrder: 1},
+			{Name: "DURATION", Numeric: true, DropOrder: 3}, {Name: "RESULT"},
+		},
+	}
+	var requests, errors, duration uint64
+	for _, job := range jobs {
+		result := SummaryNone
+		if maxErrorRate >= 0 {
+			result = SummaryPass
+			if float64(job.ErrorRate) > maxErrorRate {
+				result = SummaryFail
+				failed++
+				summary.Warnings = append(summary.Warnings, fmt.Sprintf(
+					"%s error rate %s exceeds max error rate %s", job.JobName, HumanizeRate(float64(job.ErrorRate)), HumanizeRate(maxErrorRate),
+				))
+			}
+		}
+		if job.StopReason == "cancelled" {
+			summary.Warnings = append(summary.Warnings, job.JobName+" was cancelled before it finished")
+		}
+		summary.Rows = append(summary.Rows, SummaryRow{
+			Cells: []string{
+				job.JobName, job.JobType, HumanizeCount(job.Requests), HumanizeCount(job.Errors),
+				HumanizeRate(float64(job.ErrorRate)), HumanizeCount(job.Rps), HumanizeSeconds(job.Duration), result,
+			},
+			Failed: result == SummaryFail,
+		})
+		requests += job.Requests
+		errors += job.Errors
+		duration = max(duration, job.Duration)
+	}
+	if elapsed > 0 {
+		duration = uint64(elapsed.Seconds())
+	}
+	var rps uint64
+	if duration > 0 {
+		rps = requests / duration
+	}
+	totalResult := SummaryNone
+	if maxErrorRate >= 0 {
+		totalResult = lo.Ternary(failed > 0, SummaryFail, SummaryPass)
+	}
+	summary.Totals = []string{
+		"total", "", HumanizeCount(requests), HumanizeCount(errors), HumanizeRate(errorRate(requests, errors)),
+		HumanizeCount(rps), HumanizeSeconds(duration), totalResult,
+	}
+	return summary, failed
+}
+
+// StoppedSummary builds summary of workloads stopped by stop request
+func StoppedSummary(workloads []*proto.StoppedWorkload) (summary Summary) {
+	summary = Summary{
+		Columns: []SummaryColumn{
+			{Name: "JOB"}, {Name: "TYPE", DropOrder: 2}, {Name: "WORKLOAD", DropOrder: 4}, {Name: "STATUS"},
+			{Name: "REQUESTS", Numeric: true}, {Name: "ERRORS", Numeric: true}, {Name: "ABANDONED", Numeric: true, DropOrder: 1},
+			{Name: "DURATION", Numeric: true, DropOrder: 3},
+		},
+	}
+	var requests, errors, abandoned uint64
+	for _, workload := range workloads {
+		summary.Rows = append(summary.Rows, SummaryRow{
+			Cells: []string{
+				workload.JobName, workload.JobType, workload.WorkloadId, workload.Status, HumanizeCount(workload.Requests),
+				HumanizeCount(workload.Errors), HumanizeCount(workload.Abandoned), HumanizeSeconds(workload.Duration),
+			},
+			Failed: workload.Abandoned > 0,
+		})
+		requests += workload.Requests
+		errors += workload.Errors
+		abandoned += workload.Abandoned
+	}
+	summary.Totals = []string{"total", "", "", "", HumanizeCount(requests), HumanizeCount(errors), HumanizeCount(abandoned), ""}
+	if abandoned > 0 {
+		summary.Warnings = append(summary.Warnings, fmt.Sprintf("%d in-flight operations were abandoned after grace period", abandoned))
+	}
+	return summary
+}
+
+func errorRate(requests uint64, errors uint64) float64 {
+	if requests == 0 {
+		return 0
+	}
+	return float64(errors) / float64(requests)
+}
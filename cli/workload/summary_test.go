@@ -0,0 +1,74 @@
+package workload
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHumanize(t *testing.T) {
+	assert.Equal(t, "999", HumanizeCount(999))
+	assert.Equal(t, "12.3k", HumanizeCount(12345))
+	assert.Equal(t, "1.0M", HumanizeCount(999999))
+	assert.Equal(t, "2.5G", HumanizeCount(2500000000))
+
+	assert.Equal(t, "1m30s", HumanizeSeconds(90))
+	assert.Equal(t, "0%", HumanizeRate(0))
+	assert.Equal(t, "<0.01%", HumanizeRate(0.00001))
+	assert.Equal(t, "1.25%", HumanizeRate(0.0125))
+}
+
+func TestFinishedSummary(t *testing.T) {
+	jobs := []*proto.ProgressResponse{
+		{JobName: "inserts", JobType: "write", Requests: 10000, Errors: 5, ErrorRate: 0.0005, Rps: 100, Duration: 100},
+		{JobName: "reads", JobType: "read", Requests: 2000, Errors: 200, ErrorRate: 0.1, Rps: 20, Duration: 90},
+	}
+	summary, failed := FinishedSummary(jobs, 0, 0.01)
+	assert.Equal(t, 1, failed)
+	assert.False(t, summary.Rows[0].Failed)
+	assert.True(t, summary.Rows[1].Failed)
+	assert.Equal(t, []string{"total", "", "12.0k", "205", "1.71%", "120", "1m40s", SummaryFail}, summary.Totals)
+
+	// sequential jobs, rps of total is requests over wall-clock time
+	summary, _ = FinishedSummary(jobs, 190*time.Second, 0.01)
+	assert.Equal(t, []string{"total", "", "12.0k", "205", "1.71%", "63", "3m10s", SummaryFail}, summary.Totals)
+	assert.Equal(t, []string{"reads error rate 10.00% exceeds max error rate 1.00%"}, summary.Warnings)
+
+	summary, failed = FinishedSummary(jobs, 0, -1)
+	assert.Zero(t, failed)
+	assert.Equal(t, SummaryNone, summary.Rows[1].Cells[7])
+	assert.Empty(t, summary.Warnings)
+}
+
+func TestSummaryRender(t *testing.T) {
+	color.NoColor = true
+	summary := StoppedSummary([]*proto.StoppedWorkload{
+		{JobName: "inserts", JobType: "write", WorkloadId: "65f1c0ffee", Status: "drained", Requests: 1500, Duration: 60},
+		{JobName: "long-running-updates", JobType: "update", WorkloadId: "65f1decade", Status: "forced", Requests: 20, Abandoned: 3, Duration: 61},
+	})
+	assert.Equal(t, []string{"3 in-flight operations were abandoned after grace period"}, summary.Warnings)
+
+	var output bytes.Buffer
+	summary.Render(&output, 0)
+	lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+	assert.Equal(t, "   JOB                   TYPE    WORKLOAD    STATUS   REQUESTS  ERRORS  ABANDONED  DURATION", lines[0])
+	assert.Equal(t, "   inserts               write   65f1c0ffee  drained      1.5k       0          0      1m0s", lines[2])
+	assert.Equal(t, "   total                                                  1.5k       0          3          ", lines[5])
+	assert.Len(t, lines, 7)
+
+	// optional columns are dropped first, then job name is truncated
+	output.Reset()
+	summary.Render(&output, 60)
+	lines = strings.Split(output.String(), "\n")
+	assert.Equal(t, "   JOB                   STATUS   REQUESTS  ERRORS", lines[0])
+	output.Reset()
+	summary.Render(&output, 40)
+	lines = strings.Split(output.String(), "\n")
+	assert.Equal(t, "   JOB         STATUS   REQUESTS  ERRORS", lines[0])
+	assert.Equal(t, "   long-runn…  forced         20       0", lines[3])
+}
@@ -0,0 +1,129 @@
+package workload
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot"
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/samber/lo"
+	"google.golang.org/grpc"
+)
+
+var DefaultSweepConnections = []uint{8, 16, 32, 64, 128, 256, 512}
+
+// SweepPoint is result of job run with given number of connections
+type SweepPoint struct {
+	Connections      uint64  `json:"connections"`
+	Requests         uint64  `json:"requests"`
+	Errors           uint64  `json:"errors"`
+	ErrorRate        float64 `json:"error_rate"`
+	Rps              uint64  `json:"rps"`
+	AverageLatencyMs float64 `json:"average_latency_ms"`
+	DurationSeconds  uint64  `json:"duration_seconds"`
+}
+
+// SweepResult is throughput-vs-concurrency curve of single job
+type SweepResult struct {
+	Job       string       `json:"job"`
+	StartedAt time.Time    `json:"started_at"`
+	Points    []SweepPoint `json:"points"`
+}
+
+// NewSweepPoint sums stats of job workloads (one per agent), latency is weighted by requests
+func NewSweepPoint(connections uint64, workloads []*proto.ProgressResponse) SweepPoint {
+	point := SweepPoint{Connections: connections}
+	var latencyTotal float64
+	for _, workload := range workloads {
+		point.Requests += workload.Requests
+		point.Errors += workload.Errors
+		point.Rps += workload.Rps
+		point.DurationSeconds = max(point.DurationSeconds, workload.Duration)
+		latencyTotal += float64(workload.AverageLatencyMs) * float64(workload.Requests)
+	}
+	if point.Requests > 0 {
+		point.ErrorRate = float64(point.Errors) / float64(point.Requests)
+		point.AverageLatencyMs = latencyTotal / float64(point.Requests)
+	}
+	return point
+}
+
+// SweepConcurrency runs job once for every connections count, collecting throughput-vs-concurrency curve,
+// agent config is replaced with single job config for the time of sweep and restored afterwards
+func SweepConcurrency(
+	conn grpc.ClientConnInterface, parsedConfig *lbot.ConfigRequest, jobName string, connections []uint,
+	interval time.Duration, output string,
+) (err error) {
+	job, err := sweptJob(parsedConfig, jobName)
+	if err != nil {
+		return err
+	}
+	if job.Pace > 0 {
+		fmt.Printf("⚠️ Job %s has pace %d rps, throughput curve will flatten at pace\n", job.Name, job.Pace)
+	}
+
+	client := proto.NewConfigServiceClient(conn)
+	defer func() {
+		if _, restoreErr := client.SetConfig(context.TODO(), lbot.NewProtoConfigRequest(parsedConfig)); restoreErr != nil {
+			fmt.Printf("⚠️ Restoring config failed: %s\n", restoreErr)
+		}
+	}()
+
+	result := SweepResult{Job: job.Name, StartedAt: time.Now()}
+	fmt.Printf("📈 Sweeping job %s over %v connections\n", job.Name, connections)
+	for _, count := range connections {
+		sweepJob := *job
+		sweepJob.Connections = uint64(count)
+		sweepConfig := *parsedConfig
+		sweepConfig.Jobs = []*lbot.JobRequest{&sweepJob}
+		if _, err = client.SetConfig(context.TODO(), lbot.NewProtoConfigRequest(&sweepConfig)); err != nil {
+			return fmt.Errorf("Setting config failed: %w", err)
+		}
+
+		workloads, err := runAndWait(conn, &proto.StartRequest{}, interval)
+		if err != nil {
+			return saveSweepResult(result, output, err)
+		}
+		point := NewSweepPoint(uint64(count), workloads)
+		result.Points = append(result.Points, point)
+		fmt.Printf(
+			"   %4d connections - %d rps, avg latency %.2fms, error rate %.4f\n",
+			point.Connections, point.Rps, point.AverageLatencyMs, point.ErrorRate,
+		)
+	}
+
+	return saveSweepResult(result, output, nil)
+}
+
+func sweptJob(cfg *lbot.ConfigRequest, jobName string) (*lbot.JobRequest, error) {
+	if jobName == "" {
+		if len(cfg.Jobs) != 1 {
+			return nil, fmt.Errorf("config has %d jobs, choose swept job with --job", len(cfg.Jobs))
+		}
+		return cfg.Jobs[0], nil
+	}
+	job, ok := lo.Find(cfg.Jobs, func(job *lbot.JobRequest) bool { return job.Name == jobName })
+	if !ok {
+		return nil, fmt.Errorf("job %s not found in config", jobName)
+	}
+	return job, nil
+}
+
+// saveSweepResult saves collected points also when sweep failed, so finished runs are not lost
+func saveSweepResult(result SweepResult, output string, sweepErr error) error {
+	data, err := json.MarshalIndent(result, "", "\t")
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		fmt.Println(string(data))
+	} else if err = os.WriteFile(output, data, 0o644); err != nil {
+		return err
+	} else {
+		fmt.Printf("✅ Sweep result saved to %s\n", output)
+	}
+	return sweepErr
+}
@@ -0,0 +1,19 @@
+package workload
+
+import (
+	"testing"
+
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSweepPointSumsAgents(t *testing.T) {
+	point := NewSweepPoint(32, []*proto.ProgressResponse{
+		{Requests: 300, Errors: 3, Rps: 30, Duration: 10, AverageLatencyMs: 2},
+		{Requests: 100, Errors: 1, Rps: 10, Duration: 11, AverageLatencyMs: 6},
+	})
+
+	assert.Equal(t, SweepPoint{
+		Connections: 32, Requests: 400, Errors: 4, ErrorRate: 0.01, Rps: 40, AverageLatencyMs: 3, DurationSeconds: 11,
+	}, point)
+}
@@ -0,0 +1,336 @@
+package workload
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/kuzxnia/loadbot/lbot"
+	"github.com/kuzxnia/loadbot/lbot/database"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/samber/lo"
+	"github.com/tailscale/hujson"
+	"oras.land/oras-go/pkg/content"
+	"oras.land/oras-go/pkg/oras"
+)
+
+const (
+	TemplateConfigMediaType = "application/vnd.loadbot.template.config.v1+json"
+	TemplateLayerMediaType  = "application/vnd.loadbot.template.v1+json"
+
+	// file name of template layer
+	templateFileName = "loadbot.json"
+	// git+<repository url>[?ref=<branch>]#<path of config in repository>
+	gitTemplatePrefix = "git+"
+	ociTemplatePrefix = "oci://"
+)
+
+// TemplateOptions configures access to OCI registry, git uses its own credentials
+type TemplateOptions struct {
+	Username  string
+	Password  string
+	PlainHTTP bool
+}
+
+// gitTemplate is parsed git template reference
+type gitTemplate struct {
+	repository string
+	branch     string
+	path       string
+}
+
+func parseGitTemplate(ref string) (*gitTemplate, error) {
+	repository, path, _ := strings.Cut(strings.TrimPrefix(ref, gitTemplatePrefix), "#")
+	repository, rawQuery, _ := strings.Cut(repository, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("invalid git template reference: %w", err)
+	}
+	template := &gitTemplate{path: path, branch: query.Get("ref")}
+	if template.path == "" || filepath.IsAbs(template.path) || strings.HasPrefix(filepath.Clean(template.path), "..") {
+		return nil, fmt.Errorf("git template reference requires path of config in repository, ex. git+https://github.com/team/presets.git#reads.json")
+	}
+	// repository and branch are passed to git command, ones looking like options would be parsed as such
+	if strings.HasPrefix(repository, "-") || strings.HasPrefix(template.branch, "-") {
+		return nil, fmt.Errorf("invalid git template reference: repository and ref can't start with '-'")
+	}
+	// scp-like repositories (git@github.com:team/presets.git) aren't urls, git gets them as they are
+	if strings.Contains(repository, "://") {
+		if _, err = url.Parse(repository); err != nil {
+			return nil, fmt.Errorf("invalid git template reference: %w", err)
+		}
+	}
+	query.Del("ref")
+	template.repository = repository + lo.Ternary(len(query) > 0, "?"+query.Encode(), "")
+	return template, nil
+}
+
+// PushTemplate publishes workload config as template under reference, oci://<registry>/<repository>:<tag>
+// or git+<repository url>[?ref=<branch>]#<path>, returns digest of published template. User and password of
+// connection strings aren't published
+func PushTemplate(ref string, config []byte, options TemplateOptions) (string, error) {
+	config, err := withoutCredentials(config)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case strings.HasPrefix(ref, gitTemplatePrefix):
+		template, err := parseGitTemplate(ref)
+		if err != nil {
+			return "", err
+		}
+		return pushGitTemplate(template, config)
+	case strings.HasPrefix(ref, ociTemplatePrefix):
+		return pushOciTemplate(strings.TrimPrefix(ref, ociTemplatePrefix), config, options)
+	}
+	return "", fmt.Errorf("unsupported template reference %q, use oci:// or git+ prefix", ref)
+}
+
+// PullTemplate fetches template of reference, returned config records reference and digest of template
+// in top level "template" field, which agent attaches to runs started from it
+func PullTemplate(ref string, options TemplateOptions) (config []byte, digest string, err error) {
+	switch {
+	case strings.HasPrefix(ref, gitTemplatePrefix):
+		template, err := parseGitTemplate(ref)
+		if err != nil {
+			return nil, "", err
+		}
+		config, digest, err = pullGitTemplate(template)
+	case strings.HasPrefix(ref, ociTemplatePrefix):
+		config, digest, err = pullOciTemplate(strings.TrimPrefix(ref, ociTemplatePrefix), options)
+	default:
+		return nil, "", fmt.Errorf("unsupported template reference %q, use oci:// or git+ prefix", ref)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	config, err = WithTemplateProvenance(config, ref+"@"+digest)
+	return config, digest, err
+}
+
+// PushTemplateFile publishes config file as template and prints its digest
+func PushTemplateFile(ref string, configFile string, options TemplateOptions) error {
+	config, err := os.ReadFile(configFile)
+	if err != nil {
+		return err
+	}
+	digest, err := PushTemplate(ref, config, options)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Template %s pushed, digest %s\n", ref, digest)
+	return nil
+}
+
+// PullTemplateFile saves template as config file, printed to stdout if output is not set
+func PullTemplateFile(ref string, output string, options TemplateOptions) error {
+	config, digest, err := PullTemplate(ref, options)
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		fmt.Println(string(config))
+		return nil
+	}
+	if err = os.WriteFile(output, config, 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Template %s (digest %s) saved to %s\n", ref, digest, output)
+	return nil
+}
+
+// WithTemplateProvenance sets top level "template" field of config, comments are kept
+func WithTemplateProvenance(config []byte, provenance string) ([]byte, error) {
+	value, err := hujson.Parse(config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workload config: %w", err)
+	}
+	patch, err := json.Marshal([]map[string]string{{"op": "add", "path": "/template", "value": provenance}})
+	if err != nil {
+		return nil, err
+	}
+	if err = value.Patch(patch); err != nil {
+		return nil, err
+	}
+	value.Format()
+	return value.Pack(), nil
+}
+
+// withoutCredentials removes user and password from connection strings of config, comments are kept
+func withoutCredentials(config []byte) ([]byte, error) {
+	value, err := hujson.Parse(config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workload config: %w", err)
+	}
+	standard := value.Clone()
+	standard.Standardize()
+	var connections struct {
+		ConnectionString      string `json:"connection_string"`
+		ShardConnectionString string `json:"shard_connection_string"`
+	}
+	if err = json.Unmarshal(standard.Pack(), &connections); err != nil {
+		return nil, fmt.Errorf("invalid workload config: %w", err)
+	}
+	var patch []map[string]string
+	for field, connectionString := range map[string]string{
+		"/connection_string": connections.ConnectionString, "/shard_connection_string": connections.ShardConnectionString,
+	} {
+		if redacted := database.RedactConnectionString(connectionString); redacted != connectionString {
+			patch = append(patch, map[string]string{"op": "replace", "path": field, "value": redacted})
+		}
+	}
+	if len(patch) == 0 {
+		return config, nil
+	}
+	content, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	if err = value.Patch(content); err != nil {
+		return nil, err
+	}
+	return value.Pack(), nil
+}
+
+func pushOciTemplate(ref string, config []byte, options TemplateOptions) (string, error) {
+	store := content.NewMemory()
+	layer, err := store.Add(templateFileName, TemplateLayerMediaType, config)
+	if err != nil {
+		return "", err
+	}
+	configDescriptor, err := store.Add("", TemplateConfigMediaType, []byte(fmt.Sprintf(`{"version":%q}`, lbot.Version)))
+	if err != nil {
+		return "", err
+	}
+	manifest, manifestDescriptor, err := content.GenerateManifest(&configDescriptor, nil, layer)
+	if err != nil {
+		return "", err
+	}
+	if err = store.StoreManifest(ref, manifestDescriptor, manifest); err != nil {
+		return "", err
+	}
+	registry, err := newTemplateRegistry(options)
+	if err != nil {
+		return "", err
+	}
+	descriptor, err := oras.Copy(context.TODO(), store, ref, registry, "", oras.WithNameValidation(nil))
+	if err != nil {
+		return "", fmt.Errorf("pushing template failed: %w", err)
+	}
+	return descriptor.Digest.String(), nil
+}
+
+func pullOciTemplate(ref string, options TemplateOptions) ([]byte, string, error) {
+	registry, err := newTemplateRegistry(options)
+	if err != nil {
+		return nil, "", err
+	}
+	store := content.NewMemory()
+	var layers []ocispec.Descriptor
+	descriptor, err := oras.Copy(context.TODO(), registry, ref, store, "",
+		oras.WithPullEmptyNameAllowed(),
+		oras.WithAllowedMediaTypes([]string{ocispec.MediaTypeImageManifest, TemplateConfigMediaType, TemplateLayerMediaType}),
+		oras.WithLayerDescriptors(func(descriptors []ocispec.Descriptor) { layers = descriptors }),
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("pulling template failed: %w", err)
+	}
+	for _, layer := range layers {
+		if layer.MediaType != TemplateLayerMediaType {
+			continue
+		}
+		if _, config, ok := store.Get(layer); ok {
+			return config, descriptor.Digest.String(), nil
+		}
+	}
+	return nil, "", fmt.Errorf("%s is not loadbot template, no layer with media type %s", ref, TemplateLayerMediaType)
+}
+
+func newTemplateRegistry(options TemplateOptions) (*content.Registry, error) {
+	return content.NewRegistry(content.RegistryOptions{
+		Username:  options.Username,
+		Password:  options.Password,
+		PlainHTTP: options.PlainHTTP,
+	})
+}
+
+// pushGitTemplate commits config to repository branch, digest is commit hash
+func pushGitTemplate(template *gitTemplate, config []byte) (string, error) {
+	dir, err := cloneGitTemplate(template)
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, template.path)
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	if err = os.WriteFile(path, config, 0o644); err != nil {
+		return "", err
+	}
+	if _, err = git(dir, "add", "--", template.path); err != nil {
+		return "", err
+	}
+	changes, err := git(dir, "status", "--porcelain")
+	if err != nil {
+		return "", err
+	}
+	// unchanged template keeps its commit
+	if changes != "" {
+		if _, err = git(dir, "commit", "-m", "Update loadbot template "+template.path); err != nil {
+			return "", err
+		}
+		if _, err = git(dir, "push", "origin", "HEAD"); err != nil {
+			return "", err
+		}
+	}
+	return git(dir, "rev-parse", "HEAD")
+}
+
+// pullGitTemplate reads config from repository branch, digest is commit hash
+func pullGitTemplate(template *gitTemplate) ([]byte, string, error) {
+	dir, err := cloneGitTemplate(template)
+	if err != nil {
+		return nil, "", err
+	}
+	defer os.RemoveAll(dir)
+
+	config, err := os.ReadFile(filepath.Join(dir, template.path))
+	if err != nil {
+		return nil, "", fmt.Errorf("template %s not found in repository: %w", template.path, err)
+	}
+	digest, err := git(dir, "rev-parse", "HEAD")
+	return config, digest, err
+}
+
+func cloneGitTemplate(template *gitTemplate) (string, error) {
+	dir, err := os.MkdirTemp("", "loadbot-template-")
+	if err != nil {
+		return "", err
+	}
+	args := []string{"clone", "--depth", "1"}
+	if template.branch != "" {
+		args = append(args, "--branch", template.branch)
+	}
+	if _, err = git("", append(args, "--", template.repository, dir)...); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return dir, nil
+}
+
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w, %s", args[0], err, strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -2,12 +2,16 @@ package agent
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	_ "net/http/pprof"
+	"sort"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -43,27 +47,55 @@ type Agent struct {
 }
 
 func NewAgent(ctx context.Context, loadbot *lbot.Lbot) *Agent {
-	grpcServer := grpc.NewServer()
+	agent := &Agent{
+		id:          primitive.NewObjectID(),
+		ctx:         ctx,
+		lbot:        loadbot,
+		state:       AgentStateFollower,
+		stateChange: sync.NewCond(&sync.Mutex{}),
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(agent.unaryAuthInterceptor),
+		grpc.StreamInterceptor(agent.streamAuthInterceptor),
+	)
 	// register commands
 	proto.RegisterStartProcessServer(grpcServer, lbot.NewStartProcess(ctx, loadbot))
 	proto.RegisterStopProcessServer(grpcServer, lbot.NewStoppingProcess(ctx, loadbot))
 	proto.RegisterConfigServiceServer(grpcServer, lbot.NewConfigService(ctx, loadbot))
 	proto.RegisterWatchProcessServer(grpcServer, lbot.NewWatchingProcess(ctx, loadbot))
 	proto.RegisterProgressProcessServer(grpcServer, lbot.NewProgressProcess(ctx, loadbot))
+	proto.RegisterArtifactServiceServer(grpcServer, lbot.NewArtifactService(ctx, loadbot))
+	proto.RegisterAnnotationServiceServer(grpcServer, lbot.NewAnnotationService(ctx, loadbot))
+	proto.RegisterStatsServiceServer(grpcServer, lbot.NewStatsService(ctx, loadbot))
+	proto.RegisterLogServiceServer(grpcServer, lbot.NewLogService(ctx, loadbot))
+	proto.RegisterApprovalServiceServer(grpcServer, lbot.NewApprovalService(ctx, loadbot))
+	proto.RegisterRunHistoryServiceServer(grpcServer, lbot.NewRunHistoryService(ctx, loadbot))
 
 	reflection.Register(grpcServer)
+	agent.grpcServer = grpcServer
 
-	return &Agent{
-		id:          primitive.NewObjectID(),
-		ctx:         ctx,
-		lbot:        loadbot,
-		grpcServer:  grpcServer,
-		state:       AgentStateFollower,
-		stateChange: sync.NewCond(&sync.Mutex{}),
-	}
+	return agent
 }
 
+// Start runs agent until stop signal is received
 func (a *Agent) Start() error {
+	ctx, stop := signal.NotifyContext(
+		a.ctx, os.Interrupt, syscall.SIGHUP, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM,
+	)
+	defer stop()
+	a.ctx = ctx
+	go func() {
+		<-ctx.Done()
+		fmt.Println("\nReceived stop signal. Exiting.")
+	}()
+
+	return a.Run()
+}
+
+// Run serves agent until its context is done, then stops running workloads with default grace period,
+// used to embed agent ex. in integration tests
+func (a *Agent) Run() error {
 	defer func() {
 		if a.configChange != nil {
 			a.configChange.Close()
@@ -74,23 +106,18 @@ func (a *Agent) Start() error {
 	go a.Metrics()
 	go a.Heartbeat()
 	go a.Listen()
+	go a.Retention()
 
 	if err := a.lbot.InitAgent(a.id, a.lbot.Config.Agent.Name); err != nil {
 	} else {
 		log.Info("lbot-agent initialized successfuly")
 	}
+	if err := a.lbot.RestoreSchedules(); err != nil {
+		log.Warnf("Restoring scheduled starts failed: %s", err)
+	}
 
-	stopSignal := make(chan os.Signal, 1)
-	signal.Notify(
-		stopSignal, os.Interrupt, syscall.SIGHUP, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM,
-	)
-	<-stopSignal
-	fmt.Println("\nReceived stop signal. Exiting.")
-
-	// is this needed?
-	_, cancel := context.WithCancel(a.ctx)
-	cancel()
-  a.lbot.Cancel()
+	<-a.ctx.Done()
+	a.lbot.Cancel(config.DefaultStopGracePeriod)
 
 	return nil
 }
@@ -110,6 +137,10 @@ func (a *Agent) ServeGrpc() error {
 		log.Fatal("listen error:", err)
 		panic(err)
 	}
+	go func() {
+		<-a.ctx.Done()
+		a.grpcServer.Stop()
+	}()
 	if err := a.grpcServer.Serve(tcpListener); err != nil {
 		log.Fatalf("failed to serve: %s", err)
 	}
@@ -119,30 +150,96 @@ func (a *Agent) ServeGrpc() error {
 
 // remove from here
 func (a *Agent) Metrics() error {
-	if lo.IsNotEmpty(a.lbot.Config.Agent.MetricsExportPort) {
-		http.HandleFunc("/metrics", func(w http.ResponseWriter, req *http.Request) {
+	agentConfig := a.lbot.Config.Agent
+	if lo.IsNotEmpty(agentConfig.MetricsExportPort) {
+		http.HandleFunc("/metrics", a.metricsAuth(func(w http.ResponseWriter, req *http.Request) {
 			metrics.WritePrometheus(w, true)
-		})
-		log.Infof("Started metrics exporter on :%s/metrics", a.lbot.Config.Agent.MetricsExportPort)
-		http.ListenAndServe(":"+a.lbot.Config.Agent.MetricsExportPort, nil)
-	} else if lo.IsNotEmpty(a.lbot.Config.Agent.MetricsExportUrl) {
-		log.Info("Started exporting metrics to ", a.lbot.Config.Agent.MetricsExportUrl)
-
-		metricsLabels := lo.If(
-			a.lbot.Config.Agent.Name != "",
-			fmt.Sprintf(`instance="%s"`, a.lbot.Config.Agent.Name),
-		).Else("")
+		}))
+		address := ":" + agentConfig.MetricsExportPort
+		if lo.IsNotEmpty(agentConfig.MetricsExportTLSCertFile) && lo.IsNotEmpty(agentConfig.MetricsExportTLSKeyFile) {
+			log.Infof("Started metrics exporter on https://%s/metrics", address)
+			return http.ListenAndServeTLS(address, agentConfig.MetricsExportTLSCertFile, agentConfig.MetricsExportTLSKeyFile, nil)
+		}
+		log.Infof("Started metrics exporter on %s/metrics", address)
+		return http.ListenAndServe(address, nil)
+	} else if lo.IsNotEmpty(agentConfig.MetricsExportUrl) {
+		pushUrl := metricsPushUrl(agentConfig.MetricsExportUrl, a.metricsLabels())
+		log.Info("Started exporting metrics to ", pushUrl)
 
 		metrics.InitPush(
-			a.lbot.Config.Agent.MetricsExportUrl,
-			10*time.Second, // todo: add interval param
-			metricsLabels,
+			pushUrl,
+			time.Duration(lo.If(agentConfig.MetricsExportIntervalSeconds != 0, agentConfig.MetricsExportIntervalSeconds).Else(10))*time.Second,
+			formatMetricsLabels(a.metricsLabels()),
 			true,
 		)
 	}
 	return nil
 }
 
+// metricsLabels returns labels attached to every pushed series, agent_id is always set
+// so series pushed concurrently from multiple agents don't overwrite each other
+func (a *Agent) metricsLabels() map[string]string {
+	labels := map[string]string{"agent_id": a.id.Hex()}
+	if a.lbot.Config.Agent.Name != "" {
+		labels["instance"] = a.lbot.Config.Agent.Name
+	}
+	for key, value := range a.lbot.Config.Agent.MetricsExportLabels {
+		labels[key] = value
+	}
+	return labels
+}
+
+func formatMetricsLabels(labels map[string]string) string {
+	keys := lo.Keys(labels)
+	sort.Strings(keys)
+	return strings.Join(lo.Map(keys, func(key string, _ int) string {
+		return fmt.Sprintf("%s=%q", key, labels[key])
+	}), ",")
+}
+
+// metricsPushUrl appends grouping keys to pushgateway url (.../metrics/job/<job>),
+// other urls ex. victoria metrics import are returned unchanged
+func metricsPushUrl(pushUrl string, labels map[string]string) string {
+	if !strings.Contains(pushUrl, "/metrics/job/") {
+		return pushUrl
+	}
+	keys := lo.Keys(labels)
+	sort.Strings(keys)
+	for _, key := range keys {
+		pushUrl = strings.TrimSuffix(pushUrl, "/") + "/" + url.PathEscape(key) + "/" + url.PathEscape(labels[key])
+	}
+	return pushUrl
+}
+
+// metricsAuth protects metrics endpoint with bearer token or basic auth, when configured
+func (a *Agent) metricsAuth(next http.HandlerFunc) http.HandlerFunc {
+	agentConfig := a.lbot.Config.Agent
+	if lo.IsEmpty(agentConfig.MetricsExportBearerToken) && lo.IsEmpty(agentConfig.MetricsExportUsername) {
+		return next
+	}
+
+	return func(w http.ResponseWriter, req *http.Request) {
+		if lo.IsNotEmpty(agentConfig.MetricsExportBearerToken) {
+			token, found := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+			if found && subtle.ConstantTimeCompare([]byte(token), []byte(agentConfig.MetricsExportBearerToken)) == 1 {
+				next(w, req)
+				return
+			}
+		}
+		if lo.IsNotEmpty(agentConfig.MetricsExportUsername) {
+			username, password, ok := req.BasicAuth()
+			if ok &&
+				subtle.ConstantTimeCompare([]byte(username), []byte(agentConfig.MetricsExportUsername)) == 1 &&
+				subtle.ConstantTimeCompare([]byte(password), []byte(agentConfig.MetricsExportPassword)) == 1 {
+				next(w, req)
+				return
+			}
+			w.Header().Set("WWW-Authenticate", `Basic realm="metrics"`)
+		}
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+	}
+}
+
 // remove from here
 func (a *Agent) ApplyConfig(request *lbot.ConfigRequest) error {
 	cfg := lbot.NewConfig(request)
@@ -192,7 +289,7 @@ func (a *Agent) Heartbeat() error {
 
 	// master ticker should run more often, hint ticker.reset
 
-	for range ticker.C {
+	for a.tick(ticker) {
 		err := a.lbot.AgentHeartBeat(a.id, a.lbot.Config.Agent.Name)
 		if err != nil {
 			log.Error("agent status failed", err)
@@ -212,6 +309,26 @@ func (a *Agent) Heartbeat() error {
 // workload command is root command, each agent creates own workload version, or is part of this command(inside as list item)
 //
 
+// Retention prunes stored results exceeding retention of agent
+func (a *Agent) Retention() {
+	ticker := time.NewTicker(config.RetentionInterval)
+	defer ticker.Stop()
+
+	for a.tick(ticker) {
+		a.lbot.PruneResults(a.state == AgentStateLeader)
+	}
+}
+
+// tick waits for next tick of ticker, returns false when agent context is done
+func (a *Agent) tick(ticker *time.Ticker) bool {
+	select {
+	case <-a.ctx.Done():
+		return false
+	case <-ticker.C:
+		return true
+	}
+}
+
 func (a *Agent) Listen() error {
 	// todo:
 	// 1. commands - to handle on master
@@ -225,7 +342,7 @@ func (a *Agent) Listen() error {
 	ticker := time.NewTicker(config.AgentsHeartbeatInterval)
 	defer ticker.Stop()
 
-	for range ticker.C {
+	for a.tick(ticker) {
 		// check for running commands
 		a.lbot.HandleWorkload()
 
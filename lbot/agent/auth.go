@@ -0,0 +1,107 @@
+package agent
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kuzxnia/loadbot/lbot"
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/samber/lo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// methodRoles is minimal role required by api method, methods not listed require admin
+var methodRoles = map[string]string{
+	proto.ProgressProcess_Run_FullMethodName:               config.RoleViewer,
+	proto.WatchProcess_Run_FullMethodName:                  config.RoleViewer,
+	proto.ArtifactService_ListArtifacts_FullMethodName:     config.RoleViewer,
+	proto.ArtifactService_DownloadArtifact_FullMethodName:  config.RoleViewer,
+	proto.AnnotationService_ListAnnotations_FullMethodName: config.RoleViewer,
+	proto.AnnotationService_AddAnnotation_FullMethodName:   config.RoleOperator,
+	proto.StartProcess_Run_FullMethodName:                  config.RoleOperator,
+	proto.StartProcess_RunWithProgress_FullMethodName:      config.RoleOperator,
+	proto.StartProcess_Estimate_FullMethodName:             config.RoleViewer,
+	proto.StopProcess_Run_FullMethodName:                   config.RoleOperator,
+	proto.StatsService_ResetStats_FullMethodName:           config.RoleOperator,
+	proto.ApprovalService_ListApprovals_FullMethodName:     config.RoleOperator,
+	proto.ApprovalService_Approve_FullMethodName:           config.RoleOperator,
+	proto.RunHistoryService_ListRuns_FullMethodName:        config.RoleViewer,
+	proto.RunHistoryService_GetRun_FullMethodName:          config.RoleViewer,
+}
+
+var roleLevels = map[string]int{config.RoleViewer: 1, config.RoleOperator: 2, config.RoleAdmin: 3}
+
+// authorize checks if tenant role allows calling method, tenant without role is admin
+func authorize(tenant *config.Tenant, method string) error {
+	if tenant == nil {
+		return nil
+	}
+	role := lo.Ternary(tenant.Role == "", config.RoleAdmin, tenant.Role)
+	required, ok := methodRoles[method]
+	if !ok {
+		required = config.RoleAdmin
+	}
+	if roleLevels[role] < roleLevels[required] {
+		return status.Errorf(codes.PermissionDenied, "role %s is not allowed to call %s", role, method)
+	}
+	return nil
+}
+
+// authenticate resolves tenant from "authorization: Bearer <token>" metadata,
+// when agent have no tenants configured api stays open
+func (a *Agent) authenticate(ctx context.Context) (context.Context, error) {
+	if len(a.lbot.Config.Agent.Tenants) == 0 {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, header := range md.Get("authorization") {
+		token, found := strings.CutPrefix(header, "Bearer ")
+		if !found {
+			continue
+		}
+		if tenant := a.lbot.Config.Agent.GetTenantByToken(token); tenant != nil {
+			return lbot.WithTenant(ctx, tenant), nil
+		}
+	}
+	return nil, status.Error(codes.Unauthenticated, "invalid or missing token")
+}
+
+func (a *Agent) unaryAuthInterceptor(
+	ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (interface{}, error) {
+	ctx, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = authorize(lbot.TenantFromContext(ctx), info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *Agent) streamAuthInterceptor(
+	srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+) error {
+	ctx, err := a.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	if err = authorize(lbot.TenantFromContext(ctx), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,31 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthorize(t *testing.T) {
+	viewer := &config.Tenant{Name: "dashboard", Role: config.RoleViewer}
+	operator := &config.Tenant{Name: "ci", Role: config.RoleOperator}
+	admin := &config.Tenant{Name: "team"}
+
+	assert.NoError(t, authorize(nil, proto.ConfigService_SetConfig_FullMethodName))
+
+	assert.NoError(t, authorize(viewer, proto.ProgressProcess_Run_FullMethodName))
+	assert.Equal(t, codes.PermissionDenied, status.Code(authorize(viewer, proto.StartProcess_Run_FullMethodName)))
+	assert.Equal(t, codes.PermissionDenied, status.Code(authorize(viewer, proto.ConfigService_GetConfig_FullMethodName)))
+
+	assert.NoError(t, authorize(operator, proto.StopProcess_Run_FullMethodName))
+	assert.NoError(t, authorize(operator, proto.ApprovalService_Approve_FullMethodName))
+	assert.Equal(t, codes.PermissionDenied, status.Code(authorize(viewer, proto.ApprovalService_Approve_FullMethodName)))
+	assert.Equal(t, codes.PermissionDenied, status.Code(authorize(operator, proto.ConfigService_SetConfig_FullMethodName)))
+
+	assert.NoError(t, authorize(admin, proto.ConfigService_SetConfig_FullMethodName))
+	assert.NoError(t, authorize(admin, "/proto.Unknown/Method"))
+}
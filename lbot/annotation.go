@@ -0,0 +1,114 @@
+package lbot
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/database"
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const MaxAnnotationLength = 4096
+
+// Annotate attaches note to run of tenant, at is time note refers to (now if zero)
+func (l *Lbot) Annotate(ctx context.Context, tenant *config.Tenant, runId string, text string, at time.Time) (*database.Annotation, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, status.Error(codes.InvalidArgument, "annotation text is empty")
+	}
+	if len(text) > MaxAnnotationLength {
+		return nil, status.Errorf(codes.InvalidArgument, "annotation is longer than %d bytes", MaxAnnotationLength)
+	}
+	tenantName := lo.Ternary(lo.IsNil(tenant), "", tenant.Name)
+	if err := l.checkRunExists(ctx, tenantName, runId); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	annotation := &database.Annotation{
+		Id:        primitive.NewObjectID(),
+		RunId:     runId,
+		Tenant:    tenantName,
+		Text:      text,
+		At:        primitive.NewDateTimeFromTime(lo.Ternary(at.IsZero(), now, at)),
+		CreatedAt: primitive.NewDateTimeFromTime(now),
+	}
+	if err := l.internalClient.AddAnnotation(ctx, annotation); err != nil {
+		return nil, err
+	}
+	return annotation, nil
+}
+
+// Annotations returns notes attached to run of tenant
+func (l *Lbot) Annotations(ctx context.Context, tenant *config.Tenant, runId string) ([]*database.Annotation, error) {
+	tenantName := lo.Ternary(lo.IsNil(tenant), "", tenant.Name)
+	if err := l.checkRunExists(ctx, tenantName, runId); err != nil {
+		return nil, err
+	}
+	return l.internalClient.GetAnnotations(ctx, runId)
+}
+
+// checkRunExists fails with NotFound for unknown runs and runs of other tenants
+func (l *Lbot) checkRunExists(ctx context.Context, tenant string, runId string) error {
+	if !primitive.IsValidObjectID(runId) {
+		return status.Errorf(codes.InvalidArgument, "invalid run id %q", runId)
+	}
+	exists, err := l.internalClient.RunExists(ctx, runId, tenant)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return status.Errorf(codes.NotFound, "run %s not found", runId)
+	}
+	return nil
+}
+
+func NewAnnotationResponse(annotation *database.Annotation) *proto.Annotation {
+	return &proto.Annotation{
+		RunId:     annotation.RunId,
+		Text:      annotation.Text,
+		At:        annotation.At.Time().Format(time.RFC3339),
+		CreatedAt: annotation.CreatedAt.Time().Format(time.RFC3339),
+	}
+}
+
+type AnnotationService struct {
+	proto.UnimplementedAnnotationServiceServer
+	ctx  context.Context
+	lbot *Lbot
+}
+
+func NewAnnotationService(ctx context.Context, lbot *Lbot) *AnnotationService {
+	return &AnnotationService{ctx: ctx, lbot: lbot}
+}
+
+func (a *AnnotationService) AddAnnotation(ctx context.Context, request *proto.AddAnnotationRequest) (*proto.Annotation, error) {
+	var at time.Time
+	if request.At != "" {
+		var err error
+		if at, err = time.Parse(time.RFC3339, request.At); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid annotation time: %s", err)
+		}
+	}
+	annotation, err := a.lbot.Annotate(ctx, TenantFromContext(ctx), request.RunId, request.Text, at)
+	if err != nil {
+		return nil, err
+	}
+	return NewAnnotationResponse(annotation), nil
+}
+
+func (a *AnnotationService) ListAnnotations(ctx context.Context, request *proto.ListAnnotationsRequest) (*proto.ListAnnotationsResponse, error) {
+	annotations, err := a.lbot.Annotations(ctx, TenantFromContext(ctx), request.RunId)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.ListAnnotationsResponse{Annotations: lo.Map(annotations, func(annotation *database.Annotation, _ int) *proto.Annotation {
+		return NewAnnotationResponse(annotation)
+	})}, nil
+}
@@ -0,0 +1,193 @@
+package lbot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// pending starts not approved in this time are dropped
+const ApprovalExpiration = 15 * time.Minute
+
+// approval is start request of protected agent waiting for second token, config is snapshot taken
+// when start was requested, so config set afterwards can't run without approval
+type approval struct {
+	id          string
+	tenant      *config.Tenant
+	restart     bool
+	schedule    startSchedule
+	config      *config.Config
+	own         bool // config is own config of workload, not snapshot of agent config
+	hash        string
+	requestedAt time.Time
+}
+
+func (a *approval) expiresAt() time.Time {
+	return a.requestedAt.Add(ApprovalExpiration)
+}
+
+// isProtected reports whether starts of agent must be approved by second token
+func (l *Lbot) isProtected() bool {
+	return l.Config.Agent != nil && l.Config.Agent.Protected
+}
+
+// RequestStart queues start of tenant jobs until it is approved with Approve, returns approval id
+func (l *Lbot) RequestStart(tenant *config.Tenant, restart bool, schedule startSchedule, own *config.Config) string {
+	pending := &approval{
+		id:          primitive.NewObjectID().Hex(),
+		tenant:      tenant,
+		restart:     restart,
+		schedule:    schedule,
+		config:      own,
+		own:         own != nil,
+		requestedAt: time.Now(),
+	}
+	if own == nil {
+		pending.config = l.tenantSnapshot(tenant)
+	}
+	pending.hash = configHash(pending.config)
+	l.mutext.Lock()
+	l.pruneApprovals(pending.requestedAt)
+	l.approvals[pending.id] = pending
+	l.mutext.Unlock()
+
+	log.WithField("approval_id", pending.id).Infof(
+		"Start of tenant %q waits for approval of another token until %s", runKey(tenant), pending.expiresAt().Format(time.RFC3339),
+	)
+	l.events.Emit(Event{Type: EventApprovalRequested, Approval: pending.id})
+	return pending.id
+}
+
+// Approvals returns pending starts approver can approve, oldest first
+func (l *Lbot) Approvals(approver *config.Tenant) []*proto.Approval {
+	l.mutext.Lock()
+	defer l.mutext.Unlock()
+	l.pruneApprovals(time.Now())
+
+	pending := lo.Filter(lo.Values(l.approvals), func(a *approval, _ int) bool { return canApprove(approver, a) == nil })
+	sort.Slice(pending, func(i, j int) bool { return pending[i].requestedAt.Before(pending[j].requestedAt) })
+	return lo.Map(pending, func(a *approval, _ int) *proto.Approval {
+		return &proto.Approval{
+			Id:          a.id,
+			Tenant:      runKey(a.tenant),
+			Restart:     a.restart,
+			RequestedAt: a.requestedAt.Format(time.RFC3339),
+			ExpiresAt:   a.expiresAt().Format(time.RFC3339),
+			Targets:     append(config.ConnectionHosts(a.config.ConnectionString), config.ConnectionHosts(a.config.ShardConnectionString)...),
+			Jobs:        lo.Map(a.config.Jobs, func(job *config.Job, _ int) string { return job.Name }),
+			ConfigHash:  a.hash,
+		}
+	})
+}
+
+// Approve starts (or schedules) queued jobs of approval on behalf of tenant which requested it, jobs
+// of config snapshot are run, not of config current at approval
+func (l *Lbot) Approve(approver *config.Tenant, id string) (*proto.StartResponse, error) {
+	l.mutext.Lock()
+	now := time.Now()
+	l.pruneApprovals(now)
+	pending, ok := l.approvals[id]
+	if !ok {
+		l.mutext.Unlock()
+		return nil, status.Errorf(codes.NotFound, "no pending start with approval id %s", id)
+	}
+	if err := canApprove(approver, pending); err != nil {
+		l.mutext.Unlock()
+		return nil, err
+	}
+	delete(l.approvals, id)
+	l.mutext.Unlock()
+
+	log.WithField("approval_id", id).Infof(
+		"Start of tenant %q approved by %q, config %s", runKey(pending.tenant), runKey(approver), pending.hash,
+	)
+	if pending.schedule.isSet() && pending.schedule.next(now).IsZero() {
+		return nil, status.Error(codes.FailedPrecondition, "start time passed before start was approved")
+	}
+	if pending.own {
+		return l.start(pending.tenant, pending.restart, pending.schedule, pending.config, nil)
+	}
+	return l.start(pending.tenant, pending.restart, pending.schedule, nil, pending.config)
+}
+
+// tenantSnapshot copies agent config with jobs of tenant, later config changes don't change the copy
+func (l *Lbot) tenantSnapshot(tenant *config.Tenant) *config.Config {
+	snapshot := *l.Config
+	snapshot.Schemas = slices.Clone(l.Config.Schemas)
+	snapshot.Jobs = nil
+	for _, job := range l.Config.Jobs {
+		if ownsJob(tenant, job) {
+			copied := *job
+			snapshot.Jobs = append(snapshot.Jobs, &copied)
+		}
+	}
+	return &snapshot
+}
+
+// configHash identifies what config runs, targets, jobs and schemas are hashed
+func configHash(cfg *config.Config) string {
+	content, _ := json.Marshal(struct {
+		ConnectionString      string
+		ShardConnectionString string
+		Jobs                  []*config.Job
+		Schemas               []*config.Schema
+	}{cfg.ConnectionString, cfg.ShardConnectionString, cfg.Jobs, cfg.Schemas})
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// canApprove allows approving starts requested with another token, by same tenant or admin
+func canApprove(approver *config.Tenant, pending *approval) error {
+	if lo.IsNil(approver) || lo.IsNil(pending.tenant) {
+		return status.Error(codes.FailedPrecondition, "approvals require agent tenant tokens")
+	}
+	if approver.Token == pending.tenant.Token {
+		return status.Error(codes.PermissionDenied, "start must be approved with another token than it was requested")
+	}
+	isAdmin := approver.Role == "" || approver.Role == config.RoleAdmin
+	if approver.Name != pending.tenant.Name && !isAdmin {
+		return status.Errorf(codes.PermissionDenied, "only tenant %q or admin can approve its start", pending.tenant.Name)
+	}
+	return nil
+}
+
+// pruneApprovals drops expired approvals, mutex must be held
+func (l *Lbot) pruneApprovals(now time.Time) {
+	for id, pending := range l.approvals {
+		if now.After(pending.expiresAt()) {
+			log.WithField("approval_id", id).Warnf("Start of tenant %q expired without approval", runKey(pending.tenant))
+			delete(l.approvals, id)
+		}
+	}
+}
+
+type ApprovalService struct {
+	proto.UnimplementedApprovalServiceServer
+	ctx  context.Context
+	lbot *Lbot
+}
+
+func NewApprovalService(ctx context.Context, lbot *Lbot) *ApprovalService {
+	return &ApprovalService{ctx: ctx, lbot: lbot}
+}
+
+func (a *ApprovalService) ListApprovals(ctx context.Context, _ *emptypb.Empty) (*proto.ListApprovalsResponse, error) {
+	return &proto.ListApprovalsResponse{Approvals: a.lbot.Approvals(TenantFromContext(ctx))}, nil
+}
+
+func (a *ApprovalService) Approve(ctx context.Context, request *proto.ApproveRequest) (*proto.StartResponse, error) {
+	return a.lbot.Approve(TenantFromContext(ctx), request.ApprovalId)
+}
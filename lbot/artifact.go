@@ -0,0 +1,225 @@
+package lbot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/database"
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/kuzxnia/loadbot/lbot/worker"
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	ArtifactChunkSize = 64 * 1024
+	// file in run directory with name of tenant owning the run, hidden from artifact list
+	artifactTenantFile = ".tenant"
+)
+
+// JobReport is summary of finished job saved as run artifact
+type JobReport struct {
+	RunId            string                 `json:"run_id"`
+	Template         string                 `json:"template,omitempty"` // reference and digest of template config was pulled from
+	Job              string                 `json:"job"`
+	Type             string                 `json:"type"`
+	StopReason       string                 `json:"stop_reason"`
+	Requests         uint64                 `json:"requests"`
+	Errors           uint64                 `json:"errors"`
+	Rps              uint64                 `json:"rps"`
+	AverageLatencyMs float64                `json:"average_latency_ms"`
+	DurationSeconds  uint64                 `json:"duration_seconds"`
+	Collections      []string               `json:"collections,omitempty"`
+	SLA              []string               `json:"sla,omitempty"`
+	Backup           []string               `json:"backup,omitempty"`
+	DocumentSize     []string               `json:"document_size,omitempty"`
+	Bursts           []string               `json:"bursts,omitempty"`
+	Fairness         []string               `json:"fairness,omitempty"`
+	PlanChanges      []string               `json:"plan_changes,omitempty"`
+	TopologyStart    *database.Topology     `json:"topology_start,omitempty"`
+	TopologyEnd      *database.Topology     `json:"topology_end,omitempty"`
+	Annotations      []*database.Annotation `json:"annotations,omitempty"` // attached to run before job finished
+}
+
+func NewJobReport(w *worker.Worker, workload *database.Workload) *JobReport {
+	stats := NewEventStats(w.Metrics)
+	return &JobReport{
+		RunId:            workload.Data.RunId,
+		Template:         workload.Data.Template,
+		Job:              workload.Data.Name,
+		Type:             workload.Data.Type,
+		StopReason:       w.StopReason(),
+		Requests:         stats.Requests,
+		Errors:           stats.Errors,
+		Rps:              stats.Rps,
+		AverageLatencyMs: stats.AverageLatencyMs,
+		DurationSeconds:  stats.DurationSeconds,
+		Collections:      w.CollectionReport(),
+		SLA:              w.Metrics.SLAReport(),
+		Backup:           w.BackupReport(),
+		DocumentSize:     w.DocumentSizeReport(),
+		Bursts:           w.BurstReport(),
+		Fairness:         w.FairnessReport(),
+		PlanChanges:      lo.Map(w.PlanChanges(), func(change worker.PlanChange, _ int) string { return change.String() }),
+		TopologyStart:    workload.TopologyStart,
+		TopologyEnd:      workload.TopologyEnd,
+	}
+}
+
+// artifactsDir returns artifacts dir of agent, empty when agent has no artifacts dir or agent section
+func (l *Lbot) artifactsDir() string {
+	if l.Config.Agent == nil {
+		return ""
+	}
+	return l.Config.Agent.ArtifactsDir
+}
+
+// saveReport saves job report in run artifacts, no-op when agent has no artifacts dir
+func (l *Lbot) saveReport(w *worker.Worker, workload *database.Workload) {
+	dir := l.artifactsDir()
+	if dir == "" {
+		return
+	}
+	job := workload.Data
+	err := func() error {
+		report := NewJobReport(w, workload)
+		annotations, err := l.internalClient.GetAnnotations(l.ctx, job.RunId)
+		if err != nil {
+			log.WithField("run_id", job.RunId).Warnf("Job %s report is saved without annotations: %s", job.Name, err)
+		}
+		report.Annotations = annotations
+		content, err := json.MarshalIndent(report, "", "\t")
+		if err != nil {
+			return err
+		}
+		path := worker.ArtifactPath(dir, &job, worker.ReportArtifact)
+		if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if job.Tenant != "" {
+			tenantFile := filepath.Join(filepath.Dir(path), artifactTenantFile)
+			if err = os.WriteFile(tenantFile, []byte(job.Tenant), 0o644); err != nil {
+				return err
+			}
+		}
+		return os.WriteFile(path, content, 0o644)
+	}()
+	if err != nil {
+		log.WithField("run_id", job.RunId).Warnf("Job %s saving report failed: %s", job.Name, err)
+	}
+}
+
+// runArtifactsDir returns directory of run artifacts accessible by tenant
+func (l *Lbot) runArtifactsDir(tenant *config.Tenant, runId string) (string, error) {
+	dir := l.artifactsDir()
+	if dir == "" {
+		return "", status.Error(codes.FailedPrecondition, "agent is started without artifacts dir")
+	}
+	// run id is used as path, only object ids are allowed
+	if !primitive.IsValidObjectID(runId) {
+		return "", status.Errorf(codes.InvalidArgument, "invalid run id %q", runId)
+	}
+	runDir := filepath.Join(dir, runId)
+	if _, err := os.Stat(runDir); err != nil {
+		return "", status.Errorf(codes.NotFound, "no artifacts of run %s", runId)
+	}
+	if !lo.IsNil(tenant) {
+		owner, _ := os.ReadFile(filepath.Join(runDir, artifactTenantFile))
+		if string(owner) != tenant.Name {
+			return "", status.Errorf(codes.NotFound, "no artifacts of run %s", runId)
+		}
+	}
+	return runDir, nil
+}
+
+func (l *Lbot) ListArtifacts(tenant *config.Tenant, runId string) ([]*proto.Artifact, error) {
+	runDir, err := l.runArtifactsDir(tenant, runId)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(runDir)
+	if err != nil {
+		return nil, err
+	}
+	artifacts := make([]*proto.Artifact, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, &proto.Artifact{Name: entry.Name(), Size: info.Size()})
+	}
+	return artifacts, nil
+}
+
+// OpenArtifact opens artifact of run for reading, name can't point outside of run directory
+func (l *Lbot) OpenArtifact(tenant *config.Tenant, runId string, name string) (*os.File, error) {
+	runDir, err := l.runArtifactsDir(tenant, runId)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" || filepath.Base(name) != name || strings.HasPrefix(name, ".") {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid artifact name %q", name)
+	}
+	file, err := os.Open(filepath.Join(runDir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, status.Errorf(codes.NotFound, "run %s has no artifact %s", runId, name)
+	}
+	return file, err
+}
+
+type ArtifactService struct {
+	proto.UnimplementedArtifactServiceServer
+	ctx  context.Context
+	lbot *Lbot
+}
+
+func NewArtifactService(ctx context.Context, lbot *Lbot) *ArtifactService {
+	return &ArtifactService{ctx: ctx, lbot: lbot}
+}
+
+func (a *ArtifactService) ListArtifacts(ctx context.Context, request *proto.ListArtifactsRequest) (*proto.ListArtifactsResponse, error) {
+	artifacts, err := a.lbot.ListArtifacts(TenantFromContext(ctx), request.RunId)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.ListArtifactsResponse{Artifacts: artifacts}, nil
+}
+
+// DownloadArtifact streams artifact file in chunks, so large latency logs don't hit grpc message size limit
+func (a *ArtifactService) DownloadArtifact(request *proto.DownloadArtifactRequest, srv proto.ArtifactService_DownloadArtifactServer) error {
+	file, err := a.lbot.OpenArtifact(TenantFromContext(srv.Context()), request.RunId, request.Name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	buffer := make([]byte, ArtifactChunkSize)
+	for {
+		n, err := file.Read(buffer)
+		if n > 0 {
+			if err := srv.Send(&proto.ArtifactChunk{Data: buffer[:n]}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
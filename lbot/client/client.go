@@ -0,0 +1,75 @@
+// Package client is Go SDK for driving loadbot agents, it wraps agent gRPC services with plain Go types
+// so other tools can configure agents, start workloads, follow their progress and fetch results.
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kuzxnia/loadbot/lbot"
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Options configures connection to agent
+type Options struct {
+	// agent api token, sent with every request when set
+	Token string
+	// additional dial options, ex. grpc.WithContextDialer of proxied connection
+	DialOptions []grpc.DialOption
+}
+
+// Client drives single agent, it is safe for concurrent use
+type Client struct {
+	conn  grpc.ClientConnInterface
+	close func() error
+}
+
+// Connect connects to agent at address (host:port), connection is established lazily with first call
+func Connect(address string, options Options) (*Client, error) {
+	conn, err := grpc.Dial(address, append(DialOptions(options.Token), options.DialOptions...)...)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to agent %s failed: %w", address, err)
+	}
+	return &Client{conn: conn, close: conn.Close}, nil
+}
+
+// New wraps already established connection to agent, closing client doesn't close it
+func New(conn grpc.ClientConnInterface) *Client {
+	return &Client{conn: conn, close: func() error { return nil }}
+}
+
+// DialOptions returns options dialing agent without TLS, with api token when set
+func DialOptions(token string) []grpc.DialOption {
+	options := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if token != "" {
+		options = append(options, grpc.WithPerRPCCredentials(tokenCredentials(token)))
+	}
+	return options
+}
+
+// Close closes connection to agent
+func (c *Client) Close() error {
+	return c.close()
+}
+
+// SetConfig replaces agent config, config is validated by agent and rejected with InvalidArgument
+// (or PermissionDenied when it violates target rails) leaving previous config active
+func (c *Client) SetConfig(ctx context.Context, cfg *lbot.ConfigRequest) error {
+	if _, err := proto.NewConfigServiceClient(c.conn).SetConfig(ctx, lbot.NewProtoConfigRequest(cfg)); err != nil {
+		return fmt.Errorf("setting config failed: %w", err)
+	}
+	return nil
+}
+
+// tokenCredentials sends agent api token with every request
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
@@ -0,0 +1,220 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot"
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeAgent finishes started job after second progress snapshot
+type fakeAgent struct {
+	proto.UnimplementedStartProcessServer
+	proto.UnimplementedProgressProcessServer
+	proto.UnimplementedArtifactServiceServer
+	start     *proto.StartRequest
+	token     string
+	snapshots int
+}
+
+func (f *fakeAgent) Run(ctx context.Context, request *proto.StartRequest) (*proto.StartResponse, error) {
+	f.start = request
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("authorization")) > 0 {
+		f.token = md.Get("authorization")[0]
+	}
+	return &proto.StartResponse{RunId: "run1", Jobs: []*proto.StartedJob{{CommandId: "c1", JobName: "inserts", JobType: "write"}}}, nil
+}
+
+type fakeProgress struct{ *fakeAgent }
+
+func (f fakeProgress) Run(request *proto.ProgressRequest, srv proto.ProgressProcess_RunServer) error {
+	f.snapshots++
+	srv.Send(&proto.ProgressResponse{RunId: request.RunId, CommandId: "other", JobName: "other", IsFinished: false})
+	return srv.Send(&proto.ProgressResponse{
+		RunId: request.RunId, CommandId: "c1", JobName: "inserts", Requests: 100, Duration: 2, AverageLatencyMs: 1.5,
+		IsFinished: f.snapshots > 1,
+	})
+}
+
+type fakeHistory struct {
+	proto.UnimplementedRunHistoryServiceServer
+}
+
+func (fakeHistory) GetRun(ctx context.Context, request *proto.GetRunRequest) (*proto.RunSummary, error) {
+	if request.RunId != "ended" {
+		return nil, status.Errorf(codes.NotFound, "run %s is still active", request.RunId)
+	}
+	return &proto.RunSummary{RunId: "ended", Status: "finished", Jobs: []*proto.RunJobSummary{
+		{JobName: "seed", JobType: "write", Requests: 10, Errors: 1, Duration: 3, StopReason: "operations"},
+	}}, nil
+}
+
+func (f *fakeAgent) ListArtifacts(ctx context.Context, request *proto.ListArtifactsRequest) (*proto.ListArtifactsResponse, error) {
+	return &proto.ListArtifactsResponse{Artifacts: []*proto.Artifact{{Name: "inserts-report.json", Size: 2}}}, nil
+}
+
+func (f *fakeAgent) DownloadArtifact(request *proto.DownloadArtifactRequest, srv proto.ArtifactService_DownloadArtifactServer) error {
+	srv.Send(&proto.ArtifactChunk{Data: []byte("{")})
+	return srv.Send(&proto.ArtifactChunk{Data: []byte("}")})
+}
+
+func startFakeAgent(t *testing.T) (*fakeAgent, *Client) {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	agent := &fakeAgent{}
+	proto.RegisterStartProcessServer(server, agent)
+	proto.RegisterProgressProcessServer(server, fakeProgress{agent})
+	proto.RegisterArtifactServiceServer(server, agent)
+	proto.RegisterRunHistoryServiceServer(server, fakeHistory{})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	c, err := Connect("bufnet", Options{
+		Token: "secret",
+		DialOptions: []grpc.DialOption{grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		})},
+	})
+	assert.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+	return agent, c
+}
+
+func TestStartAndWait(t *testing.T) {
+	agent, c := startFakeAgent(t)
+	ctx := context.Background()
+
+	run, err := c.Start(ctx, StartOptions{Confirmed: true, StartAt: time.Date(2024, 5, 3, 2, 0, 0, 0, time.UTC)})
+	assert.NoError(t, err)
+	assert.Equal(t, "run1", run.Id)
+	assert.Equal(t, []StartedJob{{CommandId: "c1", Name: "inserts", Type: "write"}}, run.Jobs)
+	assert.True(t, agent.start.Confirmed)
+	assert.Equal(t, "2024-05-03T02:00:00Z", agent.start.StartAt)
+	assert.Equal(t, "Bearer secret", agent.token)
+
+	jobs, err := c.Wait(ctx, run, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, "run1", jobs[0].RunId)
+	assert.Equal(t, uint64(100), jobs[0].Requests)
+	assert.Equal(t, 2*time.Second, jobs[0].Duration)
+	assert.Equal(t, 1500*time.Microsecond, jobs[0].AverageLatency)
+	assert.True(t, jobs[0].Finished)
+
+	_, err = c.Wait(ctx, &Run{ApprovalId: "a1"}, time.Millisecond)
+	assert.Error(t, err)
+}
+
+func TestWaitFallsBackToRunHistory(t *testing.T) {
+	_, c := startFakeAgent(t)
+
+	// job finished and agent forgot it, its stats are in run history
+	jobs, err := c.Wait(context.Background(), &Run{Id: "ended", Jobs: []StartedJob{{CommandId: "c9", Name: "seed"}}}, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, "seed", jobs[0].JobName)
+	assert.Equal(t, uint64(10), jobs[0].Requests)
+	assert.Equal(t, 0.1, jobs[0].ErrorRate)
+	assert.Equal(t, 3*time.Second, jobs[0].Duration)
+	assert.True(t, jobs[0].Finished)
+}
+
+func TestStartWithOwnConfig(t *testing.T) {
+	agent, c := startFakeAgent(t)
+
+	_, err := c.Start(context.Background(), StartOptions{Config: &lbot.ConfigRequest{
+		ConnectionString: "mongodb://localhost:27017",
+		Jobs:             []*lbot.JobRequest{{Name: "inserts", Type: "write", Schema: "users", Duration: time.Minute, Pace: 100}},
+	}})
+	assert.NoError(t, err)
+
+	// config is sent in config file format agent parses
+	parsed, err := lbot.ParseConfig([]byte(agent.start.Config))
+	assert.NoError(t, err)
+	assert.Equal(t, "mongodb://localhost:27017", parsed.ConnectionString)
+	assert.Equal(t, time.Minute, parsed.Jobs[0].Duration)
+	assert.Equal(t, uint64(100), parsed.Jobs[0].Pace)
+	assert.Equal(t, "users", parsed.Jobs[0].Schema)
+}
+
+func TestProgress(t *testing.T) {
+	_, c := startFakeAgent(t)
+
+	var names []string
+	err := c.Progress(context.Background(), ProgressOptions{RunId: "run1"}, func(job JobProgress) error {
+		names = append(names, job.JobName)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"other", "inserts"}, names)
+
+	snapshot, err := c.ProgressSnapshot(context.Background(), "run1")
+	assert.NoError(t, err)
+	assert.Len(t, snapshot, 2)
+}
+
+func TestResults(t *testing.T) {
+	_, c := startFakeAgent(t)
+
+	artifacts, err := c.Results(context.Background(), "run1")
+	assert.NoError(t, err)
+	assert.Equal(t, []Artifact{{Name: "inserts-report.json", Size: 2}}, artifacts)
+
+	var report bytes.Buffer
+	assert.NoError(t, c.Download(context.Background(), "run1", "inserts-report.json", &report))
+	assert.Equal(t, "{}", report.String())
+}
+
+// config set with SetConfig reaches agent the same as parsed from file
+func TestConfigMappedOverGrpc(t *testing.T) {
+	request := &lbot.ConfigRequest{
+		ConnectionString: "mongodb://localhost:27017",
+		Agent:            &lbot.AgentRequest{},
+		Jobs: []*lbot.JobRequest{{
+			Name: "signup", Type: "scenario", Database: "app", Collection: "users", Connections: 1,
+			Update:       map[string]interface{}{"$set": map[string]interface{}{"tags.$[tag]": "#word"}},
+			ArrayFilters: []interface{}{map[string]interface{}{"tag": "old"}},
+			Sort:         map[string]interface{}{"created_at": -1.0},
+			ClientRegions: []*config.ClientRegion{
+				{Name: "eu", Weight: 2, Latency: config.Duration{Duration: 80 * time.Millisecond}, Jitter: config.Duration{Duration: 5 * time.Millisecond}},
+			},
+			ReadPreferenceTags: []map[string]string{{"region": "eu"}, {}},
+			Disturbances:       []string{"index", "validator"},
+			BulkMix:            map[string]float64{"insert": 0.7, "delete": 0.3},
+			Lookup:             map[string]interface{}{"from": "orders", "localField": "_id", "foreignField": "user", "as": "orders"},
+			Indexes:            []*config.Index{{Name: "email", Keys: []string{"email:1"}, Unique: true, ExpireAfterSeconds: 60}},
+			Pipeline:           []interface{}{map[string]interface{}{"$match": map[string]interface{}{"status": "#word"}}},
+			Steps: []*config.ScenarioStep{
+				{Name: "signup", Type: config.ScenarioStepInsert},
+				{Name: "profile", Type: config.ScenarioStepUpdate, Filter: map[string]interface{}{"_id": "@signup._id"},
+					Update: map[string]interface{}{"$inc": map[string]interface{}{"visits": 1.0}}, Weight: 0.5},
+			},
+		}},
+	}
+
+	cfg, err := lbot.NewConfigFromProtoConfigRequest(lbot.NewProtoConfigRequest(request))
+	assert.NoError(t, err)
+	expected := lbot.NewConfig(request).Jobs[0]
+	assert.Equal(t, expected.Steps, cfg.Jobs[0].Steps)
+	assert.Equal(t, expected.Pipeline, cfg.Jobs[0].Pipeline)
+	assert.Equal(t, expected.Indexes, cfg.Jobs[0].Indexes)
+	assert.Equal(t, expected.Update, cfg.Jobs[0].Update)
+	assert.Equal(t, expected.ArrayFilters, cfg.Jobs[0].ArrayFilters)
+	assert.Equal(t, expected.Sort, cfg.Jobs[0].Sort)
+	assert.Equal(t, expected.Lookup, cfg.Jobs[0].Lookup)
+	assert.Equal(t, expected.BulkMix, cfg.Jobs[0].BulkMix)
+	assert.Equal(t, expected.Disturbances, cfg.Jobs[0].Disturbances)
+	assert.Equal(t, expected.ClientRegions, cfg.Jobs[0].ClientRegions)
+	assert.Equal(t, expected.ReadPreferenceTags, cfg.Jobs[0].ReadPreferenceTags)
+}
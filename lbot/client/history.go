@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/samber/lo"
+)
+
+// RunSummary is summary of run ended on agent, kept in agent run history
+type RunSummary struct {
+	Id     string
+	Tenant string
+	// finished or stopped
+	Status         string
+	StartedAt      time.Time
+	EndedAt        time.Time
+	Duration       time.Duration
+	Requests       uint64
+	Errors         uint64
+	Rps            uint64
+	AverageLatency time.Duration
+	// reference and digest of template config was pulled from
+	Template  string
+	OwnConfig bool
+	Jobs      []JobSummary
+}
+
+type JobSummary struct {
+	Name           string
+	Type           string
+	Requests       uint64
+	Errors         uint64
+	Rps            uint64
+	AverageLatency time.Duration
+	Duration       time.Duration
+	StopReason     string
+}
+
+// Runs returns summaries of runs ended on agent, newest first, limit 0 returns all kept runs
+func (c *Client) Runs(ctx context.Context, limit int) ([]RunSummary, error) {
+	response, err := proto.NewRunHistoryServiceClient(c.conn).ListRuns(ctx, &proto.ListRunsRequest{Limit: uint32(limit)})
+	if err != nil {
+		return nil, fmt.Errorf("listing run history failed: %w", err)
+	}
+	return lo.Map(response.Runs, func(run *proto.RunSummary, _ int) RunSummary { return newRunSummary(run) }), nil
+}
+
+// RunSummary returns summary of ended run, fails with NotFound while run is active
+func (c *Client) RunSummary(ctx context.Context, runId string) (*RunSummary, error) {
+	response, err := proto.NewRunHistoryServiceClient(c.conn).GetRun(ctx, &proto.GetRunRequest{RunId: runId})
+	if err != nil {
+		return nil, fmt.Errorf("getting run %s failed: %w", runId, err)
+	}
+	run := newRunSummary(response)
+	return &run, nil
+}
+
+func newRunSummary(run *proto.RunSummary) RunSummary {
+	startedAt, _ := time.Parse(time.RFC3339, run.StartedAt)
+	endedAt, _ := time.Parse(time.RFC3339, run.EndedAt)
+	return RunSummary{
+		Id:             run.RunId,
+		Tenant:         run.Tenant,
+		Status:         run.Status,
+		StartedAt:      startedAt,
+		EndedAt:        endedAt,
+		Duration:       time.Duration(run.Duration) * time.Second,
+		Requests:       run.Requests,
+		Errors:         run.Errors,
+		Rps:            run.Rps,
+		AverageLatency: time.Duration(run.AverageLatencyMs * float64(time.Millisecond)),
+		Template:       run.Template,
+		OwnConfig:      run.OwnConfig,
+		Jobs: lo.Map(run.Jobs, func(job *proto.RunJobSummary, _ int) JobSummary {
+			return JobSummary{
+				Name:           job.JobName,
+				Type:           job.JobType,
+				Requests:       job.Requests,
+				Errors:         job.Errors,
+				Rps:            job.Rps,
+				AverageLatency: time.Duration(job.AverageLatencyMs * float64(time.Millisecond)),
+				Duration:       time.Duration(job.Duration) * time.Second,
+				StopReason:     job.StopReason,
+			}
+		}),
+	}
+}
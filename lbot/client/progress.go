@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/samber/lo"
+)
+
+// DefaultProgressInterval is interval of progress updates and polls when options don't set it
+const DefaultProgressInterval = time.Second
+
+// ProgressOptions configures progress stream, zero value follows jobs of all runs every second
+type ProgressOptions struct {
+	// only jobs of this run
+	RunId    string
+	Interval time.Duration
+}
+
+// JobProgress is progress (or final stats) of job started by agent
+type JobProgress struct {
+	RunId      string
+	WorkloadId string
+	CommandId  string
+	JobName    string
+	JobType    string
+	Requests   uint64
+	Errors     uint64
+	// operations failed with write conflict, counted in errors as well
+	WriteConflicts uint64
+	ErrorRate      float64
+	Rps            uint64
+	AverageLatency time.Duration
+	Duration       time.Duration
+	// limits job was started with, zero when not set
+	RequestedOperations uint64
+	RequestedDuration   time.Duration
+	Finished            bool
+	Paused              bool
+	// why job finished, ex. duration, operations or cancelled
+	StopReason string
+}
+
+// Progress calls callback with progress of every running job each interval until they all finish,
+// ctx is cancelled or callback returns error, which is then returned
+func (c *Client) Progress(ctx context.Context, options ProgressOptions, callback func(JobProgress) error) error {
+	interval := lo.Ternary(options.Interval > 0, options.Interval, DefaultProgressInterval)
+	stream, err := proto.NewProgressProcessClient(c.conn).Run(ctx, &proto.ProgressRequest{
+		RefreshInterval: interval.String(),
+		RunId:           options.RunId,
+	})
+	if err != nil {
+		return fmt.Errorf("getting progress failed: %w", err)
+	}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("getting progress failed: %w", err)
+		}
+		if err = callback(newJobProgress(resp)); err != nil {
+			return err
+		}
+	}
+}
+
+// ProgressSnapshot returns progress of jobs agent runs, with run id also final progress of jobs of that run
+// which already finished. Empty run id means running jobs of all runs
+func (c *Client) ProgressSnapshot(ctx context.Context, runId string) ([]JobProgress, error) {
+	stream, err := proto.NewProgressProcessClient(c.conn).Run(ctx, &proto.ProgressRequest{Once: true, RunId: runId})
+	if err != nil {
+		return nil, fmt.Errorf("getting progress failed: %w", err)
+	}
+	snapshot := make([]JobProgress, 0)
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return snapshot, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("getting progress failed: %w", err)
+		}
+		snapshot = append(snapshot, newJobProgress(resp))
+	}
+}
+
+// Wait polls progress every interval until all jobs of run finish and returns their final stats, once
+// run has no running jobs and agent already forgot them stats come from run history. Runs waiting for
+// approval or scheduled can't be awaited
+func (c *Client) Wait(ctx context.Context, run *Run, interval time.Duration) ([]JobProgress, error) {
+	if run.ApprovalId != "" || run.Id == "" {
+		return nil, fmt.Errorf("run wasn't started, it can't be awaited")
+	}
+	interval = lo.Ternary(interval > 0, interval, DefaultProgressInterval)
+	started := lo.SliceToMap(run.Jobs, func(job StartedJob) (string, bool) { return job.CommandId, true })
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for run %s failed: %w", run.Id, ctx.Err())
+		case <-ticker.C:
+		}
+		snapshot, err := c.ProgressSnapshot(ctx, run.Id)
+		if err != nil {
+			return nil, err
+		}
+		jobs := lo.Filter(snapshot, func(job JobProgress, _ int) bool { return started[job.CommandId] })
+		commands := lo.Uniq(lo.Map(jobs, func(job JobProgress, _ int) string { return job.CommandId }))
+		if len(commands) == len(started) && lo.EveryBy(jobs, func(job JobProgress) bool { return job.Finished }) {
+			return jobs, nil
+		}
+		if lo.EveryBy(jobs, func(job JobProgress) bool { return job.Finished }) {
+			// jobs which never started (ex. skipped) or were forgotten are known only to run history
+			if summary, err := c.RunSummary(ctx, run.Id); err == nil {
+				return summaryJobs(summary), nil
+			}
+		}
+	}
+}
+
+// summaryJobs returns final stats of jobs of ended run
+func summaryJobs(summary *RunSummary) []JobProgress {
+	return lo.Map(summary.Jobs, func(job JobSummary, _ int) JobProgress {
+		return JobProgress{
+			RunId:          summary.Id,
+			JobName:        job.Name,
+			JobType:        job.Type,
+			Requests:       job.Requests,
+			Errors:         job.Errors,
+			ErrorRate:      lo.Ternary(job.Requests > 0, float64(job.Errors)/float64(job.Requests), 0),
+			Rps:            job.Rps,
+			AverageLatency: job.AverageLatency,
+			Duration:       job.Duration,
+			Finished:       true,
+			StopReason:     job.StopReason,
+		}
+	})
+}
+
+func newJobProgress(resp *proto.ProgressResponse) JobProgress {
+	return JobProgress{
+		RunId:               resp.RunId,
+		WorkloadId:          resp.WorkloadId,
+		CommandId:           resp.CommandId,
+		JobName:             resp.JobName,
+		JobType:             resp.JobType,
+		Requests:            resp.Requests,
+		Errors:              resp.Errors,
+		WriteConflicts:      resp.WriteConflicts,
+		ErrorRate:           float64(resp.ErrorRate),
+		Rps:                 resp.Rps,
+		AverageLatency:      time.Duration(float64(resp.AverageLatencyMs) * float64(time.Millisecond)),
+		Duration:            time.Duration(resp.Duration) * time.Second,
+		RequestedOperations: resp.RequestOperations,
+		RequestedDuration:   time.Duration(resp.RequestDuration) * time.Second,
+		Finished:            resp.IsFinished,
+		Paused:              resp.Paused,
+		StopReason:          resp.StopReason,
+	}
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/samber/lo"
+)
+
+// Artifact is file saved by agent for run, raw latency log or job report
+type Artifact struct {
+	Name string
+	Size int64
+}
+
+// Results lists artifacts agent saved for run, agent must be started with artifacts dir
+func (c *Client) Results(ctx context.Context, runId string) ([]Artifact, error) {
+	response, err := proto.NewArtifactServiceClient(c.conn).ListArtifacts(ctx, &proto.ListArtifactsRequest{RunId: runId})
+	if err != nil {
+		return nil, fmt.Errorf("listing run artifacts failed: %w", err)
+	}
+	return lo.Map(response.Artifacts, func(artifact *proto.Artifact, _ int) Artifact {
+		return Artifact{Name: artifact.Name, Size: artifact.Size}
+	}), nil
+}
+
+// Download streams artifact of run to w
+func (c *Client) Download(ctx context.Context, runId string, name string, w io.Writer) error {
+	stream, err := proto.NewArtifactServiceClient(c.conn).DownloadArtifact(ctx, &proto.DownloadArtifactRequest{RunId: runId, Name: name})
+	if err != nil {
+		return fmt.Errorf("downloading artifact %s failed: %w", name, err)
+	}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("downloading artifact %s failed: %w", name, err)
+		}
+		if _, err = w.Write(chunk.Data); err != nil {
+			return err
+		}
+	}
+}
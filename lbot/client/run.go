@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot"
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/samber/lo"
+)
+
+// StartOptions configures started workload, zero value starts jobs of agent config
+type StartOptions struct {
+	// stop active run of tenant before starting new one
+	Restart bool
+	// start even if estimated footprint exceeds agent thresholds
+	Confirmed bool
+	// run workload from this config alongside other runs instead of jobs of agent config
+	Config *lbot.ConfigRequest
+	// cron expression (UTC), agent starts workload at every matching time
+	Schedule string
+	// agent starts workload once at this time
+	StartAt time.Time
+}
+
+// Run is workload started (or scheduled, or waiting for approval) by agent
+type Run struct {
+	Id   string
+	Jobs []StartedJob
+	// set when agent is protected and start waits for approval
+	ApprovalId string
+	// first start of scheduled workload
+	ScheduledAt time.Time
+}
+
+type StartedJob struct {
+	CommandId string
+	Name      string
+	Type      string
+}
+
+// StopOptions configures stopping workloads, zero value stops all active runs of tenant gracefully
+// with agent default grace period
+type StopOptions struct {
+	// stop only this run
+	RunId string
+	// time to wait for in-flight operations before cancelling them, agent default when zero
+	GracePeriod time.Duration
+	// cancel in-flight operations immediately
+	Force bool
+	// cancel scheduled starts as well
+	Schedules bool
+}
+
+type StoppedWorkload struct {
+	WorkloadId string
+	CommandId  string
+	JobName    string
+	JobType    string
+	Requests   uint64
+	Errors     uint64
+	Duration   time.Duration
+	// in-flight operations cancelled after grace period
+	Abandoned uint64
+	// drained, grace_period_exceeded or forced
+	Status string
+}
+
+// Start starts workload, with own config or schedule agent is asked to run it
+func (c *Client) Start(ctx context.Context, options StartOptions) (*Run, error) {
+	request := &proto.StartRequest{Restart: options.Restart, Confirmed: options.Confirmed, Schedule: options.Schedule}
+	if !options.StartAt.IsZero() {
+		request.StartAt = options.StartAt.Format(time.RFC3339)
+	}
+	if options.Config != nil {
+		config, err := json.Marshal(options.Config)
+		if err != nil {
+			return nil, fmt.Errorf("encoding workload config failed: %w", err)
+		}
+		request.Config = string(config)
+	}
+
+	response, err := proto.NewStartProcessClient(c.conn).Run(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("starting workload failed: %w", err)
+	}
+	return newRun(response), nil
+}
+
+// Stop stops workloads and returns their final stats
+func (c *Client) Stop(ctx context.Context, options StopOptions) ([]StoppedWorkload, error) {
+	request := &proto.StopRequest{RunId: options.RunId, Force: options.Force, Schedules: options.Schedules}
+	if options.GracePeriod > 0 {
+		request.GracePeriod = options.GracePeriod.String()
+	}
+
+	response, err := proto.NewStopProcessClient(c.conn).Run(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("stopping workload failed: %w", err)
+	}
+	return lo.Map(response.Workloads, func(workload *proto.StoppedWorkload, _ int) StoppedWorkload {
+		return StoppedWorkload{
+			WorkloadId: workload.WorkloadId,
+			CommandId:  workload.CommandId,
+			JobName:    workload.JobName,
+			JobType:    workload.JobType,
+			Requests:   workload.Requests,
+			Errors:     workload.Errors,
+			Duration:   time.Duration(workload.Duration) * time.Second,
+			Abandoned:  workload.Abandoned,
+			Status:     workload.Status,
+		}
+	}), nil
+}
+
+func newRun(response *proto.StartResponse) *Run {
+	run := &Run{
+		Id:         response.RunId,
+		ApprovalId: response.ApprovalId,
+		Jobs: lo.Map(response.Jobs, func(job *proto.StartedJob, _ int) StartedJob {
+			return StartedJob{CommandId: job.CommandId, Name: job.JobName, Type: job.JobType}
+		}),
+	}
+	if response.ScheduledAt != "" {
+		run.ScheduledAt, _ = time.Parse(time.RFC3339, response.ScheduledAt)
+	}
+	return run
+}
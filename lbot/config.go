@@ -7,44 +7,166 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/kuzxnia/loadbot/lbot/config"
 	"github.com/kuzxnia/loadbot/lbot/proto"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	"github.com/samber/lo"
 	"github.com/tailscale/hujson"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 func NewConfig(request *ConfigRequest) *config.Config {
+	retentionMaxAge, _ := time.ParseDuration(request.Agent.RetentionMaxAge)
 	cfg := &config.Config{
-		ConnectionString: request.ConnectionString,
+		ConnectionString:      request.ConnectionString,
+		ShardConnectionString: request.ShardConnectionString,
 		Agent: &config.Agent{
 			Name:                         request.Agent.Name,
 			Port:                         request.Agent.Port,
 			MetricsExportUrl:             request.Agent.MetricsExportUrl,
 			MetricsExportIntervalSeconds: request.Agent.MetricsExportIntervalSeconds,
 			MetricsExportPort:            request.Agent.MetricsExportPort,
+			MetricsExportTLSCertFile:     request.Agent.MetricsExportTLSCertFile,
+			MetricsExportTLSKeyFile:      request.Agent.MetricsExportTLSKeyFile,
+			MetricsExportUsername:        request.Agent.MetricsExportUsername,
+			MetricsExportPassword:        request.Agent.MetricsExportPassword,
+			MetricsExportBearerToken:     request.Agent.MetricsExportBearerToken,
+			MetricsExportLabels:          request.Agent.MetricsExportLabels,
+			Tenants:                      make([]*config.Tenant, len(request.Agent.Tenants)),
+			EstimateAtlasCost:            request.Agent.EstimateAtlasCost,
+			EventsNdjson:                 request.Agent.EventsNdjson,
+			ArtifactsDir:                 request.Agent.ArtifactsDir,
+			MaxCpuPercent:                request.Agent.MaxCpuPercent,
+			MaxNetworkBytesPerSecond:     request.Agent.MaxNetworkBytesPerSecond,
+			Plugins:                      request.Agent.Plugins,
+			Proxy:                        request.Agent.Proxy,
+			SshKeyFile:                   request.Agent.SshKeyFile,
+			SshKnownHostsFile:            request.Agent.SshKnownHostsFile,
+			RetentionMaxRuns:             request.Agent.RetentionMaxRuns,
+			RetentionMaxDiskBytes:        request.Agent.RetentionMaxDiskBytes,
+			RetentionMaxAge:              retentionMaxAge,
+			Protected:                    request.Agent.Protected,
+			TargetAllowlist:              request.Agent.TargetAllowlist,
+			TargetDenylist:               request.Agent.TargetDenylist,
+			ConfirmAboveDocuments:        request.Agent.ConfirmAboveDocuments,
+			ConfirmAboveWrittenBytes:     request.Agent.ConfirmAboveWrittenBytes,
+			ConfirmAboveEgressBytes:      request.Agent.ConfirmAboveEgressBytes,
+			RunHistorySize:               request.Agent.RunHistorySize,
+			RunHistoryFile:               request.Agent.RunHistoryFile,
 		},
-		Jobs:    make([]*config.Job, len(request.Jobs)),
-		Schemas: make([]*config.Schema, len(request.Schemas)),
-		Debug:   request.Debug,
+		Jobs:     make([]*config.Job, len(request.Jobs)),
+		Schemas:  make([]*config.Schema, len(request.Schemas)),
+		Debug:    request.Debug,
+		Parallel: request.Parallel,
+		Template: request.Template,
+	}
+	for i, tenant := range request.Agent.Tenants {
+		cfg.Agent.Tenants[i] = &config.Tenant{
+			Name:           tenant.Name,
+			Token:          tenant.Token,
+			MaxConnections: tenant.MaxConnections,
+			MaxPace:        tenant.MaxPace,
+			Role:           tenant.Role,
+		}
 	}
 	for i, job := range request.Jobs {
 		cfg.Jobs[i] = &config.Job{
 			Name: job.Name,
 			// Parent:      cfg,
-			Database:    job.Database,
-			Collection:  job.Collection,
-			Type:        job.Type,
-			Schema:      job.Schema,
-			Connections: job.Connections,
-			Pace:        job.Pace,
-			DataSize:    job.DataSize,
-			BatchSize:   job.BatchSize,
-			Duration:    job.Duration,
-			Operations:  job.Operations,
-			Timeout:     job.Timeout,
-			Filter:      job.Filter,
+			Database:            job.Database,
+			Collection:          job.Collection,
+			Type:                job.Type,
+			Schema:              job.Schema,
+			Connections:         job.Connections,
+			Pace:                job.Pace,
+			DataSize:            job.DataSize,
+			BatchSize:           job.BatchSize,
+			Duration:            job.Duration,
+			Operations:          job.Operations,
+			Timeout:             job.Timeout,
+			Filter:              job.Filter,
+			HistogramBuckets:    job.HistogramBuckets,
+			NativeHistogram:     job.NativeHistogram,
+			Tenant:              job.Tenant,
+			EvolutionRatio:      job.EvolutionRatio,
+			Update:              job.Update,
+			ArrayFilters:        job.ArrayFilters,
+			ReturnDocument:      job.ReturnDocument,
+			Sort:                job.Sort,
+			ConsumerRatio:       job.ConsumerRatio,
+			HotDocuments:        job.HotDocuments,
+			Pagination:          job.Pagination,
+			PageSize:            job.PageSize,
+			Pages:               job.Pages,
+			Lookup:              job.Lookup,
+			Limit:               job.Limit,
+			StopWhen:            job.StopWhen,
+			PaceInterval:        job.PaceInterval,
+			CompareRouting:      job.CompareRouting,
+			ReadPreference:      job.ReadPreference,
+			ReadPreferenceTags:  job.ReadPreferenceTags,
+			HedgedReads:         job.HedgedReads,
+			BulkOrder:           job.BulkOrder,
+			BulkMix:             job.BulkMix,
+			ExpectedIndex:       job.ExpectedIndex,
+			ExplainInterval:     job.ExplainInterval,
+			DisturbanceInterval: job.DisturbanceInterval,
+			Disturbances:        job.Disturbances,
+			BackupAt:            job.BackupAt,
+			BackupDuration:      job.BackupDuration,
+			BackupCommand:       job.BackupCommand,
+			CacheMode:           job.CacheMode,
+			CacheCommand:        job.CacheCommand,
+			Collections:         job.Collections,
+			GrowField:           job.GrowField,
+			GrowMaxItems:        job.GrowMaxItems,
+			Priority:            job.Priority,
+			PaceJitter:          job.PaceJitter,
+			NoiseCollections:    job.NoiseCollections,
+			DeleteMany:          job.DeleteMany,
+			Pipeline:            job.Pipeline,
+			PauseWindows:        job.PauseWindows,
+			TransactionSteps:    job.TransactionSteps,
+			ReadConcern:         job.ReadConcern,
+			WriteConcern:        job.WriteConcern,
+			TransactionRetries:  job.TransactionRetries,
+			OperationMix:        job.OperationMix,
+			Upsert:              job.Upsert,
+			Indexes:             job.Indexes,
+			DropIndexes:         job.DropIndexes,
+			HotFraction:         job.HotFraction,
+			HotSkew:             job.HotSkew,
+			HotField:            job.HotField,
+			FullDocument:        job.FullDocument,
+			FileSize:            job.FileSize,
+			FileSizeMax:         job.FileSizeMax,
+			SlaBuckets:          job.SlaBuckets,
+			DistinctField:       job.DistinctField,
+			MetricsInclude:      job.MetricsInclude,
+			MetricsExclude:      job.MetricsExclude,
+			MetricsSample:       job.MetricsSample,
+			Search:              job.Search,
+			GeoField:            job.GeoField,
+			GeoPoint:            job.GeoPoint,
+			GeoDistance:         job.GeoDistance,
+			Command:             job.Command,
+			FanoutCollections:   job.FanoutCollections,
+			FanoutKey:           job.FanoutKey,
+			Steps:               job.Steps,
+			DependsOn:           job.DependsOn,
+			ClientRegions:       job.ClientRegions,
+			Warmup:              job.Warmup,
+			BurstDuration:       job.BurstDuration,
+			BurstIdle:           job.BurstIdle,
+			RateProfile:         job.RateProfile,
+			PaceBurst:           job.PaceBurst,
 		}
 	}
 	for i, schema := range request.Schemas {
@@ -60,9 +182,11 @@ func NewConfig(request *ConfigRequest) *config.Config {
 	return cfg
 }
 
-func NewConfigFromProtoConfigRequest(request *proto.ConfigRequest) *config.Config {
+// NewConfigFromProtoConfigRequest maps config set remotely, jobs with hook commands are rejected
+func NewConfigFromProtoConfigRequest(request *proto.ConfigRequest) (*config.Config, error) {
 	cfg := &config.Config{
-		ConnectionString: request.ConnectionString,
+		ConnectionString:      request.ConnectionString,
+		ShardConnectionString: request.ShardConnectionString,
 		Agent: &config.Agent{
 			Name:                         request.Agent.Name,
 			Port:                         request.Agent.Port,
@@ -70,13 +194,23 @@ func NewConfigFromProtoConfigRequest(request *proto.ConfigRequest) *config.Confi
 			MetricsExportIntervalSeconds: request.Agent.MetricsExportIntervalSeconds,
 			MetricsExportPort:            request.Agent.MetricsExportPort,
 		},
-		Jobs:    make([]*config.Job, len(request.Jobs)),
-		Schemas: make([]*config.Schema, len(request.Schemas)),
-		Debug:   request.Debug,
+		Jobs:     make([]*config.Job, len(request.Jobs)),
+		Schemas:  make([]*config.Schema, len(request.Schemas)),
+		Debug:    request.Debug,
+		Parallel: request.Parallel,
+		Template: request.Template,
 	}
 	for i, job := range request.Jobs {
 		duration, _ := time.ParseDuration(job.Duration)
 		timeout, _ := time.ParseDuration(job.Timeout)
+		paceInterval, _ := time.ParseDuration(job.PaceInterval)
+		explainInterval, _ := time.ParseDuration(job.ExplainInterval)
+		disturbanceInterval, _ := time.ParseDuration(job.DisturbanceInterval)
+		backupAt, _ := time.ParseDuration(job.BackupAt)
+		backupDuration, _ := time.ParseDuration(job.BackupDuration)
+		warmup, _ := time.ParseDuration(job.Warmup)
+		burstDuration, _ := time.ParseDuration(job.BurstDuration)
+		burstIdle, _ := time.ParseDuration(job.BurstIdle)
 		cfg.Jobs[i] = &config.Job{
 			Name: job.Name,
 			// Parent:      cfg,
@@ -92,6 +226,81 @@ func NewConfigFromProtoConfigRequest(request *proto.ConfigRequest) *config.Confi
 			Operations:  job.Operations,
 			Timeout:     timeout,
 			// Filter:          job.Filter,
+			HistogramBuckets:    job.HistogramBuckets,
+			NativeHistogram:     job.NativeHistogram,
+			Tenant:              job.Tenant,
+			EvolutionRatio:      job.EvolutionRatio,
+			ReturnDocument:      job.ReturnDocument,
+			ConsumerRatio:       job.ConsumerRatio,
+			HotDocuments:        job.HotDocuments,
+			Pagination:          job.Pagination,
+			PageSize:            job.PageSize,
+			Pages:               job.Pages,
+			Limit:               job.Limit,
+			StopWhen:            job.StopWhen,
+			PaceInterval:        paceInterval,
+			CompareRouting:      job.CompareRouting,
+			ReadPreference:      job.ReadPreference,
+			HedgedReads:         job.HedgedReads,
+			BulkOrder:           job.BulkOrder,
+			ExpectedIndex:       job.ExpectedIndex,
+			ExplainInterval:     explainInterval,
+			DisturbanceInterval: disturbanceInterval,
+			BackupAt:            backupAt,
+			BackupDuration:      backupDuration,
+			BackupCommand:       job.BackupCommand,
+			CacheMode:           job.CacheMode,
+			CacheCommand:        job.CacheCommand,
+			Collections:         job.Collections,
+			GrowField:           job.GrowField,
+			GrowMaxItems:        job.GrowMaxItems,
+			Priority:            job.Priority,
+			PaceJitter:          job.PaceJitter,
+			NoiseCollections:    job.NoiseCollections,
+			DeleteMany:          job.DeleteMany,
+			PauseWindows:        job.PauseWindows,
+			TransactionSteps:    job.TransactionSteps,
+			ReadConcern:         job.ReadConcern,
+			WriteConcern:        job.WriteConcern,
+			TransactionRetries:  job.TransactionRetries,
+			OperationMix:        job.OperationMix,
+			Upsert:              job.Upsert,
+			DropIndexes:         job.DropIndexes,
+			HotFraction:         job.HotFraction,
+			HotSkew:             job.HotSkew,
+			HotField:            job.HotField,
+			FullDocument:        job.FullDocument,
+			FileSize:            job.FileSize,
+			FileSizeMax:         job.FileSizeMax,
+			SlaBuckets:          job.SlaBuckets,
+			DistinctField:       job.DistinctField,
+			MetricsInclude:      job.MetricsInclude,
+			MetricsExclude:      job.MetricsExclude,
+			MetricsSample:       job.MetricsSample,
+			Search:              job.Search,
+			GeoField:            job.GeoField,
+			GeoPoint:            job.GeoPoint,
+			GeoDistance:         job.GeoDistance,
+			FanoutCollections:   job.FanoutCollections,
+			FanoutKey:           job.FanoutKey,
+			DependsOn:           job.DependsOn,
+			Warmup:              warmup,
+			BurstDuration:       burstDuration,
+			BurstIdle:           burstIdle,
+			RateProfile:         job.RateProfile,
+			PaceBurst:           job.PaceBurst,
+			Command:             jobCommand(job.Command),
+			Steps:               scenarioSteps(job.Steps),
+			Pipeline:            listValues(job.Pipeline),
+			Indexes:             jobIndexes(job.Indexes),
+			Update:              structDocument(job.Update),
+			ArrayFilters:        listValues(job.ArrayFilters),
+			Sort:                structDocument(job.Sort),
+			Lookup:              structDocument(job.Lookup),
+			BulkMix:             job.BulkMix,
+			Disturbances:        job.Disturbances,
+			ClientRegions:       clientRegions(job.ClientRegions),
+			ReadPreferenceTags:  tagSets(job.ReadPreferenceTags),
 		}
 	}
 	for i, schema := range request.Schemas {
@@ -103,12 +312,261 @@ func NewConfigFromProtoConfigRequest(request *proto.ConfigRequest) *config.Confi
 			Save: schema.Save,
 		}
 	}
+	if err := rejectHookCommands(cfg.Jobs); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// rejectHookCommands fails for jobs with commands run with sh -c on agent host, remote clients can't
+// run them, hook commands are accepted only from config file agent is started with
+func rejectHookCommands(jobs []*config.Job) error {
+	for _, job := range jobs {
+		if job.BackupCommand != "" || job.CacheCommand != "" {
+			return fmt.Errorf("job %s: 'backup_command' and 'cache_command' can be set only in agent config file", job.Name)
+		}
+	}
+	return nil
+}
+
+// protoStruct maps JSON document of config to proto, documents parsed from JSON always convert
+func protoStruct(document map[string]interface{}) *structpb.Struct {
+	if document == nil {
+		return nil
+	}
+	converted, _ := structpb.NewStruct(document)
+	return converted
+}
+
+func structDocument(document *structpb.Struct) map[string]interface{} {
+	if document == nil {
+		return nil
+	}
+	return document.AsMap()
+}
+
+func protoList(values []interface{}) *structpb.ListValue {
+	if values == nil {
+		return nil
+	}
+	converted, _ := structpb.NewList(values)
+	return converted
+}
+
+func listValues(values *structpb.ListValue) []interface{} {
+	if values == nil {
+		return nil
+	}
+	return values.AsSlice()
+}
+
+func protoIndexes(indexes []*config.Index) []*proto.Index {
+	return lo.Map(indexes, func(index *config.Index, _ int) *proto.Index {
+		return &proto.Index{
+			Name: index.Name, Keys: index.Keys, Unique: index.Unique, Sparse: index.Sparse, Background: index.Background,
+			ExpireAfterSeconds: index.ExpireAfterSeconds,
+		}
+	})
+}
+
+func jobIndexes(indexes []*proto.Index) []*config.Index {
+	return lo.Map(indexes, func(index *proto.Index, _ int) *config.Index {
+		return &config.Index{
+			Name: index.Name, Keys: index.Keys, Unique: index.Unique, Sparse: index.Sparse, Background: index.Background,
+			ExpireAfterSeconds: index.ExpireAfterSeconds,
+		}
+	})
+}
+
+func protoClientRegions(regions []*config.ClientRegion) []*proto.ClientRegion {
+	return lo.Map(regions, func(region *config.ClientRegion, _ int) *proto.ClientRegion {
+		return &proto.ClientRegion{
+			Name: region.Name, Weight: region.Weight, Latency: region.Latency.String(), Jitter: region.Jitter.String(),
+		}
+	})
+}
+
+func clientRegions(regions []*proto.ClientRegion) []*config.ClientRegion {
+	return lo.Map(regions, func(region *proto.ClientRegion, _ int) *config.ClientRegion {
+		latency, _ := time.ParseDuration(region.Latency)
+		jitter, _ := time.ParseDuration(region.Jitter)
+		return &config.ClientRegion{
+			Name: region.Name, Weight: region.Weight, Latency: config.Duration{Duration: latency}, Jitter: config.Duration{Duration: jitter},
+		}
+	})
+}
+
+func protoTagSets(tagSets []map[string]string) []*proto.TagSet {
+	return lo.Map(tagSets, func(tags map[string]string, _ int) *proto.TagSet { return &proto.TagSet{Tags: tags} })
+}
+
+func tagSets(tagSets []*proto.TagSet) []map[string]string {
+	return lo.Map(tagSets, func(tagSet *proto.TagSet, _ int) map[string]string {
+		// empty tag set matches any member, it's sent as no tags
+		return lo.Ternary(tagSet.Tags != nil, tagSet.Tags, map[string]string{})
+	})
+}
+
+func protoJobCommand(command *config.JobCommand) *proto.JobCommand {
+	if command == nil {
+		return nil
+	}
+	return &proto.JobCommand{Name: command.Name, Template: protoStruct(command.Template)}
+}
+
+func jobCommand(command *proto.JobCommand) *config.JobCommand {
+	if command == nil {
+		return nil
+	}
+	return &config.JobCommand{Name: command.Name, Template: structDocument(command.Template)}
+}
+
+func protoScenarioSteps(steps []*config.ScenarioStep) []*proto.ScenarioStep {
+	return lo.Map(steps, func(step *config.ScenarioStep, _ int) *proto.ScenarioStep {
+		return &proto.ScenarioStep{
+			Name: step.Name, Type: step.Type, Filter: protoStruct(step.Filter), Update: protoStruct(step.Update), Weight: step.Weight,
+		}
+	})
+}
+
+func scenarioSteps(steps []*proto.ScenarioStep) []*config.ScenarioStep {
+	return lo.Map(steps, func(step *proto.ScenarioStep, _ int) *config.ScenarioStep {
+		return &config.ScenarioStep{
+			Name: step.Name, Type: step.Type, Filter: structDocument(step.Filter), Update: structDocument(step.Update), Weight: step.Weight,
+		}
+	})
+}
+
+// NewProtoConfigRequest maps parsed config to request setting it on agent
+func NewProtoConfigRequest(request *ConfigRequest) *proto.ConfigRequest {
+	cfg := &proto.ConfigRequest{
+		ConnectionString:      request.ConnectionString,
+		ShardConnectionString: request.ShardConnectionString,
+		Jobs:                  make([]*proto.JobRequest, len(request.Jobs)),
+		Schemas:               make([]*proto.SchemaRequest, len(request.Schemas)),
+		Debug:                 request.Debug,
+		Parallel:              request.Parallel,
+		Template:              request.Template,
+	}
+	if request.Agent != nil {
+		cfg.Agent = &proto.AgentRequest{
+			Name:                         request.Agent.Name,
+			Port:                         request.Agent.Port,
+			MetricsExportUrl:             request.Agent.MetricsExportUrl,
+			MetricsExportIntervalSeconds: request.Agent.MetricsExportIntervalSeconds,
+			MetricsExportPort:            request.Agent.MetricsExportPort,
+		}
+	}
+	for i, job := range request.Jobs {
+		cfg.Jobs[i] = &proto.JobRequest{
+			Name:        job.Name,
+			Database:    job.Database,
+			Collection:  job.Collection,
+			Type:        job.Type,
+			Schema:      job.Schema,
+			Connections: job.Connections,
+			Pace:        job.Pace,
+			DataSize:    job.DataSize,
+			BatchSize:   job.BatchSize,
+			Duration:    job.Duration.String(),
+			Operations:  job.Operations,
+			Timeout:     job.Timeout.String(),
+			// todo: setup filters and schema inside
+			// Filter:          job.Filter,
+			HistogramBuckets:    job.HistogramBuckets,
+			NativeHistogram:     job.NativeHistogram,
+			Tenant:              job.Tenant,
+			EvolutionRatio:      job.EvolutionRatio,
+			ReturnDocument:      job.ReturnDocument,
+			ConsumerRatio:       job.ConsumerRatio,
+			HotDocuments:        job.HotDocuments,
+			Pagination:          job.Pagination,
+			PageSize:            job.PageSize,
+			Pages:               job.Pages,
+			Limit:               job.Limit,
+			StopWhen:            job.StopWhen,
+			PaceInterval:        job.PaceInterval.String(),
+			CompareRouting:      job.CompareRouting,
+			ReadPreference:      job.ReadPreference,
+			HedgedReads:         job.HedgedReads,
+			BulkOrder:           job.BulkOrder,
+			ExpectedIndex:       job.ExpectedIndex,
+			ExplainInterval:     job.ExplainInterval.String(),
+			DisturbanceInterval: job.DisturbanceInterval.String(),
+			BackupAt:            job.BackupAt.String(),
+			BackupDuration:      job.BackupDuration.String(),
+			BackupCommand:       job.BackupCommand,
+			CacheMode:           job.CacheMode,
+			CacheCommand:        job.CacheCommand,
+			Collections:         job.Collections,
+			GrowField:           job.GrowField,
+			GrowMaxItems:        job.GrowMaxItems,
+			Priority:            job.Priority,
+			PaceJitter:          job.PaceJitter,
+			NoiseCollections:    job.NoiseCollections,
+			DeleteMany:          job.DeleteMany,
+			PauseWindows:        job.PauseWindows,
+			TransactionSteps:    job.TransactionSteps,
+			ReadConcern:         job.ReadConcern,
+			WriteConcern:        job.WriteConcern,
+			TransactionRetries:  job.TransactionRetries,
+			OperationMix:        job.OperationMix,
+			Upsert:              job.Upsert,
+			DropIndexes:         job.DropIndexes,
+			HotFraction:         job.HotFraction,
+			HotSkew:             job.HotSkew,
+			HotField:            job.HotField,
+			FullDocument:        job.FullDocument,
+			FileSize:            job.FileSize,
+			FileSizeMax:         job.FileSizeMax,
+			SlaBuckets:          job.SlaBuckets,
+			DistinctField:       job.DistinctField,
+			MetricsInclude:      job.MetricsInclude,
+			MetricsExclude:      job.MetricsExclude,
+			MetricsSample:       job.MetricsSample,
+			Search:              job.Search,
+			GeoField:            job.GeoField,
+			GeoPoint:            job.GeoPoint,
+			GeoDistance:         job.GeoDistance,
+			FanoutCollections:   job.FanoutCollections,
+			FanoutKey:           job.FanoutKey,
+			DependsOn:           job.DependsOn,
+			Warmup:              job.Warmup.String(),
+			BurstDuration:       job.BurstDuration.String(),
+			BurstIdle:           job.BurstIdle.String(),
+			RateProfile:         job.RateProfile,
+			PaceBurst:           job.PaceBurst,
+			Command:             protoJobCommand(job.Command),
+			Steps:               protoScenarioSteps(job.Steps),
+			Pipeline:            protoList(job.Pipeline),
+			Indexes:             protoIndexes(job.Indexes),
+			Update:              protoStruct(job.Update),
+			ArrayFilters:        protoList(job.ArrayFilters),
+			Sort:                protoStruct(job.Sort),
+			Lookup:              protoStruct(job.Lookup),
+			BulkMix:             job.BulkMix,
+			Disturbances:        job.Disturbances,
+			ClientRegions:       protoClientRegions(job.ClientRegions),
+			ReadPreferenceTags:  protoTagSets(job.ReadPreferenceTags),
+		}
+	}
+	for i, schema := range request.Schemas {
+		cfg.Schemas[i] = &proto.SchemaRequest{
+			Name:       schema.Name,
+			Database:   schema.Database,
+			Collection: schema.Collection,
+			// Schema:     schema.Schema,
+			Save: schema.Save,
+		}
+	}
+
 	return cfg
 }
 
 func NewConfigResponseFromConfig(cfg *config.Config) *proto.ConfigResponse {
 	response := &proto.ConfigResponse{
-		ConnectionString: cfg.ConnectionString,
+		ConnectionString:      cfg.ConnectionString,
+		ShardConnectionString: cfg.ShardConnectionString,
 		Agent: &proto.AgentRequest{
 			Name:                         cfg.Agent.Name,
 			Port:                         cfg.Agent.Port,
@@ -116,9 +574,11 @@ func NewConfigResponseFromConfig(cfg *config.Config) *proto.ConfigResponse {
 			MetricsExportIntervalSeconds: cfg.Agent.MetricsExportIntervalSeconds,
 			MetricsExportPort:            cfg.Agent.MetricsExportPort,
 		},
-		Jobs:    make([]*proto.JobRequest, len(cfg.Jobs)),
-		Schemas: make([]*proto.SchemaRequest, len(cfg.Schemas)),
-		Debug:   cfg.Debug,
+		Jobs:     make([]*proto.JobRequest, len(cfg.Jobs)),
+		Schemas:  make([]*proto.SchemaRequest, len(cfg.Schemas)),
+		Debug:    cfg.Debug,
+		Parallel: cfg.Parallel,
+		Template: cfg.Template,
 	}
 	for i, job := range cfg.Jobs {
 		response.Jobs[i] = &proto.JobRequest{
@@ -136,6 +596,81 @@ func NewConfigResponseFromConfig(cfg *config.Config) *proto.ConfigResponse {
 			Operations:  job.Operations,
 			Timeout:     job.Timeout.String(),
 			// Filter:          job.Filter,
+			HistogramBuckets:    job.HistogramBuckets,
+			NativeHistogram:     job.NativeHistogram,
+			Tenant:              job.Tenant,
+			EvolutionRatio:      job.EvolutionRatio,
+			ReturnDocument:      job.ReturnDocument,
+			ConsumerRatio:       job.ConsumerRatio,
+			HotDocuments:        job.HotDocuments,
+			Pagination:          job.Pagination,
+			PageSize:            job.PageSize,
+			Pages:               job.Pages,
+			Limit:               job.Limit,
+			StopWhen:            job.StopWhen,
+			PaceInterval:        job.PaceInterval.String(),
+			CompareRouting:      job.CompareRouting,
+			ReadPreference:      job.ReadPreference,
+			HedgedReads:         job.HedgedReads,
+			BulkOrder:           job.BulkOrder,
+			ExpectedIndex:       job.ExpectedIndex,
+			ExplainInterval:     job.ExplainInterval.String(),
+			DisturbanceInterval: job.DisturbanceInterval.String(),
+			BackupAt:            job.BackupAt.String(),
+			BackupDuration:      job.BackupDuration.String(),
+			BackupCommand:       job.BackupCommand,
+			CacheMode:           job.CacheMode,
+			CacheCommand:        job.CacheCommand,
+			Collections:         job.Collections,
+			GrowField:           job.GrowField,
+			GrowMaxItems:        job.GrowMaxItems,
+			Priority:            job.Priority,
+			PaceJitter:          job.PaceJitter,
+			NoiseCollections:    job.NoiseCollections,
+			DeleteMany:          job.DeleteMany,
+			PauseWindows:        job.PauseWindows,
+			TransactionSteps:    job.TransactionSteps,
+			ReadConcern:         job.ReadConcern,
+			WriteConcern:        job.WriteConcern,
+			TransactionRetries:  job.TransactionRetries,
+			OperationMix:        job.OperationMix,
+			Upsert:              job.Upsert,
+			DropIndexes:         job.DropIndexes,
+			HotFraction:         job.HotFraction,
+			HotSkew:             job.HotSkew,
+			HotField:            job.HotField,
+			FullDocument:        job.FullDocument,
+			FileSize:            job.FileSize,
+			FileSizeMax:         job.FileSizeMax,
+			SlaBuckets:          job.SlaBuckets,
+			DistinctField:       job.DistinctField,
+			MetricsInclude:      job.MetricsInclude,
+			MetricsExclude:      job.MetricsExclude,
+			MetricsSample:       job.MetricsSample,
+			Search:              job.Search,
+			GeoField:            job.GeoField,
+			GeoPoint:            job.GeoPoint,
+			GeoDistance:         job.GeoDistance,
+			FanoutCollections:   job.FanoutCollections,
+			FanoutKey:           job.FanoutKey,
+			DependsOn:           job.DependsOn,
+			Warmup:              job.Warmup.String(),
+			BurstDuration:       job.BurstDuration.String(),
+			BurstIdle:           job.BurstIdle.String(),
+			RateProfile:         job.RateProfile,
+			PaceBurst:           job.PaceBurst,
+			Command:             protoJobCommand(job.Command),
+			Steps:               protoScenarioSteps(job.Steps),
+			Pipeline:            protoList(job.Pipeline),
+			Indexes:             protoIndexes(job.Indexes),
+			Update:              protoStruct(job.Update),
+			ArrayFilters:        protoList(job.ArrayFilters),
+			Sort:                protoStruct(job.Sort),
+			Lookup:              protoStruct(job.Lookup),
+			BulkMix:             job.BulkMix,
+			Disturbances:        job.Disturbances,
+			ClientRegions:       protoClientRegions(job.ClientRegions),
+			ReadPreferenceTags:  protoTagSets(job.ReadPreferenceTags),
 		}
 	}
 	for i, schema := range cfg.Schemas {
@@ -147,16 +682,19 @@ func NewConfigResponseFromConfig(cfg *config.Config) *proto.ConfigResponse {
 			Save: schema.Save,
 		}
 	}
-	return response 
+	return response
 }
 
 // todo: should be pointers
 type ConfigRequest struct {
-	ConnectionString string           `json:"connection_string"`
-	Agent            *AgentRequest    `json:"agent,omitempty"`
-	Jobs             []*JobRequest    `json:"jobs,omitempty"`
-	Schemas          []*SchemaRequest `json:"schemas,omitempty"`
-	Debug            bool             `json:"debug,omitempty"`
+	ConnectionString      string           `json:"connection_string"`
+	ShardConnectionString string           `json:"shard_connection_string,omitempty"`
+	Agent                 *AgentRequest    `json:"agent,omitempty"`
+	Jobs                  []*JobRequest    `json:"jobs,omitempty"`
+	Schemas               []*SchemaRequest `json:"schemas,omitempty"`
+	Debug                 bool             `json:"debug,omitempty"`
+	Parallel              bool             `json:"parallel,omitempty"`
+	Template              string           `json:"template,omitempty"`
 }
 
 // todo: change or even remove,
@@ -164,27 +702,137 @@ type ConfigRequest struct {
 // todo: move agentn-name nad add new config flage - custom metrics label or similar
 // purpose is to export metrics with cluster name
 type AgentRequest struct {
-	Name                         string `json:"name,omitempty"`
-	Port                         string `json:"port,omitempty"`
-	MetricsExportUrl             string `json:"metrics_export_url,omitempty"`
-	MetricsExportIntervalSeconds uint64 `json:"metrics_export_interval_seconds,omitempty"`
-	MetricsExportPort            string `json:"metrics_export_port,omitempty"`
+	Name                         string            `json:"name,omitempty"`
+	Port                         string            `json:"port,omitempty"`
+	MetricsExportUrl             string            `json:"metrics_export_url,omitempty"`
+	MetricsExportIntervalSeconds uint64            `json:"metrics_export_interval_seconds,omitempty"`
+	MetricsExportPort            string            `json:"metrics_export_port,omitempty"`
+	MetricsExportTLSCertFile     string            `json:"metrics_export_tls_cert_file,omitempty"`
+	MetricsExportTLSKeyFile      string            `json:"metrics_export_tls_key_file,omitempty"`
+	MetricsExportUsername        string            `json:"metrics_export_username,omitempty"`
+	MetricsExportPassword        string            `json:"metrics_export_password,omitempty"`
+	MetricsExportBearerToken     string            `json:"metrics_export_bearer_token,omitempty"`
+	MetricsExportLabels          map[string]string `json:"metrics_export_labels,omitempty"`
+	Tenants                      []*TenantRequest  `json:"tenants,omitempty"`
+	EstimateAtlasCost            bool              `json:"estimate_atlas_cost,omitempty"`
+	EventsNdjson                 bool              `json:"events_ndjson,omitempty"`
+	ArtifactsDir                 string            `json:"artifacts_dir,omitempty"`
+	MaxCpuPercent                float64           `json:"max_cpu_percent,omitempty"`
+	MaxNetworkBytesPerSecond     uint64            `json:"max_network_bytes_per_second,omitempty"`
+	Plugins                      []string          `json:"plugins,omitempty"`
+	Proxy                        string            `json:"proxy,omitempty"`
+	SshKeyFile                   string            `json:"ssh_key_file,omitempty"`
+	SshKnownHostsFile            string            `json:"ssh_known_hosts_file,omitempty"`
+	RetentionMaxRuns             uint64            `json:"retention_max_runs,omitempty"`
+	RetentionMaxDiskBytes        uint64            `json:"retention_max_disk_bytes,omitempty"`
+	RetentionMaxAge              string            `json:"retention_max_age,omitempty"`
+	Protected                    bool              `json:"protected,omitempty"`
+	TargetAllowlist              []string          `json:"target_allowlist,omitempty"`
+	TargetDenylist               []string          `json:"target_denylist,omitempty"`
+	ConfirmAboveDocuments        uint64            `json:"confirm_above_documents,omitempty"`
+	ConfirmAboveWrittenBytes     uint64            `json:"confirm_above_written_bytes,omitempty"`
+	ConfirmAboveEgressBytes      uint64            `json:"confirm_above_egress_bytes,omitempty"`
+	RunHistorySize               uint64            `json:"run_history_size,omitempty"`
+	RunHistoryFile               string            `json:"run_history_file,omitempty"`
+}
+
+type TenantRequest struct {
+	Name           string `json:"name,omitempty"`
+	Token          string `json:"token,omitempty"`
+	MaxConnections uint64 `json:"max_connections,omitempty"`
+	MaxPace        uint64 `json:"max_pace,omitempty"`
+	Role           string `json:"role,omitempty"`
 }
 
 type JobRequest struct {
-	Name        string                 `json:"name,omitempty"`
-	Database    string                 `json:"database,omitempty"`
-	Collection  string                 `json:"collection,omitempty"`
-	Type        string                 `json:"type,omitempty"`
-	Schema      string                 `json:"schema,omitempty"`
-	Connections uint64                 `json:"connections,omitempty"`
-	Pace        uint64                 `json:"pace,omitempty"`
-	DataSize    uint64                 `json:"data_size,omitempty"`
-	BatchSize   uint64                 `json:"batch_size,omitempty"`
-	Duration    time.Duration          `json:"duration,omitempty"`
-	Operations  uint64                 `json:"operations,omitempty"`
-	Timeout     time.Duration          `json:"timeout,omitempty"`
-	Filter      map[string]interface{} `json:"filter,omitempty"`
+	Name                string                 `json:"name,omitempty"`
+	Database            string                 `json:"database,omitempty"`
+	Collection          string                 `json:"collection,omitempty"`
+	Type                string                 `json:"type,omitempty"`
+	Schema              string                 `json:"template,omitempty"` // config files name job schema "template"
+	Connections         uint64                 `json:"connections,omitempty"`
+	Pace                uint64                 `json:"pace,omitempty"`
+	DataSize            uint64                 `json:"data_size,omitempty"`
+	BatchSize           uint64                 `json:"batch_size,omitempty"`
+	Duration            time.Duration          `json:"duration,omitempty"`
+	Operations          uint64                 `json:"operations,omitempty"`
+	Timeout             time.Duration          `json:"timeout,omitempty"`
+	Filter              map[string]interface{} `json:"filter,omitempty"`
+	HistogramBuckets    []float64              `json:"histogram_buckets,omitempty"`
+	NativeHistogram     bool                   `json:"native_histogram,omitempty"`
+	Tenant              string                 `json:"tenant,omitempty"`
+	EvolutionRatio      float64                `json:"evolution_ratio,omitempty"`
+	Update              map[string]interface{} `json:"update,omitempty"`
+	ArrayFilters        []interface{}          `json:"array_filters,omitempty"`
+	ReturnDocument      string                 `json:"return_document,omitempty"`
+	Sort                map[string]interface{} `json:"sort,omitempty"`
+	ConsumerRatio       float64                `json:"consumer_ratio,omitempty"`
+	HotDocuments        uint64                 `json:"hot_documents,omitempty"`
+	Pagination          string                 `json:"pagination,omitempty"`
+	PageSize            uint64                 `json:"page_size,omitempty"`
+	Pages               uint64                 `json:"pages,omitempty"`
+	Lookup              map[string]interface{} `json:"lookup,omitempty"`
+	Limit               uint64                 `json:"limit,omitempty"`
+	StopWhen            string                 `json:"stop_when,omitempty"`
+	PaceInterval        time.Duration          `json:"pace_interval,omitempty"`
+	CompareRouting      bool                   `json:"compare_routing,omitempty"`
+	ReadPreference      string                 `json:"read_preference,omitempty"`
+	ReadPreferenceTags  []map[string]string    `json:"read_preference_tags,omitempty"`
+	HedgedReads         bool                   `json:"hedged_reads,omitempty"`
+	BulkOrder           string                 `json:"bulk_order,omitempty"`
+	BulkMix             map[string]float64     `json:"bulk_mix,omitempty"`
+	ExpectedIndex       string                 `json:"expected_index,omitempty"`
+	ExplainInterval     time.Duration          `json:"explain_interval,omitempty"`
+	DisturbanceInterval time.Duration          `json:"disturbance_interval,omitempty"`
+	Disturbances        []string               `json:"disturbances,omitempty"`
+	BackupAt            time.Duration          `json:"backup_at,omitempty"`
+	BackupDuration      time.Duration          `json:"backup_duration,omitempty"`
+	BackupCommand       string                 `json:"backup_command,omitempty"`
+	CacheMode           string                 `json:"cache_mode,omitempty"`
+	CacheCommand        string                 `json:"cache_command,omitempty"`
+	Collections         []string               `json:"collections,omitempty"`
+	GrowField           string                 `json:"grow_field,omitempty"`
+	GrowMaxItems        uint64                 `json:"grow_max_items,omitempty"`
+	Priority            uint64                 `json:"priority,omitempty"`
+	PaceJitter          float64                `json:"pace_jitter,omitempty"`
+	NoiseCollections    uint64                 `json:"noise_collections,omitempty"`
+	DeleteMany          bool                   `json:"delete_many,omitempty"`
+	Pipeline            []interface{}          `json:"pipeline,omitempty"`
+	PauseWindows        []string               `json:"pause_windows,omitempty"`
+	TransactionSteps    []string               `json:"transaction_steps,omitempty"`
+	ReadConcern         string                 `json:"read_concern,omitempty"`
+	WriteConcern        string                 `json:"write_concern,omitempty"`
+	TransactionRetries  uint64                 `json:"transaction_retries,omitempty"`
+	OperationMix        map[string]float64     `json:"operation_mix,omitempty"`
+	Upsert              bool                   `json:"upsert,omitempty"`
+	Indexes             []*config.Index        `json:"indexes,omitempty"`
+	DropIndexes         bool                   `json:"drop_indexes,omitempty"`
+	HotFraction         float64                `json:"hot_fraction,omitempty"`
+	HotSkew             float64                `json:"hot_skew,omitempty"`
+	HotField            string                 `json:"hot_field,omitempty"`
+	FullDocument        string                 `json:"full_document,omitempty"`
+	FileSize            uint64                 `json:"file_size,omitempty"`
+	FileSizeMax         uint64                 `json:"file_size_max,omitempty"`
+	SlaBuckets          []float64              `json:"sla_buckets,omitempty"`
+	DistinctField       string                 `json:"distinct_field,omitempty"`
+	MetricsInclude      []string               `json:"metrics_include,omitempty"`
+	MetricsExclude      []string               `json:"metrics_exclude,omitempty"`
+	MetricsSample       float64                `json:"metrics_sample,omitempty"`
+	Search              string                 `json:"search,omitempty"`
+	GeoField            string                 `json:"geo_field,omitempty"`
+	GeoPoint            string                 `json:"geo_point,omitempty"`
+	GeoDistance         float64                `json:"geo_distance,omitempty"`
+	Command             *config.JobCommand     `json:"command,omitempty"`
+	FanoutCollections   uint64                 `json:"fanout_collections,omitempty"`
+	FanoutKey           string                 `json:"fanout_key,omitempty"`
+	Steps               []*config.ScenarioStep `json:"steps,omitempty"`
+	DependsOn           []string               `json:"depends_on,omitempty"`
+	ClientRegions       []*config.ClientRegion `json:"client_regions,omitempty"`
+	Warmup              time.Duration          `json:"warmup,omitempty"`
+	BurstDuration       time.Duration          `json:"burst_duration,omitempty"`
+	BurstIdle           time.Duration          `json:"burst_idle,omitempty"`
+	RateProfile         []string               `json:"rate_profile,omitempty"`
+	PaceBurst           uint64                 `json:"pace_burst,omitempty"`
 }
 
 type SchemaRequest struct {
@@ -206,8 +854,19 @@ func NewConfigService(ctx context.Context, lbot *Lbot) *ConfigService {
 }
 
 func (c *ConfigService) SetConfig(ctx context.Context, request *proto.ConfigRequest) (*proto.ConfigResponse, error) {
-	cfg := NewConfigFromProtoConfigRequest(request)
-	c.lbot.SetConfig(cfg)
+	cfg, err := NewConfigFromProtoConfigRequest(request)
+	if err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "config rejected, %s", err)
+	}
+	// agent config is applied only on agent start
+	cfg.Agent = c.lbot.Config.Agent
+	if err := cfg.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "config rejected, %s", err)
+	}
+	if err := cfg.Agent.CheckTargets(cfg); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "config rejected, %s", err)
+	}
+	c.lbot.SetTenantConfig(TenantFromContext(ctx), cfg)
 
 	// before configing process it will varify health of cluster, if pods
 	return &proto.ConfigResponse{}, nil
@@ -215,17 +874,34 @@ func (c *ConfigService) SetConfig(ctx context.Context, request *proto.ConfigRequ
 
 func (c *ConfigService) GetConfig(ctx context.Context, empty *emptypb.Empty) (*proto.ConfigResponse, error) {
 	// todo: should get from db
-	response := NewConfigResponseFromConfig(c.lbot.Config)
+	cfg := *c.lbot.Config
+	tenant := TenantFromContext(ctx)
+	cfg.Jobs = lo.Filter(cfg.Jobs, func(job *config.Job, _ int) bool { return ownsJob(tenant, job) })
+	response := NewConfigResponseFromConfig(&cfg)
 
 	return response, nil
 }
 
+func (c *ConfigService) ExportConfig(ctx context.Context, request *proto.ExportRequest) (*proto.ExportResponse, error) {
+	bundle, err := c.lbot.Export(ctx, TenantFromContext(ctx), request.Credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ExportResponse{Bundle: bundle}, nil
+}
+
 func ParseConfigFile(configFile string) (*ConfigRequest, error) {
 	content, err := os.ReadFile(configFile)
 	if err != nil {
 		return nil, err
 	}
-	content, err = standardizeJSON(content)
+	return ParseConfig(content)
+}
+
+// ParseConfig parses json config, comments and trailing commas are accepted
+func ParseConfig(content []byte) (*ConfigRequest, error) {
+	content, err := standardizeJSON(content)
 	if err != nil {
 		return nil, err
 	}
@@ -254,20 +930,7 @@ func ParseStdInConfig() (*ConfigRequest, error) {
 	if err != nil {
 		return nil, err
 	}
-	// move, repetition as above
-	content, err = standardizeJSON(content)
-	if err != nil {
-		return nil, err
-	}
-
-	var cfg ConfigRequest
-	err = json.Unmarshal(content, &cfg)
-
-	if err != nil {
-		return nil, errors.New("Error during Unmarshal(): " + err.Error())
-	}
-
-	return &cfg, err
+	return ParseConfig(content)
 }
 
 func standardizeJSON(b []byte) ([]byte, error) {
@@ -281,19 +944,94 @@ func standardizeJSON(b []byte) ([]byte, error) {
 
 func (c *JobRequest) UnmarshalJSON(data []byte) (err error) {
 	var tmp struct {
-		Name        string                 `json:"name,omitempty"`
-		Type        string                 `json:"type,omitempty"`
-		Database    string                 `json:"database,omitempty"`
-		Collection  string                 `json:"collection,omitempty"`
-		Schema      string                 `json:"template,omitempty"`
-		Connections uint64                 `json:"connections,omitempty"`
-		Pace        uint64                 `json:"pace,omitempty"`
-		DataSize    uint64                 `json:"data_size,omitempty"`
-		BatchSize   uint64                 `json:"batch_size,omitempty"`
-		Duration    config.Duration        `json:"duration,omitempty"`
-		Operations  uint64                 `json:"operations,omitempty"`
-		Timeout     config.Duration        `json:"timeout,omitempty"` // if not set, default
-		Filter      map[string]interface{} `json:"filter,omitempty"`
+		Name                string                 `json:"name,omitempty"`
+		Type                string                 `json:"type,omitempty"`
+		Database            string                 `json:"database,omitempty"`
+		Collection          string                 `json:"collection,omitempty"`
+		Schema              string                 `json:"template,omitempty"`
+		Connections         uint64                 `json:"connections,omitempty"`
+		Pace                config.Pace            `json:"pace,omitempty"`
+		DataSize            uint64                 `json:"data_size,omitempty"`
+		BatchSize           uint64                 `json:"batch_size,omitempty"`
+		Duration            config.Duration        `json:"duration,omitempty"`
+		Operations          uint64                 `json:"operations,omitempty"`
+		Timeout             config.Duration        `json:"timeout,omitempty"` // if not set, default
+		Filter              map[string]interface{} `json:"filter,omitempty"`
+		HistogramBuckets    []float64              `json:"histogram_buckets,omitempty"`
+		NativeHistogram     bool                   `json:"native_histogram,omitempty"`
+		Tenant              string                 `json:"tenant,omitempty"`
+		EvolutionRatio      float64                `json:"evolution_ratio,omitempty"`
+		Update              map[string]interface{} `json:"update,omitempty"`
+		ArrayFilters        []interface{}          `json:"array_filters,omitempty"`
+		ReturnDocument      string                 `json:"return_document,omitempty"`
+		Sort                map[string]interface{} `json:"sort,omitempty"`
+		ConsumerRatio       float64                `json:"consumer_ratio,omitempty"`
+		HotDocuments        uint64                 `json:"hot_documents,omitempty"`
+		Pagination          string                 `json:"pagination,omitempty"`
+		PageSize            uint64                 `json:"page_size,omitempty"`
+		Pages               uint64                 `json:"pages,omitempty"`
+		Lookup              map[string]interface{} `json:"lookup,omitempty"`
+		Limit               uint64                 `json:"limit,omitempty"`
+		StopWhen            string                 `json:"stop_when,omitempty"`
+		PaceInterval        config.Duration        `json:"pace_interval,omitempty"`
+		CompareRouting      bool                   `json:"compare_routing,omitempty"`
+		ReadPreference      string                 `json:"read_preference,omitempty"`
+		ReadPreferenceTags  []map[string]string    `json:"read_preference_tags,omitempty"`
+		HedgedReads         bool                   `json:"hedged_reads,omitempty"`
+		BulkOrder           string                 `json:"bulk_order,omitempty"`
+		BulkMix             map[string]float64     `json:"bulk_mix,omitempty"`
+		ExpectedIndex       string                 `json:"expected_index,omitempty"`
+		ExplainInterval     config.Duration        `json:"explain_interval,omitempty"`
+		DisturbanceInterval config.Duration        `json:"disturbance_interval,omitempty"`
+		Disturbances        []string               `json:"disturbances,omitempty"`
+		BackupAt            config.Duration        `json:"backup_at,omitempty"`
+		BackupDuration      config.Duration        `json:"backup_duration,omitempty"`
+		BackupCommand       string                 `json:"backup_command,omitempty"`
+		CacheMode           string                 `json:"cache_mode,omitempty"`
+		CacheCommand        string                 `json:"cache_command,omitempty"`
+		Collections         []string               `json:"collections,omitempty"`
+		GrowField           string                 `json:"grow_field,omitempty"`
+		GrowMaxItems        uint64                 `json:"grow_max_items,omitempty"`
+		Priority            uint64                 `json:"priority,omitempty"`
+		PaceJitter          float64                `json:"pace_jitter,omitempty"`
+		NoiseCollections    uint64                 `json:"noise_collections,omitempty"`
+		DeleteMany          bool                   `json:"delete_many,omitempty"`
+		Pipeline            []interface{}          `json:"pipeline,omitempty"`
+		PauseWindows        []string               `json:"pause_windows,omitempty"`
+		TransactionSteps    []string               `json:"transaction_steps,omitempty"`
+		ReadConcern         string                 `json:"read_concern,omitempty"`
+		WriteConcern        string                 `json:"write_concern,omitempty"`
+		TransactionRetries  uint64                 `json:"transaction_retries,omitempty"`
+		OperationMix        map[string]float64     `json:"operation_mix,omitempty"`
+		Upsert              bool                   `json:"upsert,omitempty"`
+		Indexes             []*config.Index        `json:"indexes,omitempty"`
+		DropIndexes         bool                   `json:"drop_indexes,omitempty"`
+		HotFraction         float64                `json:"hot_fraction,omitempty"`
+		HotSkew             float64                `json:"hot_skew,omitempty"`
+		HotField            string                 `json:"hot_field,omitempty"`
+		FullDocument        string                 `json:"full_document,omitempty"`
+		FileSize            uint64                 `json:"file_size,omitempty"`
+		FileSizeMax         uint64                 `json:"file_size_max,omitempty"`
+		SlaBuckets          []float64              `json:"sla_buckets,omitempty"`
+		DistinctField       string                 `json:"distinct_field,omitempty"`
+		MetricsInclude      []string               `json:"metrics_include,omitempty"`
+		MetricsExclude      []string               `json:"metrics_exclude,omitempty"`
+		MetricsSample       float64                `json:"metrics_sample,omitempty"`
+		Search              string                 `json:"search,omitempty"`
+		GeoField            string                 `json:"geo_field,omitempty"`
+		GeoPoint            string                 `json:"geo_point,omitempty"`
+		GeoDistance         float64                `json:"geo_distance,omitempty"`
+		Command             *config.JobCommand     `json:"command,omitempty"`
+		FanoutCollections   uint64                 `json:"fanout_collections,omitempty"`
+		FanoutKey           string                 `json:"fanout_key,omitempty"`
+		Steps               []*config.ScenarioStep `json:"steps,omitempty"`
+		DependsOn           []string               `json:"depends_on,omitempty"`
+		ClientRegions       []*config.ClientRegion `json:"client_regions,omitempty"`
+		Warmup              config.Duration        `json:"warmup,omitempty"`
+		BurstDuration       config.Duration        `json:"burst_duration,omitempty"`
+		BurstIdle           config.Duration        `json:"burst_idle,omitempty"`
+		RateProfile         []string               `json:"rate_profile,omitempty"`
+		PaceBurst           uint64                 `json:"pace_burst,omitempty"`
 	}
 	// default values
 	tmp.Connections = 1
@@ -308,13 +1046,91 @@ func (c *JobRequest) UnmarshalJSON(data []byte) (err error) {
 	c.Type = tmp.Type
 	c.Schema = tmp.Schema
 	c.Connections = tmp.Connections
-	c.Pace = tmp.Pace
+	c.Pace = tmp.Pace.Rate
 	c.DataSize = tmp.DataSize
 	c.BatchSize = tmp.BatchSize
 	c.Duration = tmp.Duration.Duration
 	c.Operations = tmp.Operations
 	c.Timeout = tmp.Timeout.Duration
 	c.Filter = tmp.Filter
+	c.HistogramBuckets = tmp.HistogramBuckets
+	c.NativeHistogram = tmp.NativeHistogram
+	c.Tenant = tmp.Tenant
+	c.EvolutionRatio = tmp.EvolutionRatio
+	c.Update = tmp.Update
+	c.ArrayFilters = tmp.ArrayFilters
+	c.ReturnDocument = tmp.ReturnDocument
+	c.Sort = tmp.Sort
+	c.ConsumerRatio = tmp.ConsumerRatio
+	c.HotDocuments = tmp.HotDocuments
+	c.Pagination = tmp.Pagination
+	c.PageSize = tmp.PageSize
+	c.Pages = tmp.Pages
+	c.Lookup = tmp.Lookup
+	c.Limit = tmp.Limit
+	c.StopWhen = tmp.StopWhen
+	c.PaceInterval = tmp.PaceInterval.Duration
+	if tmp.Pace.Interval != 0 {
+		c.PaceInterval = tmp.Pace.Interval
+	}
+	c.CompareRouting = tmp.CompareRouting
+	c.ReadPreference = tmp.ReadPreference
+	c.ReadPreferenceTags = tmp.ReadPreferenceTags
+	c.HedgedReads = tmp.HedgedReads
+	c.BulkOrder = tmp.BulkOrder
+	c.BulkMix = tmp.BulkMix
+	c.ExpectedIndex = tmp.ExpectedIndex
+	c.ExplainInterval = tmp.ExplainInterval.Duration
+	c.DisturbanceInterval = tmp.DisturbanceInterval.Duration
+	c.Disturbances = tmp.Disturbances
+	c.BackupAt = tmp.BackupAt.Duration
+	c.BackupDuration = tmp.BackupDuration.Duration
+	c.BackupCommand = tmp.BackupCommand
+	c.CacheMode = tmp.CacheMode
+	c.CacheCommand = tmp.CacheCommand
+	c.Collections = tmp.Collections
+	c.GrowField = tmp.GrowField
+	c.GrowMaxItems = tmp.GrowMaxItems
+	c.Priority = tmp.Priority
+	c.PaceJitter = tmp.PaceJitter
+	c.NoiseCollections = tmp.NoiseCollections
+	c.DeleteMany = tmp.DeleteMany
+	c.Pipeline = tmp.Pipeline
+	c.PauseWindows = tmp.PauseWindows
+	c.TransactionSteps = tmp.TransactionSteps
+	c.ReadConcern = tmp.ReadConcern
+	c.WriteConcern = tmp.WriteConcern
+	c.TransactionRetries = tmp.TransactionRetries
+	c.OperationMix = tmp.OperationMix
+	c.Upsert = tmp.Upsert
+	c.Indexes = tmp.Indexes
+	c.DropIndexes = tmp.DropIndexes
+	c.HotFraction = tmp.HotFraction
+	c.HotSkew = tmp.HotSkew
+	c.HotField = tmp.HotField
+	c.FullDocument = tmp.FullDocument
+	c.FileSize = tmp.FileSize
+	c.FileSizeMax = tmp.FileSizeMax
+	c.SlaBuckets = tmp.SlaBuckets
+	c.DistinctField = tmp.DistinctField
+	c.MetricsInclude = tmp.MetricsInclude
+	c.MetricsExclude = tmp.MetricsExclude
+	c.MetricsSample = tmp.MetricsSample
+	c.Search = tmp.Search
+	c.GeoField = tmp.GeoField
+	c.GeoPoint = tmp.GeoPoint
+	c.GeoDistance = tmp.GeoDistance
+	c.Command = tmp.Command
+	c.FanoutCollections = tmp.FanoutCollections
+	c.FanoutKey = tmp.FanoutKey
+	c.Steps = tmp.Steps
+	c.DependsOn = tmp.DependsOn
+	c.ClientRegions = tmp.ClientRegions
+	c.Warmup = tmp.Warmup.Duration
+	c.BurstDuration = tmp.BurstDuration.Duration
+	c.BurstIdle = tmp.BurstIdle.Duration
+	c.RateProfile = tmp.RateProfile
+	c.PaceBurst = tmp.PaceBurst
 
 	return
 }
@@ -362,6 +1178,49 @@ func (job *JobRequest) Validate() error {
 		job.validateBatchSize,
 		job.validateOperations,
 		job.validateDataSize,
+		job.validateHistogram,
+		job.validateEvolutionRatio,
+		job.validateUpdate,
+		job.validateReturnDocument,
+		job.validateConsumerRatio,
+		job.validateHotDocuments,
+		job.validatePagination,
+		job.validateLookup,
+		job.validateStopWhen,
+		job.validatePaceInterval,
+		job.validateReadPreference,
+		job.validateBulk,
+		job.validateExpectedIndex,
+		job.validateExplainInterval,
+		job.validateDisturbances,
+		job.validateBackup,
+		job.validateCacheMode,
+		job.validateCollections,
+		job.validateTarget,
+		job.validateFanout,
+		job.validateDocumentGrowth,
+		job.validateNoise,
+		job.validateDelete,
+		job.validatePipeline,
+		job.validatePauseWindows,
+		job.validateTransaction,
+		job.validateOperationMix,
+		job.validateUpsert,
+		job.validateIndexes,
+		job.validateHotSpot,
+		job.validateFullDocument,
+		job.validateFileSize,
+		job.validateSlaBuckets,
+		job.validateDistinctField,
+		job.validateMetricsExport,
+		job.validateSearch,
+		job.validateGeo,
+		job.validateCommand,
+		job.validateSteps,
+		job.validateClientRegions,
+		job.validateWarmup,
+		job.validateBurst,
+		job.validateRateProfile,
 	}
 
 	for _, validate := range validators {
@@ -391,9 +1250,36 @@ func (job *JobRequest) validateType() (err error) {
 	case string(config.Read):
 	case string(config.Update):
 	case string(config.DropCollection):
+	case string(config.CreateIndex):
+	case string(config.SchemaEvolution):
+	case string(config.FindOneAndUpdate):
+	case string(config.FindOneAndDelete):
+	case string(config.FindOneAndReplace):
+	case string(config.Delete):
+	case string(config.Queue):
+	case string(config.Counter):
+	case string(config.Paginate):
+	case string(config.Lookup):
+	case string(config.Aggregate):
+	case string(config.Transaction):
+	case string(config.DocumentGrowth):
+	case string(config.Noise):
+	case string(config.Mixed):
+	case string(config.ChangeStream):
+	case string(config.GridFSUpload):
+	case string(config.GridFSDownload):
+	case string(config.Count):
+	case string(config.Distinct):
+	case string(config.TextSearch):
+	case string(config.GeoNear):
+	case string(config.GeoWithin):
+	case string(config.Command):
+	case string(config.Scenario):
 	case string(config.Sleep):
 	default:
-		err = errors.New("Job type: " + job.Type + " ")
+		if !config.IsPluginJobType(job.Type) {
+			err = errors.New("Job type: " + job.Type + " ")
+		}
 	}
 	return
 }
@@ -412,7 +1298,7 @@ func (job *JobRequest) validateCollection() (err error) {
 	if job.Schema != "" || job.Type == string(config.Sleep) {
 		return
 	}
-	if job.Collection == "" {
+	if job.Collection == "" && len(job.Collections) == 0 {
 		err = errors.New("JobValidationError: field 'collection' is required if 'template' or 'type' is not set")
 	}
 	return
@@ -445,6 +1331,11 @@ func (job *JobRequest) validatePace() (err error) {
 			err = errors.New("JobValidationError: field 'pace' must be equal 0 or must be not set for job with 'sleep' type ")
 		}
 	}
+	if job.PaceBurst > 0 && job.Pace == 0 {
+		err = errors.New("JobValidationError: field 'pace_burst' requires 'pace' to be set")
+	} else if job.PaceBurst > 0 && len(job.RateProfile) > 0 {
+		err = errors.New("JobValidationError: fields 'pace_burst' and 'rate_profile' cannot be set together")
+	}
 	return
 }
 
@@ -453,6 +1344,10 @@ func (job *JobRequest) validateBatchSize() (err error) {
 		if job.BatchSize != 0 {
 			err = errors.New("JobValidationError: field 'batch_size' must be equal 0 or must be not set for job with 'sleep' type ")
 		}
+	} else if job.BatchSize != 0 && job.Type != string(config.BulkWrite) {
+		err = errors.New("JobValidationError: field 'batch_size' is only applicable for 'bulk_write' job type")
+	} else if job.BatchSize > config.MaxBatchSize {
+		err = errors.New("JobValidationError: field 'batch_size' must be at most " + strconv.Itoa(config.MaxBatchSize))
 	}
 	return
 }
@@ -475,6 +1370,611 @@ func (job *JobRequest) validateOperations() (err error) {
 	return
 }
 
+func (job *JobRequest) validateHistogram() (err error) {
+	if job.NativeHistogram && len(job.HistogramBuckets) > 0 {
+		err = errors.New("JobValidationError: fields 'histogram_buckets' and 'native_histogram' cannot be set together")
+	}
+	for _, bucket := range job.HistogramBuckets {
+		if bucket <= 0 {
+			err = errors.New("JobValidationError: field 'histogram_buckets' values must be greater than 0")
+		}
+	}
+	return
+}
+
+func (job *JobRequest) validateEvolutionRatio() (err error) {
+	if job.EvolutionRatio < 0 || job.EvolutionRatio > 1 {
+		err = errors.New("JobValidationError: field 'evolution_ratio' must be between 0 and 1")
+	}
+	return
+}
+
+func (job *JobRequest) validateUpdate() (err error) {
+	for operator := range job.Update {
+		if !strings.HasPrefix(operator, "$") {
+			err = errors.New("JobValidationError: field 'update' keys must be update operators ex. $set, $inc, got: " + operator)
+		}
+	}
+	if len(job.ArrayFilters) > 0 && len(job.Update) == 0 {
+		err = errors.New("JobValidationError: field 'array_filters' requires 'update' to be set")
+	}
+	return
+}
+
+func (job *JobRequest) validateReturnDocument() (err error) {
+	switch job.ReturnDocument {
+	case "", config.ReturnDocumentBefore, config.ReturnDocumentAfter:
+	default:
+		err = errors.New("JobValidationError: field 'return_document' must be one of: before, after")
+	}
+	return
+}
+
+func (job *JobRequest) validateConsumerRatio() (err error) {
+	if job.ConsumerRatio < 0 || job.ConsumerRatio > 1 {
+		err = errors.New("JobValidationError: field 'consumer_ratio' must be between 0 and 1")
+	}
+	return
+}
+
+func (job *JobRequest) validateHotDocuments() (err error) {
+	switch {
+	case job.HotDocuments == 0:
+	case job.Type == string(config.Counter) || job.Type == string(config.DocumentGrowth):
+	case job.HotFraction > 0 && (job.Type == string(config.Update) || job.Type == string(config.FindOneAndUpdate)):
+	default:
+		err = errors.New("JobValidationError: field 'hot_documents' is only applicable for 'counter' and 'document_growth' job types, or update jobs with 'hot_fraction'")
+	}
+	return
+}
+
+func (job *JobRequest) validatePagination() (err error) {
+	switch job.Pagination {
+	case "", config.PaginationSkip, config.PaginationRange:
+	default:
+		err = errors.New("JobValidationError: field 'pagination' must be one of: skip, range")
+	}
+	return
+}
+
+func (job *JobRequest) validateLookup() (err error) {
+	if job.Type != string(config.Lookup) {
+		return
+	}
+	if _, ok := job.Lookup["from"]; !ok {
+		err = errors.New("JobValidationError: field 'lookup' with 'from' is required for 'lookup' job type")
+	} else if _, ok := job.Lookup["as"]; !ok {
+		err = errors.New("JobValidationError: field 'lookup' with 'as' is required for 'lookup' job type")
+	}
+	return
+}
+
+func (job *JobRequest) validateStopWhen() (err error) {
+	switch job.StopWhen {
+	case "", config.StopWhenAny:
+	case config.StopWhenAll:
+		if job.Duration == 0 || job.Operations == 0 {
+			err = errors.New("JobValidationError: field 'stop_when' all requires both 'duration' and 'operations'")
+		}
+	default:
+		err = errors.New("JobValidationError: field 'stop_when' must be one of: any, all")
+	}
+	return
+}
+
+func (job *JobRequest) validatePaceInterval() (err error) {
+	if job.PaceInterval < 0 {
+		err = errors.New("JobValidationError: field 'pace_interval' must be greater than 0")
+	} else if job.PaceInterval > 0 && job.Pace != 0 {
+		err = errors.New("JobValidationError: fields 'pace' rps and 'pace_interval' cannot be set together")
+	} else if job.PaceInterval > 0 && job.Type == string(config.Sleep) {
+		err = errors.New("JobValidationError: field 'pace_interval' must be not set for job with 'sleep' type")
+	}
+	return
+}
+
+func (job *JobRequest) validateReadPreference() (err error) {
+	switch job.ReadPreference {
+	case "", "secondaryPreferred", "secondary", "nearest", "primaryPreferred":
+	case "primary":
+		if len(job.ReadPreferenceTags) > 0 || job.HedgedReads {
+			err = errors.New("JobValidationError: fields 'read_preference_tags' and 'hedged_reads' cannot be used with 'primary' read preference")
+		}
+	default:
+		err = errors.New("JobValidationError: field 'read_preference' must be one of: primary, primaryPreferred, secondary, secondaryPreferred, nearest")
+	}
+	return
+}
+
+func (job *JobRequest) validateBulk() (err error) {
+	switch job.BulkOrder {
+	case "", config.BulkOrderOrdered, config.BulkOrderUnordered:
+	default:
+		return errors.New("JobValidationError: field 'bulk_order' must be one of: ordered, unordered")
+	}
+	if len(job.BulkMix) == 0 {
+		return
+	}
+	if job.Type != string(config.BulkWrite) {
+		return errors.New("JobValidationError: field 'bulk_mix' can be set only for 'bulk_write' job type")
+	}
+	total := 0.0
+	for operation, weight := range job.BulkMix {
+		if operation != config.BulkOpInsert && operation != config.BulkOpUpdate && operation != config.BulkOpDelete {
+			return errors.New("JobValidationError: field 'bulk_mix' operation must be one of: insert, update, delete, got " + operation)
+		}
+		if weight < 0 {
+			return errors.New("JobValidationError: field 'bulk_mix' weights must be greater or equal 0")
+		}
+		total += weight
+	}
+	if total == 0 {
+		err = errors.New("JobValidationError: field 'bulk_mix' must have at least one positive weight")
+	}
+	return
+}
+
+func (job *JobRequest) validateExpectedIndex() error {
+	if job.ExpectedIndex == "" {
+		return nil
+	}
+	if job.Type != string(config.Read) && job.Type != string(config.Paginate) {
+		return errors.New("JobValidationError: field 'expected_index' can be set only for 'read' and 'paginate' job types")
+	}
+	if len(job.Filter) == 0 {
+		return errors.New("JobValidationError: field 'expected_index' requires 'filter'")
+	}
+	return nil
+}
+
+func (job *JobRequest) validateExplainInterval() error {
+	if job.ExplainInterval < 0 {
+		return errors.New("JobValidationError: field 'explain_interval' must be greater than 0")
+	}
+	if job.ExplainInterval > 0 && len(job.Filter) == 0 {
+		return errors.New("JobValidationError: field 'explain_interval' requires 'filter'")
+	}
+	return nil
+}
+
+func (job *JobRequest) validateDisturbances() error {
+	if job.DisturbanceInterval < 0 {
+		return errors.New("JobValidationError: field 'disturbance_interval' must be greater than 0")
+	}
+	if job.DisturbanceInterval == 0 {
+		if len(job.Disturbances) > 0 {
+			return errors.New("JobValidationError: field 'disturbances' requires 'disturbance_interval'")
+		}
+		return nil
+	}
+	if job.Type == string(config.Sleep) {
+		return errors.New("JobValidationError: field 'disturbance_interval' must be not set for job with 'sleep' type")
+	}
+	for _, disturbance := range job.Disturbances {
+		if disturbance != config.DisturbanceCollMod && disturbance != config.DisturbanceIndex && disturbance != config.DisturbanceValidator {
+			return errors.New("JobValidationError: field 'disturbances' must contain only: collmod, index, validator, got " + disturbance)
+		}
+	}
+	return nil
+}
+
+func (job *JobRequest) validateBackup() error {
+	if job.BackupAt < 0 || job.BackupDuration < 0 {
+		return errors.New("JobValidationError: fields 'backup_at' and 'backup_duration' must be greater than 0")
+	}
+	if job.BackupAt == 0 {
+		if job.BackupDuration > 0 || job.BackupCommand != "" {
+			return errors.New("JobValidationError: fields 'backup_duration' and 'backup_command' require 'backup_at'")
+		}
+		return nil
+	}
+	if job.Type == string(config.Sleep) {
+		return errors.New("JobValidationError: field 'backup_at' must be not set for job with 'sleep' type")
+	}
+	if job.BackupCommand != "" && job.BackupDuration > 0 {
+		return errors.New("JobValidationError: fields 'backup_duration' and 'backup_command' cannot be set together")
+	}
+	if job.BackupCommand == "" && job.BackupDuration == 0 {
+		return errors.New("JobValidationError: field 'backup_at' requires 'backup_duration' (fsync lock) or 'backup_command'")
+	}
+	return nil
+}
+
+func (job *JobRequest) validateCacheMode() error {
+	switch job.CacheMode {
+	case "":
+		if job.CacheCommand != "" {
+			return errors.New("JobValidationError: field 'cache_command' requires 'cache_mode' cold")
+		}
+	case config.CacheModeWarm:
+		if job.CacheCommand != "" {
+			return errors.New("JobValidationError: field 'cache_command' can be set only for 'cache_mode' cold")
+		}
+	case config.CacheModeCold:
+		if job.CacheCommand == "" {
+			return errors.New("JobValidationError: 'cache_mode' cold requires 'cache_command' clearing cache, ex. restarting mongod")
+		}
+	default:
+		return errors.New("JobValidationError: field 'cache_mode' must be one of: warm, cold")
+	}
+	if job.CacheMode != "" && job.Type == string(config.Sleep) {
+		return errors.New("JobValidationError: field 'cache_mode' must be not set for job with 'sleep' type")
+	}
+	return nil
+}
+
+func (job *JobRequest) validateCollections() error {
+	if len(job.Collections) == 0 {
+		return nil
+	}
+	if job.Type == string(config.Sleep) {
+		return errors.New("JobValidationError: field 'collections' must be not set for job with 'sleep' type")
+	}
+	seen := make(map[string]bool, len(job.Collections))
+	for _, collection := range job.Collections {
+		if collection == "" || seen[collection] {
+			return errors.New("JobValidationError: field 'collections' must contain unique, non empty collection names")
+		}
+		seen[collection] = true
+	}
+	return nil
+}
+
+func (job *JobRequest) validateDocumentGrowth() error {
+	if job.Type == string(config.DocumentGrowth) {
+		return nil
+	}
+	if job.GrowField != "" {
+		return errors.New("JobValidationError: field 'grow_field' is only applicable for 'document_growth' job type")
+	}
+	if job.GrowMaxItems > 0 {
+		return errors.New("JobValidationError: field 'grow_max_items' is only applicable for 'document_growth' job type")
+	}
+	return nil
+}
+
+func (job *JobRequest) validateNoise() error {
+	if job.PaceJitter < 0 || job.PaceJitter > 1 {
+		return errors.New("JobValidationError: field 'pace_jitter' must be between 0 and 1")
+	}
+	if job.NoiseCollections > 0 && job.Type != string(config.Noise) {
+		return errors.New("JobValidationError: field 'noise_collections' is only applicable for 'noise' job type")
+	}
+	if job.NoiseCollections > 0 && len(job.Collections) > 0 {
+		return errors.New("JobValidationError: fields 'noise_collections' and 'collections' are mutually exclusive")
+	}
+	// collection prefixes generated noise collection names
+	if job.Type == string(config.Noise) && len(job.Collections) == 0 && job.Collection == "" {
+		return errors.New("JobValidationError: field 'collection' is required for 'noise' job type without 'collections'")
+	}
+	return nil
+}
+
+func (job *JobRequest) validateDelete() error {
+	if job.DeleteMany && job.Type != string(config.Delete) {
+		return errors.New("JobValidationError: field 'delete_many' is only applicable for 'delete' job type")
+	}
+	if job.Type == string(config.Delete) && len(job.Filter) == 0 {
+		return errors.New("JobValidationError: 'delete' job requires 'filter', empty filter would remove whole collection")
+	}
+	return nil
+}
+
+func (job *JobRequest) validatePipeline() error {
+	switch job.Type {
+	case string(config.Aggregate):
+	case string(config.ChangeStream), string(config.Mixed):
+		// optional, change_stream filters events with it, mixed requires it for aggregate operation
+	default:
+		if len(job.Pipeline) > 0 {
+			return errors.New("JobValidationError: field 'pipeline' is only applicable for 'aggregate', 'change_stream' and 'mixed' job types")
+		}
+		return nil
+	}
+	if job.Type == string(config.Aggregate) && len(job.Pipeline) == 0 {
+		return errors.New("JobValidationError: field 'pipeline' is required for 'aggregate' job type")
+	}
+	for _, stage := range job.Pipeline {
+		if stage, ok := stage.(map[string]interface{}); !ok || len(stage) != 1 {
+			return errors.New("JobValidationError: every 'pipeline' stage must be object with single stage operator, ex. {\"$match\": {...}}")
+		}
+	}
+	return nil
+}
+
+func (job *JobRequest) validatePauseWindows() error {
+	for _, window := range job.PauseWindows {
+		if _, err := config.ParsePauseWindow(window); err != nil {
+			return errors.New("JobValidationError: field 'pause_windows' invalid, " + err.Error())
+		}
+	}
+	return nil
+}
+
+func (job *JobRequest) validateTransaction() error {
+	if job.Type != string(config.Transaction) {
+		if len(job.TransactionSteps) > 0 || job.ReadConcern != "" || job.WriteConcern != "" || job.TransactionRetries > 0 {
+			return errors.New("JobValidationError: fields 'transaction_steps', 'read_concern', 'write_concern' and 'transaction_retries' are only applicable for 'transaction' job type")
+		}
+		return nil
+	}
+	if len(job.TransactionSteps) == 0 {
+		return errors.New("JobValidationError: field 'transaction_steps' is required for 'transaction' job type")
+	}
+	for _, step := range job.TransactionSteps {
+		switch step {
+		case config.TransactionStepInsert, config.TransactionStepUpdate, config.TransactionStepRead:
+		default:
+			return errors.New("JobValidationError: field 'transaction_steps' values must be one of: insert, update, read, got: " + step)
+		}
+	}
+	switch job.ReadConcern {
+	case "", "local", "majority", "snapshot":
+	default:
+		return errors.New("JobValidationError: field 'read_concern' must be one of: local, majority, snapshot")
+	}
+	if job.WriteConcern != "" && job.WriteConcern != "majority" {
+		if _, err := strconv.ParseUint(job.WriteConcern, 10, 32); err != nil {
+			return errors.New("JobValidationError: field 'write_concern' must be 'majority' or number of nodes")
+		}
+	}
+	return nil
+}
+
+func (job *JobRequest) validateOperationMix() error {
+	if len(job.OperationMix) > 0 && job.Type != string(config.Mixed) {
+		return errors.New("JobValidationError: field 'operation_mix' is only applicable for 'mixed' job type")
+	}
+	if job.Type != string(config.Mixed) {
+		return nil
+	}
+	if len(job.OperationMix) == 0 {
+		return errors.New("JobValidationError: field 'operation_mix' is required for 'mixed' job type")
+	}
+	for operation, weight := range job.OperationMix {
+		switch operation {
+		case string(config.Write), string(config.Read), string(config.Update), string(config.FindOneAndUpdate), string(config.FindOneAndDelete), string(config.FindOneAndReplace), string(config.Count):
+		case string(config.Delete):
+			if job.Filter == nil {
+				return errors.New("JobValidationError: field 'filter' is required for 'delete' operation of 'operation_mix'")
+			}
+		case string(config.Aggregate):
+			if len(job.Pipeline) == 0 {
+				return errors.New("JobValidationError: field 'pipeline' is required for 'aggregate' operation of 'operation_mix'")
+			}
+		case string(config.Distinct):
+			if job.DistinctField == "" {
+				return errors.New("JobValidationError: field 'distinct_field' is required for 'distinct' operation of 'operation_mix'")
+			}
+		default:
+			return errors.New("JobValidationError: 'operation_mix' operation must be one of write, read, update, delete, find_one_and_update, find_one_and_delete, find_one_and_replace, aggregate, count, distinct, got '" + operation + "'")
+		}
+		if weight <= 0 {
+			return errors.New("JobValidationError: 'operation_mix' weight of '" + operation + "' must be greater than 0")
+		}
+	}
+	return nil
+}
+
+func (job *JobRequest) validateUpsert() error {
+	if job.Upsert && job.Type != string(config.FindOneAndUpdate) && job.Type != string(config.FindOneAndReplace) {
+		return errors.New("JobValidationError: field 'upsert' is only applicable for 'find_one_and_update' and 'find_one_and_replace' job types")
+	}
+	return nil
+}
+
+func (job *JobRequest) validateIndexes() error {
+	if job.Type == string(config.CreateIndex) && len(job.Indexes) == 0 {
+		return errors.New("JobValidationError: field 'indexes' is required for 'create_index' job type")
+	}
+	if job.DropIndexes && len(job.Indexes) == 0 {
+		return errors.New("JobValidationError: field 'drop_indexes' requires 'indexes'")
+	}
+	for _, index := range job.Indexes {
+		if index.Name == "" {
+			return errors.New("JobValidationError: every index of 'indexes' requires 'name'")
+		}
+		if _, err := index.ParseKeys(); err != nil {
+			return errors.New("JobValidationError: " + err.Error())
+		}
+	}
+	return nil
+}
+
+func (job *JobRequest) validateHotSpot() (err error) {
+	switch {
+	case job.HotFraction == 0 && (job.HotSkew != 0 || job.HotField != ""):
+		err = errors.New("JobValidationError: fields 'hot_skew' and 'hot_field' require 'hot_fraction'")
+	case job.HotFraction == 0:
+	case job.HotFraction < 0 || job.HotFraction > 1:
+		err = errors.New("JobValidationError: field 'hot_fraction' must be between 0 and 1")
+	case job.Type != string(config.Update) && job.Type != string(config.FindOneAndUpdate):
+		err = errors.New("JobValidationError: field 'hot_fraction' is only applicable for 'update' and 'find_one_and_update' job types")
+	case job.HotSkew < 0:
+		err = errors.New("JobValidationError: field 'hot_skew' must be positive")
+	case job.HotField == "":
+		err = errors.New("JobValidationError: field 'hot_field' is required with 'hot_fraction', hot documents are matched by its values from 0 to 'hot_documents' - 1")
+	}
+	return
+}
+
+func (job *JobRequest) validateFullDocument() (err error) {
+	switch job.FullDocument {
+	case "":
+	case config.FullDocumentUpdateLookup, config.FullDocumentWhenAvailable, config.FullDocumentRequired:
+		if job.Type != string(config.ChangeStream) {
+			err = errors.New("JobValidationError: field 'full_document' is only applicable for 'change_stream' job type")
+		}
+	default:
+		err = errors.New("JobValidationError: field 'full_document' must be one of: updateLookup, whenAvailable, required")
+	}
+	return
+}
+
+func (job *JobRequest) validateFileSize() (err error) {
+	switch {
+	case job.Type != string(config.GridFSUpload):
+		if job.FileSize > 0 || job.FileSizeMax > 0 {
+			err = errors.New("JobValidationError: fields 'file_size' and 'file_size_max' are only applicable for 'gridfs_upload' job type")
+		}
+	case job.FileSize == 0:
+		err = errors.New("JobValidationError: field 'file_size' is required for 'gridfs_upload' job type")
+	case job.FileSizeMax > 0 && job.FileSizeMax < job.FileSize:
+		err = errors.New("JobValidationError: field 'file_size_max' must be greater than or equal to 'file_size'")
+	}
+	return
+}
+
+func (job *JobRequest) validateSlaBuckets() error {
+	for i, bucket := range job.SlaBuckets {
+		if bucket <= 0 {
+			return errors.New("JobValidationError: every value of 'sla_buckets' must be positive number of seconds")
+		}
+		if i > 0 && bucket <= job.SlaBuckets[i-1] {
+			return errors.New("JobValidationError: values of 'sla_buckets' must be increasing")
+		}
+	}
+	return nil
+}
+
+func (job *JobRequest) validateDistinctField() (err error) {
+	switch {
+	case job.Type == string(config.Distinct) && job.DistinctField == "":
+		err = errors.New("JobValidationError: field 'distinct_field' is required for 'distinct' job type")
+	case job.DistinctField != "" && job.Type != string(config.Distinct) && job.Type != string(config.Mixed):
+		err = errors.New("JobValidationError: field 'distinct_field' is only applicable for 'distinct' and 'mixed' job types")
+	}
+	return
+}
+
+func (job *JobRequest) validateMetricsExport() error {
+	if job.MetricsSample < 0 || job.MetricsSample > 1 {
+		return errors.New("JobValidationError: field 'metrics_sample' must be between 0 and 1")
+	}
+	if job.MetricsSample > 0 && len(job.Collections) == 0 {
+		return errors.New("JobValidationError: field 'metrics_sample' is only applicable for job with 'collections'")
+	}
+	if slices.Contains(job.MetricsInclude, "") || slices.Contains(job.MetricsExclude, "") {
+		return errors.New("JobValidationError: fields 'metrics_include' and 'metrics_exclude' must contain non empty metric name prefixes")
+	}
+	return nil
+}
+
+func (job *JobRequest) validateSearch() error {
+	if job.Search != "" && job.Type != string(config.TextSearch) {
+		return errors.New("JobValidationError: field 'search' is only applicable for 'text_search' job type")
+	}
+	return nil
+}
+
+func (job *JobRequest) validateGeo() error {
+	isGeo := job.Type == string(config.GeoNear) || job.Type == string(config.GeoWithin)
+	switch {
+	case isGeo && job.GeoField == "":
+		return errors.New("JobValidationError: field 'geo_field' is required for 'geo_near' and 'geo_within' job types")
+	case !isGeo && (job.GeoField != "" || job.GeoPoint != "" || job.GeoDistance != 0):
+		return errors.New("JobValidationError: fields 'geo_field', 'geo_point' and 'geo_distance' are only applicable for 'geo_near' and 'geo_within' job types")
+	case job.GeoDistance < 0:
+		return errors.New("JobValidationError: field 'geo_distance' must be positive number of meters")
+	case job.GeoPoint != "" && !strings.HasPrefix(job.GeoPoint, "#point"):
+		return errors.New("JobValidationError: field 'geo_point' must be #point generator, ex. #point(14.1,49.0,24.2,54.8)")
+	}
+	return nil
+}
+
+func (job *JobRequest) validateCommand() (err error) {
+	switch {
+	case job.Type == string(config.Command) && job.Command == nil:
+		err = errors.New("JobValidationError: field 'command' is required for 'command' job type")
+	case job.Command != nil && job.Type != string(config.Command):
+		err = errors.New("JobValidationError: field 'command' is only applicable for 'command' job type")
+	}
+	return
+}
+
+func (job *JobRequest) validateTarget() error {
+	if !config.IsTemplate(job.Database) && !config.IsTemplate(job.Collection) {
+		return nil
+	}
+	if len(job.Collections) > 0 {
+		return errors.New("JobValidationError: templated 'database' or 'collection' can't be combined with 'collections'")
+	}
+	targets := []struct{ field, target string }{{"database", job.Database}, {"collection", job.Collection}}
+	for _, target := range targets {
+		rendered, err := config.RenderTarget(target.target, config.TargetData{})
+		if err != nil {
+			return errors.New("JobValidationError: field '" + target.field + "' invalid template, " + err.Error())
+		}
+		if target.target != "" && rendered == "" {
+			return errors.New("JobValidationError: field '" + target.field + "' template renders empty name")
+		}
+	}
+	return nil
+}
+
+func (job *JobRequest) validateFanout() error {
+	switch {
+	case job.FanoutCollections == 0 && job.FanoutKey != "":
+		return errors.New("JobValidationError: field 'fanout_key' requires 'fanout_collections'")
+	case job.FanoutCollections == 0:
+		return nil
+	case job.Type != string(config.Write):
+		return errors.New("JobValidationError: field 'fanout_collections' is only applicable for 'write' job type")
+	case len(job.Collections) > 0:
+		return errors.New("JobValidationError: fields 'fanout_collections' and 'collections' are mutually exclusive")
+	case config.IsTemplate(job.Database) || config.IsTemplate(job.Collection):
+		return errors.New("JobValidationError: field 'fanout_collections' can't be combined with templated 'database' or 'collection'")
+	}
+	return nil
+}
+
+func (job *JobRequest) validateSteps() error {
+	return config.ValidateScenario(job.Type, job.Steps)
+}
+
+func (job *JobRequest) validateClientRegions() error {
+	return config.ValidateClientRegions(job.ClientRegions, job.Connections)
+}
+
+func (job *JobRequest) validateBurst() error {
+	if job.BurstDuration < 0 || job.BurstIdle < 0 {
+		return errors.New("JobValidationError: fields 'burst_duration' and 'burst_idle' must be greater than 0")
+	}
+	if (job.BurstDuration == 0) != (job.BurstIdle == 0) {
+		return errors.New("JobValidationError: fields 'burst_duration' and 'burst_idle' must be set together")
+	}
+	if job.BurstDuration > 0 && job.Type == string(config.Sleep) {
+		return errors.New("JobValidationError: field 'burst_duration' must be not set for job with 'sleep' type")
+	}
+	return nil
+}
+
+func (job *JobRequest) validateRateProfile() error {
+	if len(job.RateProfile) == 0 {
+		return nil
+	}
+	if job.Type == string(config.Sleep) {
+		return errors.New("JobValidationError: field 'rate_profile' must be not set for job with 'sleep' type")
+	}
+	if job.PaceInterval > 0 || job.PaceJitter > 0 {
+		return errors.New("JobValidationError: field 'rate_profile' can't be combined with interval pace or 'pace_jitter'")
+	}
+	if _, err := config.ParseRateProfile(job.Pace, job.RateProfile); err != nil {
+		return errors.New("JobValidationError: field 'rate_profile' is invalid, " + err.Error())
+	}
+	return nil
+}
+
+func (job *JobRequest) validateWarmup() error {
+	if job.Warmup < 0 {
+		return errors.New("JobValidationError: field 'warmup' must be greater than 0")
+	}
+	if job.Warmup > 0 && job.Type == string(config.Sleep) {
+		return errors.New("JobValidationError: field 'warmup' must be not set for job with 'sleep' type")
+	}
+	return nil
+}
+
 // todo: add schema validation
 // schema keys
 // save key should be in schema
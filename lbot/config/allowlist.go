@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"path"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// CheckTargets returns error when any host of config connection strings is denylisted or, with allowlist set,
+// is not allowlisted by agent, protecting clusters from connection string copy-pasted to benchmark config
+func (a *Agent) CheckTargets(c *Config) error {
+	if a == nil {
+		return nil
+	}
+	for _, connectionString := range []string{c.ConnectionString, c.ShardConnectionString} {
+		for _, host := range ConnectionHosts(connectionString) {
+			if pattern, denied := matchTarget(a.TargetDenylist, host); denied {
+				return fmt.Errorf("target %s is denylisted by agent (%s)", host, pattern)
+			}
+			if _, allowed := matchTarget(a.TargetAllowlist, host); len(a.TargetAllowlist) > 0 && !allowed {
+				return fmt.Errorf("target %s is not allowlisted by agent", host)
+			}
+		}
+	}
+	return nil
+}
+
+// ConnectionHosts returns lower-cased hosts (with port if set) of mongodb:// or mongodb+srv:// connection string,
+// credentials, database and options are skipped
+func ConnectionHosts(connectionString string) []string {
+	if connectionString == "" {
+		return nil
+	}
+	_, rest, found := strings.Cut(connectionString, "://")
+	if !found {
+		rest = connectionString
+	}
+	hosts, _, _ := strings.Cut(rest, "/")
+	hosts, _, _ = strings.Cut(hosts, "?")
+	if i := strings.LastIndex(hosts, "@"); i >= 0 {
+		hosts = hosts[i+1:]
+	}
+	return strings.Split(strings.ToLower(hosts), ",")
+}
+
+// matchTarget returns first pattern matching host, patterns are hostname globs (ex. *.staging.example.com)
+// with optional port, or connection strings whose hosts are matched
+func matchTarget(patterns []string, host string) (string, bool) {
+	hostname := host
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		hostname = name
+	}
+	for _, pattern := range patterns {
+		for _, target := range ConnectionHosts(pattern) {
+			// pattern without port matches every port
+			_, _, err := net.SplitHostPort(target)
+			if matched, _ := path.Match(target, lo.Ternary(err == nil, host, hostname)); matched {
+				return pattern, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// JobCommand is command document template of command job, ex. {"collStats": "orders", "scale": 1024},
+// server takes first key as command name, so it is kept with template and always sent first
+type JobCommand struct {
+	Name     string
+	Template map[string]interface{}
+}
+
+func (c *JobCommand) UnmarshalJSON(b []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return errors.New("invalid command, expected command document")
+	}
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	name, ok := token.(string)
+	if !ok {
+		return errors.New("invalid command, command document is empty")
+	}
+	c.Name = name
+	c.Template = nil
+	return json.Unmarshal(b, &c.Template)
+}
+
+func (c JobCommand) MarshalJSON() ([]byte, error) {
+	keys := make([]string, 0, len(c.Template))
+	for key := range c.Template {
+		if key != c.Name {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, key := range append([]string{c.Name}, keys...) {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		value, err := json.Marshal(c.Template[key])
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(name)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
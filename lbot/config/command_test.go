@@ -0,0 +1,25 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandJSON(t *testing.T) {
+	var command JobCommand
+	assert.NoError(t, json.Unmarshal([]byte(`{"scale": 1024, "collStats": "orders"}`), &command))
+	assert.Equal(t, "scale", command.Name)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"collStats": "orders", "scale": 1024, "indexDetails": true}`), &command))
+	assert.Equal(t, "collStats", command.Name)
+	assert.Equal(t, map[string]interface{}{"collStats": "orders", "scale": float64(1024), "indexDetails": true}, command.Template)
+
+	marshaled, err := json.Marshal(command)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"collStats":"orders","indexDetails":true,"scale":1024}`, string(marshaled))
+
+	assert.Error(t, json.Unmarshal([]byte(`{}`), &command))
+	assert.Error(t, json.Unmarshal([]byte(`["ping"]`), &command))
+}
@@ -1,15 +1,19 @@
 package config
 
 import (
+	"crypto/subtle"
 	"time"
 )
 
 type Config struct {
-	ConnectionString string    `json:"connection_string"`
-	Agent            *Agent    `json:"agent,omitempty"`
-	Jobs             []*Job    `json:"jobs,omitempty"`
-	Schemas          []*Schema `json:"schemas,omitempty"`
-	Debug            bool      `json:"debug,omitempty"`
+	ConnectionString      string    `json:"connection_string"`
+	ShardConnectionString string    `json:"shard_connection_string,omitempty"` // direct connection to shard primary, used by routing comparison
+	Agent                 *Agent    `json:"agent,omitempty"`
+	Jobs                  []*Job    `json:"jobs,omitempty"`
+	Schemas               []*Schema `json:"schemas,omitempty"`
+	Debug                 bool      `json:"debug,omitempty"`
+	Parallel              bool      `json:"parallel,omitempty"` // all jobs start at once instead of one after another
+	Template              string    `json:"template,omitempty"` // reference and digest of template config was pulled from
 }
 
 func (c *Config) GetSchema(name string) *Schema {
@@ -22,27 +26,160 @@ func (c *Config) GetSchema(name string) *Schema {
 }
 
 type Agent struct {
-	Name                         string `json:"name,omitempty"`
-	Port                         string `json:"port,omitempty"`
-	MetricsExportUrl             string `json:"metrics_export_url,omitempty"`
-	MetricsExportIntervalSeconds uint64 `json:"metrics_export_interval_seconds,omitempty"`
-	MetricsExportPort            string `json:"metrics_export_port,omitempty"`
+	Name                         string            `json:"name,omitempty"`
+	Port                         string            `json:"port,omitempty"`
+	MetricsExportUrl             string            `json:"metrics_export_url,omitempty"`
+	MetricsExportIntervalSeconds uint64            `json:"metrics_export_interval_seconds,omitempty"`
+	MetricsExportPort            string            `json:"metrics_export_port,omitempty"`
+	MetricsExportTLSCertFile     string            `json:"metrics_export_tls_cert_file,omitempty"`
+	MetricsExportTLSKeyFile      string            `json:"metrics_export_tls_key_file,omitempty"`
+	MetricsExportUsername        string            `json:"metrics_export_username,omitempty"`
+	MetricsExportPassword        string            `json:"metrics_export_password,omitempty"`
+	MetricsExportBearerToken     string            `json:"metrics_export_bearer_token,omitempty"`
+	MetricsExportLabels          map[string]string `json:"metrics_export_labels,omitempty"`
+	Tenants                      []*Tenant         `json:"tenants,omitempty"`
+	EstimateAtlasCost            bool              `json:"estimate_atlas_cost,omitempty"`
+	EventsNdjson                 bool              `json:"events_ndjson,omitempty"`   // newline-delimited json events on stdout
+	ArtifactsDir                 string            `json:"artifacts_dir,omitempty"`   // raw latency logs and job reports are saved here
+	MaxCpuPercent                float64           `json:"max_cpu_percent,omitempty"` // 100 is one core, dispatch is throttled above
+	MaxNetworkBytesPerSecond     uint64            `json:"max_network_bytes_per_second,omitempty"`
+	Plugins                      []string          `json:"plugins,omitempty"`                  // go plugins registering custom job types
+	Proxy                        string            `json:"proxy,omitempty"`                    // socks5:// proxy or ssh:// jump host database connections are dialed through
+	SshKeyFile                   string            `json:"ssh_key_file,omitempty"`             // private key of ssh jump host
+	SshKnownHostsFile            string            `json:"ssh_known_hosts_file,omitempty"`     // default ~/.ssh/known_hosts
+	RetentionMaxRuns             uint64            `json:"retention_max_runs,omitempty"`       // finished runs kept in history and artifacts
+	RetentionMaxDiskBytes        uint64            `json:"retention_max_disk_bytes,omitempty"` // artifacts size above which oldest runs are pruned
+	RetentionMaxAge              time.Duration     `json:"retention_max_age,omitempty"`        // finished runs older than it are pruned
+	Protected                    bool              `json:"protected,omitempty"`                // starts wait for approval of second token
+	TargetAllowlist              []string          `json:"target_allowlist,omitempty"`         // host globs configs may point at, any if empty
+	TargetDenylist               []string          `json:"target_denylist,omitempty"`          // host globs configs can't point at
+	ConfirmAboveDocuments        uint64            `json:"confirm_above_documents,omitempty"`  // estimated footprint above which start requires confirmation
+	ConfirmAboveWrittenBytes     uint64            `json:"confirm_above_written_bytes,omitempty"`
+	ConfirmAboveEgressBytes      uint64            `json:"confirm_above_egress_bytes,omitempty"`
+	RunHistorySize               uint64            `json:"run_history_size,omitempty"` // summaries of ended runs kept in memory, DefaultRunHistorySize if not set
+	RunHistoryFile               string            `json:"run_history_file,omitempty"` // summaries are saved here and survive agent restart
+}
+
+// Tenant scopes agent api access, token owner can only start, stop and modify jobs of his tenant
+type Tenant struct {
+	Name           string `json:"name,omitempty"`
+	Token          string `json:"token,omitempty"`
+	MaxConnections uint64 `json:"max_connections,omitempty"` // quota, job connections are capped to this value
+	MaxPace        uint64 `json:"max_pace,omitempty"`        // quota, job rps is capped to this value
+	Role           string `json:"role,omitempty"`            // viewer|operator|admin, admin if not set
+}
+
+func (a *Agent) GetTenantByToken(token string) *Tenant {
+	for _, tenant := range a.Tenants {
+		if subtle.ConstantTimeCompare([]byte(tenant.Token), []byte(token)) == 1 {
+			return tenant
+		}
+	}
+	return nil
+}
+
+func (a *Agent) GetTenant(name string) *Tenant {
+	for _, tenant := range a.Tenants {
+		if tenant.Name == name {
+			return tenant
+		}
+	}
+	return nil
 }
 
 type Job struct {
-	Name        string                 `json:"name,omitempty"`
-	Database    string                 `json:"database,omitempty"`
-	Collection  string                 `json:"collection,omitempty"`
-	Type        string                 `json:"type,omitempty"`
-	Schema      string                 `json:"schema,omitempty"`
-	Connections uint64                 `json:"connections,omitempty"` // Maximum number of concurrent connections
-	Pace        uint64                 `json:"pace,omitempty"` // rps limit / peace - if not set max
-	DataSize    uint64                 `json:"data_size,omitempty"` // data size in bytes
-	BatchSize   uint64                 `json:"batch_size,omitempty"`
-	Duration    time.Duration          `json:"duration,omitempty"`
-	Operations  uint64                 `json:"operations,omitempty"`
-	Timeout     time.Duration          `json:"timeout,omitempty"` // if not set, default
-	Filter      map[string]interface{} `json:"filter,omitempty"`
+	Name                string                 `json:"name,omitempty"`
+	Database            string                 `json:"database,omitempty"`
+	Collection          string                 `json:"collection,omitempty"`
+	Type                string                 `json:"type,omitempty"`
+	Schema              string                 `json:"schema,omitempty"`
+	Connections         uint64                 `json:"connections,omitempty"` // Maximum number of concurrent connections
+	Pace                uint64                 `json:"pace,omitempty"`        // rps limit / peace - if not set max
+	DataSize            uint64                 `json:"data_size,omitempty"`   // data size in bytes
+	BatchSize           uint64                 `json:"batch_size,omitempty"`
+	Duration            time.Duration          `json:"duration,omitempty"`
+	Operations          uint64                 `json:"operations,omitempty"`
+	Timeout             time.Duration          `json:"timeout,omitempty"` // if not set, default
+	Filter              map[string]interface{} `json:"filter,omitempty"`
+	HistogramBuckets    []float64              `json:"histogram_buckets,omitempty"`
+	NativeHistogram     bool                   `json:"native_histogram,omitempty"`
+	Tenant              string                 `json:"tenant,omitempty"`
+	EvolutionRatio      float64                `json:"evolution_ratio,omitempty"` // fraction of schema_evolution writes with mutated document shape
+	Update              map[string]interface{} `json:"update,omitempty"`          // update document template ex. {"$set": {"name": "#name"}, "$inc": {"counter": 1}}
+	ArrayFilters        []interface{}          `json:"array_filters,omitempty"`
+	ReturnDocument      string                 `json:"return_document,omitempty"` // before|after, document returned by find_one_and_update
+	Sort                map[string]interface{} `json:"sort,omitempty"`
+	ConsumerRatio       float64                `json:"consumer_ratio,omitempty"` // fraction of queue operations claiming messages
+	HotDocuments        uint64                 `json:"hot_documents,omitempty"`  // number of hot documents of counter, document_growth and hot-spot update jobs
+	Pagination          string                 `json:"pagination,omitempty"`     // skip|range pagination strategy
+	PageSize            uint64                 `json:"page_size,omitempty"`
+	Pages               uint64                 `json:"pages,omitempty"`
+	Lookup              map[string]interface{} `json:"lookup,omitempty"`
+	Limit               uint64                 `json:"limit,omitempty"`
+	StopWhen            string                 `json:"stop_when,omitempty"`            // any|all, stop condition when both duration and operations are set
+	PaceInterval        time.Duration          `json:"pace_interval,omitempty"`        // fixed interval between operations, alternative to pace
+	CompareRouting      bool                   `json:"compare_routing,omitempty"`      // run job through mongos and directly on shard and compare
+	ReadPreference      string                 `json:"read_preference,omitempty"`      // primary|primaryPreferred|secondary|secondaryPreferred|nearest
+	ReadPreferenceTags  []map[string]string    `json:"read_preference_tags,omitempty"` // replica tag sets, tried in order
+	HedgedReads         bool                   `json:"hedged_reads,omitempty"`
+	BulkOrder           string                 `json:"bulk_order,omitempty"`           // ordered|unordered, execution of bulk_write operations
+	BulkMix             map[string]float64     `json:"bulk_mix,omitempty"`             // operation weights of bulk_write, ex. {"insert": 0.7, "update": 0.2, "delete": 0.1}
+	ExpectedIndex       string                 `json:"expected_index,omitempty"`       // index which filters of job must use, verified with explain before job starts
+	ExplainInterval     time.Duration          `json:"explain_interval,omitempty"`     // interval of explaining sample filter during job, plan changes are reported
+	DisturbanceInterval time.Duration          `json:"disturbance_interval,omitempty"` // interval of background DDL operations run while job is running
+	Disturbances        []string               `json:"disturbances,omitempty"`         // DDL operations run every disturbance_interval, collmod|index|validator, default all
+	BackupAt            time.Duration          `json:"backup_at,omitempty"`            // time after job start when backup hook is triggered
+	BackupDuration      time.Duration          `json:"backup_duration,omitempty"`      // time database is kept fsync locked, when backup_command is not set
+	BackupCommand       string                 `json:"backup_command,omitempty"`       // external backup/snapshot command run with sh -c, ex. mongodump
+	CacheMode           string                 `json:"cache_mode,omitempty"`           // warm|cold, cache state prepared before job starts
+	CacheCommand        string                 `json:"cache_command,omitempty"`        // external command clearing cache (ex. mongod restart) run with sh -c before cold job
+	Collections         []string               `json:"collections,omitempty"`          // operations are spread across collections (instead of collection), stats are reported per collection
+	GrowField           string                 `json:"grow_field,omitempty"`           // array field growing in document_growth job, default items
+	GrowMaxItems        uint64                 `json:"grow_max_items,omitempty"`       // size cap of growing array, oldest items are removed above it
+	Priority            uint64                 `json:"priority,omitempty"`             // running workload pauses agent workloads with lower priority until it finishes
+	PaceJitter          float64                `json:"pace_jitter,omitempty"`          // pace is randomly changed by up to this fraction every 10s
+	NoiseCollections    uint64                 `json:"noise_collections,omitempty"`    // number of collections noise job spreads operations over when collections are not set
+	DeleteMany          bool                   `json:"delete_many,omitempty"`          // delete job removes all documents matching filter instead of first one
+	Pipeline            []interface{}          `json:"pipeline,omitempty"`             // aggregation pipeline stages of aggregate job, values can be generator templates
+	PauseWindows        []string               `json:"pause_windows,omitempty"`        // daily UTC windows job is paused in, ex. 02:00-02:30
+	TransactionSteps    []string               `json:"transaction_steps,omitempty"`    // operations run in single transaction, insert|update|read
+	ReadConcern         string                 `json:"read_concern,omitempty"`         // transaction read concern, local|majority|snapshot
+	WriteConcern        string                 `json:"write_concern,omitempty"`        // transaction write concern, majority or number of nodes
+	TransactionRetries  uint64                 `json:"transaction_retries,omitempty"`  // retries of transaction failed with transient error, default 3
+	OperationMix        map[string]float64     `json:"operation_mix,omitempty"`        // operation type weights of mixed job
+	Upsert              bool                   `json:"upsert,omitempty"`               // find_one_and_update/find_one_and_replace insert document when filter matches nothing
+	Indexes             []*Index               `json:"indexes,omitempty"`              // created on job collection before job starts
+	DropIndexes         bool                   `json:"drop_indexes,omitempty"`         // indexes are dropped after job finishes
+	HotFraction         float64                `json:"hot_fraction,omitempty"`         // fraction of update operations targeting hot documents
+	HotSkew             float64                `json:"hot_skew,omitempty"`             // zipf exponent of hot documents picks, 0 uniform
+	HotField            string                 `json:"hot_field,omitempty"`            // field matching hot documents, required with hot_fraction
+	FullDocument        string                 `json:"full_document,omitempty"`        // full document of change_stream update events, updateLookup, whenAvailable or required
+	FileSize            uint64                 `json:"file_size,omitempty"`            // size in bytes of files uploaded by gridfs_upload job
+	FileSizeMax         uint64                 `json:"file_size_max,omitempty"`        // file sizes are random between file_size and it
+	SlaBuckets          []float64              `json:"sla_buckets,omitempty"`          // SLA latency thresholds in seconds, fraction of operations within every one is reported
+	DistinctField       string                 `json:"distinct_field,omitempty"`       // field of distinct job values
+	MetricsInclude      []string               `json:"metrics_include,omitempty"`      // prefixes of exported job metric names, all by default
+	MetricsExclude      []string               `json:"metrics_exclude,omitempty"`      // prefixes of job metric names not exported
+	MetricsSample       float64                `json:"metrics_sample,omitempty"`       // fraction of collections exporting per-collection metrics
+	Search              string                 `json:"search,omitempty"`               // $text search template of text_search job, default #word
+	GeoField            string                 `json:"geo_field,omitempty"`            // GeoJSON field queried by geo_near and geo_within jobs
+	GeoPoint            string                 `json:"geo_point,omitempty"`            // template of geo query center, default #point
+	GeoDistance         float64                `json:"geo_distance,omitempty"`         // max distance of geo_near and radius of geo_within in meters
+	Command             *JobCommand            `json:"command,omitempty"`              // command document of command job, values can be generator templates
+	FanoutCollections   uint64                 `json:"fanout_collections,omitempty"`   // inserts of write job are spread across <collection>_0..N-1
+	FanoutKey           string                 `json:"fanout_key,omitempty"`           // schema field hashed to choose fan-out collection, round-robin if not set
+	Steps               []*ScenarioStep        `json:"steps,omitempty"`                // operations of scenario job run in order, later steps use documents of earlier ones
+	DependsOn           []string               `json:"depends_on,omitempty"`           // names of jobs which must finish before job starts
+	ClientRegions       []*ClientRegion        `json:"client_regions,omitempty"`       // groups of connections simulating clients of remote regions with network latency
+	Warmup              time.Duration          `json:"warmup,omitempty"`               // operations run without recording stats for this time after job start, not counted to duration
+	BurstDuration       time.Duration          `json:"burst_duration,omitempty"`       // job runs at its pace for this time in every burst cycle
+	BurstIdle           time.Duration          `json:"burst_idle,omitempty"`           // job is idle for this time between bursts
+	RateProfile         []string               `json:"rate_profile,omitempty"`         // stages of rate changing from pace, ex. "5000 over 10m", "10000 for 30s", "hold 30m", "repeat"
+	PaceBurst           uint64                 `json:"pace_burst,omitempty"`           // operations issued at once above pace after idle time, token bucket size
+	RunId               string                 `json:"-"`                              // set by agent on start
+	MaxPace             uint64                 `json:"-"`                              // set by agent on start from tenant quota, caps rate profile
+	Template            string                 `json:"-"`                              // set by agent on start from config template
+	WorkloadConfig      *Config                `json:"-"`                              // set by agent on start of workload with own config, without jobs and agent
 }
 
 type Schema struct {
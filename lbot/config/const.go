@@ -10,17 +10,95 @@ const (
 )
 
 const (
-	Write          JobType = "write"
-	BulkWrite      JobType = "bulk_write"
-	Read           JobType = "read"
-	Update         JobType = "update"
-	Sleep          JobType = "sleep"
-	DropCollection JobType = "drop_collection"
+	Write             JobType = "write"
+	BulkWrite         JobType = "bulk_write"
+	Read              JobType = "read"
+	Update            JobType = "update"
+	Sleep             JobType = "sleep"
+	DropCollection    JobType = "drop_collection"
+	CreateIndex       JobType = "create_index"
+	SchemaEvolution   JobType = "schema_evolution"
+	FindOneAndUpdate  JobType = "find_one_and_update"
+	FindOneAndDelete  JobType = "find_one_and_delete"
+	FindOneAndReplace JobType = "find_one_and_replace"
+	Delete            JobType = "delete"
+	Queue             JobType = "queue"
+	Counter           JobType = "counter"
+	Paginate          JobType = "paginate"
+	Lookup            JobType = "lookup"
+	Aggregate         JobType = "aggregate"
+	Transaction       JobType = "transaction"
+	DocumentGrowth    JobType = "document_growth"
+	Noise             JobType = "noise"
+	Mixed             JobType = "mixed"
+	ChangeStream      JobType = "change_stream"
+	GridFSUpload      JobType = "gridfs_upload"
+	GridFSDownload    JobType = "gridfs_download"
+	Count             JobType = "count"
+	Distinct          JobType = "distinct"
+	TextSearch        JobType = "text_search"
+	GeoNear           JobType = "geo_near"
+	GeoWithin         JobType = "geo_within"
+	Command           JobType = "command"
+	Scenario          JobType = "scenario"
+)
+
+const (
+	ReturnDocumentBefore = "before"
+	ReturnDocumentAfter  = "after"
+)
+
+const (
+	FullDocumentUpdateLookup  = "updateLookup"
+	FullDocumentWhenAvailable = "whenAvailable"
+	FullDocumentRequired      = "required"
+)
+
+const (
+	StopWhenAny = "any"
+	StopWhenAll = "all"
+)
+
+const (
+	RoleViewer   = "viewer"   // progress and results
+	RoleOperator = "operator" // viewer and start/stop
+	RoleAdmin    = "admin"    // operator and config
+)
+
+// server limit of operations in single write batch
+const MaxBatchSize = 100000
+
+const (
+	BulkOrderOrdered   = "ordered"
+	BulkOrderUnordered = "unordered"
+
+	BulkOpInsert = "insert"
+	BulkOpUpdate = "update"
+	BulkOpDelete = "delete"
+)
+
+const (
+	DisturbanceCollMod   = "collmod"
+	DisturbanceIndex     = "index"
+	DisturbanceValidator = "validator"
+)
+
+const (
+	CacheModeWarm = "warm"
+	CacheModeCold = "cold"
+)
+
+const (
+	PaginationSkip  = "skip"
+	PaginationRange = "range"
 )
 
 const (
 	AgentsHeartbeatInterval   = time.Second * 2
 	AgentsHeartbeatExpiration = -time.Second * 4
+	DefaultStopGracePeriod    = time.Second * 10
+	RetentionInterval         = time.Minute
+	DefaultRunHistorySize     = 100
 )
 
 const (
@@ -29,6 +107,14 @@ const (
 	WorkloadCollection    = "lbotWorkload"
 	ConfigCollection      = "lbotConfig"
 	AgentStatusCollection = "lbotAgent"
+	AnnotationCollection  = "lbotAnnotation"
+	ScheduleCollection    = "lbotSchedule"
 
 	// new commands
 )
+
+const (
+	TransactionStepInsert = "insert"
+	TransactionStepUpdate = "update"
+	TransactionStepRead   = "read"
+)
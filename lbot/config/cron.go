@@ -0,0 +1,145 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// schedules not firing within this time (ex. 30th of February) are treated as never firing
+const cronSearchLimit = 5 * 366 * day
+
+var cronMacros = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@yearly":   "0 0 1 1 *",
+}
+
+// cronField is set of allowed values of cron field, bit n is set when value n is allowed
+type cronField uint64
+
+func (f cronField) has(value int) bool {
+	return f&(1<<uint(value)) != 0
+}
+
+// Cron is parsed "<minute> <hour> <day of month> <month> <day of week>" expression, evaluated in UTC
+type Cron struct {
+	expression string
+	minutes    cronField
+	hours      cronField
+	days       cronField
+	months     cronField
+	weekdays   cronField
+	// day of month and day of week restricted together match when either matches, like in cron
+	anyDay     bool
+	anyWeekday bool
+}
+
+// ParseCron parses five field cron expression, fields accept *, values, ranges (1-5), lists (1,15)
+// and steps (*/15, 0-30/10), day of week is 0-7 where both 0 and 7 are sunday, @hourly, @daily,
+// @weekly, @monthly and @yearly are accepted as well
+func ParseCron(expression string) (*Cron, error) {
+	expression = strings.TrimSpace(expression)
+	original := expression
+	if macro, ok := cronMacros[expression]; ok {
+		expression = macro
+	}
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields: minute hour day-of-month month day-of-week", expression)
+	}
+	bounds := [][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	parsed := make([]cronField, len(fields))
+	for i, field := range fields {
+		value, err := parseCronField(field, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("cron expression %q: %w", expression, err)
+		}
+		parsed[i] = value
+	}
+	// sunday is 0 and 7
+	if parsed[4].has(7) {
+		parsed[4] |= 1
+	}
+	return &Cron{
+		expression: original,
+		minutes:    parsed[0],
+		hours:      parsed[1],
+		days:       parsed[2],
+		months:     parsed[3],
+		weekdays:   parsed[4],
+		anyDay:     strings.HasPrefix(fields[2], "*"),
+		anyWeekday: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// String returns expression cron was parsed from
+func (c *Cron) String() string {
+	return c.expression
+}
+
+func parseCronField(field string, low int, high int) (value cronField, err error) {
+	for _, part := range strings.Split(field, ",") {
+		span, stepValue, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			if step, err = strconv.Atoi(stepValue); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part)
+			}
+		}
+		from, to := low, high
+		if span != "*" {
+			start, end, isRange := strings.Cut(span, "-")
+			if from, err = strconv.Atoi(start); err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			to = from
+			if isRange {
+				if to, err = strconv.Atoi(end); err != nil {
+					return 0, fmt.Errorf("invalid range %q", part)
+				}
+			} else if hasStep {
+				to = high
+			}
+		}
+		if from < low || to > high || from > to {
+			return 0, fmt.Errorf("%q is out of range %d-%d", part, low, high)
+		}
+		for v := from; v <= to; v += step {
+			value |= 1 << uint(v)
+		}
+	}
+	return value, nil
+}
+
+// Next returns first time after t matching expression, zero time if expression never matches
+func (c *Cron) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	for limit := t.Add(cronSearchLimit); t.Before(limit); {
+		switch {
+		case !c.months.has(int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !c.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !c.hours.has(t.Hour()):
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case !c.minutes.has(t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (c *Cron) matchesDay(t time.Time) bool {
+	day, weekday := c.days.has(t.Day()), c.weekdays.has(int(t.Weekday()))
+	if !c.anyDay && !c.anyWeekday {
+		return day || weekday
+	}
+	return day && weekday
+}
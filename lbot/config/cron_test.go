@@ -0,0 +1,36 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCronNext(t *testing.T) {
+	// wednesday
+	now := time.Date(2024, 5, 1, 10, 17, 30, 0, time.UTC)
+	next := func(expression string) time.Time {
+		cron, err := ParseCron(expression)
+		assert.NoError(t, err, expression)
+		return cron.Next(now)
+	}
+
+	assert.Equal(t, time.Date(2024, 5, 2, 2, 0, 0, 0, time.UTC), next("0 2 * * *"))
+	assert.Equal(t, time.Date(2024, 5, 2, 2, 0, 0, 0, time.UTC), next("@daily").Add(2*time.Hour))
+	assert.Equal(t, time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC), next("*/15 * * * *"))
+	assert.Equal(t, time.Date(2024, 5, 1, 10, 18, 0, 0, time.UTC), next("* * * * *"))
+	assert.Equal(t, time.Date(2024, 5, 4, 3, 30, 0, 0, time.UTC), next("30 3 * * 6"))
+	assert.Equal(t, time.Date(2024, 5, 5, 0, 0, 0, 0, time.UTC), next("0 0 * * 7"))
+	assert.Equal(t, time.Date(2024, 5, 1, 22, 0, 0, 0, time.UTC), next("0 22 * * 1-5/2"))
+	assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), next("0 0 1 6,12 *"))
+	// day of month or day of week when both are restricted
+	assert.Equal(t, time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC), next("0 0 15 * 5"))
+	assert.Equal(t, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), next("0 0 29 2 *"))
+	assert.True(t, next("0 0 30 2 *").IsZero())
+
+	for _, expression := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "0 0 0 * *", "* * * 13 *", "*/0 * * * *", "a * * * *", "5-1 * * * *"} {
+		_, err := ParseCron(expression)
+		assert.Error(t, err, expression)
+	}
+}
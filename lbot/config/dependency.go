@@ -0,0 +1,61 @@
+package config
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// HasDependencies checks if any of jobs declares depends_on, jobs without dependencies run sequentially
+// in order of config
+func HasDependencies(jobs []*Job) bool {
+	return lo.SomeBy(jobs, func(job *Job) bool { return len(job.DependsOn) > 0 })
+}
+
+// OrderJobs returns jobs ordered so every job comes after jobs it depends on, order of config is kept
+// otherwise, fails on dependency on unknown or ambiguous job name and on dependency cycle
+func OrderJobs(jobs []*Job) ([]*Job, error) {
+	byName := lo.GroupBy(jobs, func(job *Job) string { return job.Name })
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := map[*Job]int{}
+	ordered := make([]*Job, 0, len(jobs))
+
+	var visit func(job *Job, path []string) error
+	visit = func(job *Job, path []string) error {
+		path = append(path, job.Name)
+		switch state[job] {
+		case visited:
+			return nil
+		case visiting:
+			return errors.New("JobValidationError: field 'depends_on' contains dependency cycle: " + strings.Join(path, " -> "))
+		}
+		state[job] = visiting
+		for _, name := range job.DependsOn {
+			dependencies := byName[name]
+			switch {
+			case len(dependencies) == 0:
+				return errors.New("JobValidationError: job " + job.Name + " depends on unknown job: " + name)
+			case len(dependencies) > 1:
+				return errors.New("JobValidationError: job " + job.Name + " depends on job with duplicated name: " + name)
+			}
+			if err := visit(dependencies[0], path); err != nil {
+				return err
+			}
+		}
+		state[job] = visited
+		ordered = append(ordered, job)
+		return nil
+	}
+
+	for _, job := range jobs {
+		if err := visit(job, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
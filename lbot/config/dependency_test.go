@@ -0,0 +1,48 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func jobNames(jobs []*Job) []string {
+	return lo.Map(jobs, func(job *Job, _ int) string { return job.Name })
+}
+
+func TestOrderJobs(t *testing.T) {
+	jobs := []*Job{
+		{Name: "validate", DependsOn: []string{"reads", "writes"}},
+		{Name: "reads", DependsOn: []string{"seed"}},
+		{Name: "writes", DependsOn: []string{"seed"}},
+		{Name: "seed"},
+		{Name: "cleanup"},
+	}
+	ordered, err := OrderJobs(jobs)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"seed", "reads", "writes", "validate", "cleanup"}, jobNames(ordered))
+	assert.True(t, HasDependencies(jobs))
+
+	independent := []*Job{{Name: "a"}, {Name: "b"}}
+	ordered, err = OrderJobs(independent)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, jobNames(ordered))
+	assert.False(t, HasDependencies(independent))
+}
+
+func TestOrderJobsInvalid(t *testing.T) {
+	invalid := map[string][]*Job{
+		"depends on unknown job": {{Name: "reads", DependsOn: []string{"seed"}}},
+		"dependency cycle: a -> b -> a": {
+			{Name: "a", DependsOn: []string{"b"}},
+			{Name: "b", DependsOn: []string{"a"}},
+		},
+		"dependency cycle: a -> a": {{Name: "a", DependsOn: []string{"a"}}},
+		"job with duplicated name": {{Name: "seed"}, {Name: "seed"}, {Name: "reads", DependsOn: []string{"seed"}}},
+	}
+	for message, jobs := range invalid {
+		_, err := OrderJobs(jobs)
+		assert.ErrorContains(t, err, message)
+	}
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/json"
 	"errors"
+	"strings"
 	"time"
 )
 
@@ -34,3 +35,31 @@ func (d *Duration) UnmarshalJSON(b []byte) error {
 		return errors.New("invalid duration")
 	}
 }
+
+// Pace is requests per second number or fixed interval between operations in "every 100ms" format
+type Pace struct {
+	Rate     uint64
+	Interval time.Duration
+}
+
+func (p *Pace) UnmarshalJSON(b []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	switch value := v.(type) {
+	case float64:
+		p.Rate = uint64(value)
+		return nil
+	case string:
+		interval, found := strings.CutPrefix(strings.TrimSpace(value), "every ")
+		if !found {
+			return errors.New("invalid pace, expected number or \"every <duration>\"")
+		}
+		var err error
+		p.Interval, err = time.ParseDuration(strings.TrimSpace(interval))
+		return err
+	default:
+		return errors.New("invalid pace")
+	}
+}
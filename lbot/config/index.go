@@ -0,0 +1,55 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Index is index created on job collection before job starts (or by create_index job)
+type Index struct {
+	Name               string   `json:"name,omitempty"`
+	Keys               []string `json:"keys,omitempty"` // ordered "field:<1|-1|text|2dsphere|hashed>", ascending if kind is omitted
+	Unique             bool     `json:"unique,omitempty"`
+	Sparse             bool     `json:"sparse,omitempty"`
+	Background         bool     `json:"background,omitempty"`           // ignored by mongodb 4.2+, builds are always optimized
+	ExpireAfterSeconds uint64   `json:"expire_after_seconds,omitempty"` // TTL index, requires single date field
+}
+
+// IndexKey is single field of index key pattern, Kind is 1, -1 or string index type
+type IndexKey struct {
+	Field string
+	Kind  interface{}
+}
+
+func (i *Index) ParseKeys() ([]IndexKey, error) {
+	if len(i.Keys) == 0 {
+		return nil, fmt.Errorf("index %q has no keys", i.Name)
+	}
+	keys := make([]IndexKey, len(i.Keys))
+	for k, key := range i.Keys {
+		field, kind, found := strings.Cut(key, ":")
+		field = strings.TrimSpace(field)
+		if field == "" {
+			return nil, fmt.Errorf("index %q key %q has no field", i.Name, key)
+		}
+		keys[k] = IndexKey{Field: field, Kind: 1}
+		if !found {
+			continue
+		}
+		switch kind = strings.TrimSpace(kind); kind {
+		case "text", "2dsphere", "2d", "hashed":
+			keys[k].Kind = kind
+		default:
+			direction, err := strconv.Atoi(kind)
+			if err != nil || (direction != 1 && direction != -1) {
+				return nil, fmt.Errorf("index %q key %q must be one of 1, -1, text, 2dsphere, 2d, hashed", i.Name, key)
+			}
+			keys[k].Kind = direction
+		}
+	}
+	if i.ExpireAfterSeconds > 0 && len(keys) > 1 {
+		return nil, fmt.Errorf("TTL index %q must have single key", i.Name)
+	}
+	return keys, nil
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexParseKeys(t *testing.T) {
+	index := &Index{Name: "user_created", Keys: []string{"user", "created_at:-1", "location:2dsphere"}}
+	keys, err := index.ParseKeys()
+	assert.Nil(t, err)
+	assert.Equal(t, []IndexKey{{"user", 1}, {"created_at", -1}, {"location", "2dsphere"}}, keys)
+
+	_, err = (&Index{Name: "empty"}).ParseKeys()
+	assert.Error(t, err)
+	_, err = (&Index{Name: "invalid", Keys: []string{"user:2"}}).ParseKeys()
+	assert.Error(t, err)
+	_, err = (&Index{Name: "no field", Keys: []string{":1"}}).ParseKeys()
+	assert.Error(t, err)
+	_, err = (&Index{Name: "ttl", Keys: []string{"a", "b"}, ExpireAfterSeconds: 60}).ParseKeys()
+	assert.Error(t, err)
+}
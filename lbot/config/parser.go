@@ -36,19 +36,94 @@ func standardizeJSON(b []byte) ([]byte, error) {
 
 func (c *Job) UnmarshalJSON(data []byte) (err error) {
 	var tmp struct {
-		Name        string                 `json:"name"`
-		Type        string                 `json:"type"`
-		Database    string                 `json:"database"`
-		Collection  string                 `json:"collection"`
-		Schema      string                 `json:"template"`
-		Connections uint64                 `json:"connections"`
-		Pace        uint64                 `json:"pace"`
-		DataSize    uint64                 `json:"data_size"`
-		BatchSize   uint64                 `json:"batch_size"`
-		Duration    Duration               `json:"duration"`
-		Operations  uint64                 `json:"operations"`
-		Timeout     Duration               `json:"timeout"` // if not set, default
-		Filter      map[string]interface{} `json:"filter"`
+		Name                string                 `json:"name"`
+		Type                string                 `json:"type"`
+		Database            string                 `json:"database"`
+		Collection          string                 `json:"collection"`
+		Schema              string                 `json:"template"`
+		Connections         uint64                 `json:"connections"`
+		Pace                Pace                   `json:"pace"`
+		DataSize            uint64                 `json:"data_size"`
+		BatchSize           uint64                 `json:"batch_size"`
+		Duration            Duration               `json:"duration"`
+		Operations          uint64                 `json:"operations"`
+		Timeout             Duration               `json:"timeout"` // if not set, default
+		Filter              map[string]interface{} `json:"filter"`
+		HistogramBuckets    []float64              `json:"histogram_buckets"`
+		NativeHistogram     bool                   `json:"native_histogram"`
+		Tenant              string                 `json:"tenant"`
+		EvolutionRatio      float64                `json:"evolution_ratio"`
+		Update              map[string]interface{} `json:"update"`
+		ArrayFilters        []interface{}          `json:"array_filters"`
+		ReturnDocument      string                 `json:"return_document"`
+		Sort                map[string]interface{} `json:"sort"`
+		ConsumerRatio       float64                `json:"consumer_ratio"`
+		HotDocuments        uint64                 `json:"hot_documents"`
+		Pagination          string                 `json:"pagination"`
+		PageSize            uint64                 `json:"page_size"`
+		Pages               uint64                 `json:"pages"`
+		Lookup              map[string]interface{} `json:"lookup"`
+		Limit               uint64                 `json:"limit"`
+		StopWhen            string                 `json:"stop_when"`
+		PaceInterval        Duration               `json:"pace_interval"`
+		CompareRouting      bool                   `json:"compare_routing"`
+		ReadPreference      string                 `json:"read_preference"`
+		ReadPreferenceTags  []map[string]string    `json:"read_preference_tags"`
+		HedgedReads         bool                   `json:"hedged_reads"`
+		BulkOrder           string                 `json:"bulk_order"`
+		BulkMix             map[string]float64     `json:"bulk_mix"`
+		ExpectedIndex       string                 `json:"expected_index"`
+		ExplainInterval     Duration               `json:"explain_interval"`
+		DisturbanceInterval Duration               `json:"disturbance_interval"`
+		Disturbances        []string               `json:"disturbances"`
+		BackupAt            Duration               `json:"backup_at"`
+		BackupDuration      Duration               `json:"backup_duration"`
+		BackupCommand       string                 `json:"backup_command"`
+		CacheMode           string                 `json:"cache_mode"`
+		CacheCommand        string                 `json:"cache_command"`
+		Collections         []string               `json:"collections"`
+		GrowField           string                 `json:"grow_field"`
+		GrowMaxItems        uint64                 `json:"grow_max_items"`
+		Priority            uint64                 `json:"priority"`
+		PaceJitter          float64                `json:"pace_jitter"`
+		NoiseCollections    uint64                 `json:"noise_collections"`
+		DeleteMany          bool                   `json:"delete_many"`
+		Pipeline            []interface{}          `json:"pipeline"`
+		PauseWindows        []string               `json:"pause_windows"`
+		TransactionSteps    []string               `json:"transaction_steps"`
+		ReadConcern         string                 `json:"read_concern"`
+		WriteConcern        string                 `json:"write_concern"`
+		TransactionRetries  uint64                 `json:"transaction_retries"`
+		OperationMix        map[string]float64     `json:"operation_mix"`
+		Upsert              bool                   `json:"upsert"`
+		Indexes             []*Index               `json:"indexes"`
+		DropIndexes         bool                   `json:"drop_indexes"`
+		HotFraction         float64                `json:"hot_fraction"`
+		HotSkew             float64                `json:"hot_skew"`
+		HotField            string                 `json:"hot_field"`
+		FullDocument        string                 `json:"full_document"`
+		FileSize            uint64                 `json:"file_size"`
+		FileSizeMax         uint64                 `json:"file_size_max"`
+		SlaBuckets          []float64              `json:"sla_buckets"`
+		DistinctField       string                 `json:"distinct_field"`
+		MetricsInclude      []string               `json:"metrics_include"`
+		MetricsExclude      []string               `json:"metrics_exclude"`
+		MetricsSample       float64                `json:"metrics_sample"`
+		Search              string                 `json:"search"`
+		GeoField            string                 `json:"geo_field"`
+		GeoPoint            string                 `json:"geo_point"`
+		GeoDistance         float64                `json:"geo_distance"`
+		Command             *JobCommand            `json:"command"`
+		FanoutCollections   uint64                 `json:"fanout_collections"`
+		FanoutKey           string                 `json:"fanout_key"`
+		Steps               []*ScenarioStep        `json:"steps"`
+		DependsOn           []string               `json:"depends_on"`
+		ClientRegions       []*ClientRegion        `json:"client_regions"`
+		Warmup              Duration               `json:"warmup"`
+		BurstDuration       Duration               `json:"burst_duration"`
+		BurstIdle           Duration               `json:"burst_idle"`
+		RateProfile         []string               `json:"rate_profile"`
+		PaceBurst           uint64                 `json:"pace_burst"`
 	}
 	// default values
 	tmp.Connections = 1
@@ -63,13 +138,91 @@ func (c *Job) UnmarshalJSON(data []byte) (err error) {
 	c.Type = tmp.Type
 	c.Schema = tmp.Schema
 	c.Connections = tmp.Connections
-	c.Pace = tmp.Pace
+	c.Pace = tmp.Pace.Rate
 	c.DataSize = tmp.DataSize
 	c.BatchSize = tmp.BatchSize
 	c.Duration = tmp.Duration.Duration
 	c.Operations = tmp.Operations
 	c.Timeout = tmp.Timeout.Duration
 	c.Filter = tmp.Filter
+	c.HistogramBuckets = tmp.HistogramBuckets
+	c.NativeHistogram = tmp.NativeHistogram
+	c.Tenant = tmp.Tenant
+	c.EvolutionRatio = tmp.EvolutionRatio
+	c.Update = tmp.Update
+	c.ArrayFilters = tmp.ArrayFilters
+	c.ReturnDocument = tmp.ReturnDocument
+	c.Sort = tmp.Sort
+	c.ConsumerRatio = tmp.ConsumerRatio
+	c.HotDocuments = tmp.HotDocuments
+	c.Pagination = tmp.Pagination
+	c.PageSize = tmp.PageSize
+	c.Pages = tmp.Pages
+	c.Lookup = tmp.Lookup
+	c.Limit = tmp.Limit
+	c.StopWhen = tmp.StopWhen
+	c.PaceInterval = tmp.PaceInterval.Duration
+	if tmp.Pace.Interval != 0 {
+		c.PaceInterval = tmp.Pace.Interval
+	}
+	c.CompareRouting = tmp.CompareRouting
+	c.ReadPreference = tmp.ReadPreference
+	c.ReadPreferenceTags = tmp.ReadPreferenceTags
+	c.HedgedReads = tmp.HedgedReads
+	c.BulkOrder = tmp.BulkOrder
+	c.BulkMix = tmp.BulkMix
+	c.ExpectedIndex = tmp.ExpectedIndex
+	c.ExplainInterval = tmp.ExplainInterval.Duration
+	c.DisturbanceInterval = tmp.DisturbanceInterval.Duration
+	c.Disturbances = tmp.Disturbances
+	c.BackupAt = tmp.BackupAt.Duration
+	c.BackupDuration = tmp.BackupDuration.Duration
+	c.BackupCommand = tmp.BackupCommand
+	c.CacheMode = tmp.CacheMode
+	c.CacheCommand = tmp.CacheCommand
+	c.Collections = tmp.Collections
+	c.GrowField = tmp.GrowField
+	c.GrowMaxItems = tmp.GrowMaxItems
+	c.Priority = tmp.Priority
+	c.PaceJitter = tmp.PaceJitter
+	c.NoiseCollections = tmp.NoiseCollections
+	c.DeleteMany = tmp.DeleteMany
+	c.Pipeline = tmp.Pipeline
+	c.PauseWindows = tmp.PauseWindows
+	c.TransactionSteps = tmp.TransactionSteps
+	c.ReadConcern = tmp.ReadConcern
+	c.WriteConcern = tmp.WriteConcern
+	c.TransactionRetries = tmp.TransactionRetries
+	c.OperationMix = tmp.OperationMix
+	c.Upsert = tmp.Upsert
+	c.Indexes = tmp.Indexes
+	c.DropIndexes = tmp.DropIndexes
+	c.HotFraction = tmp.HotFraction
+	c.HotSkew = tmp.HotSkew
+	c.HotField = tmp.HotField
+	c.FullDocument = tmp.FullDocument
+	c.FileSize = tmp.FileSize
+	c.FileSizeMax = tmp.FileSizeMax
+	c.SlaBuckets = tmp.SlaBuckets
+	c.DistinctField = tmp.DistinctField
+	c.MetricsInclude = tmp.MetricsInclude
+	c.MetricsExclude = tmp.MetricsExclude
+	c.MetricsSample = tmp.MetricsSample
+	c.Search = tmp.Search
+	c.GeoField = tmp.GeoField
+	c.GeoPoint = tmp.GeoPoint
+	c.GeoDistance = tmp.GeoDistance
+	c.Command = tmp.Command
+	c.FanoutCollections = tmp.FanoutCollections
+	c.FanoutKey = tmp.FanoutKey
+	c.Steps = tmp.Steps
+	c.DependsOn = tmp.DependsOn
+	c.ClientRegions = tmp.ClientRegions
+	c.Warmup = tmp.Warmup.Duration
+	c.BurstDuration = tmp.BurstDuration.Duration
+	c.BurstIdle = tmp.BurstIdle.Duration
+	c.RateProfile = tmp.RateProfile
+	c.PaceBurst = tmp.PaceBurst
 
 	return
 }
@@ -0,0 +1,20 @@
+package config
+
+import "sync"
+
+// job types registered by agent plugins, accepted by job type validation
+var pluginJobTypes sync.Map
+
+func RegisterPluginJobType(jobType string) {
+	pluginJobTypes.Store(jobType, true)
+}
+
+func IsPluginJobType(jobType string) bool {
+	_, ok := pluginJobTypes.Load(jobType)
+	return ok
+}
+
+// IsJobType checks if job type is built-in or registered by plugin
+func IsJobType(jobType string) bool {
+	return (&Job{Type: jobType}).validateType() == nil
+}
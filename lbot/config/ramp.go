@@ -0,0 +1,318 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// keyword of last rate profile stage starting profile over when it ends
+	RateProfileRepeat = "repeat"
+	// periodic stages "sine <min>-<max> [every <period>] for <duration>" and
+	// "diurnal <24 hourly rps> [every <period>] for <duration>"
+	RateWaveSine    = "sine"
+	RateWaveDiurnal = "diurnal"
+	// period of wave stage without "every", one day
+	DefaultRateWavePeriod = 24 * time.Hour
+	// points of diurnal curve, one per hour of day
+	DiurnalCurvePoints = 24
+
+	rateStageFormats = `"<rps> over <duration>", "<rps> for <duration>", "hold <duration>", ` +
+		`"sine <min>-<max> [every <period>] for <duration>" or "diurnal <24 hourly rps> [every <period>] for <duration>"`
+)
+
+// RateStage is single stage of job rate profile, rate changes linearly from rate of previous stage
+// (or job pace) to target over stage duration, step stage switches to target immediately
+type RateStage struct {
+	Target   uint64
+	Duration time.Duration
+	Step     bool
+	Wave     *RateWave // periodic stage, target is wave rate at the end of stage
+}
+
+// RateWave is rate repeating every period, sine wave between min and max starting at min, or hourly curve
+// with rates between its points interpolated linearly, last hour goes back to first point
+type RateWave struct {
+	Min    uint64
+	Max    uint64
+	Curve  []uint64
+	Period time.Duration
+}
+
+// Rate returns wave rate after elapsed time since wave start
+func (w *RateWave) Rate(elapsed time.Duration) uint64 {
+	phase := float64(elapsed%w.Period) / float64(w.Period)
+	if len(w.Curve) == 0 {
+		return w.Min + uint64(math.Round(float64(w.Max-w.Min)*(1-math.Cos(2*math.Pi*phase))/2))
+	}
+	position := phase * float64(len(w.Curve))
+	point := int(position)
+	from, to := float64(w.Curve[point]), float64(w.Curve[(point+1)%len(w.Curve)])
+	return uint64(math.Round(from + (to-from)*(position-float64(point))))
+}
+
+// operations returns number of operations issued at wave rate capped to limit (0 doesn't cap) over elapsed time
+// since wave start
+func (w *RateWave) operations(elapsed time.Duration, limit uint64) float64 {
+	periods := float64(elapsed / w.Period)
+	return periods*w.periodOperations(w.Period, limit) + w.periodOperations(elapsed%w.Period, limit)
+}
+
+// periodOperations integrates wave rate over elapsed time of single period
+func (w *RateWave) periodOperations(elapsed time.Duration, limit uint64) float64 {
+	if len(w.Curve) > 0 {
+		// curve is linear between hourly points
+		segment := w.Period / time.Duration(len(w.Curve))
+		operations := 0.0
+		for point := 0; elapsed > 0 && point < len(w.Curve); point++ {
+			span := min(elapsed, segment)
+			from, to := float64(w.Curve[point]), float64(w.Curve[(point+1)%len(w.Curve)])
+			operations += linearOperations(from, from+(to-from)*float64(span)/float64(segment), span, limit)
+			elapsed -= span
+		}
+		return operations
+	}
+	period := w.Period.Seconds()
+	phase := float64(elapsed) / float64(w.Period)
+	low, amplitude := float64(w.Min), float64(w.Max-w.Min)
+	// antiderivative of sine wave rate over phase
+	integral := func(x float64) float64 {
+		return (low*x + amplitude*(x-math.Sin(2*math.Pi*x)/(2*math.Pi))/2) * period
+	}
+	switch {
+	case limit == 0 || limit >= w.Max:
+		return integral(phase)
+	case limit <= w.Min:
+		return float64(limit) * phase * period
+	}
+	// wave is above limit between phases rising and falling
+	rising := math.Acos(1-2*(float64(limit)-low)/amplitude) / (2 * math.Pi)
+	falling := 1 - rising
+	operations := integral(min(phase, rising))
+	if phase > rising {
+		operations += float64(limit) * (min(phase, falling) - rising) * period
+	}
+	if phase > falling {
+		operations += integral(phase) - integral(falling)
+	}
+	return operations
+}
+
+// linearOperations integrates rate changing linearly from one rate to another over elapsed time, capped
+// to limit (0 doesn't cap)
+func linearOperations(from float64, to float64, elapsed time.Duration, limit uint64) float64 {
+	seconds, ceiling := elapsed.Seconds(), float64(limit)
+	switch {
+	case limit == 0 || (from <= ceiling && to <= ceiling):
+		return (from + to) / 2 * seconds
+	case from >= ceiling && to >= ceiling:
+		return ceiling * seconds
+	}
+	// rate crosses limit at this fraction of elapsed time
+	crossing := (ceiling - from) / (to - from)
+	if from < ceiling {
+		return (from+ceiling)/2*seconds*crossing + ceiling*seconds*(1-crossing)
+	}
+	return ceiling*seconds*crossing + (ceiling+to)/2*seconds*(1-crossing)
+}
+
+// RateProfile is job rate changing over time, ex. ramp-up, hold and ramp-down, last rate is kept after
+// profile ends unless profile is repeated
+type RateProfile struct {
+	Start  uint64
+	Stages []RateStage
+	Repeat bool
+	Max    uint64 // rate is capped to max when set, ex. tenant quota
+}
+
+// ParseRateStage parses "<target rps> over <duration>" ramp stage, ex. "5000 over 10m", "<rps> for <duration>"
+// step stage, ex. "10000 for 30s", "hold <duration>" keeping previous rate, or periodic sine or diurnal stage,
+// ex. "sine 500-5000 every 1h for 24h"
+func ParseRateStage(stage string, previous uint64) (RateStage, error) {
+	if kind, _, _ := strings.Cut(strings.TrimSpace(stage), " "); kind == RateWaveSine || kind == RateWaveDiurnal {
+		return parseRateWaveStage(stage, kind)
+	}
+	if target, duration, found := strings.Cut(stage, " for "); found {
+		parsed, err := parseRateStage(stage, target, duration)
+		parsed.Step = true
+		return parsed, err
+	}
+	if duration, found := strings.CutPrefix(strings.TrimSpace(stage), "hold "); found {
+		parsed, err := time.ParseDuration(strings.TrimSpace(duration))
+		if err != nil || parsed <= 0 {
+			return RateStage{}, fmt.Errorf("rate stage %q must be in %s format", stage, rateStageFormats)
+		}
+		return RateStage{Target: previous, Duration: parsed}, nil
+	}
+	target, duration, found := strings.Cut(stage, " over ")
+	if !found {
+		return RateStage{}, fmt.Errorf("rate stage %q must be in %s format", stage, rateStageFormats)
+	}
+	return parseRateStage(stage, target, duration)
+}
+
+func parseRateStage(stage string, target string, duration string) (RateStage, error) {
+	rate, err := strconv.ParseUint(strings.TrimSpace(target), 10, 64)
+	if err != nil {
+		return RateStage{}, fmt.Errorf("rate stage %q has invalid target rps", stage)
+	}
+	parsed, err := time.ParseDuration(strings.TrimSpace(duration))
+	if err != nil || parsed <= 0 {
+		return RateStage{}, fmt.Errorf("rate stage %q has invalid duration", stage)
+	}
+	return RateStage{Target: rate, Duration: parsed}, nil
+}
+
+// parseRateWaveStage parses "<kind> <wave> [every <period>] for <duration>" stage
+func parseRateWaveStage(stage string, kind string) (RateStage, error) {
+	spec, duration, found := strings.Cut(strings.TrimPrefix(strings.TrimSpace(stage), kind), " for ")
+	if !found {
+		return RateStage{}, fmt.Errorf("rate stage %q must be in %s format", stage, rateStageFormats)
+	}
+	parsed, err := time.ParseDuration(strings.TrimSpace(duration))
+	if err != nil || parsed <= 0 {
+		return RateStage{}, fmt.Errorf("rate stage %q has invalid duration", stage)
+	}
+	wave := &RateWave{Period: DefaultRateWavePeriod}
+	if values, period, found := strings.Cut(spec, " every "); found {
+		spec = values
+		if wave.Period, err = time.ParseDuration(strings.TrimSpace(period)); err != nil || wave.Period <= 0 {
+			return RateStage{}, fmt.Errorf("rate stage %q has invalid period", stage)
+		}
+	}
+
+	if kind == RateWaveSine {
+		low, high, found := strings.Cut(strings.TrimSpace(spec), "-")
+		wave.Min, err = strconv.ParseUint(strings.TrimSpace(low), 10, 64)
+		if err == nil {
+			wave.Max, err = strconv.ParseUint(strings.TrimSpace(high), 10, 64)
+		}
+		if !found || err != nil || wave.Min > wave.Max {
+			return RateStage{}, fmt.Errorf("rate stage %q must have \"<min rps>-<max rps>\" range", stage)
+		}
+	} else {
+		points := strings.Split(spec, ",")
+		if len(points) != DiurnalCurvePoints {
+			return RateStage{}, fmt.Errorf("rate stage %q must have %d comma separated hourly rates, got %d", stage, DiurnalCurvePoints, len(points))
+		}
+		wave.Curve = make([]uint64, len(points))
+		for i, point := range points {
+			if wave.Curve[i], err = strconv.ParseUint(strings.TrimSpace(point), 10, 64); err != nil {
+				return RateStage{}, fmt.Errorf("rate stage %q has invalid rate of hour %d", stage, i)
+			}
+		}
+	}
+	return RateStage{Target: wave.Rate(parsed), Duration: parsed, Wave: wave}, nil
+}
+
+// JobRateProfile parses rate profile of job capped to its max pace
+func JobRateProfile(job *Job) (RateProfile, error) {
+	profile, err := ParseRateProfile(job.Pace, job.RateProfile)
+	profile.Max = job.MaxPace
+	return profile, err
+}
+
+// ParseRateProfile parses rate profile stages starting at job pace, "repeat" as last stage repeats profile
+func ParseRateProfile(start uint64, stages []string) (RateProfile, error) {
+	profile := RateProfile{Start: start}
+	previous := start
+	for i, stage := range stages {
+		if strings.TrimSpace(stage) == RateProfileRepeat {
+			if i == 0 || i != len(stages)-1 {
+				return RateProfile{}, fmt.Errorf("rate stage %q must be last stage of profile with other stages", stage)
+			}
+			profile.Repeat = true
+			break
+		}
+		parsed, err := ParseRateStage(stage, previous)
+		if err != nil {
+			return RateProfile{}, err
+		}
+		profile.Stages = append(profile.Stages, parsed)
+		previous = parsed.Target
+	}
+	return profile, nil
+}
+
+// Rate returns rate of profile after elapsed time since job start, repeated profile starts every next cycle
+// from rate of its last stage
+func (p RateProfile) Rate(elapsed time.Duration) uint64 {
+	if p.Max > 0 {
+		return min(p.rate(elapsed), p.Max)
+	}
+	return p.rate(elapsed)
+}
+
+func (p RateProfile) rate(elapsed time.Duration) uint64 {
+	from := p.Start
+	if duration := p.Duration(); p.Repeat && elapsed >= duration {
+		from = p.Stages[len(p.Stages)-1].Target
+		elapsed %= duration
+	}
+	for _, stage := range p.Stages {
+		if elapsed < stage.Duration && stage.Wave != nil {
+			return stage.Wave.Rate(elapsed)
+		}
+		if elapsed < stage.Duration && stage.Step {
+			return stage.Target
+		}
+		if elapsed < stage.Duration {
+			progress := float64(elapsed) / float64(stage.Duration)
+			return uint64(float64(from) + (float64(stage.Target)-float64(from))*progress)
+		}
+		elapsed -= stage.Duration
+		from = stage.Target
+	}
+	return from
+}
+
+// Operations returns number of operations issued at profile rate over elapsed time since job start,
+// integrated per stage
+func (p RateProfile) Operations(elapsed time.Duration) float64 {
+	duration := p.Duration()
+	if !p.Repeat || duration == 0 || elapsed <= duration {
+		return p.cycleOperations(p.Start, elapsed)
+	}
+	// first cycle starts from job pace, next ones from rate of last stage
+	last := p.Stages[len(p.Stages)-1].Target
+	cycles := float64((elapsed - duration) / duration)
+	return p.cycleOperations(p.Start, duration) + cycles*p.cycleOperations(last, duration) +
+		p.cycleOperations(last, (elapsed-duration)%duration)
+}
+
+// cycleOperations integrates rate of single profile cycle starting from rate, last rate is kept after it ends
+func (p RateProfile) cycleOperations(from uint64, elapsed time.Duration) (operations float64) {
+	for _, stage := range p.Stages {
+		if elapsed <= 0 {
+			return operations
+		}
+		span := min(elapsed, stage.Duration)
+		switch {
+		case stage.Wave != nil:
+			operations += stage.Wave.operations(span, p.Max)
+		case stage.Step:
+			operations += linearOperations(float64(stage.Target), float64(stage.Target), span, p.Max)
+		default:
+			progress := float64(span) / float64(stage.Duration)
+			to := float64(from) + (float64(stage.Target)-float64(from))*progress
+			operations += linearOperations(float64(from), to, span, p.Max)
+		}
+		elapsed -= stage.Duration
+		from = stage.Target
+	}
+	if elapsed > 0 {
+		operations += linearOperations(float64(from), float64(from), elapsed, p.Max)
+	}
+	return operations
+}
+
+// Duration returns total duration of profile stages
+func (p RateProfile) Duration() (duration time.Duration) {
+	for _, stage := range p.Stages {
+		duration += stage.Duration
+	}
+	return duration
+}
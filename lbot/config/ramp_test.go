@@ -0,0 +1,135 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRateProfile(t *testing.T) {
+	profile, err := ParseRateProfile(100, []string{"5000 over 10m", "hold 30m", "100 over 5m"})
+	assert.NoError(t, err)
+	assert.Equal(t, RateProfile{Start: 100, Stages: []RateStage{
+		{Target: 5000, Duration: 10 * time.Minute},
+		{Target: 5000, Duration: 30 * time.Minute},
+		{Target: 100, Duration: 5 * time.Minute},
+	}}, profile)
+	assert.Equal(t, 45*time.Minute, profile.Duration())
+
+	for _, stage := range []string{"5000", "5000 over", "fast over 10m", "5000 over 0s", "hold", "hold -1m"} {
+		_, err = ParseRateProfile(100, []string{stage})
+		assert.Error(t, err, stage)
+	}
+}
+
+func TestRateProfileRate(t *testing.T) {
+	profile, _ := ParseRateProfile(100, []string{"5100 over 10m", "hold 30m", "100 over 5m"})
+	assert.Equal(t, uint64(100), profile.Rate(0))
+	assert.Equal(t, uint64(2600), profile.Rate(5*time.Minute))
+	assert.Equal(t, uint64(5100), profile.Rate(10*time.Minute))
+	assert.Equal(t, uint64(5100), profile.Rate(39*time.Minute))
+	assert.Equal(t, uint64(2600), profile.Rate(42*time.Minute+30*time.Second))
+	// last rate is kept after profile
+	assert.Equal(t, uint64(100), profile.Rate(time.Hour))
+}
+
+func TestJobRateProfileMax(t *testing.T) {
+	profile, err := JobRateProfile(&Job{Pace: 100, RateProfile: []string{"5100 over 10m"}, MaxPace: 1000})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), profile.Rate(0))
+	assert.Equal(t, uint64(1000), profile.Rate(5*time.Minute))
+	assert.Equal(t, uint64(1000), profile.Rate(time.Hour))
+}
+
+func TestRateProfileSteps(t *testing.T) {
+	profile, err := ParseRateProfile(0, []string{"1000 for 5m", "10000 for 30s", "repeat"})
+	assert.NoError(t, err)
+	assert.Equal(t, RateProfile{Stages: []RateStage{
+		{Target: 1000, Duration: 5 * time.Minute, Step: true},
+		{Target: 10000, Duration: 30 * time.Second, Step: true},
+	}, Repeat: true}, profile)
+
+	assert.Equal(t, uint64(1000), profile.Rate(0))
+	assert.Equal(t, uint64(1000), profile.Rate(4*time.Minute))
+	assert.Equal(t, uint64(10000), profile.Rate(5*time.Minute+10*time.Second))
+	// second cycle
+	assert.Equal(t, uint64(1000), profile.Rate(6*time.Minute))
+	assert.Equal(t, uint64(10000), profile.Rate(10*time.Minute+40*time.Second))
+
+	// repeated ramp starts from rate of last stage
+	profile, _ = ParseRateProfile(0, []string{"1000 over 1m", "100 for 1m", "repeat"})
+	assert.Equal(t, uint64(500), profile.Rate(30*time.Second))
+	assert.Equal(t, uint64(550), profile.Rate(2*time.Minute+30*time.Second))
+
+	for _, stages := range [][]string{{"repeat"}, {"1000 for 5m", "repeat", "10 for 1m"}, {"fast for 5m"}} {
+		_, err = ParseRateProfile(100, stages)
+		assert.Error(t, err, stages)
+	}
+}
+
+func TestRateProfileWaves(t *testing.T) {
+	profile, err := ParseRateProfile(100, []string{"sine 1000-5000 every 1h for 3h"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3*time.Hour, profile.Duration())
+	assert.Equal(t, uint64(1000), profile.Rate(0))
+	assert.Equal(t, uint64(3000), profile.Rate(15*time.Minute))
+	assert.Equal(t, uint64(5000), profile.Rate(30*time.Minute))
+	assert.Equal(t, uint64(1000), profile.Rate(2*time.Hour))
+	// wave rate at the end of stage is kept
+	assert.Equal(t, uint64(1000), profile.Rate(4*time.Hour))
+
+	hours := "100,100,100,100,100,100,200,400,800,1000,1000,1000,1000,1000,1000,1000,1000,800,600,400,300,200,100,100"
+	profile, err = ParseRateProfile(0, []string{"diurnal " + hours + " for 48h", "repeat"})
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultRateWavePeriod, profile.Stages[0].Wave.Period)
+	assert.Equal(t, uint64(100), profile.Rate(0))
+	assert.Equal(t, uint64(600), profile.Rate(7*time.Hour+30*time.Minute))
+	assert.Equal(t, uint64(1000), profile.Rate(24*time.Hour+12*time.Hour))
+	// last hour goes back to first point
+	assert.Equal(t, uint64(100), profile.Rate(23*time.Hour+30*time.Minute))
+
+	// compressed day followed by ramp starting from wave rate
+	profile, err = ParseRateProfile(0, []string{"diurnal " + hours + " every 24m for 12m", "0 over 10m"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1000), profile.Stages[0].Target)
+	assert.Equal(t, uint64(500), profile.Rate(17*time.Minute))
+
+	for _, stage := range []string{
+		"sine 1000 for 1h", "sine 5000-1000 for 1h", "sine 1000-5000", "sine 1000-5000 every 0s for 1h",
+		"diurnal 100,200 for 1h", "diurnal " + strings.Replace(hours, "800", "x", 1) + " for 1h",
+	} {
+		_, err = ParseRateProfile(100, []string{stage})
+		assert.Error(t, err, stage)
+	}
+}
+
+func TestRateProfileOperations(t *testing.T) {
+	// sum of rate of every second
+	sampled := func(profile RateProfile, elapsed time.Duration) float64 {
+		total := 0.0
+		for at := time.Duration(0); at < elapsed; at += time.Second {
+			total += float64(profile.Rate(at))
+		}
+		return total
+	}
+	for _, test := range []struct {
+		stages  []string
+		max     uint64
+		elapsed time.Duration
+	}{
+		{[]string{"1100 over 10m", "hold 5m", "100 for 5m"}, 0, time.Hour},
+		{[]string{"1100 over 10m", "0 over 1m"}, 600, 20 * time.Minute},
+		{[]string{"1000 for 5m", "10000 for 30s", "repeat"}, 0, 2 * time.Hour},
+		{[]string{"sine 500-5000 every 1h for 3h"}, 0, 2*time.Hour + 10*time.Minute},
+		{[]string{"sine 500-5000 every 1h for 3h"}, 3000, 2*time.Hour + 40*time.Minute},
+		{[]string{"diurnal " + strings.TrimSuffix(strings.Repeat("100,900,", 12), ",") + " every 24m for 1h"}, 500, time.Hour},
+	} {
+		profile, err := ParseRateProfile(100, test.stages)
+		assert.NoError(t, err, test.stages)
+		profile.Max = test.max
+		expected := sampled(profile, test.elapsed)
+		assert.InEpsilon(t, expected, profile.Operations(test.elapsed), 0.01, test.stages)
+	}
+}
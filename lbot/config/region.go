@@ -0,0 +1,45 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ClientRegion is group of job connections simulating clients of remote region, every operation
+// of its connections is delayed by network round trip of region
+type ClientRegion struct {
+	Name    string   `json:"name,omitempty"`
+	Weight  float64  `json:"weight,omitempty"`  // share of job connections in region, default 1
+	Latency Duration `json:"latency,omitempty"` // round trip time added to every operation
+	Jitter  Duration `json:"jitter,omitempty"`  // latency is randomly changed by up to it
+}
+
+// ValidateClientRegions checks client regions of job, every region needs at least one connection
+func ValidateClientRegions(regions []*ClientRegion, connections uint64) error {
+	if len(regions) == 0 {
+		return nil
+	}
+	if uint64(len(regions)) > connections {
+		return fmt.Errorf("JobValidationError: field 'client_regions' has %d regions, job needs at least as many connections", len(regions))
+	}
+	names := map[string]bool{}
+	for i, region := range regions {
+		if region.Name == "" {
+			return fmt.Errorf("JobValidationError: field 'client_regions[%d].name' is required", i)
+		}
+		if names[region.Name] {
+			return errors.New("JobValidationError: field 'client_regions' contains duplicated region name: " + region.Name)
+		}
+		names[region.Name] = true
+		if region.Weight < 0 {
+			return errors.New("JobValidationError: field 'client_regions.weight' can't be negative, region: " + region.Name)
+		}
+		if region.Latency.Duration < 0 || region.Jitter.Duration < 0 {
+			return errors.New("JobValidationError: fields 'client_regions.latency' and 'client_regions.jitter' can't be negative, region: " + region.Name)
+		}
+		if region.Jitter.Duration > region.Latency.Duration {
+			return errors.New("JobValidationError: field 'client_regions.jitter' can't be greater than latency, region: " + region.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateClientRegions(t *testing.T) {
+	regions := []*ClientRegion{
+		{Name: "eu", Weight: 2, Latency: Duration{80 * time.Millisecond}, Jitter: Duration{10 * time.Millisecond}},
+		{Name: "us", Latency: Duration{150 * time.Millisecond}},
+	}
+	assert.NoError(t, ValidateClientRegions(regions, 10))
+	assert.NoError(t, ValidateClientRegions(nil, 1))
+	assert.ErrorContains(t, ValidateClientRegions(regions, 1), "at least as many connections")
+
+	invalid := map[string][]*ClientRegion{
+		"'client_regions[0].name' is required": {{Latency: Duration{time.Millisecond}}},
+		"duplicated region name: eu":           {{Name: "eu"}, {Name: "eu"}},
+		"can't be negative":                    {{Name: "eu", Weight: -1}},
+		"can't be greater than latency":        {{Name: "eu", Latency: Duration{time.Millisecond}, Jitter: Duration{time.Second}}},
+	}
+	for message, regions := range invalid {
+		assert.ErrorContains(t, ValidateClientRegions(regions, 10), message)
+	}
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	ScenarioStepInsert = "insert"
+	ScenarioStepRead   = "read"
+	ScenarioStepUpdate = "update"
+	ScenarioStepDelete = "delete"
+
+	// string values of step filter and update starting with it are replaced with field of document
+	// produced by earlier step, ex. "@signup.email"
+	ScenarioReferencePrefix = "@"
+)
+
+// ScenarioStep is single operation of scenario job, steps run in order in every operation of job,
+// inserted and read documents are shared with following steps
+type ScenarioStep struct {
+	Name   string                 `json:"name,omitempty"`
+	Type   string                 `json:"type,omitempty"`   // insert|read|update|delete
+	Filter map[string]interface{} `json:"filter,omitempty"` // template of read, update and delete steps
+	Update map[string]interface{} `json:"update,omitempty"` // template of update step
+	Weight float64                `json:"weight,omitempty"` // probability step runs in operation, default 1
+}
+
+// ScenarioReference is parsed "@step.field" value
+type ScenarioReference struct {
+	Step  string
+	Field string
+}
+
+// ParseScenarioReference parses "@step.field" value, ok is false for other values
+func ParseScenarioReference(value string) (reference ScenarioReference, ok bool) {
+	path, found := strings.CutPrefix(value, ScenarioReferencePrefix)
+	if !found {
+		return ScenarioReference{}, false
+	}
+	reference.Step, reference.Field, ok = strings.Cut(path, ".")
+	return reference, ok && reference.Step != "" && reference.Field != ""
+}
+
+// ScenarioReferences returns steps referenced by values of template
+func ScenarioReferences(template interface{}) (steps []string) {
+	switch value := template.(type) {
+	case string:
+		if reference, ok := ParseScenarioReference(value); ok {
+			steps = append(steps, reference.Step)
+		}
+	case []interface{}:
+		for _, nested := range value {
+			steps = append(steps, ScenarioReferences(nested)...)
+		}
+	case map[string]interface{}:
+		for _, nested := range value {
+			steps = append(steps, ScenarioReferences(nested)...)
+		}
+	}
+	return steps
+}
+
+// validateScenarioSteps checks steps of scenario job, steps can reference only documents
+// of earlier insert and read steps
+func validateScenarioSteps(steps []*ScenarioStep) error {
+	producers := map[string]bool{}
+	for i, step := range steps {
+		if step.Name == "" {
+			return fmt.Errorf("JobValidationError: field 'steps[%d].name' is required", i)
+		}
+		if strings.Contains(step.Name, ".") || strings.HasPrefix(step.Name, ScenarioReferencePrefix) {
+			return errors.New("JobValidationError: field 'steps.name' can't contain '.' or start with '@', got: " + step.Name)
+		}
+		if _, ok := producers[step.Name]; ok {
+			return errors.New("JobValidationError: field 'steps' contains duplicated step name: " + step.Name)
+		}
+		switch step.Type {
+		case ScenarioStepInsert:
+		case ScenarioStepRead, ScenarioStepDelete:
+			if len(step.Filter) == 0 {
+				return errors.New("JobValidationError: field 'filter' is required for '" + step.Type + "' step " + step.Name)
+			}
+		case ScenarioStepUpdate:
+			if len(step.Filter) == 0 || len(step.Update) == 0 {
+				return errors.New("JobValidationError: fields 'filter' and 'update' are required for 'update' step " + step.Name)
+			}
+		default:
+			return errors.New("JobValidationError: field 'steps.type' must be one of: insert, read, update, delete, got: " + step.Type)
+		}
+		if step.Weight < 0 || step.Weight > 1 {
+			return errors.New("JobValidationError: field 'steps.weight' must be in range 0-1 in step " + step.Name)
+		}
+		for _, template := range []map[string]interface{}{step.Filter, step.Update} {
+			for _, referenced := range ScenarioReferences(template) {
+				produces, ok := producers[referenced]
+				if !ok {
+					return errors.New("JobValidationError: step " + step.Name + " references unknown or later step: " + referenced)
+				}
+				if !produces {
+					return errors.New("JobValidationError: step " + step.Name + " references step " + referenced + " which doesn't produce document, only insert and read steps do")
+				}
+			}
+		}
+		producers[step.Name] = step.Type == ScenarioStepInsert || step.Type == ScenarioStepRead
+	}
+	return nil
+}
+
+// ValidateScenario checks steps of scenario job, steps are only applicable for scenario job
+func ValidateScenario(jobType string, steps []*ScenarioStep) error {
+	if jobType != string(Scenario) {
+		if len(steps) > 0 {
+			return errors.New("JobValidationError: field 'steps' is only applicable for 'scenario' job type")
+		}
+		return nil
+	}
+	if len(steps) == 0 {
+		return errors.New("JobValidationError: field 'steps' is required for 'scenario' job type")
+	}
+	return validateScenarioSteps(steps)
+}
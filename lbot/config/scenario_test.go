@@ -0,0 +1,49 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScenarioReference(t *testing.T) {
+	reference, ok := ParseScenarioReference("@signup.profile.email")
+	assert.True(t, ok)
+	assert.Equal(t, ScenarioReference{Step: "signup", Field: "profile.email"}, reference)
+
+	for _, value := range []string{"#email", "signup.email", "@signup", "@.email", "@signup."} {
+		_, ok = ParseScenarioReference(value)
+		assert.False(t, ok, value)
+	}
+}
+
+func TestValidateScenario(t *testing.T) {
+	steps := []*ScenarioStep{
+		{Name: "signup", Type: ScenarioStepInsert},
+		{Name: "profile", Type: ScenarioStepRead, Filter: map[string]interface{}{"_id": "@signup._id"}},
+		{Name: "visit", Type: ScenarioStepUpdate, Weight: 0.5, Filter: map[string]interface{}{"_id": "@profile._id"}, Update: map[string]interface{}{"$inc": map[string]interface{}{"visits": 1}}},
+	}
+	assert.NoError(t, ValidateScenario(string(Scenario), steps))
+	assert.ErrorContains(t, ValidateScenario(string(Write), steps), "only applicable for 'scenario'")
+	assert.ErrorContains(t, ValidateScenario(string(Scenario), nil), "is required")
+
+	invalid := map[string][]*ScenarioStep{
+		"references unknown or later step": {
+			{Name: "profile", Type: ScenarioStepRead, Filter: map[string]interface{}{"_id": "@signup._id"}},
+			{Name: "signup", Type: ScenarioStepInsert},
+		},
+		"doesn't produce document": {
+			{Name: "signup", Type: ScenarioStepInsert},
+			{Name: "remove", Type: ScenarioStepDelete, Filter: map[string]interface{}{"_id": "@signup._id"}},
+			{Name: "profile", Type: ScenarioStepRead, Filter: map[string]interface{}{"_id": "@remove._id"}},
+		},
+		"duplicated step name": {{Name: "signup", Type: ScenarioStepInsert}, {Name: "signup", Type: ScenarioStepInsert}},
+		"'filter' is required": {{Name: "profile", Type: ScenarioStepRead}},
+		"must be in range 0-1": {{Name: "signup", Type: ScenarioStepInsert, Weight: 2}},
+		"must be one of":       {{Name: "signup", Type: "upsert"}},
+		"can't contain '.'":    {{Name: "sign.up", Type: ScenarioStepInsert}},
+	}
+	for message, steps := range invalid {
+		assert.ErrorContains(t, ValidateScenario(string(Scenario), steps), message)
+	}
+}
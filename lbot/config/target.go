@@ -0,0 +1,57 @@
+package config
+
+import (
+	"strings"
+	"text/template"
+)
+
+// TargetData is data available in job database and collection templates, ex. "tenant_{{.WorkerId}}"
+type TargetData struct {
+	WorkerId int // index of job connection, from 0 to connections-1
+	Job      string
+	RunId    string
+}
+
+// IsTargetTemplated checks if job database or collection is rendered separately for every connection
+func (job *Job) IsTargetTemplated() bool {
+	return IsTemplate(job.Database) || IsTemplate(job.Collection)
+}
+
+// Target returns database and collection of job connection, job fields take precedence over schema ones
+func (job *Job) Target(schema *Schema, workerId int) (database string, collection string, err error) {
+	database, collection = job.Database, job.Collection
+	if schema != nil {
+		if database == "" {
+			database = schema.Database
+		}
+		if collection == "" {
+			collection = schema.Collection
+		}
+	}
+	data := TargetData{WorkerId: workerId, Job: job.Name, RunId: job.RunId}
+	if database, err = RenderTarget(database, data); err != nil {
+		return
+	}
+	collection, err = RenderTarget(collection, data)
+	return
+}
+
+func IsTemplate(target string) bool {
+	return strings.Contains(target, "{{")
+}
+
+// RenderTarget renders database or collection template, plain names are returned unchanged
+func RenderTarget(target string, data TargetData) (string, error) {
+	if !IsTemplate(target) {
+		return target, nil
+	}
+	tmpl, err := template.New("target").Option("missingkey=error").Parse(target)
+	if err != nil {
+		return "", err
+	}
+	var rendered strings.Builder
+	if err = tmpl.Execute(&rendered, data); err != nil {
+		return "", err
+	}
+	return rendered.String(), nil
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobTarget(t *testing.T) {
+	job := &Job{Name: "orders", Database: "shop", Collection: "orders_{{.WorkerId}}"}
+	assert.True(t, job.IsTargetTemplated())
+
+	database, collection, err := job.Target(nil, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, "shop", database)
+	assert.Equal(t, "orders_3", collection)
+
+	// job fields take precedence over schema
+	schema := &Schema{Database: "default_db", Collection: "default_collection"}
+	database, collection, err = (&Job{Database: "tenant_{{.WorkerId}}"}).Target(schema, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, "tenant_1", database)
+	assert.Equal(t, "default_collection", collection)
+
+	_, _, err = (&Job{Collection: "orders_{{.Unknown}}"}).Target(nil, 0)
+	assert.Error(t, err)
+}
+
+func TestValidateNoiseCollection(t *testing.T) {
+	assert.Error(t, (&Job{Type: string(Noise), NoiseCollections: 3}).validateNoise())
+	assert.Nil(t, (&Job{Type: string(Noise), Collection: "noise"}).validateNoise())
+	assert.Nil(t, (&Job{Type: string(Noise), Collections: []string{"a", "b"}}).validateNoise())
+}
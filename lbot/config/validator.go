@@ -2,11 +2,18 @@ package config
 
 import (
 	"errors"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 func (c *Config) Validate() error {
 	validators := []func() error{
 		c.validateJobs,
+		c.validateDependencies,
+		c.validateTenants,
+		c.validateBudget,
+		c.validateMetricsExport,
 		// c.validateSchemas,
 	}
 
@@ -18,6 +25,44 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+func (c *Config) validateTenants() error {
+	if c.Agent == nil {
+		return nil
+	}
+	for _, tenant := range c.Agent.Tenants {
+		switch tenant.Role {
+		case "", RoleViewer, RoleOperator, RoleAdmin:
+		default:
+			return errors.New("TenantValidationError: field 'role' must be one of viewer, operator, admin, got '" + tenant.Role + "'")
+		}
+	}
+	if c.Agent.Protected && len(c.Agent.Tenants) < 2 {
+		return errors.New("AgentValidationError: field 'protected' requires at least two tenant tokens, starts are approved by second token")
+	}
+	return nil
+}
+
+func (c *Config) validateBudget() error {
+	if c.Agent == nil {
+		return nil
+	}
+	if c.Agent.MaxCpuPercent < 0 {
+		return errors.New("AgentValidationError: field 'max_cpu_percent' cannot be negative")
+	}
+	return nil
+}
+
+func (c *Config) validateMetricsExport() error {
+	if c.Agent == nil {
+		return nil
+	}
+	// exporter would silently serve plaintext with only one of them
+	if (c.Agent.MetricsExportTLSCertFile == "") != (c.Agent.MetricsExportTLSKeyFile == "") {
+		return errors.New("AgentValidationError: fields 'metrics_export_tls_cert_file' and 'metrics_export_tls_key_file' must be set together")
+	}
+	return nil
+}
+
 func (c *Config) validateJobs() error {
 	for _, job := range c.Jobs {
 		if error := job.Validate(); error != nil {
@@ -27,6 +72,11 @@ func (c *Config) validateJobs() error {
 	return nil
 }
 
+func (c *Config) validateDependencies() error {
+	_, err := OrderJobs(c.Jobs)
+	return err
+}
+
 func (job *Job) Validate() error {
 	validators := []func() error{
 		job.validateSchema,
@@ -39,6 +89,49 @@ func (job *Job) Validate() error {
 		job.validateBatchSize,
 		job.validateOperations,
 		job.validateDataSize,
+		job.validateHistogram,
+		job.validateEvolutionRatio,
+		job.validateUpdate,
+		job.validateReturnDocument,
+		job.validateConsumerRatio,
+		job.validateHotDocuments,
+		job.validatePagination,
+		job.validateLookup,
+		job.validateStopWhen,
+		job.validatePaceInterval,
+		job.validateReadPreference,
+		job.validateBulk,
+		job.validateExpectedIndex,
+		job.validateExplainInterval,
+		job.validateDisturbances,
+		job.validateBackup,
+		job.validateCacheMode,
+		job.validateCollections,
+		job.validateTarget,
+		job.validateFanout,
+		job.validateDocumentGrowth,
+		job.validateNoise,
+		job.validateDelete,
+		job.validatePipeline,
+		job.validatePauseWindows,
+		job.validateTransaction,
+		job.ValidateOperationMix,
+		job.validateUpsert,
+		job.validateIndexes,
+		job.validateHotSpot,
+		job.validateFullDocument,
+		job.validateFileSize,
+		job.validateSlaBuckets,
+		job.validateDistinctField,
+		job.validateMetricsExport,
+		job.validateSearch,
+		job.validateGeo,
+		job.validateCommand,
+		job.validateSteps,
+		job.validateClientRegions,
+		job.validateWarmup,
+		job.validateBurst,
+		job.validateRateProfile,
 	}
 
 	for _, validate := range validators {
@@ -68,9 +161,36 @@ func (job *Job) validateType() (err error) {
 	case string(Read):
 	case string(Update):
 	case string(DropCollection):
+	case string(CreateIndex):
+	case string(SchemaEvolution):
+	case string(FindOneAndUpdate):
+	case string(FindOneAndDelete):
+	case string(FindOneAndReplace):
+	case string(Delete):
+	case string(Queue):
+	case string(Counter):
+	case string(Paginate):
+	case string(Lookup):
+	case string(Aggregate):
+	case string(Transaction):
+	case string(DocumentGrowth):
+	case string(Noise):
+	case string(Mixed):
+	case string(ChangeStream):
+	case string(GridFSUpload):
+	case string(GridFSDownload):
+	case string(Count):
+	case string(Distinct):
+	case string(TextSearch):
+	case string(GeoNear):
+	case string(GeoWithin):
+	case string(Command):
+	case string(Scenario):
 	case string(Sleep):
 	default:
-		err = errors.New("Job type: " + job.Type + " ")
+		if !IsPluginJobType(job.Type) {
+			err = errors.New("Job type: " + job.Type + " ")
+		}
 	}
 	return
 }
@@ -89,7 +209,7 @@ func (job *Job) validateCollection() (err error) {
 	if job.Schema != "" || job.Type == string(Sleep) {
 		return
 	}
-	if job.Collection == "" {
+	if job.Collection == "" && len(job.Collections) == 0 {
 		err = errors.New("JobValidationError: field 'collection' is required if 'template' or 'type' is not set")
 	}
 	return
@@ -122,6 +242,11 @@ func (job *Job) validatePace() (err error) {
 			err = errors.New("JobValidationError: field 'pace' must be equal 0 or must be not set for job with 'sleep' type ")
 		}
 	}
+	if job.PaceBurst > 0 && job.Pace == 0 {
+		err = errors.New("JobValidationError: field 'pace_burst' requires 'pace' to be set")
+	} else if job.PaceBurst > 0 && len(job.RateProfile) > 0 {
+		err = errors.New("JobValidationError: fields 'pace_burst' and 'rate_profile' cannot be set together")
+	}
 	return
 }
 
@@ -130,6 +255,10 @@ func (job *Job) validateBatchSize() (err error) {
 		if job.BatchSize != 0 {
 			err = errors.New("JobValidationError: field 'batch_size' must be equal 0 or must be not set for job with 'sleep' type ")
 		}
+	} else if job.BatchSize != 0 && job.Type != string(BulkWrite) {
+		err = errors.New("JobValidationError: field 'batch_size' is only applicable for 'bulk_write' job type")
+	} else if job.BatchSize > MaxBatchSize {
+		err = errors.New("JobValidationError: field 'batch_size' must be at most " + strconv.Itoa(MaxBatchSize))
 	}
 	return
 }
@@ -152,6 +281,612 @@ func (job *Job) validateOperations() (err error) {
 	return
 }
 
+func (job *Job) validateHistogram() (err error) {
+	if job.NativeHistogram && len(job.HistogramBuckets) > 0 {
+		err = errors.New("JobValidationError: fields 'histogram_buckets' and 'native_histogram' cannot be set together")
+	}
+	for _, bucket := range job.HistogramBuckets {
+		if bucket <= 0 {
+			err = errors.New("JobValidationError: field 'histogram_buckets' values must be greater than 0")
+		}
+	}
+	return
+}
+
+func (job *Job) validateEvolutionRatio() (err error) {
+	if job.EvolutionRatio < 0 || job.EvolutionRatio > 1 {
+		err = errors.New("JobValidationError: field 'evolution_ratio' must be between 0 and 1")
+	}
+	return
+}
+
+func (job *Job) validateUpdate() (err error) {
+	for operator := range job.Update {
+		if !strings.HasPrefix(operator, "$") {
+			err = errors.New("JobValidationError: field 'update' keys must be update operators ex. $set, $inc, got: " + operator)
+		}
+	}
+	if len(job.ArrayFilters) > 0 && len(job.Update) == 0 {
+		err = errors.New("JobValidationError: field 'array_filters' requires 'update' to be set")
+	}
+	return
+}
+
+func (job *Job) validateReturnDocument() (err error) {
+	switch job.ReturnDocument {
+	case "", ReturnDocumentBefore, ReturnDocumentAfter:
+	default:
+		err = errors.New("JobValidationError: field 'return_document' must be one of: before, after")
+	}
+	return
+}
+
+func (job *Job) validateConsumerRatio() (err error) {
+	if job.ConsumerRatio < 0 || job.ConsumerRatio > 1 {
+		err = errors.New("JobValidationError: field 'consumer_ratio' must be between 0 and 1")
+	}
+	return
+}
+
+func (job *Job) validateHotDocuments() (err error) {
+	switch {
+	case job.HotDocuments == 0:
+	case job.Type == string(Counter) || job.Type == string(DocumentGrowth):
+	case job.HotFraction > 0 && (job.Type == string(Update) || job.Type == string(FindOneAndUpdate)):
+	default:
+		err = errors.New("JobValidationError: field 'hot_documents' is only applicable for 'counter' and 'document_growth' job types, or update jobs with 'hot_fraction'")
+	}
+	return
+}
+
+func (job *Job) validatePagination() (err error) {
+	switch job.Pagination {
+	case "", PaginationSkip, PaginationRange:
+	default:
+		err = errors.New("JobValidationError: field 'pagination' must be one of: skip, range")
+	}
+	return
+}
+
+func (job *Job) validateLookup() (err error) {
+	if job.Type != string(Lookup) {
+		return
+	}
+	if _, ok := job.Lookup["from"]; !ok {
+		err = errors.New("JobValidationError: field 'lookup' with 'from' is required for 'lookup' job type")
+	} else if _, ok := job.Lookup["as"]; !ok {
+		err = errors.New("JobValidationError: field 'lookup' with 'as' is required for 'lookup' job type")
+	}
+	return
+}
+
+func (job *Job) validateStopWhen() (err error) {
+	switch job.StopWhen {
+	case "", StopWhenAny:
+	case StopWhenAll:
+		if job.Duration == 0 || job.Operations == 0 {
+			err = errors.New("JobValidationError: field 'stop_when' all requires both 'duration' and 'operations'")
+		}
+	default:
+		err = errors.New("JobValidationError: field 'stop_when' must be one of: any, all")
+	}
+	return
+}
+
+func (job *Job) validatePaceInterval() (err error) {
+	if job.PaceInterval < 0 {
+		err = errors.New("JobValidationError: field 'pace_interval' must be greater than 0")
+	} else if job.PaceInterval > 0 && job.Pace != 0 {
+		err = errors.New("JobValidationError: fields 'pace' rps and 'pace_interval' cannot be set together")
+	} else if job.PaceInterval > 0 && job.Type == string(Sleep) {
+		err = errors.New("JobValidationError: field 'pace_interval' must be not set for job with 'sleep' type")
+	}
+	return
+}
+
+func (job *Job) validateReadPreference() (err error) {
+	switch job.ReadPreference {
+	case "", "secondaryPreferred", "secondary", "nearest", "primaryPreferred":
+	case "primary":
+		if len(job.ReadPreferenceTags) > 0 || job.HedgedReads {
+			err = errors.New("JobValidationError: fields 'read_preference_tags' and 'hedged_reads' cannot be used with 'primary' read preference")
+		}
+	default:
+		err = errors.New("JobValidationError: field 'read_preference' must be one of: primary, primaryPreferred, secondary, secondaryPreferred, nearest")
+	}
+	return
+}
+
+func (job *Job) validateBulk() (err error) {
+	switch job.BulkOrder {
+	case "", BulkOrderOrdered, BulkOrderUnordered:
+	default:
+		return errors.New("JobValidationError: field 'bulk_order' must be one of: ordered, unordered")
+	}
+	if len(job.BulkMix) == 0 {
+		return
+	}
+	if job.Type != string(BulkWrite) {
+		return errors.New("JobValidationError: field 'bulk_mix' can be set only for 'bulk_write' job type")
+	}
+	total := 0.0
+	for operation, weight := range job.BulkMix {
+		if operation != BulkOpInsert && operation != BulkOpUpdate && operation != BulkOpDelete {
+			return errors.New("JobValidationError: field 'bulk_mix' operation must be one of: insert, update, delete, got " + operation)
+		}
+		if weight < 0 {
+			return errors.New("JobValidationError: field 'bulk_mix' weights must be greater or equal 0")
+		}
+		total += weight
+	}
+	if total == 0 {
+		err = errors.New("JobValidationError: field 'bulk_mix' must have at least one positive weight")
+	}
+	return
+}
+
+func (job *Job) validateExpectedIndex() error {
+	if job.ExpectedIndex == "" {
+		return nil
+	}
+	if job.Type != string(Read) && job.Type != string(Paginate) {
+		return errors.New("JobValidationError: field 'expected_index' can be set only for 'read' and 'paginate' job types")
+	}
+	if len(job.Filter) == 0 {
+		return errors.New("JobValidationError: field 'expected_index' requires 'filter'")
+	}
+	return nil
+}
+
+func (job *Job) validateExplainInterval() error {
+	if job.ExplainInterval < 0 {
+		return errors.New("JobValidationError: field 'explain_interval' must be greater than 0")
+	}
+	if job.ExplainInterval > 0 && len(job.Filter) == 0 {
+		return errors.New("JobValidationError: field 'explain_interval' requires 'filter'")
+	}
+	return nil
+}
+
+func (job *Job) validateDisturbances() error {
+	if job.DisturbanceInterval < 0 {
+		return errors.New("JobValidationError: field 'disturbance_interval' must be greater than 0")
+	}
+	if job.DisturbanceInterval == 0 {
+		if len(job.Disturbances) > 0 {
+			return errors.New("JobValidationError: field 'disturbances' requires 'disturbance_interval'")
+		}
+		return nil
+	}
+	if job.Type == string(Sleep) {
+		return errors.New("JobValidationError: field 'disturbance_interval' must be not set for job with 'sleep' type")
+	}
+	for _, disturbance := range job.Disturbances {
+		if disturbance != DisturbanceCollMod && disturbance != DisturbanceIndex && disturbance != DisturbanceValidator {
+			return errors.New("JobValidationError: field 'disturbances' must contain only: collmod, index, validator, got " + disturbance)
+		}
+	}
+	return nil
+}
+
+func (job *Job) validateBackup() error {
+	if job.BackupAt < 0 || job.BackupDuration < 0 {
+		return errors.New("JobValidationError: fields 'backup_at' and 'backup_duration' must be greater than 0")
+	}
+	if job.BackupAt == 0 {
+		if job.BackupDuration > 0 || job.BackupCommand != "" {
+			return errors.New("JobValidationError: fields 'backup_duration' and 'backup_command' require 'backup_at'")
+		}
+		return nil
+	}
+	if job.Type == string(Sleep) {
+		return errors.New("JobValidationError: field 'backup_at' must be not set for job with 'sleep' type")
+	}
+	if job.BackupCommand != "" && job.BackupDuration > 0 {
+		return errors.New("JobValidationError: fields 'backup_duration' and 'backup_command' cannot be set together")
+	}
+	if job.BackupCommand == "" && job.BackupDuration == 0 {
+		return errors.New("JobValidationError: field 'backup_at' requires 'backup_duration' (fsync lock) or 'backup_command'")
+	}
+	return nil
+}
+
+func (job *Job) validateCacheMode() error {
+	switch job.CacheMode {
+	case "":
+		if job.CacheCommand != "" {
+			return errors.New("JobValidationError: field 'cache_command' requires 'cache_mode' cold")
+		}
+	case CacheModeWarm:
+		if job.CacheCommand != "" {
+			return errors.New("JobValidationError: field 'cache_command' can be set only for 'cache_mode' cold")
+		}
+	case CacheModeCold:
+		if job.CacheCommand == "" {
+			return errors.New("JobValidationError: 'cache_mode' cold requires 'cache_command' clearing cache, ex. restarting mongod")
+		}
+	default:
+		return errors.New("JobValidationError: field 'cache_mode' must be one of: warm, cold")
+	}
+	if job.CacheMode != "" && job.Type == string(Sleep) {
+		return errors.New("JobValidationError: field 'cache_mode' must be not set for job with 'sleep' type")
+	}
+	return nil
+}
+
+func (job *Job) validateCollections() error {
+	if len(job.Collections) == 0 {
+		return nil
+	}
+	if job.Type == string(Sleep) {
+		return errors.New("JobValidationError: field 'collections' must be not set for job with 'sleep' type")
+	}
+	seen := make(map[string]bool, len(job.Collections))
+	for _, collection := range job.Collections {
+		if collection == "" || seen[collection] {
+			return errors.New("JobValidationError: field 'collections' must contain unique, non empty collection names")
+		}
+		seen[collection] = true
+	}
+	return nil
+}
+
+func (job *Job) validateDocumentGrowth() error {
+	if job.Type == string(DocumentGrowth) {
+		return nil
+	}
+	if job.GrowField != "" {
+		return errors.New("JobValidationError: field 'grow_field' is only applicable for 'document_growth' job type")
+	}
+	if job.GrowMaxItems > 0 {
+		return errors.New("JobValidationError: field 'grow_max_items' is only applicable for 'document_growth' job type")
+	}
+	return nil
+}
+
+func (job *Job) validateNoise() error {
+	if job.PaceJitter < 0 || job.PaceJitter > 1 {
+		return errors.New("JobValidationError: field 'pace_jitter' must be between 0 and 1")
+	}
+	if job.NoiseCollections > 0 && job.Type != string(Noise) {
+		return errors.New("JobValidationError: field 'noise_collections' is only applicable for 'noise' job type")
+	}
+	if job.NoiseCollections > 0 && len(job.Collections) > 0 {
+		return errors.New("JobValidationError: fields 'noise_collections' and 'collections' are mutually exclusive")
+	}
+	// collection prefixes generated noise collection names
+	if job.Type == string(Noise) && len(job.Collections) == 0 && job.Collection == "" {
+		return errors.New("JobValidationError: field 'collection' is required for 'noise' job type without 'collections'")
+	}
+	return nil
+}
+
+func (job *Job) validateDelete() error {
+	if job.DeleteMany && job.Type != string(Delete) {
+		return errors.New("JobValidationError: field 'delete_many' is only applicable for 'delete' job type")
+	}
+	if job.Type == string(Delete) && len(job.Filter) == 0 {
+		return errors.New("JobValidationError: 'delete' job requires 'filter', empty filter would remove whole collection")
+	}
+	return nil
+}
+
+func (job *Job) validatePipeline() error {
+	switch job.Type {
+	case string(Aggregate):
+	case string(ChangeStream), string(Mixed):
+		// optional, change_stream filters events with it, mixed requires it for aggregate operation
+	default:
+		if len(job.Pipeline) > 0 {
+			return errors.New("JobValidationError: field 'pipeline' is only applicable for 'aggregate', 'change_stream' and 'mixed' job types")
+		}
+		return nil
+	}
+	if job.Type == string(Aggregate) && len(job.Pipeline) == 0 {
+		return errors.New("JobValidationError: field 'pipeline' is required for 'aggregate' job type")
+	}
+	for _, stage := range job.Pipeline {
+		if stage, ok := stage.(map[string]interface{}); !ok || len(stage) != 1 {
+			return errors.New("JobValidationError: every 'pipeline' stage must be object with single stage operator, ex. {\"$match\": {...}}")
+		}
+	}
+	return nil
+}
+
+func (job *Job) validatePauseWindows() error {
+	for _, window := range job.PauseWindows {
+		if _, err := ParsePauseWindow(window); err != nil {
+			return errors.New("JobValidationError: field 'pause_windows' invalid, " + err.Error())
+		}
+	}
+	return nil
+}
+
+func (job *Job) validateTransaction() error {
+	if job.Type != string(Transaction) {
+		if len(job.TransactionSteps) > 0 || job.ReadConcern != "" || job.WriteConcern != "" || job.TransactionRetries > 0 {
+			return errors.New("JobValidationError: fields 'transaction_steps', 'read_concern', 'write_concern' and 'transaction_retries' are only applicable for 'transaction' job type")
+		}
+		return nil
+	}
+	if len(job.TransactionSteps) == 0 {
+		return errors.New("JobValidationError: field 'transaction_steps' is required for 'transaction' job type")
+	}
+	for _, step := range job.TransactionSteps {
+		switch step {
+		case TransactionStepInsert, TransactionStepUpdate, TransactionStepRead:
+		default:
+			return errors.New("JobValidationError: field 'transaction_steps' values must be one of: insert, update, read, got: " + step)
+		}
+	}
+	switch job.ReadConcern {
+	case "", "local", "majority", "snapshot":
+	default:
+		return errors.New("JobValidationError: field 'read_concern' must be one of: local, majority, snapshot")
+	}
+	if job.WriteConcern != "" && job.WriteConcern != "majority" {
+		if _, err := strconv.ParseUint(job.WriteConcern, 10, 32); err != nil {
+			return errors.New("JobValidationError: field 'write_concern' must be 'majority' or number of nodes")
+		}
+	}
+	return nil
+}
+
+// ValidateOperationMix checks operations and weights of mixed job, mixed handler can't be built without it
+func (job *Job) ValidateOperationMix() error {
+	if len(job.OperationMix) > 0 && job.Type != string(Mixed) {
+		return errors.New("JobValidationError: field 'operation_mix' is only applicable for 'mixed' job type")
+	}
+	if job.Type != string(Mixed) {
+		return nil
+	}
+	if len(job.OperationMix) == 0 {
+		return errors.New("JobValidationError: field 'operation_mix' is required for 'mixed' job type")
+	}
+	for operation, weight := range job.OperationMix {
+		switch operation {
+		case string(Write), string(Read), string(Update), string(FindOneAndUpdate), string(FindOneAndDelete), string(FindOneAndReplace), string(Count):
+		case string(Delete):
+			if job.Filter == nil {
+				return errors.New("JobValidationError: field 'filter' is required for 'delete' operation of 'operation_mix'")
+			}
+		case string(Aggregate):
+			if len(job.Pipeline) == 0 {
+				return errors.New("JobValidationError: field 'pipeline' is required for 'aggregate' operation of 'operation_mix'")
+			}
+		case string(Distinct):
+			if job.DistinctField == "" {
+				return errors.New("JobValidationError: field 'distinct_field' is required for 'distinct' operation of 'operation_mix'")
+			}
+		default:
+			return errors.New("JobValidationError: 'operation_mix' operation must be one of write, read, update, delete, find_one_and_update, find_one_and_delete, find_one_and_replace, aggregate, count, distinct, got '" + operation + "'")
+		}
+		if weight <= 0 {
+			return errors.New("JobValidationError: 'operation_mix' weight of '" + operation + "' must be greater than 0")
+		}
+	}
+	return nil
+}
+
+func (job *Job) validateUpsert() error {
+	if job.Upsert && job.Type != string(FindOneAndUpdate) && job.Type != string(FindOneAndReplace) {
+		return errors.New("JobValidationError: field 'upsert' is only applicable for 'find_one_and_update' and 'find_one_and_replace' job types")
+	}
+	return nil
+}
+
+func (job *Job) validateIndexes() error {
+	if job.Type == string(CreateIndex) && len(job.Indexes) == 0 {
+		return errors.New("JobValidationError: field 'indexes' is required for 'create_index' job type")
+	}
+	if job.DropIndexes && len(job.Indexes) == 0 {
+		return errors.New("JobValidationError: field 'drop_indexes' requires 'indexes'")
+	}
+	for _, index := range job.Indexes {
+		if index.Name == "" {
+			return errors.New("JobValidationError: every index of 'indexes' requires 'name'")
+		}
+		if _, err := index.ParseKeys(); err != nil {
+			return errors.New("JobValidationError: " + err.Error())
+		}
+	}
+	return nil
+}
+
+func (job *Job) validateHotSpot() (err error) {
+	switch {
+	case job.HotFraction == 0 && (job.HotSkew != 0 || job.HotField != ""):
+		err = errors.New("JobValidationError: fields 'hot_skew' and 'hot_field' require 'hot_fraction'")
+	case job.HotFraction == 0:
+	case job.HotFraction < 0 || job.HotFraction > 1:
+		err = errors.New("JobValidationError: field 'hot_fraction' must be between 0 and 1")
+	case job.Type != string(Update) && job.Type != string(FindOneAndUpdate):
+		err = errors.New("JobValidationError: field 'hot_fraction' is only applicable for 'update' and 'find_one_and_update' job types")
+	case job.HotSkew < 0:
+		err = errors.New("JobValidationError: field 'hot_skew' must be positive")
+	case job.HotField == "":
+		err = errors.New("JobValidationError: field 'hot_field' is required with 'hot_fraction', hot documents are matched by its values from 0 to 'hot_documents' - 1")
+	}
+	return
+}
+
+func (job *Job) validateFullDocument() (err error) {
+	switch job.FullDocument {
+	case "":
+	case FullDocumentUpdateLookup, FullDocumentWhenAvailable, FullDocumentRequired:
+		if job.Type != string(ChangeStream) {
+			err = errors.New("JobValidationError: field 'full_document' is only applicable for 'change_stream' job type")
+		}
+	default:
+		err = errors.New("JobValidationError: field 'full_document' must be one of: updateLookup, whenAvailable, required")
+	}
+	return
+}
+
+func (job *Job) validateFileSize() (err error) {
+	switch {
+	case job.Type != string(GridFSUpload):
+		if job.FileSize > 0 || job.FileSizeMax > 0 {
+			err = errors.New("JobValidationError: fields 'file_size' and 'file_size_max' are only applicable for 'gridfs_upload' job type")
+		}
+	case job.FileSize == 0:
+		err = errors.New("JobValidationError: field 'file_size' is required for 'gridfs_upload' job type")
+	case job.FileSizeMax > 0 && job.FileSizeMax < job.FileSize:
+		err = errors.New("JobValidationError: field 'file_size_max' must be greater than or equal to 'file_size'")
+	}
+	return
+}
+
+func (job *Job) validateSlaBuckets() error {
+	for i, bucket := range job.SlaBuckets {
+		if bucket <= 0 {
+			return errors.New("JobValidationError: every value of 'sla_buckets' must be positive number of seconds")
+		}
+		if i > 0 && bucket <= job.SlaBuckets[i-1] {
+			return errors.New("JobValidationError: values of 'sla_buckets' must be increasing")
+		}
+	}
+	return nil
+}
+
+func (job *Job) validateDistinctField() (err error) {
+	switch {
+	case job.Type == string(Distinct) && job.DistinctField == "":
+		err = errors.New("JobValidationError: field 'distinct_field' is required for 'distinct' job type")
+	case job.DistinctField != "" && job.Type != string(Distinct) && job.Type != string(Mixed):
+		err = errors.New("JobValidationError: field 'distinct_field' is only applicable for 'distinct' and 'mixed' job types")
+	}
+	return
+}
+
+func (job *Job) validateMetricsExport() error {
+	if job.MetricsSample < 0 || job.MetricsSample > 1 {
+		return errors.New("JobValidationError: field 'metrics_sample' must be between 0 and 1")
+	}
+	if job.MetricsSample > 0 && len(job.Collections) == 0 {
+		return errors.New("JobValidationError: field 'metrics_sample' is only applicable for job with 'collections'")
+	}
+	if slices.Contains(job.MetricsInclude, "") || slices.Contains(job.MetricsExclude, "") {
+		return errors.New("JobValidationError: fields 'metrics_include' and 'metrics_exclude' must contain non empty metric name prefixes")
+	}
+	return nil
+}
+
+func (job *Job) validateSearch() error {
+	if job.Search != "" && job.Type != string(TextSearch) {
+		return errors.New("JobValidationError: field 'search' is only applicable for 'text_search' job type")
+	}
+	return nil
+}
+
+func (job *Job) validateGeo() error {
+	isGeo := job.Type == string(GeoNear) || job.Type == string(GeoWithin)
+	switch {
+	case isGeo && job.GeoField == "":
+		return errors.New("JobValidationError: field 'geo_field' is required for 'geo_near' and 'geo_within' job types")
+	case !isGeo && (job.GeoField != "" || job.GeoPoint != "" || job.GeoDistance != 0):
+		return errors.New("JobValidationError: fields 'geo_field', 'geo_point' and 'geo_distance' are only applicable for 'geo_near' and 'geo_within' job types")
+	case job.GeoDistance < 0:
+		return errors.New("JobValidationError: field 'geo_distance' must be positive number of meters")
+	case job.GeoPoint != "" && !strings.HasPrefix(job.GeoPoint, "#point"):
+		return errors.New("JobValidationError: field 'geo_point' must be #point generator, ex. #point(14.1,49.0,24.2,54.8)")
+	}
+	return nil
+}
+
+func (job *Job) validateCommand() (err error) {
+	switch {
+	case job.Type == string(Command) && job.Command == nil:
+		err = errors.New("JobValidationError: field 'command' is required for 'command' job type")
+	case job.Command != nil && job.Type != string(Command):
+		err = errors.New("JobValidationError: field 'command' is only applicable for 'command' job type")
+	}
+	return
+}
+
+func (job *Job) validateTarget() error {
+	if !IsTemplate(job.Database) && !IsTemplate(job.Collection) {
+		return nil
+	}
+	if len(job.Collections) > 0 {
+		return errors.New("JobValidationError: templated 'database' or 'collection' can't be combined with 'collections'")
+	}
+	targets := []struct{ field, target string }{{"database", job.Database}, {"collection", job.Collection}}
+	for _, target := range targets {
+		rendered, err := RenderTarget(target.target, TargetData{})
+		if err != nil {
+			return errors.New("JobValidationError: field '" + target.field + "' invalid template, " + err.Error())
+		}
+		if target.target != "" && rendered == "" {
+			return errors.New("JobValidationError: field '" + target.field + "' template renders empty name")
+		}
+	}
+	return nil
+}
+
+func (job *Job) validateFanout() error {
+	switch {
+	case job.FanoutCollections == 0 && job.FanoutKey != "":
+		return errors.New("JobValidationError: field 'fanout_key' requires 'fanout_collections'")
+	case job.FanoutCollections == 0:
+		return nil
+	case job.Type != string(Write):
+		return errors.New("JobValidationError: field 'fanout_collections' is only applicable for 'write' job type")
+	case len(job.Collections) > 0:
+		return errors.New("JobValidationError: fields 'fanout_collections' and 'collections' are mutually exclusive")
+	case IsTemplate(job.Database) || IsTemplate(job.Collection):
+		return errors.New("JobValidationError: field 'fanout_collections' can't be combined with templated 'database' or 'collection'")
+	}
+	return nil
+}
+
+func (job *Job) validateSteps() error {
+	return ValidateScenario(job.Type, job.Steps)
+}
+
+func (job *Job) validateClientRegions() error {
+	return ValidateClientRegions(job.ClientRegions, job.Connections)
+}
+
+func (job *Job) validateBurst() error {
+	if job.BurstDuration < 0 || job.BurstIdle < 0 {
+		return errors.New("JobValidationError: fields 'burst_duration' and 'burst_idle' must be greater than 0")
+	}
+	if (job.BurstDuration == 0) != (job.BurstIdle == 0) {
+		return errors.New("JobValidationError: fields 'burst_duration' and 'burst_idle' must be set together")
+	}
+	if job.BurstDuration > 0 && job.Type == string(Sleep) {
+		return errors.New("JobValidationError: field 'burst_duration' must be not set for job with 'sleep' type")
+	}
+	return nil
+}
+
+func (job *Job) validateRateProfile() error {
+	if len(job.RateProfile) == 0 {
+		return nil
+	}
+	if job.Type == string(Sleep) {
+		return errors.New("JobValidationError: field 'rate_profile' must be not set for job with 'sleep' type")
+	}
+	if job.PaceInterval > 0 || job.PaceJitter > 0 {
+		return errors.New("JobValidationError: field 'rate_profile' can't be combined with interval pace or 'pace_jitter'")
+	}
+	if _, err := ParseRateProfile(job.Pace, job.RateProfile); err != nil {
+		return errors.New("JobValidationError: field 'rate_profile' is invalid, " + err.Error())
+	}
+	return nil
+}
+
+func (job *Job) validateWarmup() error {
+	if job.Warmup < 0 {
+		return errors.New("JobValidationError: field 'warmup' must be greater than 0")
+	}
+	if job.Warmup > 0 && job.Type == string(Sleep) {
+		return errors.New("JobValidationError: field 'warmup' must be not set for job with 'sleep' type")
+	}
+	return nil
+}
+
 // todo: add schema validation
 // schema keys
 // save key should be in schema
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+// PauseWindow is daily UTC time range, ex. 02:00-02:30, window ending before its start crosses midnight
+type PauseWindow struct {
+	Start time.Duration // since midnight
+	End   time.Duration
+}
+
+func ParsePauseWindow(window string) (PauseWindow, error) {
+	start, end, found := strings.Cut(window, "-")
+	if !found {
+		return PauseWindow{}, fmt.Errorf("pause window %q must be in HH:MM-HH:MM format", window)
+	}
+	startTime, err := time.Parse("15:04", strings.TrimSpace(start))
+	if err != nil {
+		return PauseWindow{}, fmt.Errorf("pause window %q must be in HH:MM-HH:MM format", window)
+	}
+	endTime, err := time.Parse("15:04", strings.TrimSpace(end))
+	if err != nil {
+		return PauseWindow{}, fmt.Errorf("pause window %q must be in HH:MM-HH:MM format", window)
+	}
+	if startTime.Equal(endTime) {
+		return PauseWindow{}, fmt.Errorf("pause window %q is empty", window)
+	}
+	midnight := time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC)
+	return PauseWindow{Start: startTime.Sub(midnight), End: endTime.Sub(midnight)}, nil
+}
+
+// Contains checks if time of day of t (in UTC) is in window
+func (w PauseWindow) Contains(t time.Time) bool {
+	now := sinceMidnight(t)
+	if w.Start < w.End {
+		return now >= w.Start && now < w.End
+	}
+	return now >= w.Start || now < w.End
+}
+
+// NextChange returns closest time after t when window starts or ends
+func (w PauseWindow) NextChange(t time.Time) time.Time {
+	now := sinceMidnight(t)
+	next := min(untilTimeOfDay(now, w.Start), untilTimeOfDay(now, w.End))
+	return t.Add(next)
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	t = t.UTC()
+	return t.Sub(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC))
+}
+
+func untilTimeOfDay(now time.Duration, timeOfDay time.Duration) time.Duration {
+	if timeOfDay > now {
+		return timeOfDay - now
+	}
+	return timeOfDay - now + day
+}
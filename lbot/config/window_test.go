@@ -0,0 +1,34 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPauseWindow(t *testing.T) {
+	window, err := ParsePauseWindow("02:00-02:30")
+	assert.NoError(t, err)
+
+	at := func(hour, minute int) time.Time { return time.Date(2024, 5, 2, hour, minute, 0, 0, time.UTC) }
+	assert.False(t, window.Contains(at(1, 59)))
+	assert.True(t, window.Contains(at(2, 0)))
+	assert.True(t, window.Contains(at(2, 29)))
+	assert.False(t, window.Contains(at(2, 30)))
+	assert.Equal(t, at(2, 0), window.NextChange(at(1, 0)))
+	assert.Equal(t, at(2, 30), window.NextChange(at(2, 0)))
+	assert.Equal(t, at(2, 0).Add(24*time.Hour), window.NextChange(at(3, 0)))
+
+	overnight, err := ParsePauseWindow("23:30-00:15")
+	assert.NoError(t, err)
+	assert.True(t, overnight.Contains(at(23, 45)))
+	assert.True(t, overnight.Contains(at(0, 10)))
+	assert.False(t, overnight.Contains(at(12, 0)))
+	assert.Equal(t, at(0, 15), overnight.NextChange(at(0, 0)))
+
+	for _, invalid := range []string{"02:00", "2am-3am", "02:00-02:00", "25:00-26:00"} {
+		_, err = ParsePauseWindow(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
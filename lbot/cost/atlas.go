@@ -0,0 +1,95 @@
+package cost
+
+import (
+	"fmt"
+	"time"
+)
+
+// AtlasTier describes dedicated atlas cluster tier, prices are approximate on-demand
+// AWS us-east-1 prices of 3 node replica set and change over time, treat them as hint only
+type AtlasTier struct {
+	Name        string
+	VCPU        uint64
+	MemoryBytes uint64
+	HourlyCost  float64
+}
+
+const (
+	GB = 1 << 30
+
+	// rough sustained throughput of one vCPU for simple crud operations
+	OpsPerVCPU = 2500
+	// wiredtiger cache takes ~50% of memory, working set should fit into it
+	CacheRatio = 0.5
+	// hours in month used for monthly cost
+	HoursInMonth = 730
+)
+
+var AtlasTiers = []AtlasTier{
+	{Name: "M10", VCPU: 2, MemoryBytes: 2 * GB, HourlyCost: 0.08},
+	{Name: "M20", VCPU: 2, MemoryBytes: 4 * GB, HourlyCost: 0.20},
+	{Name: "M30", VCPU: 2, MemoryBytes: 8 * GB, HourlyCost: 0.54},
+	{Name: "M40", VCPU: 4, MemoryBytes: 16 * GB, HourlyCost: 1.04},
+	{Name: "M50", VCPU: 8, MemoryBytes: 32 * GB, HourlyCost: 2.00},
+	{Name: "M60", VCPU: 16, MemoryBytes: 64 * GB, HourlyCost: 3.95},
+	{Name: "M80", VCPU: 32, MemoryBytes: 128 * GB, HourlyCost: 7.30},
+}
+
+// Usage is measured workload footprint
+type Usage struct {
+	Operations   uint64
+	Duration     time.Duration
+	BytesWritten uint64
+}
+
+func (u Usage) OpsPerSecond() float64 {
+	if u.Duration <= 0 {
+		return 0
+	}
+	return float64(u.Operations) / u.Duration.Seconds()
+}
+
+type Estimate struct {
+	Usage Usage
+	Tier  *AtlasTier // nil if usage exceeds biggest tier
+}
+
+// RunCost is approximate cost of running measured workload on recommended tier
+func (e Estimate) RunCost() float64 {
+	if e.Tier == nil {
+		return 0
+	}
+	return e.Tier.HourlyCost * e.Usage.Duration.Hours()
+}
+
+func (e Estimate) MonthlyCost() float64 {
+	if e.Tier == nil {
+		return 0
+	}
+	return e.Tier.HourlyCost * HoursInMonth
+}
+
+func (e Estimate) String() string {
+	usage := fmt.Sprintf(
+		"%.0f ops/s, %.2f MB written in %s", e.Usage.OpsPerSecond(), float64(e.Usage.BytesWritten)/(1<<20), e.Usage.Duration.Round(time.Second),
+	)
+	if e.Tier == nil {
+		return usage + ", exceeds biggest general tier - consider sharding"
+	}
+	return fmt.Sprintf(
+		"%s, recommended atlas tier %s (~$%.2f/h, ~$%.0f/month, this run ~$%.2f)",
+		usage, e.Tier.Name, e.Tier.HourlyCost, e.MonthlyCost(), e.RunCost(),
+	)
+}
+
+// EstimateAtlasTier picks smallest tier which sustains measured throughput and keeps written data in cache
+func EstimateAtlasTier(usage Usage) Estimate {
+	for i := range AtlasTiers {
+		tier := &AtlasTiers[i]
+		if usage.OpsPerSecond() <= float64(tier.VCPU*OpsPerVCPU) &&
+			float64(usage.BytesWritten) <= float64(tier.MemoryBytes)*CacheRatio {
+			return Estimate{Usage: usage, Tier: tier}
+		}
+	}
+	return Estimate{Usage: usage}
+}
@@ -0,0 +1,33 @@
+package cost
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateSmallestTier(t *testing.T) {
+	estimate := EstimateAtlasTier(Usage{Operations: 1000, Duration: time.Minute, BytesWritten: 1 << 20})
+
+	assert.Equal(t, "M10", estimate.Tier.Name)
+}
+
+func TestEstimateTierByThroughput(t *testing.T) {
+	estimate := EstimateAtlasTier(Usage{Operations: 9000 * 60, Duration: time.Minute})
+
+	assert.Equal(t, "M40", estimate.Tier.Name)
+}
+
+func TestEstimateTierByDataSize(t *testing.T) {
+	estimate := EstimateAtlasTier(Usage{Operations: 10, Duration: time.Minute, BytesWritten: 6 * GB})
+
+	assert.Equal(t, "M40", estimate.Tier.Name)
+}
+
+func TestEstimateExceedsTiers(t *testing.T) {
+	estimate := EstimateAtlasTier(Usage{Operations: 1000000 * 60, Duration: time.Minute})
+
+	assert.Nil(t, estimate.Tier)
+	assert.Zero(t, estimate.RunCost())
+}
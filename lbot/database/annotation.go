@@ -0,0 +1,52 @@
+package database
+
+import (
+	"context"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Annotation is free-form note attached to run, ex. incident noticed during the run
+type Annotation struct {
+	Id        primitive.ObjectID `bson:"_id" json:"-"`
+	RunId     string             `bson:"run_id" json:"run_id"`
+	Tenant    string             `bson:"tenant,omitempty" json:"tenant,omitempty"`
+	Text      string             `bson:"text" json:"text"`
+	At        primitive.DateTime `bson:"at" json:"at"` // moment annotation refers to
+	CreatedAt primitive.DateTime `bson:"created_at" json:"created_at"`
+}
+
+func (c *MongoClient) AddAnnotation(ctx context.Context, annotation *Annotation) error {
+	_, err := c.client.Database(config.DB).Collection(config.AnnotationCollection).InsertOne(ctx, annotation)
+	return err
+}
+
+// GetAnnotations returns annotations of run ordered by time they refer to, all annotations if run id is empty
+func (c *MongoClient) GetAnnotations(ctx context.Context, runId string) ([]*Annotation, error) {
+	filter := bson.M{}
+	if runId != "" {
+		filter["run_id"] = runId
+	}
+	cursor, err := c.client.Database(config.DB).Collection(config.AnnotationCollection).
+		Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "at", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	annotations := make([]*Annotation, 0)
+	err = cursor.All(ctx, &annotations)
+	return annotations, err
+}
+
+// RunExists checks if any job of run was started, with tenant only its jobs are checked
+func (c *MongoClient) RunExists(ctx context.Context, runId string, tenant string) (bool, error) {
+	filter := bson.M{"data.runid": runId}
+	if tenant != "" {
+		filter["data.tenant"] = tenant
+	}
+	count, err := c.client.Database(config.DB).Collection(config.CommandCollection).
+		CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	return count > 0, err
+}
@@ -9,33 +9,79 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/tag"
 )
 
+const WriteConflictErrorCode = 112
+
+// TransientTransactionErrorLabel is set by server on transactions aborted by concurrent writes
+const TransientTransactionErrorLabel = "TransientTransactionError"
+
 type Client interface {
-	InsertOne(interface{}) (bool, error)
-	InsertMany([]interface{}) (bool, error)
-	ReadOne(interface{}) (bool, error)
-	ReadMany(interface{}) (bool, error)
-	UpdateOne(interface{}, interface{}) (bool, error)
-	DropCollection() error
+	InsertOne(context.Context, interface{}) (bool, error)
+	InsertMany(context.Context, []interface{}) (bool, error)
+	BulkWrite(context.Context, []mongo.WriteModel, ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error)
+	ReadOne(context.Context, interface{}) (bool, error)
+	ReadMany(context.Context, interface{}) (bool, error)
+	Find(context.Context, interface{}, ...*options.FindOptions) ([]bson.M, error)
+	Aggregate(context.Context, interface{}, ...*options.AggregateOptions) ([]bson.M, error)
+	CountDocuments(context.Context, interface{}) (int64, error)
+	Distinct(context.Context, string, interface{}) ([]interface{}, error)
+	RunCommand(context.Context, interface{}) (bson.M, error)
+	Watch(context.Context, interface{}, ...*options.ChangeStreamOptions) (*mongo.ChangeStream, error)
+	GridFSBucket() (*gridfs.Bucket, error)
+	UpdateOne(context.Context, interface{}, interface{}, ...*options.UpdateOptions) (bool, error)
+	FindOneAndUpdate(context.Context, interface{}, interface{}, ...*options.FindOneAndUpdateOptions) (bson.M, error)
+	FindOneAndDelete(context.Context, interface{}, ...*options.FindOneAndDeleteOptions) (bson.M, error)
+	FindOneAndReplace(context.Context, interface{}, interface{}, ...*options.FindOneAndReplaceOptions) (bson.M, error)
+	DeleteOne(context.Context, interface{}) (int64, error)
+	DeleteMany(context.Context, interface{}) (int64, error)
+	DropCollection(context.Context) error
+	Explain(context.Context, interface{}) (*QueryPlan, error)
+	CollMod(context.Context, bson.D) error
+	CollectionOptions(context.Context) (bson.M, error)
+	CreateIndex(context.Context, interface{}, string) error
+	CreateIndexes(context.Context, []*config.Index) error
+	DropIndex(context.Context, string) error
+	FsyncLock(context.Context) error
+	FsyncUnlock(context.Context) error
+	Topology(context.Context) (*Topology, error)
+	Touch(context.Context) (int64, error)
+	AverageDocumentSize(context.Context) (float64, error)
+	Transaction(context.Context, *options.TransactionOptions, int, func(context.Context) error) (TransactionResult, error)
+	Leaks() Leaks
 	Disconnect() error
 }
 
 type MongoClient struct {
-	ctx        context.Context
-	client     *mongo.Client
-	collection *mongo.Collection
+	ctx         context.Context
+	client      *mongo.Client
+	collection  *mongo.Collection
+	leakTracker *LeakTracker
 }
 
 func NewMongoClient(connectionString string, cfg *config.Job, schema *config.Schema) (*MongoClient, error) {
+	// templated targets are rendered for first connection, other connections use WithNamespace
+	database, collection, err := cfg.Target(schema, 0)
+	if err != nil {
+		return nil, err
+	}
 	opts := &options.ClientOptions{
 		HTTPClient: HTTPClient(cfg),
 	}
+	readPreference, err := ReadPreference(cfg)
+	if err != nil {
+		return nil, err
+	}
+	leakTracker := NewLeakTracker()
 	opts = opts.
 		ApplyURI(connectionString).
-		SetReadPreference(readpref.SecondaryPreferred()).
+		SetMonitor(leakTracker.CommandMonitor()).
+		SetDialer(&countingDialer{}).
+		SetReadPreference(readPreference).
 		SetMaxPoolSize(cfg.Connections * 2).
 		// SetMaxConnecting(100).
 		SetMaxConnIdleTime(90 * time.Second).
@@ -56,13 +102,32 @@ func NewMongoClient(connectionString string, cfg *config.Job, schema *config.Sch
 		// log.Info("Successfully connected to database server")
 	}
 
-	var collection *mongo.Collection
-	if schema != nil {
-		collection = client.Database(schema.Database).Collection(schema.Collection)
-	} else {
-		collection = client.Database(cfg.Database).Collection(cfg.Collection)
+	return &MongoClient{ctx: ctx, client: client, collection: client.Database(database).Collection(collection), leakTracker: leakTracker}, err
+}
+
+// ReadPreference builds job read preference, secondaryPreferred if not set
+func ReadPreference(cfg *config.Job) (*readpref.ReadPref, error) {
+	mode := readpref.SecondaryPreferredMode
+	if cfg.ReadPreference != "" {
+		var err error
+		mode, err = readpref.ModeFromString(cfg.ReadPreference)
+		if err != nil {
+			return nil, err
+		}
 	}
-	return &MongoClient{ctx: ctx, client: client, collection: collection}, err
+
+	var opts []readpref.Option
+	if len(cfg.ReadPreferenceTags) > 0 {
+		tagSets := make([]tag.Set, len(cfg.ReadPreferenceTags))
+		for i, tags := range cfg.ReadPreferenceTags {
+			tagSets[i] = tag.NewTagSetFromMap(tags)
+		}
+		opts = append(opts, readpref.WithTagSets(tagSets...))
+	}
+	if cfg.HedgedReads {
+		opts = append(opts, readpref.WithHedgeEnabled(true))
+	}
+	return readpref.New(mode, opts...)
 }
 
 func NewInternalMongoClient(connectionString string) (*MongoClient, error) {
@@ -74,6 +139,9 @@ func NewInternalMongoClient(connectionString string) (*MongoClient, error) {
 		SetReadPreference(readpref.SecondaryPreferred()).
 		SetMaxPoolSize(10).
 		SetMaxConnIdleTime(90 * time.Second)
+	if proxyDialer != nil {
+		opts.SetDialer(proxyDialer)
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -87,6 +155,29 @@ func NewInternalMongoClient(connectionString string) (*MongoClient, error) {
 	return &MongoClient{ctx: ctx, client: client, collection: nil}, err
 }
 
+// WithCollection returns client operating on other collection of the same database, sharing connection pool,
+// only one of clients should be disconnected
+func (c *MongoClient) WithCollection(name string) *MongoClient {
+	clone := *c
+	clone.collection = c.collection.Database().Collection(name)
+	return &clone
+}
+
+// WithNamespace returns client operating on other database and collection, sharing connection pool,
+// only one of clients should be disconnected
+func (c *MongoClient) WithNamespace(database string, collection string) *MongoClient {
+	clone := *c
+	clone.collection = c.client.Database(database).Collection(collection)
+	return &clone
+}
+
+// Leaks returns cursors and sessions not closed by handlers, have to be called before disconnect
+func (c *MongoClient) Leaks() Leaks {
+	leaks := c.leakTracker.Leaks()
+	leaks.SessionsInProgress = c.client.NumberSessionsInProgress()
+	return leaks
+}
+
 func (c *MongoClient) Disconnect() (err error) {
 	err = c.client.Disconnect(c.ctx)
 	if err != nil {
@@ -97,19 +188,23 @@ func (c *MongoClient) Disconnect() (err error) {
 	return
 }
 
-func (c *MongoClient) InsertOne(data interface{}) (bool, error) {
-	_, err := c.collection.InsertOne(context.TODO(), data)
+func (c *MongoClient) InsertOne(ctx context.Context, data interface{}) (bool, error) {
+	_, err := c.collection.InsertOne(ctx, data)
 	return bool(err == nil), err
 }
 
-func (c *MongoClient) InsertMany(data []interface{}) (bool, error) {
-	_, err := c.collection.InsertMany(context.TODO(), data)
+func (c *MongoClient) InsertMany(ctx context.Context, data []interface{}) (bool, error) {
+	_, err := c.collection.InsertMany(ctx, data)
 	return bool(err == nil), err
 }
 
-func (c *MongoClient) ReadOne(filter interface{}) (bool, error) {
+func (c *MongoClient) BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+	return c.collection.BulkWrite(ctx, models, opts...)
+}
+
+func (c *MongoClient) ReadOne(ctx context.Context, filter interface{}) (bool, error) {
 	var result bson.M
-	err := c.collection.FindOne(context.TODO(), filter).Decode(&result)
+	err := c.collection.FindOne(ctx, filter).Decode(&result)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return true, err
@@ -119,18 +214,18 @@ func (c *MongoClient) ReadOne(filter interface{}) (bool, error) {
 	return true, nil
 }
 
-func (c *MongoClient) ReadMany(filter interface{}) (bool, error) {
+func (c *MongoClient) ReadMany(ctx context.Context, filter interface{}) (bool, error) {
 	batch_size := int32(1000)
 
-	cursor, err := c.collection.Find(context.TODO(), bson.M{"author": "Franz Kafkaaa"}, &options.FindOptions{BatchSize: &batch_size})
+	cursor, err := c.collection.Find(ctx, bson.M{"author": "Franz Kafkaaa"}, &options.FindOptions{BatchSize: &batch_size})
 	if err != nil {
 		// log.Error(err)
 	}
 
-	defer cursor.Close(context.TODO())
+	defer cursor.Close(ctx)
 
 	totalFound := 0
-	for cursor.Next(context.Background()) {
+	for cursor.Next(ctx) {
 		var data bson.M
 
 		if err = cursor.Decode(&data); err != nil {
@@ -142,9 +237,9 @@ func (c *MongoClient) ReadMany(filter interface{}) (bool, error) {
 	return true, nil
 }
 
-func (c *MongoClient) UpdateOne(filter interface{}, data interface{}) (bool, error) {
+func (c *MongoClient) UpdateOne(ctx context.Context, filter interface{}, data interface{}, opts ...*options.UpdateOptions) (bool, error) {
 	// todo: only for now
-	_, err := c.collection.UpdateOne(context.TODO(), filter, data)
+	_, err := c.collection.UpdateOne(ctx, filter, data, opts...)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return true, err
@@ -154,12 +249,104 @@ func (c *MongoClient) UpdateOne(filter interface{}, data interface{}) (bool, err
 	return true, nil
 }
 
-func (c *MongoClient) DropCollection() error {
-	return c.collection.Drop(context.TODO())
+func (c *MongoClient) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) ([]bson.M, error) {
+	cursor, err := c.collection.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []bson.M
+	err = cursor.All(ctx, &result)
+	return result, err
+}
+
+func (c *MongoClient) Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) ([]bson.M, error) {
+	cursor, err := c.collection.Aggregate(ctx, pipeline, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []bson.M
+	err = cursor.All(ctx, &result)
+	return result, err
+}
+
+// GridFSBucket returns GridFS bucket named as job collection (files and chunks are stored in
+// <collection>.files and <collection>.chunks), bucket should be reused, its first write checks indexes
+func (c *MongoClient) GridFSBucket() (*gridfs.Bucket, error) {
+	return gridfs.NewBucket(c.collection.Database(), options.GridFSBucket().SetName(c.collection.Name()))
+}
+
+// Watch opens change stream on job collection, caller has to close it
+func (c *MongoClient) Watch(ctx context.Context, pipeline interface{}, opts ...*options.ChangeStreamOptions) (*mongo.ChangeStream, error) {
+	return c.collection.Watch(ctx, pipeline, opts...)
+}
+
+func (c *MongoClient) CountDocuments(ctx context.Context, filter interface{}) (int64, error) {
+	return c.collection.CountDocuments(ctx, filter)
+}
+
+func (c *MongoClient) Distinct(ctx context.Context, field string, filter interface{}) ([]interface{}, error) {
+	return c.collection.Distinct(ctx, field, filter)
+}
+
+// RunCommand runs command on database of job collection
+func (c *MongoClient) RunCommand(ctx context.Context, command interface{}) (bson.M, error) {
+	var result bson.M
+	err := c.collection.Database().RunCommand(ctx, command).Decode(&result)
+	return result, err
+}
+
+func (c *MongoClient) FindOneAndUpdate(ctx context.Context, filter interface{}, data interface{}, opts ...*options.FindOneAndUpdateOptions) (bson.M, error) {
+	var result bson.M
+	err := c.collection.FindOneAndUpdate(ctx, filter, data, opts...).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *MongoClient) FindOneAndDelete(ctx context.Context, filter interface{}, opts ...*options.FindOneAndDeleteOptions) (bson.M, error) {
+	var result bson.M
+	err := c.collection.FindOneAndDelete(ctx, filter, opts...).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *MongoClient) FindOneAndReplace(ctx context.Context, filter interface{}, replacement interface{}, opts ...*options.FindOneAndReplaceOptions) (bson.M, error) {
+	var result bson.M
+	err := c.collection.FindOneAndReplace(ctx, filter, replacement, opts...).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteOne removes first document matching filter, returns number of deleted documents
+func (c *MongoClient) DeleteOne(ctx context.Context, filter interface{}) (int64, error) {
+	result, err := c.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+func (c *MongoClient) DeleteMany(ctx context.Context, filter interface{}) (int64, error) {
+	result, err := c.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+func (c *MongoClient) DropCollection(ctx context.Context) error {
+	return c.collection.Drop(ctx)
 }
 
 func (c *MongoClient) ClusterTime() (*primitive.DateTime, error) {
-	res := c.client.Database(config.DB).RunCommand(context.TODO(), bson.D{{"isMaster", 1}})
+	res := c.client.Database(config.DB).RunCommand(context.TODO(), bson.D{{Key: "isMaster", Value: 1}})
 
 	if err := res.Err(); err != nil {
 		return nil, errors.WithMessage(err, "cmd: isMaster")
@@ -172,32 +359,36 @@ func (c *MongoClient) ClusterTime() (*primitive.DateTime, error) {
 }
 
 // workload
-func (c *MongoClient) RunJob(job config.Job) error {
+// RunJob creates command of job, started after commands it depends on, failure of dependency fails command
+// unless sequential is set
+func (c *MongoClient) RunJob(job config.Job, dependsOn []primitive.ObjectID, sequential bool) (primitive.ObjectID, error) {
 	// add lock??
 	ct, err := c.ClusterTime()
 	if err != nil {
-		return errors.Wrap(err, "get cluster time")
+		return primitive.NilObjectID, errors.Wrap(err, "get cluster time")
 	}
 
 	// drop circular reference
 	// job.Parent = nil
 
 	cmd := Command{
-		Id:        primitive.NewObjectID(),
-		Data:      job,
-		Type:      CommandTypeStartWorkload.String(),
-		State:     CommandStateCreated.String(),
-		CreatedAt: *ct,
+		Id:         primitive.NewObjectID(),
+		Data:       job,
+		Type:       CommandTypeStartWorkload.String(),
+		State:      CommandStateCreated.String(),
+		CreatedAt:  *ct,
+		DependsOn:  dependsOn,
+		Sequential: sequential,
 	}
 
 	_, err = c.client.Database(config.DB).Collection(config.CommandCollection).
 		InsertOne(context.TODO(), cmd)
 
 	if err != nil {
-		return err
+		return primitive.NilObjectID, err
 	}
 
-	return nil
+	return cmd.Id, nil
 }
 
 func (c *MongoClient) SaveWorkload(workload *Workload) error {
@@ -239,27 +430,51 @@ func (c *MongoClient) SaveCommand(command *Command) error {
 	return nil
 }
 
-func (c *MongoClient) GetNextUnFinishedCommand() (*Command, error) {
-	var cmd Command
-	err := c.client.Database(config.DB).Collection(config.CommandCollection).
-		FindOne(
-			context.TODO(),
-			bson.M{"state": bson.M{"$nin": bson.A{CommandStateDone.String(), CommandStateError.String()}}},
-			&options.FindOneOptions{Sort: bson.M{"created_at": -1}},
-		).Decode(&cmd)
+// GetUnfinishedCommands returns commands not yet done or failed, oldest first
+func (c *MongoClient) GetUnfinishedCommands() ([]*Command, error) {
+	cursor, err := c.client.Database(config.DB).Collection(config.CommandCollection).Find(
+		context.TODO(),
+		bson.M{"state": bson.M{"$nin": bson.A{CommandStateDone.String(), CommandStateError.String()}}},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}),
+	)
 	if err != nil {
 		return nil, err
 	}
+	commands := make([]*Command, 0)
+	err = cursor.All(context.TODO(), &commands)
+	return commands, err
+}
 
-	return &cmd, nil
+// GetCommandStates returns states of commands from ids, missing commands are skipped
+func (c *MongoClient) GetCommandStates(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]string, error) {
+	cursor, err := c.client.Database(config.DB).Collection(config.CommandCollection).Find(
+		ctx, bson.M{"_id": bson.M{"$in": ids}}, options.Find().SetProjection(bson.M{"state": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	commands := make([]*Command, 0)
+	if err = cursor.All(ctx, &commands); err != nil {
+		return nil, err
+	}
+	states := make(map[primitive.ObjectID]string, len(commands))
+	for _, command := range commands {
+		states[command.Id] = command.State
+	}
+	return states, nil
 }
 
 // todo: temp change to stream for commands
-func (c *MongoClient) GetNewWorkloads() (*Workload, error) {
+// GetNewWorkloads returns workload waiting for agent, skipping workloads of excluded commands
+func (c *MongoClient) GetNewWorkloads(excludedCommands []primitive.ObjectID) (*Workload, error) {
 	// add lock??
 	var cmd Workload
+	filter := bson.M{"state": WorkloadStateCreated.String()}
+	if len(excludedCommands) > 0 {
+		filter["command_id"] = bson.M{"$nin": excludedCommands}
+	}
 	err := c.client.Database(config.DB).Collection(config.WorkloadCollection).
-		FindOne(context.TODO(), bson.M{"state": WorkloadStateCreated.String()}).
+		FindOne(context.TODO(), filter, options.FindOne().SetSort(bson.M{"created_at": 1})).
 		Decode(&cmd)
 	if err != nil {
 		return nil, err
@@ -291,6 +506,38 @@ func (c *MongoClient) GetCommandWorkloads(command *Command) ([]*Workload, error)
 	return workloads, nil
 }
 
+// CountUnfinishedCommands counts commands from ids not yet done or failed
+func (c *MongoClient) CountUnfinishedCommands(ctx context.Context, ids []primitive.ObjectID) (int64, error) {
+	return c.client.Database(config.DB).Collection(config.CommandCollection).CountDocuments(ctx, bson.M{
+		"_id":   bson.M{"$in": ids},
+		"state": bson.M{"$nin": bson.A{CommandStateDone.String(), CommandStateError.String()}},
+	})
+}
+
+// GetCommands returns all recorded commands with their workloads, oldest first
+func (c *MongoClient) GetCommands(ctx context.Context) ([]*Command, []*Workload, error) {
+	opts := options.Find().SetSort(bson.M{"created_at": 1})
+	commands := make([]*Command, 0)
+	cursor, err := c.client.Database(config.DB).Collection(config.CommandCollection).Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = cursor.All(ctx, &commands); err != nil {
+		return nil, nil, err
+	}
+
+	workloads := make([]*Workload, 0)
+	cursor, err = c.client.Database(config.DB).Collection(config.WorkloadCollection).Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = cursor.All(ctx, &workloads); err != nil {
+		return nil, nil, err
+	}
+
+	return commands, workloads, nil
+}
+
 func (c *MongoClient) CancelCommand() error {
 	return nil
 }
@@ -358,14 +605,44 @@ func (c *MongoClient) SaveAgentStatus(stat AgentStatus) error {
 	}
 	stat.Heartbeat = *ct
 
-	_, err = c.client.Database(config.DB).Collection(config.AgentStatusCollection).
-		UpdateOne(context.TODO(), bson.M{"name": stat.Name, "_id": stat.Id}, bson.M{"$set": stat})
+	// only heartbeat fields, created_at is kept for master election
+	_, err = c.client.Database(config.DB).Collection(config.AgentStatusCollection).UpdateOne(
+		context.TODO(),
+		bson.M{"name": stat.Name, "_id": stat.Id},
+		bson.M{"$set": bson.M{
+			"heartbeat": stat.Heartbeat, "host": stat.Host, "running_workers": stat.RunningWorkers,
+			"version": stat.Version, "jobs": stat.Jobs, "cluster": stat.Cluster,
+		}},
+	)
 	return errors.Wrap(err, "write into db")
 }
 
+// GetAgentStatuses returns all registered agents sorted by name
+func (c *MongoClient) GetAgentStatuses(ctx context.Context) ([]*AgentStatus, error) {
+	cursor, err := c.client.Database(config.DB).Collection(config.AgentStatusCollection).
+		Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"name": 1}))
+	if err != nil {
+		return nil, err
+	}
+	agents := make([]*AgentStatus, 0)
+	err = cursor.All(ctx, &agents)
+	return agents, err
+}
+
 func (c *MongoClient) RemoveAgentStatus(stat AgentStatus) error {
 	_, err := c.client.Database(config.DB).Collection(config.AgentStatusCollection).DeleteOne(
 		context.TODO(), bson.M{"name": stat.Name},
 	)
 	return errors.WithMessage(err, "query")
 }
+
+// IsWriteConflict reports whether operation failed because of concurrent write to the same document,
+// either directly or by aborting transaction (network errors carry transient label too, those are skipped)
+func IsWriteConflict(err error) bool {
+	var serverError mongo.ServerError
+	if !errors.As(err, &serverError) {
+		return false
+	}
+	return serverError.HasErrorCode(WriteConflictErrorCode) ||
+		serverError.HasErrorLabel(TransientTransactionErrorLabel) && !mongo.IsNetworkError(err)
+}
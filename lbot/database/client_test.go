@@ -0,0 +1,27 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestIsWriteConflict(t *testing.T) {
+	commandError := mongo.CommandError{Code: WriteConflictErrorCode, Name: "WriteConflict", Message: "Write conflict during plan execution"}
+	writeException := mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: WriteConflictErrorCode, Message: "WriteConflict"}}}
+	transactionError := mongo.CommandError{Code: 251, Name: "NoSuchTransaction", Labels: []string{TransientTransactionErrorLabel}}
+
+	assert.True(t, IsWriteConflict(commandError))
+	assert.True(t, IsWriteConflict(writeException))
+	assert.True(t, IsWriteConflict(transactionError))
+	assert.True(t, IsWriteConflict(errors.WithMessage(commandError, "update")))
+
+	networkError := mongo.CommandError{Labels: []string{"NetworkError", TransientTransactionErrorLabel}}
+	assert.False(t, IsWriteConflict(networkError))
+	assert.False(t, IsWriteConflict(mongo.CommandError{Code: 11000, Name: "DuplicateKey"}))
+	assert.False(t, IsWriteConflict(context.DeadlineExceeded))
+	assert.False(t, IsWriteConflict(nil))
+}
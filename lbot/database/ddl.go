@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CollMod runs collMod command on job collection with given options, no options only takes collection lock
+func (c *MongoClient) CollMod(ctx context.Context, opts bson.D) error {
+	command := append(bson.D{{Key: "collMod", Value: c.collection.Name()}}, opts...)
+	err := c.collection.Database().RunCommand(ctx, command).Err()
+	return errors.WithMessage(err, "cmd: collMod")
+}
+
+// CollectionOptions returns options of job collection (ex. validator), nil when collection does not exist
+func (c *MongoClient) CollectionOptions(ctx context.Context) (bson.M, error) {
+	specifications, err := c.collection.Database().ListCollectionSpecifications(ctx, bson.M{"name": c.collection.Name()})
+	if err != nil || len(specifications) == 0 {
+		return nil, err
+	}
+	var result bson.M
+	err = bson.Unmarshal(specifications[0].Options, &result)
+	return result, err
+}
+
+func (c *MongoClient) CreateIndex(ctx context.Context, keys interface{}, name string) error {
+	_, err := c.collection.Indexes().CreateOne(ctx, mongo.IndexModel{Keys: keys, Options: options.Index().SetName(name)})
+	return err
+}
+
+// CreateIndexes builds indexes on job collection in single createIndexes command, existing indexes with the same
+// specification are kept untouched
+func (c *MongoClient) CreateIndexes(ctx context.Context, indexes []*config.Index) error {
+	models := make([]mongo.IndexModel, len(indexes))
+	for i, index := range indexes {
+		keys, err := index.ParseKeys()
+		if err != nil {
+			return err
+		}
+		keyPattern := make(bson.D, len(keys))
+		for k, key := range keys {
+			keyPattern[k] = bson.E{Key: key.Field, Value: key.Kind}
+		}
+		opts := options.Index().SetName(index.Name)
+		if index.Unique {
+			opts.SetUnique(true)
+		}
+		if index.Sparse {
+			opts.SetSparse(true)
+		}
+		if index.Background {
+			opts.SetBackground(true)
+		}
+		if index.ExpireAfterSeconds > 0 {
+			opts.SetExpireAfterSeconds(int32(index.ExpireAfterSeconds))
+		}
+		models[i] = mongo.IndexModel{Keys: keyPattern, Options: opts}
+	}
+	_, err := c.collection.Indexes().CreateMany(ctx, models)
+	return err
+}
+
+func (c *MongoClient) DropIndex(ctx context.Context, name string) error {
+	_, err := c.collection.Indexes().DropOne(ctx, name)
+	return err
+}
+
+// FsyncLock flushes writes to disk and blocks writes on connected node until FsyncUnlock, as during backup
+func (c *MongoClient) FsyncLock(ctx context.Context) error {
+	err := c.client.Database(config.DB).RunCommand(ctx, bson.D{{Key: "fsync", Value: 1}, {Key: "lock", Value: true}}).Err()
+	return errors.WithMessage(err, "cmd: fsync")
+}
+
+func (c *MongoClient) FsyncUnlock(ctx context.Context) error {
+	err := c.client.Database(config.DB).RunCommand(ctx, bson.D{{Key: "fsyncUnlock", Value: 1}}).Err()
+	return errors.WithMessage(err, "cmd: fsyncUnlock")
+}
+
+// Touch reads every document of job collection, loading it to storage engine cache, returns number of documents
+func (c *MongoClient) Touch(ctx context.Context) (int64, error) {
+	cursor, err := c.collection.Aggregate(ctx, bson.A{
+		// $bsonSize forces fetching whole document, counting alone could be answered from index
+		bson.M{"$group": bson.M{"_id": nil, "documents": bson.M{"$sum": 1}, "bytes": bson.M{"$sum": bson.M{"$bsonSize": "$$ROOT"}}}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	var result []bson.M
+	if err = cursor.All(ctx, &result); err != nil || len(result) == 0 {
+		return 0, err
+	}
+	return toInt64(result[0]["documents"]), nil
+}
+
+const IndexNotFoundErrorCode = 27
+
+// IsIndexNotFound checks if index could not be dropped because it doesn't exist
+func IsIndexNotFound(err error) bool {
+	var serverError mongo.ServerError
+	return errors.As(err, &serverError) && serverError.HasErrorCode(IndexNotFoundErrorCode)
+}
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+)
+
+// bytes written to database connections of all job clients, used by agent network budget
+var sentBytes uint64
+
+// SentBytes returns number of bytes sent by job clients since agent start
+func SentBytes() uint64 {
+	return atomic.LoadUint64(&sentBytes)
+}
+
+// countingDialer dials database connections counting written bytes, TLS overhead included,
+// through agent proxy when set
+type countingDialer struct {
+	net.Dialer
+}
+
+func (d *countingDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	var dialer ContextDialer = &d.Dialer
+	if proxyDialer != nil {
+		dialer = proxyDialer
+	}
+	conn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	return &countingConn{Conn: conn}, nil
+}
+
+type countingConn struct {
+	net.Conn
+}
+
+func (c *countingConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	atomic.AddUint64(&sentBytes, uint64(n))
+	return n, err
+}
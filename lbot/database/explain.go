@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	PlanStageCollectionScan = "COLLSCAN"
+	PlanStageIndexScan      = "IXSCAN"
+)
+
+// QueryPlan is summary of explained find, for sharded clusters and plans with multiple index scans
+// (ex. $or) Indexes contains every index used by winning plans
+type QueryPlan struct {
+	Stages       []string
+	Indexes      []string
+	KeysExamined int64
+	DocsExamined int64
+	Returned     int64
+}
+
+func (p *QueryPlan) IsCollectionScan() bool {
+	for _, stage := range p.Stages {
+		if stage == PlanStageCollectionScan {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesIndex reports whether winning plan uses only given index, collection scan on any shard fails the check
+func (p *QueryPlan) UsesIndex(name string) bool {
+	if p.IsCollectionScan() || len(p.Indexes) == 0 {
+		return false
+	}
+	for _, index := range p.Indexes {
+		if index != name {
+			return false
+		}
+	}
+	return true
+}
+
+// Explain runs find with filter through explain command with executionStats verbosity
+func (c *MongoClient) Explain(ctx context.Context, filter interface{}) (*QueryPlan, error) {
+	command := bson.D{
+		{Key: "explain", Value: bson.D{{Key: "find", Value: c.collection.Name()}, {Key: "filter", Value: filter}}},
+		{Key: "verbosity", Value: "executionStats"},
+	}
+	var result bson.M
+	if err := c.collection.Database().RunCommand(ctx, command).Decode(&result); err != nil {
+		return nil, errors.WithMessage(err, "cmd: explain")
+	}
+	return NewQueryPlan(result), nil
+}
+
+// NewQueryPlan extracts stages and indexes of winning plans from explain output
+func NewQueryPlan(explain bson.M) *QueryPlan {
+	plan := &QueryPlan{}
+	if planner, ok := explain["queryPlanner"].(bson.M); ok {
+		plan.walk(planner["winningPlan"])
+	}
+	if stats, ok := explain["executionStats"].(bson.M); ok {
+		plan.KeysExamined = toInt64(stats["totalKeysExamined"])
+		plan.DocsExamined = toInt64(stats["totalDocsExamined"])
+		plan.Returned = toInt64(stats["nReturned"])
+	}
+	return plan
+}
+
+// walk visits nested stages, ex. inputStage, inputStages, shards[].winningPlan and queryPlan of SBE plans
+func (p *QueryPlan) walk(node interface{}) {
+	switch n := node.(type) {
+	case bson.M:
+		if stage, ok := n["stage"].(string); ok {
+			p.Stages = append(p.Stages, stage)
+			if index, ok := n["indexName"].(string); ok && stage == PlanStageIndexScan {
+				p.Indexes = append(p.Indexes, index)
+			}
+		}
+		for key, value := range n {
+			// rejected plans were not executed
+			if key != "rejectedPlans" {
+				p.walk(value)
+			}
+		}
+	case bson.A:
+		for _, item := range n {
+			p.walk(item)
+		}
+	}
+}
+
+func toInt64(value interface{}) int64 {
+	switch v := value.(type) {
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	}
+	return 0
+}
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestQueryPlanUsesIndex(t *testing.T) {
+	indexScan := func(index string) bson.M {
+		return bson.M{"stage": "FETCH", "inputStage": bson.M{"stage": PlanStageIndexScan, "indexName": index}}
+	}
+	explain := func(winningPlan bson.M) bson.M {
+		return bson.M{
+			"queryPlanner":   bson.M{"winningPlan": winningPlan, "rejectedPlans": bson.A{indexScan("other_1")}},
+			"executionStats": bson.M{"totalKeysExamined": int32(3), "totalDocsExamined": int32(3), "nReturned": int32(2)},
+		}
+	}
+
+	plan := NewQueryPlan(explain(indexScan("user_1")))
+	assert.True(t, plan.UsesIndex("user_1"))
+	assert.False(t, plan.UsesIndex("other_1"))
+	assert.Equal(t, int64(3), plan.DocsExamined)
+	assert.Equal(t, int64(2), plan.Returned)
+
+	plan = NewQueryPlan(explain(bson.M{"stage": PlanStageCollectionScan}))
+	assert.True(t, plan.IsCollectionScan())
+	assert.False(t, plan.UsesIndex("user_1"))
+
+	// sharded cluster, one shard scans collection
+	plan = NewQueryPlan(explain(bson.M{"stage": "SHARD_MERGE", "shards": bson.A{
+		bson.M{"winningPlan": indexScan("user_1")},
+		bson.M{"winningPlan": bson.M{"stage": PlanStageCollectionScan}},
+	}}))
+	assert.False(t, plan.UsesIndex("user_1"))
+}
@@ -0,0 +1,47 @@
+package database
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// ClusterAddress returns hosts and replica set of connection string without credentials and options,
+// ex. "mongo-0:27017,mongo-1:27017/rs0", safe to be shown in agents fleet overview,
+// srv records are not resolved
+func ClusterAddress(connectionString string) string {
+	_, rest, found := strings.Cut(connectionString, "://")
+	if !found {
+		return ""
+	}
+	hosts, options, _ := strings.Cut(rest, "/")
+	if i := strings.LastIndex(hosts, "@"); i >= 0 {
+		hosts = hosts[i+1:]
+	}
+	_, query, _ := strings.Cut(options, "?")
+	if values, err := url.ParseQuery(query); err == nil && values.Get("replicaSet") != "" {
+		return hosts + "/" + values.Get("replicaSet")
+	}
+	return hosts
+}
+
+// RedactConnectionString removes user and password from connection string, hosts and options are kept
+func RedactConnectionString(connectionString string) string {
+	scheme, rest, found := strings.Cut(connectionString, "://")
+	if !found {
+		return connectionString
+	}
+	hosts, path, hasPath := strings.Cut(rest, "/")
+	i := strings.LastIndex(hosts, "@")
+	if i < 0 {
+		return connectionString
+	}
+	return scheme + "://" + hosts[i+1:] + lo.Ternary(hasPath, "/"+path, "")
+}
+
+// Uptime returns time from agent registration to its last heartbeat
+func (s *AgentStatus) Uptime() time.Duration {
+	return s.Heartbeat.Time().Sub(s.CreatedAt.Time())
+}
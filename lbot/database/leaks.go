@@ -0,0 +1,140 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// cursorCommands return cursor which stays open on server until exhausted or killed
+var cursorCommands = map[string]bool{
+	"find": true, "aggregate": true, "listCollections": true, "listIndexes": true,
+}
+
+// LeakTracker counts cursors opened and closed by client using command monitoring,
+// cursors still open when workload ends are leaked (not exhausted or closed by handler)
+type LeakTracker struct {
+	mutex          sync.Mutex
+	openCursors    map[int64]bool
+	getMoreCursors map[int64]int64 // getMore request id -> cursor id
+	cursorsOpened  atomic.Uint64
+	cursorsClosed  atomic.Uint64
+}
+
+type Leaks struct {
+	CursorsOpened uint64
+	CursorsClosed uint64
+	CursorsOpen   int
+	// sessions checked out from client and not ended, implicit and explicit
+	SessionsInProgress int
+}
+
+func NewLeakTracker() *LeakTracker {
+	return &LeakTracker{openCursors: map[int64]bool{}, getMoreCursors: map[int64]int64{}}
+}
+
+func (t *LeakTracker) CommandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, e *event.CommandStartedEvent) {
+			if e.CommandName != "getMore" {
+				return
+			}
+			if id, ok := e.Command.Lookup("getMore").Int64OK(); ok {
+				t.mutex.Lock()
+				t.getMoreCursors[e.RequestID] = id
+				t.mutex.Unlock()
+			}
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			if e.CommandName == "getMore" {
+				t.mutex.Lock()
+				delete(t.getMoreCursors, e.RequestID)
+				t.mutex.Unlock()
+			}
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			switch {
+			case cursorCommands[e.CommandName]:
+				if id := cursorId(e.Reply); id != 0 {
+					t.opened(id)
+				}
+			case e.CommandName == "getMore":
+				t.getMoreDone(e.RequestID, cursorId(e.Reply) == 0)
+			case e.CommandName == "killCursors":
+				var reply struct {
+					CursorsKilled   []int64 `bson:"cursorsKilled"`
+					CursorsNotFound []int64 `bson:"cursorsNotFound"`
+				}
+				if bson.Unmarshal(e.Reply, &reply) == nil {
+					t.closed(append(reply.CursorsKilled, reply.CursorsNotFound...)...)
+				}
+			}
+		},
+	}
+}
+
+// getMore reply of exhausted cursor have cursor id 0, original id is taken from started getMore command
+func (t *LeakTracker) getMoreDone(requestId int64, exhausted bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	id, ok := t.getMoreCursors[requestId]
+	delete(t.getMoreCursors, requestId)
+	if ok && exhausted {
+		t.closeLocked(id)
+	}
+}
+
+func (t *LeakTracker) opened(id int64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.openCursors[id] = true
+	t.cursorsOpened.Add(1)
+}
+
+func (t *LeakTracker) closed(ids ...int64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	for _, id := range ids {
+		t.closeLocked(id)
+	}
+}
+
+func (t *LeakTracker) closeLocked(id int64) {
+	if t.openCursors[id] {
+		delete(t.openCursors, id)
+		t.cursorsClosed.Add(1)
+	}
+}
+
+func (t *LeakTracker) Leaks() Leaks {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return Leaks{
+		CursorsOpened: t.cursorsOpened.Load(),
+		CursorsClosed: t.cursorsClosed.Load(),
+		CursorsOpen:   len(t.openCursors),
+	}
+}
+
+func (l Leaks) Any() bool {
+	return l.CursorsOpen > 0 || l.SessionsInProgress > 0
+}
+
+func (l Leaks) String() string {
+	return fmt.Sprintf(
+		"cursors opened: %d, closed: %d, leaked: %d, sessions not ended: %d",
+		l.CursorsOpened, l.CursorsClosed, l.CursorsOpen, l.SessionsInProgress,
+	)
+}
+
+func cursorId(reply bson.Raw) int64 {
+	id, ok := reply.Lookup("cursor", "id").Int64OK()
+	if !ok {
+		return 0
+	}
+	return id
+}
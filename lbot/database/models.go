@@ -1,6 +1,8 @@
 package database
 
 import (
+	"time"
+
 	"github.com/kuzxnia/loadbot/lbot/config"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -10,8 +12,17 @@ type AgentStatus struct {
 	Name      string             `bson:"name"`
 	Host      string             `bson:"host"`
   // add state
-	CreatedAt primitive.DateTime `bson:"created_at"`
-	Heartbeat primitive.DateTime `bson:"heartbeat"`
+	CreatedAt      primitive.DateTime `bson:"created_at"`
+	Heartbeat      primitive.DateTime `bson:"heartbeat"`
+	RunningWorkers int                `bson:"running_workers"`
+	Version        string             `bson:"version"`
+	Jobs           []string           `bson:"jobs"`    // names of running jobs
+	Cluster        string             `bson:"cluster"` // configured cluster address without credentials
+}
+
+// IsAlive checks if agent sent heartbeat within expiration period before now (cluster time)
+func (s *AgentStatus) IsAlive(now time.Time) bool {
+	return !s.Heartbeat.Time().Before(now.Add(config.AgentsHeartbeatExpiration))
 }
 
 type Command struct {
@@ -21,6 +32,10 @@ type Command struct {
 	State     string             `bson:"state"`
 	CreatedAt primitive.DateTime `bson:"created_at"`
 	Version   primitive.ObjectID `bson:"version"`
+	// commands which must be done before command starts, command fails when any of them fails,
+	// unless sequential is set, then it only waits for them to finish
+	DependsOn  []primitive.ObjectID `bson:"depends_on,omitempty"`
+	Sequential bool                 `bson:"sequential,omitempty"`
 }
 
 type Workload struct {
@@ -31,6 +46,9 @@ type Workload struct {
 	State     string             `bson:"state"`
 	CreatedAt primitive.DateTime `bson:"created_at"`
 	Version   primitive.ObjectID `bson:"version"`
+	// cluster snapshots taken when workload started and finished
+	TopologyStart *Topology `bson:"topology_start,omitempty"`
+	TopologyEnd   *Topology `bson:"topology_end,omitempty"`
 }
 
 // todo: move to different place
@@ -0,0 +1,48 @@
+package database
+
+import (
+	"context"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// StoredRun is run recorded in commands history
+type StoredRun struct {
+	RunId      string             `bson:"_id"`
+	CreatedAt  primitive.DateTime `bson:"created_at"` // creation of last command of run
+	Unfinished int                `bson:"unfinished"` // commands not yet done or failed
+}
+
+// GetStoredRuns returns runs recorded in commands history
+func (c *MongoClient) GetStoredRuns(ctx context.Context) ([]*StoredRun, error) {
+	finished := bson.A{CommandStateDone.String(), CommandStateError.String()}
+	cursor, err := c.client.Database(config.DB).Collection(config.CommandCollection).Aggregate(ctx, bson.A{
+		bson.M{"$match": bson.M{"data.runid": bson.M{"$nin": bson.A{nil, ""}}}},
+		bson.M{"$group": bson.M{
+			"_id":        "$data.runid",
+			"created_at": bson.M{"$max": "$created_at"},
+			"unfinished": bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$in": bson.A{"$state", finished}}, 0, 1}}},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]*StoredRun, 0)
+	err = cursor.All(ctx, &runs)
+	return runs, err
+}
+
+// DeleteRuns removes commands, workloads and annotations of runs
+func (c *MongoClient) DeleteRuns(ctx context.Context, runIds []string) error {
+	db := c.client.Database(config.DB)
+	if _, err := db.Collection(config.CommandCollection).DeleteMany(ctx, bson.M{"data.runid": bson.M{"$in": runIds}}); err != nil {
+		return err
+	}
+	if _, err := db.Collection(config.WorkloadCollection).DeleteMany(ctx, bson.M{"data.runid": bson.M{"$in": runIds}}); err != nil {
+		return err
+	}
+	_, err := db.Collection(config.AnnotationCollection).DeleteMany(ctx, bson.M{"run_id": bson.M{"$in": runIds}})
+	return err
+}
@@ -0,0 +1,47 @@
+package database
+
+import (
+	"context"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Schedule is scheduled start of tenant jobs, persisted so agent restores it after restart
+type Schedule struct {
+	Id      primitive.ObjectID `bson:"_id"`
+	Agent   string             `bson:"agent"` // name of agent running schedule
+	Tenant  string             `bson:"tenant,omitempty"`
+	Restart bool               `bson:"restart,omitempty"`
+	Cron    string             `bson:"cron,omitempty"`
+	At      primitive.DateTime `bson:"at,omitempty"` // one-off start time
+	// footprint over agent thresholds was confirmed with start request
+	Confirmed bool `bson:"confirmed,omitempty"`
+	// approved config snapshot without agent section, nil runs agent config current at start time
+	Config    *config.Config     `bson:"config,omitempty"`
+	CreatedAt primitive.DateTime `bson:"created_at"`
+}
+
+func (c *MongoClient) SaveSchedule(ctx context.Context, schedule *Schedule) error {
+	_, err := c.client.Database(config.DB).Collection(config.ScheduleCollection).InsertOne(ctx, schedule)
+	return err
+}
+
+func (c *MongoClient) DeleteSchedule(ctx context.Context, id primitive.ObjectID) error {
+	_, err := c.client.Database(config.DB).Collection(config.ScheduleCollection).DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+// GetSchedules returns schedules of agent, oldest first
+func (c *MongoClient) GetSchedules(ctx context.Context, agent string) ([]*Schedule, error) {
+	cursor, err := c.client.Database(config.DB).Collection(config.ScheduleCollection).
+		Find(ctx, bson.M{"agent": agent}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	schedules := make([]*Schedule, 0)
+	err = cursor.All(ctx, &schedules)
+	return schedules, err
+}
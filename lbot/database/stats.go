@@ -0,0 +1,32 @@
+package database
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AverageDocumentSize returns average BSON size of job collection documents from storage stats,
+// on sharded collection average is weighted by number of documents on every shard
+func (c *MongoClient) AverageDocumentSize(ctx context.Context) (float64, error) {
+	cursor, err := c.collection.Aggregate(ctx, bson.A{
+		bson.M{"$collStats": bson.M{"storageStats": bson.M{}}},
+		bson.M{"$project": bson.M{"size": "$storageStats.size", "count": "$storageStats.count"}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	var shards []bson.M
+	if err = cursor.All(ctx, &shards); err != nil {
+		return 0, err
+	}
+	var size, count int64
+	for _, shard := range shards {
+		size += toInt64(shard["size"])
+		count += toInt64(shard["count"])
+	}
+	if count == 0 {
+		return 0, nil
+	}
+	return float64(size) / float64(count), nil
+}
@@ -0,0 +1,144 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	TopologyStandalone = "standalone"
+	TopologyReplicaSet = "replica_set"
+	TopologySharded    = "sharded"
+)
+
+// Topology is snapshot of cluster saved with results, so archived results can be interpreted later
+type Topology struct {
+	CapturedAt     time.Time        `bson:"captured_at" json:"captured_at"`
+	Version        string           `bson:"version" json:"version"`
+	GitVersion     string           `bson:"git_version" json:"git_version"`
+	Kind           string           `bson:"kind" json:"kind"`
+	ReplicaSet     string           `bson:"replica_set,omitempty" json:"replica_set,omitempty"`
+	Members        []TopologyMember `bson:"members,omitempty" json:"members,omitempty"`
+	Shards         []TopologyShard  `bson:"shards,omitempty" json:"shards,omitempty"`
+	StorageEngine  string           `bson:"storage_engine,omitempty" json:"storage_engine,omitempty"`
+	CacheSizeBytes int64            `bson:"cache_size_bytes,omitempty" json:"cache_size_bytes,omitempty"`
+	Errors         []string         `bson:"errors,omitempty" json:"errors,omitempty"` // parts not captured, ex. missing clusterMonitor role
+}
+
+type TopologyMember struct {
+	Host  string `bson:"host" json:"host"`
+	State string `bson:"state" json:"state"`
+}
+
+type TopologyShard struct {
+	Id   string `bson:"id" json:"id"`
+	Host string `bson:"host" json:"host"`
+}
+
+type buildInfo struct {
+	Version    string `bson:"version"`
+	GitVersion string `bson:"gitVersion"`
+}
+
+type helloInfo struct {
+	Msg     string `bson:"msg"`
+	SetName string `bson:"setName"`
+}
+
+type replSetStatus struct {
+	Members []struct {
+		Name     string `bson:"name"`
+		StateStr string `bson:"stateStr"`
+	} `bson:"members"`
+}
+
+type shardList struct {
+	Shards []struct {
+		Id   string `bson:"_id"`
+		Host string `bson:"host"`
+	} `bson:"shards"`
+}
+
+type serverStatus struct {
+	StorageEngine struct {
+		Name string `bson:"name"`
+	} `bson:"storageEngine"`
+	WiredTiger struct {
+		Cache map[string]interface{} `bson:"cache"`
+	} `bson:"wiredTiger"`
+}
+
+// Topology captures server version, topology and storage parameters, only buildInfo is required,
+// other parts need clusterMonitor role and are skipped with error note when not permitted
+func (c *MongoClient) Topology(ctx context.Context) (*Topology, error) {
+	admin := c.client.Database(config.DB)
+	runCommand := func(name string, result interface{}) error {
+		err := admin.RunCommand(ctx, bson.D{{Key: name, Value: 1}}).Decode(result)
+		return errors.WithMessage(err, "cmd: "+name)
+	}
+
+	var build buildInfo
+	if err := runCommand("buildInfo", &build); err != nil {
+		return nil, err
+	}
+	topology := &Topology{CapturedAt: time.Now(), Version: build.Version, GitVersion: build.GitVersion}
+
+	var hello helloInfo
+	if err := runCommand("isMaster", &hello); err != nil {
+		topology.Errors = append(topology.Errors, err.Error())
+	}
+	switch {
+	case hello.Msg == "isdbgrid":
+		topology.Kind = TopologySharded
+		var shards shardList
+		if err := runCommand("listShards", &shards); err != nil {
+			topology.Errors = append(topology.Errors, err.Error())
+		}
+		for _, shard := range shards.Shards {
+			topology.Shards = append(topology.Shards, TopologyShard{Id: shard.Id, Host: shard.Host})
+		}
+	case hello.SetName != "":
+		topology.Kind = TopologyReplicaSet
+		topology.ReplicaSet = hello.SetName
+		var status replSetStatus
+		if err := runCommand("replSetGetStatus", &status); err != nil {
+			topology.Errors = append(topology.Errors, err.Error())
+		}
+		for _, member := range status.Members {
+			topology.Members = append(topology.Members, TopologyMember{Host: member.Name, State: member.StateStr})
+		}
+	default:
+		topology.Kind = TopologyStandalone
+	}
+
+	// mongos has no storage engine
+	if topology.Kind != TopologySharded {
+		var status serverStatus
+		if err := runCommand("serverStatus", &status); err != nil {
+			topology.Errors = append(topology.Errors, err.Error())
+		}
+		topology.StorageEngine = status.StorageEngine.Name
+		topology.CacheSizeBytes = toInt64(status.WiredTiger.Cache["maximum bytes configured"])
+	}
+	return topology, nil
+}
+
+// String describes topology in single line, ex. "MongoDB 7.0.2 replica_set rs0, 3 members, wiredTiger cache 1.0 GiB"
+func (t *Topology) String() string {
+	description := fmt.Sprintf("MongoDB %s %s", t.Version, t.Kind)
+	switch t.Kind {
+	case TopologyReplicaSet:
+		description += fmt.Sprintf(" %s, %d members", t.ReplicaSet, len(t.Members))
+	case TopologySharded:
+		description += fmt.Sprintf(", %d shards", len(t.Shards))
+	}
+	if t.StorageEngine != "" {
+		description += fmt.Sprintf(", %s cache %.1f GiB", t.StorageEngine, float64(t.CacheSizeBytes)/(1<<30))
+	}
+	return description
+}
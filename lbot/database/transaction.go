@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// TransactionResult describes transaction attempts, every retried attempt was aborted
+type TransactionResult struct {
+	Retries       int
+	CommitRetries int
+}
+
+// TransactionOptions returns transaction options of job read and write concern, transactions always read from primary
+func TransactionOptions(cfg *config.Job) *options.TransactionOptions {
+	opts := options.Transaction().SetReadPreference(readpref.Primary())
+	if cfg.ReadConcern != "" {
+		opts.SetReadConcern(readconcern.New(readconcern.Level(cfg.ReadConcern)))
+	}
+	if cfg.WriteConcern == "majority" {
+		opts.SetWriteConcern(writeconcern.Majority())
+	} else if w, err := strconv.Atoi(cfg.WriteConcern); err == nil {
+		opts.SetWriteConcern(&writeconcern.WriteConcern{W: w})
+	}
+	return opts
+}
+
+// Transaction runs body in multi-document transaction, transaction failed with transient error is retried
+// up to maxRetries times, commit with unknown result is retried without running body again
+func (c *MongoClient) Transaction(
+	ctx context.Context, opts *options.TransactionOptions, maxRetries int, body func(context.Context) error,
+) (result TransactionResult, err error) {
+	session, err := c.client.StartSession()
+	if err != nil {
+		return result, err
+	}
+	defer session.EndSession(context.Background())
+
+	for {
+		err = c.runTransaction(mongo.NewSessionContext(ctx, session), session, opts, body, maxRetries, &result)
+		if !hasErrorLabel(err, driverTransientTransactionError) || result.Retries >= maxRetries || ctx.Err() != nil {
+			return result, err
+		}
+		result.Retries++
+	}
+}
+
+func (c *MongoClient) runTransaction(
+	ctx mongo.SessionContext, session mongo.Session, opts *options.TransactionOptions,
+	body func(context.Context) error, maxRetries int, result *TransactionResult,
+) error {
+	if err := session.StartTransaction(opts); err != nil {
+		return err
+	}
+	if err := body(ctx); err != nil {
+		// abort uses own context, transaction has to be aborted even when operation was cancelled
+		_ = session.AbortTransaction(context.Background())
+		return err
+	}
+	for {
+		err := session.CommitTransaction(ctx)
+		if !hasErrorLabel(err, driverUnknownTransactionCommitResult) || result.CommitRetries >= maxRetries || ctx.Err() != nil {
+			return err
+		}
+		result.CommitRetries++
+	}
+}
+
+const (
+	driverTransientTransactionError      = "TransientTransactionError"
+	driverUnknownTransactionCommitResult = "UnknownTransactionCommitResult"
+)
+
+func hasErrorLabel(err error, label string) bool {
+	var labeled mongo.LabeledError
+	return err != nil && errors.As(err, &labeled) && labeled.HasErrorLabel(label)
+}
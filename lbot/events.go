@@ -0,0 +1,132 @@
+package lbot
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/worker"
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// interval of stats events of running jobs
+const EventsStatsInterval = 5 * time.Second
+
+const (
+	EventRunStarted  = "run_started"
+	EventRunFinished = "run_finished"
+	EventJobStarted  = "job_started"
+	EventJobFinished = "job_finished"
+	EventJobStats    = "stats"
+	EventStatsReset  = "stats_reset"
+	EventError       = "error"
+	// start of protected agent waits for approval
+	EventApprovalRequested = "approval_requested"
+)
+
+// Event is single line of newline-delimited json event stream, consumed by wrappers without grpc
+type Event struct {
+	Time     time.Time   `json:"time"`
+	Type     string      `json:"type"`
+	RunId    string      `json:"run_id,omitempty"`
+	Template string      `json:"template,omitempty"` // reference and digest of template config was pulled from
+	Job      string      `json:"job,omitempty"`
+	Jobs     []string    `json:"jobs,omitempty"`
+	Stats    *EventStats `json:"stats,omitempty"`
+	Reason   string      `json:"reason,omitempty"`
+	Error    string      `json:"error,omitempty"`
+	Approval string      `json:"approval,omitempty"` // id of pending start, passed to approve
+}
+
+type EventStats struct {
+	Requests         uint64  `json:"requests"`
+	Errors           uint64  `json:"errors"`
+	Rps              uint64  `json:"rps"`
+	AverageLatencyMs float64 `json:"average_latency_ms"`
+	DurationSeconds  uint64  `json:"duration_seconds"`
+}
+
+func NewEventStats(metrics *worker.Metrics) *EventStats {
+	return &EventStats{
+		Requests:         metrics.Requests(),
+		Errors:           metrics.Errors(),
+		Rps:              metrics.Rps(),
+		AverageLatencyMs: float64(metrics.AverageLatency().Microseconds()) / 1000,
+		DurationSeconds:  metrics.DurationSeconds(),
+	}
+}
+
+// EventWriter writes events as ndjson, nil writer drops events
+type EventWriter struct {
+	mutex   sync.Mutex
+	encoder *json.Encoder
+}
+
+func NewEventWriter(writer io.Writer) *EventWriter {
+	return &EventWriter{encoder: json.NewEncoder(writer)}
+}
+
+func (w *EventWriter) Emit(event Event) {
+	if w == nil {
+		return
+	}
+	event.Time = time.Now()
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.encoder.Encode(event)
+}
+
+func (l *Lbot) SetEventWriter(events *EventWriter) {
+	l.events = events
+}
+
+// emitStats emits stats of worker every EventsStatsInterval until done is closed
+func (l *Lbot) emitStats(w *worker.Worker, runId string, done chan struct{}) {
+	if l.events == nil {
+		return
+	}
+	ticker := time.NewTicker(EventsStatsInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			l.events.Emit(Event{Type: EventJobStats, RunId: runId, Job: w.JobName(), Stats: NewEventStats(w.Metrics)})
+		}
+	}
+}
+
+// emitRunFinished logs run summary and emits run finished when command was last unfinished command
+// of run started by this agent
+func (l *Lbot) emitRunFinished(commandId string) {
+	l.mutext.Lock()
+	current, ok := lo.Find(lo.Values(l.runs), func(r *run) bool {
+		return lo.ContainsBy(r.commands, func(id primitive.ObjectID) bool { return id.Hex() == commandId })
+	})
+	l.mutext.Unlock()
+	if !ok {
+		return
+	}
+	unfinished, err := l.internalClient.CountUnfinishedCommands(l.ctx, current.commands)
+	if err != nil || unfinished > 0 {
+		return
+	}
+	l.mutext.Lock()
+	jobs, stats := current.jobs, current.stats(time.Now())
+	l.mutext.Unlock()
+	log.WithField("run_id", current.id).Infof(
+		"Run finished, %d jobs: %d requests, %d errors, %d rps, average latency %.2fms, took %ds",
+		jobs, stats.Requests, stats.Errors, stats.Rps, stats.AverageLatencyMs, stats.DurationSeconds,
+	)
+	l.events.Emit(Event{Type: EventRunFinished, RunId: current.id, Stats: stats})
+	l.recordRun(current, RunStatusFinished, nil)
+	if current.own {
+		// workloads don't block next start like runs of agent config, nothing else forgets them, waiters
+		// find ended run in history
+		l.forgetRunId(current.id)
+	}
+}
@@ -0,0 +1,85 @@
+package lbot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/database"
+	"github.com/samber/lo"
+)
+
+// Export builds tar.gz bundle with effective config, schemas and run history of tenant jobs,
+// secrets of agent config are not exported, connection strings keep credentials only when asked for
+func (l *Lbot) Export(ctx context.Context, tenant *config.Tenant, credentials bool) ([]byte, error) {
+	cfg := *l.Config
+	if !credentials {
+		cfg.ConnectionString = database.RedactConnectionString(cfg.ConnectionString)
+		cfg.ShardConnectionString = database.RedactConnectionString(cfg.ShardConnectionString)
+	}
+	cfg.Jobs = lo.Filter(cfg.Jobs, func(job *config.Job, _ int) bool { return ownsJob(tenant, job) })
+	if cfg.Agent != nil {
+		agent := *cfg.Agent
+		agent.MetricsExportPassword = ""
+		agent.MetricsExportBearerToken = ""
+		agent.Proxy = database.RedactProxyUrl(agent.Proxy)
+		agent.Tenants = nil
+		cfg.Agent = &agent
+	}
+
+	commands, workloads, err := l.internalClient.GetCommands(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Reading run history failed: %w", err)
+	}
+	commands = lo.Filter(commands, func(command *database.Command, _ int) bool { return ownsJob(tenant, &command.Data) })
+	workloads = lo.Filter(workloads, func(workload *database.Workload, _ int) bool { return ownsJob(tenant, &workload.Data) })
+
+	annotations, err := l.internalClient.GetAnnotations(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("Reading run annotations failed: %w", err)
+	}
+	annotations = lo.Filter(annotations, func(annotation *database.Annotation, _ int) bool {
+		return lo.IsNil(tenant) || annotation.Tenant == tenant.Name
+	})
+
+	files := map[string]interface{}{
+		"config.json":              cfg,
+		"history/commands.json":    commands,
+		"history/workloads.json":   workloads,
+		"history/annotations.json": annotations,
+	}
+	for _, schema := range cfg.Schemas {
+		files["schemas/"+schema.Name+".json"] = schema
+	}
+
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	now := time.Now()
+	for _, name := range lo.Keys(files) {
+		content, err := json.MarshalIndent(files[name], "", "\t")
+		if err != nil {
+			return nil, err
+		}
+		header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), ModTime: now}
+		if err = tarWriter.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err = tarWriter.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err = tarWriter.Close(); err != nil {
+		return nil, err
+	}
+	if err = gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
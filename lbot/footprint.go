@@ -0,0 +1,71 @@
+package lbot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/worker"
+	"github.com/samber/lo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Footprint estimates volume of jobs tenant would start, with tenant quota applied
+func (l *Lbot) Footprint(tenant *config.Tenant) worker.Footprint {
+	return configFootprint(tenant, l.Config, lo.Filter(l.Config.Jobs, func(job *config.Job, _ int) bool { return ownsJob(tenant, job) }))
+}
+
+// configFootprint estimates volume of jobs of cfg, with tenant quota applied
+func configFootprint(tenant *config.Tenant, cfg *config.Config, jobs []*config.Job) worker.Footprint {
+	var footprint worker.Footprint
+	for _, job := range jobs {
+		runJob := applyTenantQuota(tenant, *job)
+		sizes := worker.SamplePayloadSizes(&runJob, cfg.GetSchema(runJob.Schema), worker.FootprintSamples)
+		footprint.Add(worker.EstimateFootprint(&runJob, sizes))
+	}
+	return footprint
+}
+
+// exceededThresholds returns agent confirmation thresholds exceeded by footprint, jobs which can't be
+// estimated exceed every set threshold
+func exceededThresholds(agent *config.Agent, footprint worker.Footprint) (exceeded []string) {
+	if agent == nil || (agent.ConfirmAboveDocuments == 0 && agent.ConfirmAboveWrittenBytes == 0 && agent.ConfirmAboveEgressBytes == 0) {
+		return nil
+	}
+	if agent.ConfirmAboveDocuments > 0 && footprint.Documents > agent.ConfirmAboveDocuments {
+		exceeded = append(exceeded, fmt.Sprintf("%d documents > %d", footprint.Documents, agent.ConfirmAboveDocuments))
+	}
+	if agent.ConfirmAboveWrittenBytes > 0 && footprint.WrittenBytes > agent.ConfirmAboveWrittenBytes {
+		exceeded = append(exceeded, fmt.Sprintf(
+			"%s written > %s", worker.FormatBytes(footprint.WrittenBytes), worker.FormatBytes(agent.ConfirmAboveWrittenBytes),
+		))
+	}
+	if agent.ConfirmAboveEgressBytes > 0 && footprint.EgressBytes > agent.ConfirmAboveEgressBytes {
+		exceeded = append(exceeded, fmt.Sprintf(
+			"%s sent > %s", worker.FormatBytes(footprint.EgressBytes), worker.FormatBytes(agent.ConfirmAboveEgressBytes),
+		))
+	}
+	if len(footprint.Unbounded) > 0 {
+		exceeded = append(exceeded, "footprint of jobs "+strings.Join(footprint.Unbounded, ", ")+" is unknown")
+	}
+	return exceeded
+}
+
+// checkFootprint fails with FailedPrecondition when unconfirmed start exceeds agent thresholds, own is
+// config of workload started with its own config, nil for agent config
+func (l *Lbot) checkFootprint(tenant *config.Tenant, own *config.Config, confirmed bool) error {
+	if confirmed {
+		return nil
+	}
+	footprint := l.Footprint(tenant)
+	if own != nil {
+		footprint = configFootprint(tenant, own, own.Jobs)
+	}
+	if exceeded := exceededThresholds(l.Config.Agent, footprint); len(exceeded) > 0 {
+		return status.Errorf(
+			codes.FailedPrecondition, "estimated footprint exceeds agent thresholds (%s), confirm start", strings.Join(exceeded, ", "),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package lbot
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	RunStatusFinished = "finished"
+	RunStatusStopped  = "stopped"
+)
+
+// runHistory keeps summaries of runs ended on agent, oldest are dropped above size. With file summaries
+// are saved after every run and loaded on agent start, so results outlive clients and agent restarts
+type runHistory struct {
+	mutex sync.Mutex
+	size  int
+	file  string
+	runs  []*proto.RunSummary // oldest first
+}
+
+func newRunHistory(agent *config.Agent) *runHistory {
+	history := &runHistory{size: config.DefaultRunHistorySize}
+	if agent == nil {
+		return history
+	}
+	if agent.RunHistorySize > 0 {
+		history.size = int(agent.RunHistorySize)
+	}
+	history.file = agent.RunHistoryFile
+	if err := history.load(); err != nil {
+		log.Warnf("Loading run history from %s failed: %s", history.file, err)
+	}
+	return history
+}
+
+func (h *runHistory) load() error {
+	if h.file == "" {
+		return nil
+	}
+	content, err := os.ReadFile(h.file)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var stored proto.ListRunsResponse
+	if err = protojson.Unmarshal(content, &stored); err != nil {
+		return err
+	}
+	h.runs = stored.Runs[max(len(stored.Runs)-h.size, 0):]
+	return nil
+}
+
+// add records summary of ended run, failure of saving it to file is logged
+func (h *runHistory) add(summary *proto.RunSummary) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.runs = append(h.runs, summary)
+	if len(h.runs) > h.size {
+		h.runs = slices.Clone(h.runs[len(h.runs)-h.size:])
+	}
+	if err := h.save(); err != nil {
+		log.WithField("run_id", summary.RunId).Warnf("Saving run history to %s failed: %s", h.file, err)
+	}
+}
+
+// save replaces history file, content is written to temporary file first so crash doesn't truncate it
+func (h *runHistory) save() error {
+	if h.file == "" {
+		return nil
+	}
+	content, err := protojson.MarshalOptions{Multiline: true}.Marshal(&proto.ListRunsResponse{Runs: h.runs})
+	if err != nil {
+		return err
+	}
+	temporary, err := os.CreateTemp(filepath.Dir(h.file), filepath.Base(h.file)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temporary.Name())
+	if _, err = temporary.Write(content); err != nil {
+		temporary.Close()
+		return err
+	}
+	if err = temporary.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temporary.Name(), h.file)
+}
+
+// list returns runs of tenant (all runs if tenant is nil), newest first, limit 0 returns all
+func (h *runHistory) list(tenant *config.Tenant, limit int) []*proto.RunSummary {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	runs := make([]*proto.RunSummary, 0)
+	for i := len(h.runs) - 1; i >= 0 && (limit == 0 || len(runs) < limit); i-- {
+		if lo.IsNil(tenant) || h.runs[i].Tenant == tenant.Name {
+			runs = append(runs, h.runs[i])
+		}
+	}
+	return runs
+}
+
+func (h *runHistory) get(tenant *config.Tenant, runId string) (*proto.RunSummary, bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return lo.Find(h.runs, func(summary *proto.RunSummary) bool {
+		return summary.RunId == runId && (lo.IsNil(tenant) || summary.Tenant == tenant.Name)
+	})
+}
+
+// recordRun adds summary of ended run to history, jobs stopped before they finished are added from
+// stop results, every run is recorded once
+func (l *Lbot) recordRun(r *run, runStatus string, stopped []*proto.StoppedWorkload) {
+	now := time.Now()
+	l.mutext.Lock()
+	if r.recorded {
+		l.mutext.Unlock()
+		return
+	}
+	r.recorded = true
+	stats := r.stats(now)
+	jobs := lo.Map(r.results, func(result jobResult, _ int) *proto.RunJobSummary {
+		return &proto.RunJobSummary{
+			JobName:          result.name,
+			JobType:          result.jobType,
+			Requests:         result.stats.Requests,
+			Errors:           result.stats.Errors,
+			Rps:              result.stats.Rps,
+			AverageLatencyMs: result.stats.AverageLatencyMs,
+			Duration:         result.stats.DurationSeconds,
+			StopReason:       result.stopReason,
+		}
+	})
+	finished := lo.SliceToMap(r.results, func(result jobResult) (string, bool) { return result.workloadId, true })
+	l.mutext.Unlock()
+
+	for _, workload := range stopped {
+		if finished[workload.WorkloadId] || !lo.ContainsBy(r.commands, func(id primitive.ObjectID) bool { return id.Hex() == workload.CommandId }) {
+			continue
+		}
+		job := &proto.RunJobSummary{
+			JobName:    workload.JobName,
+			JobType:    workload.JobType,
+			Requests:   workload.Requests,
+			Errors:     workload.Errors,
+			Duration:   workload.Duration,
+			StopReason: workload.Status,
+		}
+		if workload.Duration > 0 {
+			job.Rps = workload.Requests / workload.Duration
+		}
+		jobs = append(jobs, job)
+		stats.Requests += workload.Requests
+		stats.Errors += workload.Errors
+	}
+	if stats.DurationSeconds > 0 {
+		stats.Rps = stats.Requests / stats.DurationSeconds
+	}
+
+	l.history.add(&proto.RunSummary{
+		RunId:            r.id,
+		Tenant:           r.tenant,
+		Status:           runStatus,
+		StartedAt:        r.startedAt.Format(time.RFC3339),
+		EndedAt:          now.Format(time.RFC3339),
+		Duration:         stats.DurationSeconds,
+		Requests:         stats.Requests,
+		Errors:           stats.Errors,
+		Rps:              stats.Rps,
+		AverageLatencyMs: stats.AverageLatencyMs,
+		Template:         r.template,
+		OwnConfig:        r.own,
+		Jobs:             jobs,
+	})
+}
+
+// Runs returns summaries of ended runs of tenant, newest first
+func (l *Lbot) Runs(tenant *config.Tenant, limit int) []*proto.RunSummary {
+	return l.history.list(tenant, limit)
+}
+
+// RunSummary returns summary of ended run of tenant, NotFound when run is active, unknown or was
+// dropped from history
+func (l *Lbot) RunSummary(tenant *config.Tenant, runId string) (*proto.RunSummary, error) {
+	summary, ok := l.history.get(tenant, runId)
+	if !ok {
+		if l.hasActiveRun(tenant, runId) {
+			return nil, status.Errorf(codes.NotFound, "run %s is still active, follow it with progress", runId)
+		}
+		return nil, status.Errorf(codes.NotFound, "run %s not found in run history", runId)
+	}
+	return summary, nil
+}
+
+type RunHistoryService struct {
+	proto.UnimplementedRunHistoryServiceServer
+	ctx  context.Context
+	lbot *Lbot
+}
+
+func NewRunHistoryService(ctx context.Context, lbot *Lbot) *RunHistoryService {
+	return &RunHistoryService{ctx: ctx, lbot: lbot}
+}
+
+func (s *RunHistoryService) ListRuns(ctx context.Context, request *proto.ListRunsRequest) (*proto.ListRunsResponse, error) {
+	return &proto.ListRunsResponse{Runs: s.lbot.Runs(TenantFromContext(ctx), int(request.Limit))}, nil
+}
+
+func (s *RunHistoryService) GetRun(ctx context.Context, request *proto.GetRunRequest) (*proto.RunSummary, error) {
+	return s.lbot.RunSummary(TenantFromContext(ctx), request.RunId)
+}
@@ -3,44 +3,87 @@ package lbot
 import (
 	"context"
 	"fmt"
+	"os"
+	"slices"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/cost"
 	"github.com/kuzxnia/loadbot/lbot/database"
+	"github.com/kuzxnia/loadbot/lbot/proto"
 	"github.com/kuzxnia/loadbot/lbot/schema"
 	"github.com/kuzxnia/loadbot/lbot/worker"
 	"github.com/pkg/errors"
 	"github.com/samber/lo"
 	log "github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// Version of running agent binary, reported in agent heartbeats
+var Version = "dev"
+
 type Lbot struct {
 	Config         *config.Config
 	ctx            context.Context
 	mutext         sync.Mutex
 	workers        map[string]*worker.Worker
+	runs           map[string]*run      // active runs by id
+	approvals      map[string]*approval // starts of protected agent waiting for approval, by id
+	schedules      map[string]*scheduledStart
 	done           chan bool
 	runningAgents  uint64 // todo: remove from here
 	changed        chan uint64
+	events         *EventWriter   // nil if event stream is disabled
+	budget         *worker.Budget // nil if agent has no cpu/network limits
+	fairness       *worker.Fairness
+	history        *runHistory
 
   // todo: to move to abstraction
 	internalClient *database.MongoClient
 }
 
 func NewLbot(ctx context.Context, cfg *config.Config) (*Lbot, error) {
+	if cfg.Agent != nil {
+		if err := cfg.Agent.CheckTargets(cfg); err != nil {
+			return nil, fmt.Errorf("Config rejected: %w", err)
+		}
+		if err := worker.LoadPlugins(cfg.Agent.Plugins); err != nil {
+			return nil, err
+		}
+		dialer, err := database.NewProxyDialer(cfg.Agent.Proxy, cfg.Agent.SshKeyFile, cfg.Agent.SshKnownHostsFile)
+		if err != nil {
+			return nil, err
+		}
+		database.SetProxyDialer(dialer)
+	}
 	client, err := database.NewInternalMongoClient(cfg.ConnectionString)
 	if err != nil {
 		return nil, fmt.Errorf("Connecting to database failed: %w", err)
 	}
 
+	budget := worker.NewBudget(cfg.Agent)
+	if budget != nil {
+		go budget.Run(ctx)
+	}
+	fairness := worker.NewFairness()
+	go fairness.Run(ctx)
+
 	return &Lbot{
 		ctx:            ctx,
 		Config:         cfg,
+		budget:         budget,
+		fairness:       fairness,
+		history:        newRunHistory(cfg.Agent),
 		runningAgents:  1,
 		changed:        make(chan uint64),
 		workers:        map[string]*worker.Worker{},
+		runs:           map[string]*run{},
+		approvals:      map[string]*approval{},
+		schedules:      map[string]*scheduledStart{},
 		internalClient: client,
 	}, nil
 }
@@ -53,21 +96,110 @@ func (l *Lbot) Client() (err error) {
 }
 
 func (l *Lbot) Close() (err error) {
-	if l.internalClient == nil {
+	if l.internalClient != nil {
 		return l.internalClient.Disconnect()
 	}
 	return nil
 }
 
-func (l *Lbot) Run() (err error) {
-	for _, job := range l.Config.Jobs {
-		err = l.internalClient.RunJob(*job)
+// Run starts jobs owned by tenant under new run id, tenant quota is applied to every started job,
+// fails with AlreadyExists when previous run of tenant is still active. Jobs declaring depends_on
+// start once jobs they depend on are done, independent jobs run in parallel, without any depends_on
+// jobs run sequentially in order of config, unless config is parallel
+func (l *Lbot) Run(tenant *config.Tenant) (runId string, started []*proto.StartedJob, err error) {
+	return l.runConfig(tenant, l.Config)
+}
+
+// runConfig starts jobs of tenant from agent config or its approved snapshot
+func (l *Lbot) runConfig(tenant *config.Tenant, cfg *config.Config) (runId string, started []*proto.StartedJob, err error) {
+	jobs := lo.Filter(cfg.Jobs, func(job *config.Job, _ int) bool { return ownsJob(tenant, job) })
+	return l.runJobs(tenant, cfg, jobs, false)
+}
+
+// RunWorkload starts all jobs of own config as workload independent of agent config and of other runs,
+// so many workloads can run concurrently, returned run id identifies workload
+func (l *Lbot) RunWorkload(tenant *config.Tenant, cfg *config.Config) (runId string, started []*proto.StartedJob, err error) {
+	if len(cfg.Jobs) == 0 {
+		return "", nil, status.Error(codes.InvalidArgument, "workload config has no jobs")
+	}
+	return l.runJobs(tenant, cfg, cfg.Jobs, true)
+}
+
+// runJobs creates commands of jobs of cfg under new run id, jobs of own config carry their config
+// (connection strings and schemas), so agents run them apart from agent config
+func (l *Lbot) runJobs(tenant *config.Tenant, cfg *config.Config, jobs []*config.Job, own bool) (runId string, started []*proto.StartedJob, err error) {
+	current := &run{
+		id: primitive.NewObjectID().Hex(), tenant: runKey(tenant), own: own, startedAt: time.Now(), template: cfg.Template,
+	}
+	if err = l.registerRun(current); err != nil {
+		return "", nil, err
+	}
+	// run is starting until its commands are set, run without any command is dropped
+	var created []primitive.ObjectID
+	defer func() {
+		l.mutext.Lock()
+		defer l.mutext.Unlock()
+		if len(created) == 0 {
+			delete(l.runs, current.id)
+			return
+		}
+		current.commands = created
+	}()
+
+	var workloadConfig *config.Config
+	// approved snapshot carries its config too, agent config can change before its jobs start
+	if own || cfg != l.Config {
+		workloadConfig = &config.Config{
+			ConnectionString:      cfg.ConnectionString,
+			ShardConnectionString: cfg.ShardConnectionString,
+			Schemas:               cfg.Schemas,
+			Debug:                 cfg.Debug,
+		}
+	}
+	ordered, err := config.OrderJobs(jobs)
+	if err != nil {
+		return current.id, nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	sequential := !cfg.Parallel && !config.HasDependencies(jobs)
+	commands := make(map[string]primitive.ObjectID, len(ordered))
+	var previous []primitive.ObjectID
+
+	for _, job := range ordered {
+		if job.CompareRouting && cfg.ShardConnectionString == "" {
+			return current.id, started, fmt.Errorf("job %s: 'compare_routing' requires 'shard_connection_string'", job.Name)
+		}
+		runJob := applyTenantQuota(tenant, *job)
+		runJob.RunId = current.id
+		runJob.Template = cfg.Template
+		runJob.WorkloadConfig = workloadConfig
+		dependsOn := previous
+		if !sequential {
+			dependsOn = lo.Map(job.DependsOn, func(name string, _ int) primitive.ObjectID { return commands[name] })
+		}
+		commandId, err := l.internalClient.RunJob(runJob, dependsOn, sequential)
 		if err != nil {
-			return err
+			return current.id, started, err
+		}
+		commands[job.Name] = commandId
+		if sequential {
+			previous = []primitive.ObjectID{commandId}
 		}
+		created = append(created, commandId)
+		started = append(started, &proto.StartedJob{
+			CommandId: commandId.Hex(),
+			JobName:   job.Name,
+			JobType:   job.Type,
+		})
 	}
 
-	return nil
+	if cfg.Template != "" {
+		log.WithField("run_id", current.id).Infof("Run started from template %s", cfg.Template)
+	}
+	l.events.Emit(Event{
+		Type: EventRunStarted, RunId: current.id, Template: cfg.Template,
+		Jobs: lo.Map(started, func(job *proto.StartedJob, _ int) string { return job.JobName }),
+	})
+	return current.id, started, nil
 }
 
 func (l *Lbot) SetCommandState(command *database.Command, state database.CommandState) error {
@@ -84,6 +216,33 @@ func (l *Lbot) SetConfig(config *config.Config) {
 	l.Config = config
 }
 
+// SetTenantConfig replaces only jobs owned by tenant, other tenants jobs and connection string are kept
+func (l *Lbot) SetTenantConfig(tenant *config.Tenant, cfg *config.Config) {
+	if lo.IsNil(tenant) {
+		l.SetConfig(cfg)
+		return
+	}
+	jobs := lo.Reject(l.Config.Jobs, func(job *config.Job, _ int) bool { return ownsJob(tenant, job) })
+	for _, job := range cfg.Jobs {
+		job.Tenant = tenant.Name
+		jobs = append(jobs, job)
+	}
+	schemas := lo.Reject(l.Config.Schemas, func(schema *config.Schema, _ int) bool {
+		return cfg.GetSchema(schema.Name) != nil
+	})
+
+	l.Config = &config.Config{
+		ConnectionString:      l.Config.ConnectionString,
+		ShardConnectionString: l.Config.ShardConnectionString,
+		Agent:                 l.Config.Agent,
+		Jobs:                  jobs,
+		Schemas:               append(schemas, cfg.Schemas...),
+		Debug:                 l.Config.Debug,
+		Parallel:              l.Config.Parallel,
+		Template:              l.Config.Template,
+	}
+}
+
 func (l *Lbot) StartWorkload(workload *database.Workload) {
 	if workload == nil {
 		return
@@ -95,61 +254,254 @@ func (l *Lbot) StartWorkload(workload *database.Workload) {
 	}
 
 	l.done = make(chan bool)
+	job := workload.Data
+	// jobs of workload started with own config run with it instead of agent config
+	cfg := l.Config
+	if job.WorkloadConfig != nil {
+		workloadConfig := *job.WorkloadConfig
+		workloadConfig.Agent = l.Config.Agent
+		cfg = &workloadConfig
+	}
 	// todo: ping db, before workers init
 	// init datapools
 	dataPools := make(map[string]schema.DataPool)
-	for _, sh := range l.Config.Schemas {
+	for _, sh := range cfg.Schemas {
 		dataPools[sh.Name] = schema.NewDataPool(sh)
 	}
 
-	job := workload.Data
 	// // todo: in a parallel depending on type
 	func() {
 		dataPool := dataPools[job.Schema]
 
-		worker, error := worker.NewWorker(l.ctx, l.Config, &job, dataPool, l.runningAgents)
-		if error != nil {
-			panic("Worker initialization error")
+		worker, err := worker.NewWorker(l.ctx, cfg, &job, dataPool, l.runningAgents)
+		if err != nil {
+			// invalid job config fails only its job
+			log.WithField("run_id", job.RunId).Errorf("Job %s skipped, %s", job.Name, err)
+			l.events.Emit(Event{Type: EventError, RunId: job.RunId, Job: job.Name, Error: err.Error()})
+			l.mutext.Lock()
+			if err = l.SetWorkloadState(workload, database.WorkloadStateError); err != nil {
+				log.Println("error found setting workload error", err)
+			}
+			l.mutext.Unlock()
+			return
 		}
-		fmt.Printf("init worker with job %s\n", job.Name)
+		log.WithField("run_id", job.RunId).Infof("init worker with job %s", job.Name)
+
+		workload.TopologyStart = l.captureTopology(worker, job)
 
 		l.mutext.Lock()
-		err := l.SetWorkloadState(workload, database.WorkloadStateRunning)
+		err = l.SetWorkloadState(workload, database.WorkloadStateRunning)
 		if err != nil {
 			log.Println("error found setting workload done", err)
 			return
 		}
+		worker.SetWorkload(workload.Id.Hex(), workload.CommandId.Hex())
+		worker.SetBudget(l.budget)
+		worker.SetFairness(l.fairness)
 		l.workers[workload.Id.String()] = worker
+		l.preempt()
 		l.mutext.Unlock()
 		// todo: fix here, no schema data pool will be nill
 
 		// update: workload state
 
 		defer worker.Close()
+		if err := worker.Prepare(); err != nil {
+			log.WithField("run_id", job.RunId).Errorf("Job %s skipped, %s", job.Name, err)
+			l.events.Emit(Event{Type: EventError, RunId: job.RunId, Job: job.Name, Error: err.Error()})
+			l.mutext.Lock()
+			if err = l.SetWorkloadState(workload, database.WorkloadStateError); err != nil {
+				log.Println("error found setting workload error", err)
+			}
+			l.finishJob(&proto.ProgressResponse{
+				RunId: job.RunId, WorkloadId: worker.WorkloadId(), CommandId: worker.CommandId(), JobName: job.Name,
+				JobType: job.Type, IsFinished: true, StopReason: stopReasonPrepareFailed,
+			})
+			delete(l.workers, workload.Id.String())
+			l.preempt()
+			l.mutext.Unlock()
+			return
+		}
 		worker.InitMetrics()
+		l.events.Emit(Event{Type: EventJobStarted, RunId: job.RunId, Job: job.Name})
+		statsDone := make(chan struct{})
+		go l.emitStats(worker, job.RunId, statsDone)
 		// workaround
 		worker.Work(l.changed)
+		close(statsDone)
+		worker.DropIndexes()
+		runLog := log.WithField("run_id", job.RunId)
+		runLog.Infof("Job %s stopped by %s", job.Name, worker.StopReason())
+		jobStats := NewEventStats(worker.Metrics)
+		l.addJobResult(job.RunId, jobResult{
+			workloadId: worker.WorkloadId(), name: job.Name, jobType: job.Type, stopReason: worker.StopReason(), stats: jobStats,
+		})
+		l.events.Emit(Event{
+			Type: EventJobFinished, RunId: job.RunId, Job: job.Name, Reason: worker.StopReason(), Stats: jobStats,
+		})
+		if leaks := worker.Leaks(); leaks != nil && leaks.Any() {
+			runLog.Warnf("Job %s leaked resources, %s", job.Name, leaks)
+		}
+		if report := worker.CollectionReport(); len(report) > 0 {
+			runLog.Infof("Job %s collections:", job.Name)
+			for _, line := range report {
+				runLog.Infof("   %s", line)
+			}
+		}
+		if report := worker.Metrics.SLAReport(); len(report) > 0 {
+			runLog.Infof("Job %s operations within SLA:", job.Name)
+			for _, line := range report {
+				runLog.Infof("   %s", line)
+			}
+		}
+		if report := worker.BackupReport(); len(report) > 0 {
+			runLog.Infof("Job %s backup impact:", job.Name)
+			for _, line := range report {
+				runLog.Infof("   %s", line)
+			}
+		}
+		if report := worker.DocumentSizeReport(); len(report) > 0 {
+			runLog.Infof("Job %s average document size:", job.Name)
+			for _, line := range report {
+				runLog.Infof("   %s", line)
+			}
+		}
+		if report := worker.BurstReport(); len(report) > 0 {
+			runLog.Infof("Job %s bursts:", job.Name)
+			for _, line := range report {
+				runLog.Infof("   %s", line)
+			}
+		}
+		if report := worker.FairnessReport(); len(report) > 0 {
+			runLog.Infof("Job %s fairness:", job.Name)
+			for _, line := range report {
+				runLog.Infof("   %s", line)
+			}
+		}
+		if changes := worker.PlanChanges(); len(changes) > 0 {
+			runLog.Warnf("Job %s query plan changed %d times:", job.Name, len(changes))
+			for _, change := range changes {
+				runLog.Warnf("   %s", change)
+			}
+		}
 		// worker.Summary()
+		if l.Config.Agent != nil && l.Config.Agent.EstimateAtlasCost {
+			runLog.Infof("Job %s: %s", job.Name, cost.EstimateAtlasTier(worker.Usage()))
+		}
 		worker.ExtendCopySavedFieldsToDataPool()
 
+		if job.CompareRouting && !worker.IsCancelled() {
+			l.compareRouting(cfg, workload, job, dataPool, worker)
+		}
+
+		if !worker.IsCancelled() {
+			// cancelled worker is already disconnected
+			workload.TopologyEnd = l.captureTopology(worker, job)
+		}
+		l.saveReport(worker, workload)
+
 		l.mutext.Lock()
 		err = l.SetWorkloadState(workload, database.WorkloadStateDone)
 		if err != nil {
 			log.Println("error found setting workload done", err)
 		}
+		l.finishJob(NewProgressResponse(worker, true))
 		delete(l.workers, workload.Id.String())
+		l.preempt()
 		l.mutext.Unlock()
 	}()
 	l.done <- true
 }
 
-func (l *Lbot) Cancel() error {
-	for _, worker := range l.workers {
-		worker.Cancel()
+// captureTopology returns cluster snapshot saved with workload results, failure is logged and doesn't stop job
+func (l *Lbot) captureTopology(w *worker.Worker, job config.Job) *database.Topology {
+	topology, err := w.Topology()
+	if err != nil {
+		log.WithField("run_id", job.RunId).Warnf("Job %s capturing cluster topology failed: %s", job.Name, err)
+	} else if topology != nil {
+		log.WithField("run_id", job.RunId).Infof("Job %s cluster: %s", job.Name, topology)
 	}
-	l.workers = map[string]*worker.Worker{}
+	return topology
+}
 
-	return nil
+func (l *Lbot) Cancel(gracePeriod time.Duration) []*proto.StoppedWorkload {
+	return l.CancelTenant(nil, gracePeriod, false)
+}
+
+// CancelTenant cancels workloads owned by tenant (all if tenant is nil) and returns their final stats,
+// workers are cancelled in parallel so whole stop takes at most grace period, force skips grace period
+func (l *Lbot) CancelTenant(tenant *config.Tenant, gracePeriod time.Duration, force bool) []*proto.StoppedWorkload {
+	stopped := l.cancelWorkers(func(w *worker.Worker) bool { return ownsWorker(tenant, w) }, gracePeriod, force)
+	l.mutext.Lock()
+	runs := lo.Filter(lo.Values(l.runs), func(r *run, _ int) bool { return lo.IsNil(tenant) || r.tenant == tenant.Name })
+	l.mutext.Unlock()
+	for _, r := range runs {
+		l.recordRun(r, RunStatusStopped, stopped)
+	}
+	l.forgetRun(tenant)
+	return stopped
+}
+
+// CancelRun cancels workloads of run started by this agent, other runs keep running
+func (l *Lbot) CancelRun(tenant *config.Tenant, runId string, gracePeriod time.Duration, force bool) ([]*proto.StoppedWorkload, error) {
+	current, err := l.findRun(tenant, runId)
+	if err != nil {
+		return nil, err
+	}
+	stopped := l.cancelWorkers(func(w *worker.Worker) bool { return w.RunId() == runId }, gracePeriod, force)
+	l.recordRun(current, RunStatusStopped, stopped)
+	l.forgetRunId(runId)
+	return stopped, nil
+}
+
+// cancelWorkers cancels workers matching filter and returns their final stats
+func (l *Lbot) cancelWorkers(filter func(w *worker.Worker) bool, gracePeriod time.Duration, force bool) []*proto.StoppedWorkload {
+	if force {
+		gracePeriod = 0
+	}
+
+	l.mutext.Lock()
+	workers := lo.PickBy(l.workers, func(_ string, worker *worker.Worker) bool { return filter(worker) })
+	for id := range workers {
+		delete(l.workers, id)
+	}
+	l.preempt()
+	l.mutext.Unlock()
+
+	var wg sync.WaitGroup
+	stopped := make([]*proto.StoppedWorkload, 0, len(workers))
+	for _, w := range workers {
+		stat := &proto.StoppedWorkload{
+			WorkloadId: w.WorkloadId(),
+			CommandId:  w.CommandId(),
+			JobName:    w.JobName(),
+			JobType:    w.JobType(),
+		}
+		stopped = append(stopped, stat)
+
+		wg.Add(1)
+		go func(w *worker.Worker) {
+			defer wg.Done()
+			stat.Abandoned = w.Cancel(gracePeriod)
+			stat.Requests = w.Metrics.Requests()
+			stat.Errors = w.Metrics.Errors()
+			stat.Duration = w.Metrics.DurationSeconds()
+			switch {
+			case force:
+				stat.Status = StopStatusForced
+			case stat.Abandoned > 0:
+				stat.Status = StopStatusGracePeriodExceeded
+			default:
+				stat.Status = StopStatusDrained
+			}
+			if stat.Abandoned > 0 && !force {
+				log.Warnf("Job %s: %d operations abandoned after %s grace period", stat.JobName, stat.Abandoned, gracePeriod)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	return stopped
 }
 
 func (l *Lbot) InitAgent(id primitive.ObjectID, name string) error {
@@ -158,66 +510,114 @@ func (l *Lbot) InitAgent(id primitive.ObjectID, name string) error {
 		return errors.Wrap(err, "get cluster time")
 	}
 
+	host, _ := os.Hostname()
 	agentStatus := database.AgentStatus{
 		Id:        id,
 		Name:      name,
+		Host:      host,
 		CreatedAt: *ct,
+		Version:   Version,
+		Cluster:   database.ClusterAddress(l.Config.ConnectionString),
 	}
 
 	return l.internalClient.AddAgentStatus(agentStatus)
 }
 
 func (l *Lbot) AgentHeartBeat(id primitive.ObjectID, name string) error {
+	host, _ := os.Hostname()
+	l.mutext.Lock()
+	runningWorkers := len(l.workers)
+	jobs := lo.MapToSlice(l.workers, func(_ string, w *worker.Worker) string { return w.JobName() })
+	l.mutext.Unlock()
+	slices.Sort(jobs)
 	agentStatus := database.AgentStatus{
-		Id:   id,
-		Name: name,
+		Id:             id,
+		Name:           name,
+		Host:           host,
+		RunningWorkers: runningWorkers,
+		Version:        Version,
+		Jobs:           jobs,
+		Cluster:        database.ClusterAddress(l.Config.ConnectionString),
 	}
 
 	return l.internalClient.SaveAgentStatus(agentStatus)
 }
 
+// HandleWorkload starts new workloads, agent runs at most one workload of every command,
+// so workloads of parallel jobs are spread over agents
 func (l *Lbot) HandleWorkload() {
 	log.Println("Fetching new workloads")
-	// todo: change to commands
-	workload, err := l.internalClient.GetNewWorkloads()
-	if err != nil {
-		return
-	}
-	log.Println("Fetched new workload with: ", workload.Id.String())
-
-	switch workload.State {
-	case database.WorkloadStateCreated.String():
-
-		err := l.SetWorkloadState(workload, database.WorkloadStateToRun)
+	l.mutext.Lock()
+	running := lo.Uniq(lo.FilterMap(lo.Values(l.workers), func(w *worker.Worker, _ int) (primitive.ObjectID, bool) {
+		id, err := primitive.ObjectIDFromHex(w.CommandId())
+		return id, err == nil
+	}))
+	l.mutext.Unlock()
+
+	for {
+		// todo: change to commands
+		workload, err := l.internalClient.GetNewWorkloads(running)
 		if err != nil {
-			log.Println("Fetched command with: ", err)
-			// if not saved, propably other agent taked
 			return
 		}
+		log.Println("Fetched new workload with: ", workload.Id.String())
+		running = append(running, workload.CommandId)
 
-		go l.StartWorkload(workload)
-	case database.WorkloadStateToDelete.String():
+		switch workload.State {
+		case database.WorkloadStateCreated.String():
 
-		// remove awaiting batches
-		// change
-		// send stop commands
-		// todo: add arg workload, if nil stop all
-		go l.Cancel()
+			err := l.SetWorkloadState(workload, database.WorkloadStateToRun)
+			if err != nil {
+				log.Println("Fetched command with: ", err)
+				// if not saved, propably other agent taked
+				continue
+			}
+
+			go l.StartWorkload(workload)
+		case database.WorkloadStateToDelete.String():
+
+			// remove awaiting batches
+			// change
+			// send stop commands
+			// todo: add arg workload, if nil stop all
+			go l.Cancel(config.DefaultStopGracePeriod)
+		}
 	}
 }
 
+// HandleCommand moves unfinished commands forward, commands are started once commands they depend
+// on are done, so independent commands run in parallel
 func (l *Lbot) HandleCommand() {
 	// todo: change to generic abstraction
 	log.Println("Fetching not finished commands")
-	// todo: change to commands
-	command, err := l.internalClient.GetNextUnFinishedCommand()
+	commands, err := l.internalClient.GetUnfinishedCommands()
 	if err != nil {
 		return
 	}
+	for _, command := range commands {
+		l.handleCommand(command)
+	}
+}
+
+func (l *Lbot) handleCommand(command *database.Command) {
 	log.Println("Fetched command with: ", command.Id.String())
 
 	switch command.State {
 	case database.CommandStateCreated.String():
+		ready, err := l.areDependenciesDone(command)
+		if err != nil {
+			log.WithField("run_id", command.Data.RunId).Errorf("Job %s skipped, %s", command.Data.Name, err)
+			l.events.Emit(Event{Type: EventError, RunId: command.Data.RunId, Job: command.Data.Name, Error: err.Error()})
+			if err := l.SetCommandState(command, database.CommandStateError); err != nil {
+				return
+			}
+			l.emitRunFinished(command.Id.Hex())
+			return
+		}
+		if !ready {
+			return
+		}
+
 		log.Println("Set command: ", command.Id.String(), " - running")
 		if err := l.SetCommandState(command, database.CommandStateRunning); err != nil {
 			return
@@ -234,21 +634,52 @@ func (l *Lbot) HandleCommand() {
 
 	case database.CommandStateRunning.String():
 
-		finished, err := l.AreWorkloadsFinished(command)
+		workloads, err := l.internalClient.GetCommandWorkloads(command)
 		if err != nil {
 			return
 		}
 
-		if finished {
-			log.Println("Set command: ", command.Id.String(), " - done")
-			if err := l.SetCommandState(command, database.CommandStateDone); err != nil {
+		if areWorkloadsFinished(workloads) {
+			// failed command fails jobs depending on it
+			state := database.CommandStateDone
+			if lo.SomeBy(workloads, func(w *database.Workload) bool { return w.State == database.WorkloadStateError.String() }) {
+				state = database.CommandStateError
+			}
+			log.Println("Set command: ", command.Id.String(), " - ", state.String())
+			if err := l.SetCommandState(command, state); err != nil {
 				return
 			}
+			l.emitRunFinished(command.Id.Hex())
 		}
-		// check if everything is done and I can set command done
 	}
 }
 
+// areDependenciesDone checks if commands which command depends on are done, fails when any of them
+// failed or was removed, sequential command only waits for them to finish
+func (l *Lbot) areDependenciesDone(command *database.Command) (bool, error) {
+	if len(command.DependsOn) == 0 {
+		return true, nil
+	}
+	states, err := l.internalClient.GetCommandStates(l.ctx, command.DependsOn)
+	if err != nil {
+		return false, nil
+	}
+	for i, id := range command.DependsOn {
+		state, ok := states[id]
+		failed := !ok || state == database.CommandStateError.String()
+		switch {
+		case failed && command.Sequential:
+		case failed && i < len(command.Data.DependsOn):
+			return false, fmt.Errorf("job %s it depends on failed", command.Data.DependsOn[i])
+		case failed:
+			return false, fmt.Errorf("job it depends on failed")
+		case state != database.CommandStateDone.String():
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 func (l *Lbot) IsMasterAgent(agentId primitive.ObjectID) (bool, error) {
 	return l.internalClient.IsMasterAgent(agentId)
 }
@@ -272,21 +703,20 @@ func (l *Lbot) UpdateRunningAgents() error {
 	return nil
 }
 
-func (l *Lbot) GetNextUnFinishedCommand() (*database.Command, error) {
-	return l.internalClient.GetNextUnFinishedCommand()
-}
-
 func (l *Lbot) AreWorkloadsFinished(command *database.Command) (bool, error) {
 	workloads, err := l.internalClient.GetCommandWorkloads(command)
 	if err != nil {
 		return false, err
 	}
+	return areWorkloadsFinished(workloads), nil
+}
 
+func areWorkloadsFinished(workloads []*database.Workload) bool {
 	finished := lo.Filter(workloads, func(w *database.Workload, index int) bool {
 		return w.State == database.WorkloadStateDone.String() || w.State == database.WorkloadStateError.String()
 	})
 
-	return len(workloads) == len(finished) && len(workloads) != 0, nil
+	return len(workloads) == len(finished) && len(workloads) != 0
 }
 
 func (l *Lbot) GenerateWorkload(command *database.Command) ([]*database.Workload, error) {
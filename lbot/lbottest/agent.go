@@ -0,0 +1,130 @@
+package lbottest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot"
+	"github.com/kuzxnia/loadbot/lbot/agent"
+	"github.com/kuzxnia/loadbot/lbot/client"
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+)
+
+// how often progress of started jobs is polled
+const PollInterval = 200 * time.Millisecond
+
+// Agent is loadbot agent running in test process, custom job types registered with worker.RegisterJobType
+// in test are available to its jobs
+type Agent struct {
+	Lbot    *lbot.Lbot
+	Address string
+	Client  *client.Client
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// Result holds progress of finished jobs of run
+type Result struct {
+	RunId string
+	Jobs  []client.JobProgress
+}
+
+// LoadConfig parses workload config file the way agent does, without agent section it's added
+func LoadConfig(path string) (*config.Config, error) {
+	request, err := lbot.ParseConfigFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("parsing config %s failed: %w", path, err)
+	}
+	if request.Agent == nil {
+		request.Agent = &lbot.AgentRequest{}
+	}
+	return lbot.NewConfig(request), nil
+}
+
+// StartAgent starts agent with config on free port and connects to it, agent name defaults to random one
+func StartAgent(cfg *config.Config) (*Agent, error) {
+	ports, err := freePorts(1)
+	if err != nil {
+		return nil, err
+	}
+	agentConfig := config.Agent{}
+	if cfg.Agent != nil {
+		agentConfig = *cfg.Agent
+	}
+	agentConfig.Port = fmt.Sprint(ports[0])
+	agentConfig.Name = lo.Ternary(agentConfig.Name == "", "lbottest-"+primitive.NewObjectID().Hex(), agentConfig.Name)
+	cfg.Agent = &agentConfig
+
+	ctx, cancel := context.WithCancel(context.Background())
+	loadbot, err := lbot.NewLbot(ctx, cfg)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	a := &Agent{Lbot: loadbot, Address: "127.0.0.1:" + agentConfig.Port, cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(a.done)
+		agent.NewAgent(ctx, loadbot).Run()
+	}()
+
+	// agent may not listen yet
+	a.Client, err = client.Connect(a.Address, client.Options{
+		DialOptions: []grpc.DialOption{grpc.WithDefaultCallOptions(grpc.WaitForReady(true))},
+	})
+	if err != nil {
+		a.Stop()
+		return nil, fmt.Errorf("connecting to agent failed: %w", err)
+	}
+	return a, nil
+}
+
+// RunWorkload starts all jobs of agent config and waits until they finish, result has final stats of every
+// job, also of ones finished long before the last one. Footprint thresholds are confirmed, runs of protected
+// agents can't be awaited
+func (a *Agent) RunWorkload(ctx context.Context) (*Result, error) {
+	run, err := a.Client.Start(ctx, client.StartOptions{Confirmed: true})
+	if err != nil {
+		return nil, err
+	}
+	if run.ApprovalId != "" {
+		return nil, fmt.Errorf("agent is protected, start %s waits for approval", run.ApprovalId)
+	}
+	jobs, err := a.Client.Wait(ctx, run, PollInterval)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{RunId: run.Id, Jobs: jobs}, nil
+}
+
+// Stop stops running workloads and agent
+func (a *Agent) Stop() error {
+	if a.Client != nil {
+		a.Client.Close()
+	}
+	a.cancel()
+	<-a.done
+	return a.Lbot.Close()
+}
+
+// Job returns progress of job by name, nil when job didn't run
+func (r *Result) Job(name string) *client.JobProgress {
+	job, ok := lo.Find(r.Jobs, func(job client.JobProgress) bool { return job.JobName == name })
+	if !ok {
+		return nil
+	}
+	return &job
+}
+
+// Requests returns number of operations of all jobs
+func (r *Result) Requests() uint64 {
+	return lo.SumBy(r.Jobs, func(job client.JobProgress) uint64 { return job.Requests })
+}
+
+// Errors returns number of failed operations of all jobs
+func (r *Result) Errors() uint64 {
+	return lo.SumBy(r.Jobs, func(job client.JobProgress) uint64 { return job.Errors })
+}
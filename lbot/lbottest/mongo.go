@@ -0,0 +1,362 @@
+// Package lbottest runs MongoDB topologies in docker containers and loadbot agents in test process,
+// so workload configs and custom job types can be covered by Go integration tests:
+//
+//	func TestOrdersWorkload(t *testing.T) {
+//		mongo := lbottest.NewMongo(t, lbottest.MongoOptions{Topology: lbottest.ReplicaSet})
+//		cfg, err := lbottest.LoadConfig("testdata/orders.json")
+//		require.NoError(t, err)
+//		result := lbottest.Run(t, mongo.ConnectionString, cfg)
+//		assert.Zero(t, result.Errors())
+//	}
+package lbottest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Topology string
+
+const (
+	Standalone Topology = "standalone"
+	ReplicaSet Topology = "replica_set"
+	Sharded    Topology = "sharded"
+)
+
+const (
+	DefaultMongoImage        = "mongo:7"
+	DefaultMongoStartTimeout = 2 * time.Minute
+	DefaultReplicaSetMembers = 3
+	DefaultShards            = 2
+)
+
+// MongoOptions describe started topology, zero values are replaced with defaults
+type MongoOptions struct {
+	Topology Topology // default standalone
+	Image    string
+	// members of replica set, or of every shard replica set
+	Members      int
+	Shards       int
+	StartTimeout time.Duration
+}
+
+func (o MongoOptions) withDefaults() MongoOptions {
+	o.Topology = lo.Ternary(o.Topology == "", Standalone, o.Topology)
+	o.Image = lo.Ternary(o.Image == "", DefaultMongoImage, o.Image)
+	o.StartTimeout = lo.Ternary(o.StartTimeout == 0, DefaultMongoStartTimeout, o.StartTimeout)
+	switch o.Topology {
+	case ReplicaSet:
+		o.Members = lo.Ternary(o.Members == 0, DefaultReplicaSetMembers, o.Members)
+	case Sharded:
+		o.Members = lo.Ternary(o.Members == 0, 1, o.Members)
+		o.Shards = lo.Ternary(o.Shards == 0, DefaultShards, o.Shards)
+	}
+	return o
+}
+
+// Mongo is topology running in single docker container, every mongod and mongos listens on its own
+// port published on 127.0.0.1 under the same number, so members advertise addresses reachable both
+// from container and from test process
+type Mongo struct {
+	Options          MongoOptions
+	ConnectionString string
+	containerId      string
+}
+
+// mongod is process of topology
+type mongod struct {
+	name       string
+	port       int
+	replicaSet string
+	args       []string
+}
+
+// StartMongo starts topology and waits until it accepts writes, requires docker
+func StartMongo(options MongoOptions) (*Mongo, error) {
+	options = options.withDefaults()
+	if options.Members < 0 || options.Shards < 0 {
+		return nil, fmt.Errorf("members and shards can't be negative")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), options.StartTimeout)
+	defer cancel()
+
+	processes, mongosPort, err := plan(options)
+	if err != nil {
+		return nil, err
+	}
+	ports := lo.Map(processes, func(process mongod, _ int) int { return process.port })
+	if mongosPort != 0 {
+		ports = append(ports, mongosPort)
+	}
+
+	log.Infof("Starting %s mongo from %s image", options.Topology, options.Image)
+	args := []string{"run", "-d", "--rm", "--entrypoint", "sleep"}
+	for _, port := range ports {
+		args = append(args, "-p", fmt.Sprintf("127.0.0.1:%d:%d", port, port))
+	}
+	containerId, err := docker(append(args, options.Image, "infinity")...)
+	if err != nil {
+		return nil, fmt.Errorf("starting mongo container failed: %w", err)
+	}
+	m := &Mongo{Options: options, containerId: containerId}
+
+	if err = m.start(ctx, processes, mongosPort); err != nil {
+		m.Stop()
+		return nil, err
+	}
+	log.Infof("Mongo %s started on %s", options.Topology, m.ConnectionString)
+	return m, nil
+}
+
+// plan returns processes of topology on free ports, and port of mongos for sharded topology
+func plan(options MongoOptions) (processes []mongod, mongosPort int, err error) {
+	count := lo.Ternary(options.Topology == Sharded, 2+options.Shards*options.Members, max(options.Members, 1))
+	ports, err := freePorts(count)
+	if err != nil {
+		return nil, 0, err
+	}
+	switch options.Topology {
+	case Standalone:
+		processes = []mongod{{name: "standalone", port: ports[0]}}
+	case ReplicaSet:
+		for i := 0; i < options.Members; i++ {
+			processes = append(processes, mongod{name: fmt.Sprintf("rs0-%d", i), port: ports[i], replicaSet: "rs0"})
+		}
+	case Sharded:
+		mongosPort = ports[0]
+		processes = []mongod{{name: "cfg-0", port: ports[1], replicaSet: "cfg", args: []string{"--configsvr"}}}
+		for shard := 0; shard < options.Shards; shard++ {
+			for i := 0; i < options.Members; i++ {
+				processes = append(processes, mongod{
+					name:       fmt.Sprintf("shard%d-%d", shard, i),
+					port:       ports[2+shard*options.Members+i],
+					replicaSet: fmt.Sprintf("shard%d", shard),
+					args:       []string{"--shardsvr"},
+				})
+			}
+		}
+	default:
+		return nil, 0, fmt.Errorf("unknown topology %q, must be one of: %s, %s, %s", options.Topology, Standalone, ReplicaSet, Sharded)
+	}
+	return processes, mongosPort, nil
+}
+
+func (m *Mongo) start(ctx context.Context, processes []mongod, mongosPort int) error {
+	for _, process := range processes {
+		args := []string{
+			"exec", m.containerId, "mongod", "--port", fmt.Sprint(process.port), "--bind_ip_all",
+			"--dbpath", "/data/db/" + process.name, "--fork", "--logpath", "/data/db/" + process.name + ".log",
+		}
+		if process.replicaSet != "" {
+			args = append(args, "--replSet", process.replicaSet)
+		}
+		if _, err := docker("exec", m.containerId, "mkdir", "-p", "/data/db/"+process.name); err != nil {
+			return err
+		}
+		if _, err := docker(append(args, process.args...)...); err != nil {
+			return fmt.Errorf("starting mongod %s failed: %w", process.name, err)
+		}
+	}
+
+	replicaSets := lo.GroupBy(
+		lo.Filter(processes, func(process mongod, _ int) bool { return process.replicaSet != "" }),
+		func(process mongod) string { return process.replicaSet },
+	)
+	for name, members := range replicaSets {
+		if err := initReplicaSet(ctx, name, members); err != nil {
+			return err
+		}
+	}
+
+	switch m.Options.Topology {
+	case Standalone:
+		m.ConnectionString = fmt.Sprintf("mongodb://127.0.0.1:%d/?directConnection=true", processes[0].port)
+		return waitWritable(ctx, m.ConnectionString)
+	case ReplicaSet:
+		m.ConnectionString = replicaSetConnectionString("rs0", processes)
+		return nil
+	}
+
+	configServer := processes[0]
+	_, err := docker(
+		"exec", m.containerId, "mongos", "--port", fmt.Sprint(mongosPort), "--bind_ip_all",
+		"--configdb", replicaSetHosts(configServer.replicaSet, []mongod{configServer}),
+		"--fork", "--logpath", "/data/db/mongos.log",
+	)
+	if err != nil {
+		return fmt.Errorf("starting mongos failed: %w", err)
+	}
+	m.ConnectionString = fmt.Sprintf("mongodb://127.0.0.1:%d/", mongosPort)
+	for name, members := range replicaSets {
+		if name == configServer.replicaSet {
+			continue
+		}
+		if err = retry(ctx, fmt.Sprintf("adding shard %s", name), func() error {
+			return runAdminCommand(ctx, m.ConnectionString, bson.D{{Key: "addShard", Value: replicaSetHosts(name, members)}})
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// initReplicaSet initiates replica set of members and waits for its primary
+func initReplicaSet(ctx context.Context, name string, members []mongod) error {
+	uri := fmt.Sprintf("mongodb://127.0.0.1:%d/?directConnection=true", members[0].port)
+	err := retry(ctx, fmt.Sprintf("initiating replica set %s", name), func() error {
+		err := runAdminCommand(ctx, uri, bson.D{{Key: "replSetInitiate", Value: replicaSetConfig(name, members)}})
+		if err != nil && strings.Contains(err.Error(), "already initialized") {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return waitWritable(ctx, uri)
+}
+
+func replicaSetConfig(name string, members []mongod) bson.D {
+	config := bson.D{
+		{Key: "_id", Value: name},
+		{Key: "members", Value: lo.Map(members, func(member mongod, i int) bson.D {
+			return bson.D{{Key: "_id", Value: i}, {Key: "host", Value: fmt.Sprintf("127.0.0.1:%d", member.port)}}
+		})},
+	}
+	if lo.Contains(members[0].args, "--configsvr") {
+		config = append(config, bson.E{Key: "configsvr", Value: true})
+	}
+	return config
+}
+
+// replicaSetHosts formats replica set as <name>/<host>,<host>, accepted by addShard and mongos --configdb
+func replicaSetHosts(name string, members []mongod) string {
+	return name + "/" + strings.Join(lo.Map(members, func(member mongod, _ int) string {
+		return fmt.Sprintf("127.0.0.1:%d", member.port)
+	}), ",")
+}
+
+func replicaSetConnectionString(name string, members []mongod) string {
+	return fmt.Sprintf("mongodb://%s/?replicaSet=%s", strings.Join(lo.Map(members, func(member mongod, _ int) string {
+		return fmt.Sprintf("127.0.0.1:%d", member.port)
+	}), ","), name)
+}
+
+// waitWritable waits until node is writable primary (or standalone)
+func waitWritable(ctx context.Context, uri string) error {
+	return retry(ctx, "waiting for primary", func() error {
+		client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+		if err != nil {
+			return err
+		}
+		defer client.Disconnect(context.Background())
+		var hello struct {
+			IsWritablePrimary bool `bson:"isWritablePrimary"`
+		}
+		if err = client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+			return err
+		}
+		if !hello.IsWritablePrimary {
+			return fmt.Errorf("node is not writable primary")
+		}
+		return nil
+	})
+}
+
+func runAdminCommand(ctx context.Context, uri string, command bson.D) error {
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(context.Background())
+	return client.Database("admin").RunCommand(ctx, command).Err()
+}
+
+// retry calls f every half second until it succeeds or context is done
+func retry(ctx context.Context, action string, f func() error) error {
+	for {
+		err := f()
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s failed: %w", action, err)
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// Reset drops all databases except admin, config and local, including loadbot internal database
+// with agents and runs, so topology can be reused by next test
+func (m *Mongo) Reset(ctx context.Context) error {
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(m.ConnectionString))
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(context.Background())
+	names, err := client.ListDatabaseNames(ctx, bson.M{"name": bson.M{"$nin": bson.A{"admin", "config", "local"}}})
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err = client.Database(name).Drop(ctx); err != nil {
+			return fmt.Errorf("dropping database %s failed: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Stop removes container of topology
+func (m *Mongo) Stop() error {
+	log.Infof("Stopping %s mongo", m.Options.Topology)
+	_, err := docker("rm", "-f", m.containerId)
+	return err
+}
+
+// freePorts returns free tcp ports of 127.0.0.1, ports are released before they're published
+// so they can be taken in between, which is unlikely
+func freePorts(count int) ([]int, error) {
+	ports := make([]int, 0, count)
+	for i := 0; i < count; i++ {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return nil, fmt.Errorf("finding free port failed: %w", err)
+		}
+		defer listener.Close()
+		ports = append(ports, listener.Addr().(*net.TCPAddr).Port)
+	}
+	return ports, nil
+}
+
+// DockerAvailable reports whether docker cli is installed and its daemon responds
+func DockerAvailable() bool {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return false
+	}
+	_, err := docker("info", "--format", "{{.ServerVersion}}")
+	return err == nil
+}
+
+// docker runs docker command and returns its stdout, stderr (ex. image pull progress) is only part of error
+func docker(args ...string) (string, error) {
+	var stderr bytes.Buffer
+	command := exec.Command("docker", args...)
+	command.Stderr = &stderr
+	output, err := command.Output()
+	if err != nil {
+		return "", fmt.Errorf("docker %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,114 @@
+package lbottest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestPlan(t *testing.T) {
+	processes, mongosPort, err := plan(MongoOptions{Topology: Sharded, Shards: 2, Members: 2}.withDefaults())
+	assert.NoError(t, err)
+	assert.NotZero(t, mongosPort)
+	assert.Len(t, processes, 5)
+	assert.Equal(t, "cfg", processes[0].replicaSet)
+	assert.Equal(t, []string{"--configsvr"}, processes[0].args)
+	assert.Equal(t, "shard1", processes[4].replicaSet)
+
+	processes, mongosPort, err = plan(MongoOptions{Topology: ReplicaSet}.withDefaults())
+	assert.NoError(t, err)
+	assert.Zero(t, mongosPort)
+	assert.Len(t, processes, DefaultReplicaSetMembers)
+
+	_, _, err = plan(MongoOptions{Topology: "cluster"}.withDefaults())
+	assert.Error(t, err)
+}
+
+func TestReplicaSetAddresses(t *testing.T) {
+	members := []mongod{{port: 30001, replicaSet: "rs0"}, {port: 30002, replicaSet: "rs0"}}
+	assert.Equal(t, "rs0/127.0.0.1:30001,127.0.0.1:30002", replicaSetHosts("rs0", members))
+	assert.Equal(t, "mongodb://127.0.0.1:30001,127.0.0.1:30002/?replicaSet=rs0", replicaSetConnectionString("rs0", members))
+	assert.Equal(t, bson.D{
+		{Key: "_id", Value: "rs0"},
+		{Key: "members", Value: []bson.D{
+			{{Key: "_id", Value: 0}, {Key: "host", Value: "127.0.0.1:30001"}},
+			{{Key: "_id", Value: 1}, {Key: "host", Value: "127.0.0.1:30002"}},
+		}},
+	}, replicaSetConfig("rs0", members))
+}
+
+func TestRunWorkload(t *testing.T) {
+	for _, topology := range []Topology{Standalone, ReplicaSet, Sharded} {
+		t.Run(string(topology), func(t *testing.T) {
+			mongo := NewMongo(t, MongoOptions{Topology: topology})
+			result := Run(t, mongo.ConnectionString, &config.Config{
+				Jobs: []*config.Job{{
+					Name: "inserts", Type: string(config.Write), Database: "lbottest", Collection: "inserts",
+					Connections: 2, Operations: 100, Timeout: time.Second,
+				}},
+			})
+			assert.Equal(t, uint64(100), result.Job("inserts").Requests)
+			assert.Zero(t, result.Errors())
+		})
+	}
+}
+
+func TestRunWorkloadWaitsForSequentialJobs(t *testing.T) {
+	mongo := NewMongo(t, MongoOptions{Topology: Standalone})
+	// workers of finished jobs are gone before the last job ends
+	result := Run(t, mongo.ConnectionString, &config.Config{
+		Jobs: []*config.Job{
+			{Name: "seed", Type: string(config.Write), Database: "lbottest", Collection: "sequential", Connections: 2, Operations: 50, Timeout: time.Second},
+			{Name: "reads", Type: string(config.Read), Database: "lbottest", Collection: "sequential", Connections: 2, Operations: 50, Timeout: time.Second},
+		},
+	})
+	assert.Len(t, result.Jobs, 2)
+	assert.Equal(t, uint64(50), result.Job("seed").Requests)
+	assert.Equal(t, uint64(50), result.Job("reads").Requests)
+}
+
+func TestHotSpotUpdatesDocuments(t *testing.T) {
+	cluster := NewMongo(t, MongoOptions{Topology: Standalone})
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cluster.ConnectionString))
+	require.NoError(t, err)
+	defer client.Disconnect(ctx)
+	collection := client.Database("lbottest").Collection("hotspot")
+	accounts := []interface{}{}
+	for account := 0; account < 4; account++ {
+		accounts = append(accounts, bson.M{"account": account, "hits": 0})
+	}
+	_, err = collection.InsertMany(ctx, accounts)
+	require.NoError(t, err)
+
+	result := Run(t, cluster.ConnectionString, &config.Config{
+		Jobs: []*config.Job{{
+			Name: "hot", Type: string(config.Update), Database: "lbottest", Collection: "hotspot",
+			Connections: 2, Operations: 100, Timeout: time.Second,
+			Update:      map[string]interface{}{"$inc": map[string]interface{}{"hits": 1.0}},
+			HotFraction: 1, HotDocuments: 4, HotField: "account",
+		}},
+	})
+	assert.Zero(t, result.Errors())
+
+	// every hot update modified one of seeded documents
+	cursor, err := collection.Find(ctx, bson.M{})
+	require.NoError(t, err)
+	var documents []struct {
+		Hits float64 `bson:"hits"`
+	}
+	require.NoError(t, cursor.All(ctx, &documents))
+	var hits float64
+	for _, document := range documents {
+		hits += document.Hits
+	}
+	assert.Len(t, documents, 4)
+	assert.Equal(t, float64(100), hits)
+}
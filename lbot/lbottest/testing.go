@@ -0,0 +1,53 @@
+package lbottest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+)
+
+// NewMongo starts topology for test and removes it when test finishes, test is skipped in short mode
+// or when docker isn't available
+func NewMongo(t testing.TB, options MongoOptions) *Mongo {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping mongo integration test in short mode")
+	}
+	if !DockerAvailable() {
+		t.Skip("skipping mongo integration test, docker is not available")
+	}
+	m, err := StartMongo(options)
+	if err != nil {
+		t.Fatalf("starting mongo failed: %s", err)
+	}
+	t.Cleanup(func() { m.Stop() })
+	return m
+}
+
+// Run runs all jobs of config against connection string in agent started in test process and returns
+// result once they finish, run is limited by test deadline
+func Run(t testing.TB, connectionString string, cfg *config.Config) *Result {
+	t.Helper()
+	cfg.ConnectionString = connectionString
+	agent, err := StartAgent(cfg)
+	if err != nil {
+		t.Fatalf("starting agent failed: %s", err)
+	}
+	defer agent.Stop()
+
+	ctx := context.Background()
+	if deadline, ok := t.(interface{ Deadline() (time.Time, bool) }); ok {
+		if at, set := deadline.Deadline(); set {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithDeadline(ctx, at)
+			defer cancel()
+		}
+	}
+	result, err := agent.RunWorkload(ctx)
+	if err != nil {
+		t.Fatalf("running workload failed: %s", err)
+	}
+	return result
+}
@@ -0,0 +1,44 @@
+package lbot
+
+import (
+	"context"
+
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SetLogLevel changes agent logging verbosity at runtime without interrupting running jobs,
+// empty level only returns current one
+func (l *Lbot) SetLogLevel(level string) (current string, previous string, err error) {
+	previous = log.GetLevel().String()
+	if level == "" {
+		return previous, previous, nil
+	}
+	parsed, err := log.ParseLevel(level)
+	if err != nil {
+		return "", previous, status.Error(codes.InvalidArgument, err.Error())
+	}
+	log.SetLevel(parsed)
+	log.Infof("Log level changed from %s to %s", previous, parsed)
+	return parsed.String(), previous, nil
+}
+
+type LogService struct {
+	proto.UnimplementedLogServiceServer
+	ctx  context.Context
+	lbot *Lbot
+}
+
+func NewLogService(ctx context.Context, lbot *Lbot) *LogService {
+	return &LogService{ctx: ctx, lbot: lbot}
+}
+
+func (s *LogService) SetLogLevel(ctx context.Context, request *proto.SetLogLevelRequest) (*proto.SetLogLevelResponse, error) {
+	level, previous, err := s.lbot.SetLogLevel(request.Level)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.SetLogLevelResponse{Level: level, PreviousLevel: previous}, nil
+}
@@ -0,0 +1,22 @@
+package lbot
+
+import (
+	"github.com/kuzxnia/loadbot/lbot/worker"
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+)
+
+// preempt pauses workers with priority lower than the highest priority of agent workers and resumes the rest,
+// called with mutex held whenever set of workers changes
+func (l *Lbot) preempt() {
+	top := lo.Max(lo.MapToSlice(l.workers, func(_ string, w *worker.Worker) uint64 { return w.Priority() }))
+	for _, w := range l.workers {
+		if w.Priority() < top {
+			if w.Preempt() {
+				log.Infof("Job %s paused, preempted by workload with priority %d", w.JobName(), top)
+			}
+		} else if w.Resume() {
+			log.Infof("Job %s resumed", w.JobName())
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/kuzxnia/loadbot/lbot/config"
 	"github.com/kuzxnia/loadbot/lbot/proto"
 	"github.com/kuzxnia/loadbot/lbot/worker"
 	"github.com/samber/lo"
@@ -22,12 +23,28 @@ func NewProgressProcess(ctx context.Context, lbot *Lbot) *ProgressProcess {
 }
 
 func (p *ProgressProcess) Run(request *proto.ProgressRequest, srv proto.ProgressProcess_RunServer) error {
+	tenant := TenantFromContext(srv.Context())
+	if request.Once {
+		workers, finished := p.lbot.runProgress(tenant, request.RunId)
+		for _, w := range workers {
+			if err := srv.Send(NewProgressResponse(w, w.IsDone())); err != nil {
+				return err
+			}
+		}
+		for _, progress := range finished {
+			if err := srv.Send(progress); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	interval, err := time.ParseDuration(request.RefreshInterval)
 	if err != nil {
 		return err
 	}
 	// refactor
-	if len(lo.Filter(lo.Values(p.lbot.workers), func(worker *worker.Worker, index int) bool { return !worker.IsDone() })) == 0 {
+	if len(lo.Filter(p.lbot.runWorkers(tenant, request.RunId), func(worker *worker.Worker, index int) bool { return !worker.IsDone() })) == 0 {
 		log.Printf("There are no running jobs")
 		return nil
 	}
@@ -35,7 +52,7 @@ func (p *ProgressProcess) Run(request *proto.ProgressRequest, srv proto.Progress
 	done := make(chan bool)
 	ticker := time.NewTicker(interval)
 	go func() {
-    notDoneWorkers := lo.Filter(lo.Values(p.lbot.workers), func(worker *worker.Worker, index int) bool {
+    notDoneWorkers := lo.Filter(p.lbot.runWorkers(tenant, request.RunId), func(worker *worker.Worker, index int) bool {
 			return !worker.IsDone()
 		})
 		for range ticker.C {
@@ -47,24 +64,14 @@ func (p *ProgressProcess) Run(request *proto.ProgressRequest, srv proto.Progress
 			}
 			for _, w := range notDoneWorkers {
 				isWorkerFinished := w.IsDone()
-				resp := proto.ProgressResponse{
-					Requests:          w.Metrics.Requests(),
-					Duration:          uint64(w.Metrics.DurationSeconds()),
-					Rps:               w.Metrics.Rps(),
-					ErrorRate:         w.Metrics.ErrorRate(),
-					IsFinished:        isWorkerFinished,
-					JobName:           w.JobName(),
-					RequestOperations: w.RequestedOperations(),
-					RequestDuration:   w.RequestedDurationSeconds(),
-				}
-				if err := srv.Send(&resp); err != nil {
+				if err := srv.Send(NewProgressResponse(w, isWorkerFinished)); err != nil {
 					// todo: handle client not connected
 					log.Printf("Client closed connection")
 					done <- true
 					return
 				}
 				if isWorkerFinished {
-					notDoneWorkers = lo.Filter(lo.Values(p.lbot.workers), func(worker *worker.Worker, index int) bool {
+					notDoneWorkers = lo.Filter(p.lbot.runWorkers(tenant, request.RunId), func(worker *worker.Worker, index int) bool {
 						return !worker.IsDone()
 					})
 				}
@@ -76,3 +83,51 @@ func (p *ProgressProcess) Run(request *proto.ProgressRequest, srv proto.Progress
 
 	return nil
 }
+
+func NewProgressResponse(w *worker.Worker, isFinished bool) *proto.ProgressResponse {
+	return &proto.ProgressResponse{
+		Requests:          w.Metrics.Requests(),
+		Duration:          uint64(w.Metrics.DurationSeconds()),
+		Rps:               w.Metrics.Rps(),
+		ErrorRate:         w.Metrics.ErrorRate(),
+		IsFinished:        isFinished,
+		JobName:           w.JobName(),
+		RequestOperations: w.RequestedOperations(),
+		RequestDuration:   w.RequestedDurationSeconds(),
+		JobType:           w.JobType(),
+		WorkloadId:        w.WorkloadId(),
+		CommandId:         w.CommandId(),
+		Errors:            w.Metrics.Errors(),
+		WriteConflicts:    w.Metrics.WriteConflicts(),
+		StopReason:        w.StopReason(),
+		AverageLatencyMs:  float32(w.Metrics.AverageLatency().Microseconds()) / 1000,
+		Paused:            w.IsPaused(),
+		RunId:             w.RunId(),
+	}
+}
+
+// runWorkers returns workers of run, all workers if run id is empty, tenant can see only its workers
+func (l *Lbot) runWorkers(tenant *config.Tenant, runId string) []*worker.Worker {
+	l.mutext.Lock()
+	defer l.mutext.Unlock()
+	return l.tenantRunWorkers(tenant, runId)
+}
+
+// tenantRunWorkers filters workers of run visible to tenant, caller holds l.mutext
+func (l *Lbot) tenantRunWorkers(tenant *config.Tenant, runId string) []*worker.Worker {
+	return lo.Filter(lo.Values(l.workers), func(w *worker.Worker, _ int) bool {
+		return (runId == "" || w.RunId() == runId) && ownsWorker(tenant, w)
+	})
+}
+
+// runProgress returns workers of run and final progress of its jobs which workers are already gone,
+// finished jobs are known only for single run
+func (l *Lbot) runProgress(tenant *config.Tenant, runId string) ([]*worker.Worker, []*proto.ProgressResponse) {
+	l.mutext.Lock()
+	defer l.mutext.Unlock()
+	workers := l.tenantRunWorkers(tenant, runId)
+	if current, ok := l.runs[runId]; ok && (lo.IsNil(tenant) || current.tenant == tenant.Name) {
+		return workers, append([]*proto.ProgressResponse(nil), current.finished...)
+	}
+	return workers, nil
+}
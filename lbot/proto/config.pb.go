@@ -11,6 +11,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	anypb "google.golang.org/protobuf/types/known/anypb"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -180,145 +181,1085 @@ func (x *AgentRequest) GetMetricsExportPort() string {
 	return ""
 }
 
+// command document of command job, name is sent first
+type JobCommand struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Template *structpb.Struct `protobuf:"bytes,2,opt,name=template,proto3" json:"template,omitempty"`
+}
+
+func (x *JobCommand) Reset() {
+	*x = JobCommand{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lbot_proto_config_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobCommand) ProtoMessage() {}
+
+func (x *JobCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_lbot_proto_config_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobCommand.ProtoReflect.Descriptor instead.
+func (*JobCommand) Descriptor() ([]byte, []int) {
+	return file_lbot_proto_config_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *JobCommand) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JobCommand) GetTemplate() *structpb.Struct {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+type ScenarioStep struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type   string           `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Filter *structpb.Struct `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	Update *structpb.Struct `protobuf:"bytes,4,opt,name=update,proto3" json:"update,omitempty"`
+	Weight float64          `protobuf:"fixed64,5,opt,name=weight,proto3" json:"weight,omitempty"`
+}
+
+func (x *ScenarioStep) Reset() {
+	*x = ScenarioStep{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lbot_proto_config_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScenarioStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScenarioStep) ProtoMessage() {}
+
+func (x *ScenarioStep) ProtoReflect() protoreflect.Message {
+	mi := &file_lbot_proto_config_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScenarioStep.ProtoReflect.Descriptor instead.
+func (*ScenarioStep) Descriptor() ([]byte, []int) {
+	return file_lbot_proto_config_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ScenarioStep) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ScenarioStep) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ScenarioStep) GetFilter() *structpb.Struct {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ScenarioStep) GetUpdate() *structpb.Struct {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *ScenarioStep) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type Index struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name               string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Keys               []string `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	Unique             bool     `protobuf:"varint,3,opt,name=unique,proto3" json:"unique,omitempty"`
+	Sparse             bool     `protobuf:"varint,4,opt,name=sparse,proto3" json:"sparse,omitempty"`
+	Background         bool     `protobuf:"varint,5,opt,name=background,proto3" json:"background,omitempty"`
+	ExpireAfterSeconds uint64   `protobuf:"varint,6,opt,name=expire_after_seconds,json=expireAfterSeconds,proto3" json:"expire_after_seconds,omitempty"`
+}
+
+func (x *Index) Reset() {
+	*x = Index{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lbot_proto_config_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Index) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Index) ProtoMessage() {}
+
+func (x *Index) ProtoReflect() protoreflect.Message {
+	mi := &file_lbot_proto_config_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Index.ProtoReflect.Descriptor instead.
+func (*Index) Descriptor() ([]byte, []int) {
+	return file_lbot_proto_config_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Index) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Index) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *Index) GetUnique() bool {
+	if x != nil {
+		return x.Unique
+	}
+	return false
+}
+
+func (x *Index) GetSparse() bool {
+	if x != nil {
+		return x.Sparse
+	}
+	return false
+}
+
+func (x *Index) GetBackground() bool {
+	if x != nil {
+		return x.Background
+	}
+	return false
+}
+
+func (x *Index) GetExpireAfterSeconds() uint64 {
+	if x != nil {
+		return x.ExpireAfterSeconds
+	}
+	return 0
+}
+
+type ClientRegion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Weight  float64 `protobuf:"fixed64,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	Latency string  `protobuf:"bytes,3,opt,name=latency,proto3" json:"latency,omitempty"`
+	Jitter  string  `protobuf:"bytes,4,opt,name=jitter,proto3" json:"jitter,omitempty"`
+}
+
+func (x *ClientRegion) Reset() {
+	*x = ClientRegion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lbot_proto_config_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientRegion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientRegion) ProtoMessage() {}
+
+func (x *ClientRegion) ProtoReflect() protoreflect.Message {
+	mi := &file_lbot_proto_config_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientRegion.ProtoReflect.Descriptor instead.
+func (*ClientRegion) Descriptor() ([]byte, []int) {
+	return file_lbot_proto_config_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ClientRegion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ClientRegion) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *ClientRegion) GetLatency() string {
+	if x != nil {
+		return x.Latency
+	}
+	return ""
+}
+
+func (x *ClientRegion) GetJitter() string {
+	if x != nil {
+		return x.Jitter
+	}
+	return ""
+}
+
+type TagSet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tags map[string]string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *TagSet) Reset() {
+	*x = TagSet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lbot_proto_config_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TagSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagSet) ProtoMessage() {}
+
+func (x *TagSet) ProtoReflect() protoreflect.Message {
+	mi := &file_lbot_proto_config_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagSet.ProtoReflect.Descriptor instead.
+func (*TagSet) Descriptor() ([]byte, []int) {
+	return file_lbot_proto_config_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TagSet) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 type JobRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name        string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Database    string     `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`
-	Collection  string     `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
-	Type        string     `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
-	Schema      string     `protobuf:"bytes,5,opt,name=schema,proto3" json:"schema,omitempty"`
-	Connections uint64     `protobuf:"varint,6,opt,name=connections,proto3" json:"connections,omitempty"`
-	Pace        uint64     `protobuf:"varint,7,opt,name=pace,proto3" json:"pace,omitempty"`
-	DataSize    uint64     `protobuf:"varint,8,opt,name=data_size,json=dataSize,proto3" json:"data_size,omitempty"`
-	BatchSize   uint64     `protobuf:"varint,9,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
-	Duration    string     `protobuf:"bytes,10,opt,name=duration,proto3" json:"duration,omitempty"`
-	Operations  uint64     `protobuf:"varint,11,opt,name=operations,proto3" json:"operations,omitempty"`
-	Timeout     string     `protobuf:"bytes,12,opt,name=timeout,proto3" json:"timeout,omitempty"`
-	Filter      *anypb.Any `protobuf:"bytes,13,opt,name=filter,proto3" json:"filter,omitempty"`
+	Name                string              `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Database            string              `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`
+	Collection          string              `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
+	Type                string              `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Schema              string              `protobuf:"bytes,5,opt,name=schema,proto3" json:"schema,omitempty"`
+	Connections         uint64              `protobuf:"varint,6,opt,name=connections,proto3" json:"connections,omitempty"`
+	Pace                uint64              `protobuf:"varint,7,opt,name=pace,proto3" json:"pace,omitempty"`
+	DataSize            uint64              `protobuf:"varint,8,opt,name=data_size,json=dataSize,proto3" json:"data_size,omitempty"`
+	BatchSize           uint64              `protobuf:"varint,9,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	Duration            string              `protobuf:"bytes,10,opt,name=duration,proto3" json:"duration,omitempty"`
+	Operations          uint64              `protobuf:"varint,11,opt,name=operations,proto3" json:"operations,omitempty"`
+	Timeout             string              `protobuf:"bytes,12,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Filter              *anypb.Any          `protobuf:"bytes,13,opt,name=filter,proto3" json:"filter,omitempty"`
+	HistogramBuckets    []float64           `protobuf:"fixed64,14,rep,packed,name=histogram_buckets,json=histogramBuckets,proto3" json:"histogram_buckets,omitempty"`
+	NativeHistogram     bool                `protobuf:"varint,15,opt,name=native_histogram,json=nativeHistogram,proto3" json:"native_histogram,omitempty"`
+	Tenant              string              `protobuf:"bytes,16,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	EvolutionRatio      float64             `protobuf:"fixed64,17,opt,name=evolution_ratio,json=evolutionRatio,proto3" json:"evolution_ratio,omitempty"`
+	ReturnDocument      string              `protobuf:"bytes,18,opt,name=return_document,json=returnDocument,proto3" json:"return_document,omitempty"`
+	ConsumerRatio       float64             `protobuf:"fixed64,19,opt,name=consumer_ratio,json=consumerRatio,proto3" json:"consumer_ratio,omitempty"`
+	HotDocuments        uint64              `protobuf:"varint,20,opt,name=hot_documents,json=hotDocuments,proto3" json:"hot_documents,omitempty"`
+	Pagination          string              `protobuf:"bytes,21,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	PageSize            uint64              `protobuf:"varint,22,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Pages               uint64              `protobuf:"varint,23,opt,name=pages,proto3" json:"pages,omitempty"`
+	Limit               uint64              `protobuf:"varint,24,opt,name=limit,proto3" json:"limit,omitempty"`
+	StopWhen            string              `protobuf:"bytes,25,opt,name=stop_when,json=stopWhen,proto3" json:"stop_when,omitempty"`
+	PaceInterval        string              `protobuf:"bytes,26,opt,name=pace_interval,json=paceInterval,proto3" json:"pace_interval,omitempty"`
+	CompareRouting      bool                `protobuf:"varint,27,opt,name=compare_routing,json=compareRouting,proto3" json:"compare_routing,omitempty"`
+	ReadPreference      string              `protobuf:"bytes,28,opt,name=read_preference,json=readPreference,proto3" json:"read_preference,omitempty"`
+	HedgedReads         bool                `protobuf:"varint,29,opt,name=hedged_reads,json=hedgedReads,proto3" json:"hedged_reads,omitempty"`
+	BulkOrder           string              `protobuf:"bytes,30,opt,name=bulk_order,json=bulkOrder,proto3" json:"bulk_order,omitempty"`
+	ExpectedIndex       string              `protobuf:"bytes,31,opt,name=expected_index,json=expectedIndex,proto3" json:"expected_index,omitempty"`
+	ExplainInterval     string              `protobuf:"bytes,32,opt,name=explain_interval,json=explainInterval,proto3" json:"explain_interval,omitempty"`
+	DisturbanceInterval string              `protobuf:"bytes,33,opt,name=disturbance_interval,json=disturbanceInterval,proto3" json:"disturbance_interval,omitempty"`
+	BackupAt            string              `protobuf:"bytes,34,opt,name=backup_at,json=backupAt,proto3" json:"backup_at,omitempty"`
+	BackupDuration      string              `protobuf:"bytes,35,opt,name=backup_duration,json=backupDuration,proto3" json:"backup_duration,omitempty"`
+	BackupCommand       string              `protobuf:"bytes,36,opt,name=backup_command,json=backupCommand,proto3" json:"backup_command,omitempty"`
+	CacheMode           string              `protobuf:"bytes,37,opt,name=cache_mode,json=cacheMode,proto3" json:"cache_mode,omitempty"`
+	CacheCommand        string              `protobuf:"bytes,38,opt,name=cache_command,json=cacheCommand,proto3" json:"cache_command,omitempty"`
+	Collections         []string            `protobuf:"bytes,39,rep,name=collections,proto3" json:"collections,omitempty"`
+	GrowField           string              `protobuf:"bytes,40,opt,name=grow_field,json=growField,proto3" json:"grow_field,omitempty"`
+	GrowMaxItems        uint64              `protobuf:"varint,41,opt,name=grow_max_items,json=growMaxItems,proto3" json:"grow_max_items,omitempty"`
+	Priority            uint64              `protobuf:"varint,42,opt,name=priority,proto3" json:"priority,omitempty"`
+	PaceJitter          float64             `protobuf:"fixed64,43,opt,name=pace_jitter,json=paceJitter,proto3" json:"pace_jitter,omitempty"`
+	NoiseCollections    uint64              `protobuf:"varint,44,opt,name=noise_collections,json=noiseCollections,proto3" json:"noise_collections,omitempty"`
+	DeleteMany          bool                `protobuf:"varint,45,opt,name=delete_many,json=deleteMany,proto3" json:"delete_many,omitempty"`
+	PauseWindows        []string            `protobuf:"bytes,46,rep,name=pause_windows,json=pauseWindows,proto3" json:"pause_windows,omitempty"`
+	TransactionSteps    []string            `protobuf:"bytes,47,rep,name=transaction_steps,json=transactionSteps,proto3" json:"transaction_steps,omitempty"`
+	ReadConcern         string              `protobuf:"bytes,48,opt,name=read_concern,json=readConcern,proto3" json:"read_concern,omitempty"`
+	WriteConcern        string              `protobuf:"bytes,49,opt,name=write_concern,json=writeConcern,proto3" json:"write_concern,omitempty"`
+	TransactionRetries  uint64              `protobuf:"varint,50,opt,name=transaction_retries,json=transactionRetries,proto3" json:"transaction_retries,omitempty"`
+	OperationMix        map[string]float64  `protobuf:"bytes,51,rep,name=operation_mix,json=operationMix,proto3" json:"operation_mix,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	Upsert              bool                `protobuf:"varint,52,opt,name=upsert,proto3" json:"upsert,omitempty"`
+	DropIndexes         bool                `protobuf:"varint,53,opt,name=drop_indexes,json=dropIndexes,proto3" json:"drop_indexes,omitempty"`
+	HotFraction         float64             `protobuf:"fixed64,54,opt,name=hot_fraction,json=hotFraction,proto3" json:"hot_fraction,omitempty"`
+	HotSkew             float64             `protobuf:"fixed64,55,opt,name=hot_skew,json=hotSkew,proto3" json:"hot_skew,omitempty"`
+	HotField            string              `protobuf:"bytes,56,opt,name=hot_field,json=hotField,proto3" json:"hot_field,omitempty"`
+	FullDocument        string              `protobuf:"bytes,57,opt,name=full_document,json=fullDocument,proto3" json:"full_document,omitempty"`
+	FileSize            uint64              `protobuf:"varint,58,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	FileSizeMax         uint64              `protobuf:"varint,59,opt,name=file_size_max,json=fileSizeMax,proto3" json:"file_size_max,omitempty"`
+	SlaBuckets          []float64           `protobuf:"fixed64,60,rep,packed,name=sla_buckets,json=slaBuckets,proto3" json:"sla_buckets,omitempty"`
+	DistinctField       string              `protobuf:"bytes,61,opt,name=distinct_field,json=distinctField,proto3" json:"distinct_field,omitempty"`
+	MetricsInclude      []string            `protobuf:"bytes,62,rep,name=metrics_include,json=metricsInclude,proto3" json:"metrics_include,omitempty"`
+	MetricsExclude      []string            `protobuf:"bytes,63,rep,name=metrics_exclude,json=metricsExclude,proto3" json:"metrics_exclude,omitempty"`
+	MetricsSample       float64             `protobuf:"fixed64,64,opt,name=metrics_sample,json=metricsSample,proto3" json:"metrics_sample,omitempty"`
+	Search              string              `protobuf:"bytes,65,opt,name=search,proto3" json:"search,omitempty"`
+	GeoField            string              `protobuf:"bytes,66,opt,name=geo_field,json=geoField,proto3" json:"geo_field,omitempty"`
+	GeoPoint            string              `protobuf:"bytes,67,opt,name=geo_point,json=geoPoint,proto3" json:"geo_point,omitempty"`
+	GeoDistance         float64             `protobuf:"fixed64,68,opt,name=geo_distance,json=geoDistance,proto3" json:"geo_distance,omitempty"`
+	FanoutCollections   uint64              `protobuf:"varint,69,opt,name=fanout_collections,json=fanoutCollections,proto3" json:"fanout_collections,omitempty"`
+	FanoutKey           string              `protobuf:"bytes,70,opt,name=fanout_key,json=fanoutKey,proto3" json:"fanout_key,omitempty"`
+	DependsOn           []string            `protobuf:"bytes,71,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	Warmup              string              `protobuf:"bytes,72,opt,name=warmup,proto3" json:"warmup,omitempty"`
+	BurstDuration       string              `protobuf:"bytes,73,opt,name=burst_duration,json=burstDuration,proto3" json:"burst_duration,omitempty"`
+	BurstIdle           string              `protobuf:"bytes,74,opt,name=burst_idle,json=burstIdle,proto3" json:"burst_idle,omitempty"`
+	RateProfile         []string            `protobuf:"bytes,75,rep,name=rate_profile,json=rateProfile,proto3" json:"rate_profile,omitempty"`
+	PaceBurst           uint64              `protobuf:"varint,76,opt,name=pace_burst,json=paceBurst,proto3" json:"pace_burst,omitempty"`
+	Command             *JobCommand         `protobuf:"bytes,77,opt,name=command,proto3" json:"command,omitempty"`
+	Steps               []*ScenarioStep     `protobuf:"bytes,78,rep,name=steps,proto3" json:"steps,omitempty"`
+	Pipeline            *structpb.ListValue `protobuf:"bytes,79,opt,name=pipeline,proto3" json:"pipeline,omitempty"`
+	Indexes             []*Index            `protobuf:"bytes,80,rep,name=indexes,proto3" json:"indexes,omitempty"`
+	Update              *structpb.Struct    `protobuf:"bytes,81,opt,name=update,proto3" json:"update,omitempty"`
+	ArrayFilters        *structpb.ListValue `protobuf:"bytes,82,opt,name=array_filters,json=arrayFilters,proto3" json:"array_filters,omitempty"`
+	Sort                *structpb.Struct    `protobuf:"bytes,83,opt,name=sort,proto3" json:"sort,omitempty"`
+	Lookup              *structpb.Struct    `protobuf:"bytes,84,opt,name=lookup,proto3" json:"lookup,omitempty"`
+	BulkMix             map[string]float64  `protobuf:"bytes,85,rep,name=bulk_mix,json=bulkMix,proto3" json:"bulk_mix,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	Disturbances        []string            `protobuf:"bytes,86,rep,name=disturbances,proto3" json:"disturbances,omitempty"`
+	ClientRegions       []*ClientRegion     `protobuf:"bytes,87,rep,name=client_regions,json=clientRegions,proto3" json:"client_regions,omitempty"`
+	ReadPreferenceTags  []*TagSet           `protobuf:"bytes,88,rep,name=read_preference_tags,json=readPreferenceTags,proto3" json:"read_preference_tags,omitempty"`
 }
 
 func (x *JobRequest) Reset() {
 	*x = JobRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lbot_proto_config_proto_msgTypes[2]
+		mi := &file_lbot_proto_config_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *JobRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *JobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobRequest) ProtoMessage() {}
+
+func (x *JobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lbot_proto_config_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobRequest.ProtoReflect.Descriptor instead.
+func (*JobRequest) Descriptor() ([]byte, []int) {
+	return file_lbot_proto_config_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *JobRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JobRequest) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+func (x *JobRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *JobRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *JobRequest) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *JobRequest) GetConnections() uint64 {
+	if x != nil {
+		return x.Connections
+	}
+	return 0
+}
+
+func (x *JobRequest) GetPace() uint64 {
+	if x != nil {
+		return x.Pace
+	}
+	return 0
+}
+
+func (x *JobRequest) GetDataSize() uint64 {
+	if x != nil {
+		return x.DataSize
+	}
+	return 0
+}
+
+func (x *JobRequest) GetBatchSize() uint64 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *JobRequest) GetDuration() string {
+	if x != nil {
+		return x.Duration
+	}
+	return ""
+}
+
+func (x *JobRequest) GetOperations() uint64 {
+	if x != nil {
+		return x.Operations
+	}
+	return 0
+}
+
+func (x *JobRequest) GetTimeout() string {
+	if x != nil {
+		return x.Timeout
+	}
+	return ""
+}
+
+func (x *JobRequest) GetFilter() *anypb.Any {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *JobRequest) GetHistogramBuckets() []float64 {
+	if x != nil {
+		return x.HistogramBuckets
+	}
+	return nil
+}
+
+func (x *JobRequest) GetNativeHistogram() bool {
+	if x != nil {
+		return x.NativeHistogram
+	}
+	return false
+}
+
+func (x *JobRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *JobRequest) GetEvolutionRatio() float64 {
+	if x != nil {
+		return x.EvolutionRatio
+	}
+	return 0
+}
+
+func (x *JobRequest) GetReturnDocument() string {
+	if x != nil {
+		return x.ReturnDocument
+	}
+	return ""
+}
+
+func (x *JobRequest) GetConsumerRatio() float64 {
+	if x != nil {
+		return x.ConsumerRatio
+	}
+	return 0
+}
+
+func (x *JobRequest) GetHotDocuments() uint64 {
+	if x != nil {
+		return x.HotDocuments
+	}
+	return 0
+}
+
+func (x *JobRequest) GetPagination() string {
+	if x != nil {
+		return x.Pagination
+	}
+	return ""
+}
+
+func (x *JobRequest) GetPageSize() uint64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *JobRequest) GetPages() uint64 {
+	if x != nil {
+		return x.Pages
+	}
+	return 0
+}
+
+func (x *JobRequest) GetLimit() uint64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *JobRequest) GetStopWhen() string {
+	if x != nil {
+		return x.StopWhen
+	}
+	return ""
+}
+
+func (x *JobRequest) GetPaceInterval() string {
+	if x != nil {
+		return x.PaceInterval
+	}
+	return ""
+}
+
+func (x *JobRequest) GetCompareRouting() bool {
+	if x != nil {
+		return x.CompareRouting
+	}
+	return false
+}
+
+func (x *JobRequest) GetReadPreference() string {
+	if x != nil {
+		return x.ReadPreference
+	}
+	return ""
+}
+
+func (x *JobRequest) GetHedgedReads() bool {
+	if x != nil {
+		return x.HedgedReads
+	}
+	return false
+}
+
+func (x *JobRequest) GetBulkOrder() string {
+	if x != nil {
+		return x.BulkOrder
+	}
+	return ""
+}
+
+func (x *JobRequest) GetExpectedIndex() string {
+	if x != nil {
+		return x.ExpectedIndex
+	}
+	return ""
+}
+
+func (x *JobRequest) GetExplainInterval() string {
+	if x != nil {
+		return x.ExplainInterval
+	}
+	return ""
+}
+
+func (x *JobRequest) GetDisturbanceInterval() string {
+	if x != nil {
+		return x.DisturbanceInterval
+	}
+	return ""
+}
+
+func (x *JobRequest) GetBackupAt() string {
+	if x != nil {
+		return x.BackupAt
+	}
+	return ""
+}
+
+func (x *JobRequest) GetBackupDuration() string {
+	if x != nil {
+		return x.BackupDuration
+	}
+	return ""
+}
+
+func (x *JobRequest) GetBackupCommand() string {
+	if x != nil {
+		return x.BackupCommand
+	}
+	return ""
+}
+
+func (x *JobRequest) GetCacheMode() string {
+	if x != nil {
+		return x.CacheMode
+	}
+	return ""
+}
+
+func (x *JobRequest) GetCacheCommand() string {
+	if x != nil {
+		return x.CacheCommand
+	}
+	return ""
+}
+
+func (x *JobRequest) GetCollections() []string {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+func (x *JobRequest) GetGrowField() string {
+	if x != nil {
+		return x.GrowField
+	}
+	return ""
+}
+
+func (x *JobRequest) GetGrowMaxItems() uint64 {
+	if x != nil {
+		return x.GrowMaxItems
+	}
+	return 0
+}
+
+func (x *JobRequest) GetPriority() uint64 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *JobRequest) GetPaceJitter() float64 {
+	if x != nil {
+		return x.PaceJitter
+	}
+	return 0
+}
+
+func (x *JobRequest) GetNoiseCollections() uint64 {
+	if x != nil {
+		return x.NoiseCollections
+	}
+	return 0
+}
+
+func (x *JobRequest) GetDeleteMany() bool {
+	if x != nil {
+		return x.DeleteMany
+	}
+	return false
+}
+
+func (x *JobRequest) GetPauseWindows() []string {
+	if x != nil {
+		return x.PauseWindows
+	}
+	return nil
+}
+
+func (x *JobRequest) GetTransactionSteps() []string {
+	if x != nil {
+		return x.TransactionSteps
+	}
+	return nil
+}
+
+func (x *JobRequest) GetReadConcern() string {
+	if x != nil {
+		return x.ReadConcern
+	}
+	return ""
+}
+
+func (x *JobRequest) GetWriteConcern() string {
+	if x != nil {
+		return x.WriteConcern
+	}
+	return ""
+}
+
+func (x *JobRequest) GetTransactionRetries() uint64 {
+	if x != nil {
+		return x.TransactionRetries
+	}
+	return 0
+}
+
+func (x *JobRequest) GetOperationMix() map[string]float64 {
+	if x != nil {
+		return x.OperationMix
+	}
+	return nil
+}
+
+func (x *JobRequest) GetUpsert() bool {
+	if x != nil {
+		return x.Upsert
+	}
+	return false
+}
+
+func (x *JobRequest) GetDropIndexes() bool {
+	if x != nil {
+		return x.DropIndexes
+	}
+	return false
+}
+
+func (x *JobRequest) GetHotFraction() float64 {
+	if x != nil {
+		return x.HotFraction
+	}
+	return 0
+}
+
+func (x *JobRequest) GetHotSkew() float64 {
+	if x != nil {
+		return x.HotSkew
+	}
+	return 0
+}
+
+func (x *JobRequest) GetHotField() string {
+	if x != nil {
+		return x.HotField
+	}
+	return ""
+}
+
+func (x *JobRequest) GetFullDocument() string {
+	if x != nil {
+		return x.FullDocument
+	}
+	return ""
+}
+
+func (x *JobRequest) GetFileSize() uint64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *JobRequest) GetFileSizeMax() uint64 {
+	if x != nil {
+		return x.FileSizeMax
+	}
+	return 0
+}
+
+func (x *JobRequest) GetSlaBuckets() []float64 {
+	if x != nil {
+		return x.SlaBuckets
+	}
+	return nil
+}
+
+func (x *JobRequest) GetDistinctField() string {
+	if x != nil {
+		return x.DistinctField
+	}
+	return ""
+}
+
+func (x *JobRequest) GetMetricsInclude() []string {
+	if x != nil {
+		return x.MetricsInclude
+	}
+	return nil
+}
+
+func (x *JobRequest) GetMetricsExclude() []string {
+	if x != nil {
+		return x.MetricsExclude
+	}
+	return nil
+}
+
+func (x *JobRequest) GetMetricsSample() float64 {
+	if x != nil {
+		return x.MetricsSample
+	}
+	return 0
+}
+
+func (x *JobRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *JobRequest) GetGeoField() string {
+	if x != nil {
+		return x.GeoField
+	}
+	return ""
+}
+
+func (x *JobRequest) GetGeoPoint() string {
+	if x != nil {
+		return x.GeoPoint
+	}
+	return ""
+}
+
+func (x *JobRequest) GetGeoDistance() float64 {
+	if x != nil {
+		return x.GeoDistance
+	}
+	return 0
+}
+
+func (x *JobRequest) GetFanoutCollections() uint64 {
+	if x != nil {
+		return x.FanoutCollections
+	}
+	return 0
+}
+
+func (x *JobRequest) GetFanoutKey() string {
+	if x != nil {
+		return x.FanoutKey
+	}
+	return ""
+}
+
+func (x *JobRequest) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *JobRequest) GetWarmup() string {
+	if x != nil {
+		return x.Warmup
+	}
+	return ""
 }
 
-func (*JobRequest) ProtoMessage() {}
+func (x *JobRequest) GetBurstDuration() string {
+	if x != nil {
+		return x.BurstDuration
+	}
+	return ""
+}
 
-func (x *JobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_lbot_proto_config_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *JobRequest) GetBurstIdle() string {
+	if x != nil {
+		return x.BurstIdle
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use JobRequest.ProtoReflect.Descriptor instead.
-func (*JobRequest) Descriptor() ([]byte, []int) {
-	return file_lbot_proto_config_proto_rawDescGZIP(), []int{2}
+func (x *JobRequest) GetRateProfile() []string {
+	if x != nil {
+		return x.RateProfile
+	}
+	return nil
 }
 
-func (x *JobRequest) GetName() string {
+func (x *JobRequest) GetPaceBurst() uint64 {
 	if x != nil {
-		return x.Name
+		return x.PaceBurst
 	}
-	return ""
+	return 0
 }
 
-func (x *JobRequest) GetDatabase() string {
+func (x *JobRequest) GetCommand() *JobCommand {
 	if x != nil {
-		return x.Database
+		return x.Command
 	}
-	return ""
+	return nil
 }
 
-func (x *JobRequest) GetCollection() string {
+func (x *JobRequest) GetSteps() []*ScenarioStep {
 	if x != nil {
-		return x.Collection
+		return x.Steps
 	}
-	return ""
+	return nil
 }
 
-func (x *JobRequest) GetType() string {
+func (x *JobRequest) GetPipeline() *structpb.ListValue {
 	if x != nil {
-		return x.Type
+		return x.Pipeline
 	}
-	return ""
+	return nil
 }
 
-func (x *JobRequest) GetSchema() string {
+func (x *JobRequest) GetIndexes() []*Index {
 	if x != nil {
-		return x.Schema
+		return x.Indexes
 	}
-	return ""
+	return nil
 }
 
-func (x *JobRequest) GetConnections() uint64 {
+func (x *JobRequest) GetUpdate() *structpb.Struct {
 	if x != nil {
-		return x.Connections
+		return x.Update
 	}
-	return 0
+	return nil
 }
 
-func (x *JobRequest) GetPace() uint64 {
+func (x *JobRequest) GetArrayFilters() *structpb.ListValue {
 	if x != nil {
-		return x.Pace
+		return x.ArrayFilters
 	}
-	return 0
+	return nil
 }
 
-func (x *JobRequest) GetDataSize() uint64 {
+func (x *JobRequest) GetSort() *structpb.Struct {
 	if x != nil {
-		return x.DataSize
+		return x.Sort
 	}
-	return 0
+	return nil
 }
 
-func (x *JobRequest) GetBatchSize() uint64 {
+func (x *JobRequest) GetLookup() *structpb.Struct {
 	if x != nil {
-		return x.BatchSize
+		return x.Lookup
 	}
-	return 0
+	return nil
 }
 
-func (x *JobRequest) GetDuration() string {
+func (x *JobRequest) GetBulkMix() map[string]float64 {
 	if x != nil {
-		return x.Duration
+		return x.BulkMix
 	}
-	return ""
+	return nil
 }
 
-func (x *JobRequest) GetOperations() uint64 {
+func (x *JobRequest) GetDisturbances() []string {
 	if x != nil {
-		return x.Operations
+		return x.Disturbances
 	}
-	return 0
+	return nil
 }
 
-func (x *JobRequest) GetTimeout() string {
+func (x *JobRequest) GetClientRegions() []*ClientRegion {
 	if x != nil {
-		return x.Timeout
+		return x.ClientRegions
 	}
-	return ""
+	return nil
 }
 
-func (x *JobRequest) GetFilter() *anypb.Any {
+func (x *JobRequest) GetReadPreferenceTags() []*TagSet {
 	if x != nil {
-		return x.Filter
+		return x.ReadPreferenceTags
 	}
 	return nil
 }
@@ -328,17 +1269,20 @@ type ConfigRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ConnectionString string           `protobuf:"bytes,1,opt,name=connection_string,json=connectionString,proto3" json:"connection_string,omitempty"`
-	Agent            *AgentRequest    `protobuf:"bytes,2,opt,name=agent,proto3" json:"agent,omitempty"`
-	Jobs             []*JobRequest    `protobuf:"bytes,3,rep,name=jobs,proto3" json:"jobs,omitempty"`
-	Schemas          []*SchemaRequest `protobuf:"bytes,4,rep,name=schemas,proto3" json:"schemas,omitempty"`
-	Debug            bool             `protobuf:"varint,5,opt,name=debug,proto3" json:"debug,omitempty"`
+	ConnectionString      string           `protobuf:"bytes,1,opt,name=connection_string,json=connectionString,proto3" json:"connection_string,omitempty"`
+	Agent                 *AgentRequest    `protobuf:"bytes,2,opt,name=agent,proto3" json:"agent,omitempty"`
+	Jobs                  []*JobRequest    `protobuf:"bytes,3,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	Schemas               []*SchemaRequest `protobuf:"bytes,4,rep,name=schemas,proto3" json:"schemas,omitempty"`
+	Debug                 bool             `protobuf:"varint,5,opt,name=debug,proto3" json:"debug,omitempty"`
+	ShardConnectionString string           `protobuf:"bytes,6,opt,name=shard_connection_string,json=shardConnectionString,proto3" json:"shard_connection_string,omitempty"`
+	Parallel              bool             `protobuf:"varint,7,opt,name=parallel,proto3" json:"parallel,omitempty"`
+	Template              string           `protobuf:"bytes,8,opt,name=template,proto3" json:"template,omitempty"`
 }
 
 func (x *ConfigRequest) Reset() {
 	*x = ConfigRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lbot_proto_config_proto_msgTypes[3]
+		mi := &file_lbot_proto_config_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -351,7 +1295,7 @@ func (x *ConfigRequest) String() string {
 func (*ConfigRequest) ProtoMessage() {}
 
 func (x *ConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_lbot_proto_config_proto_msgTypes[3]
+	mi := &file_lbot_proto_config_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -364,7 +1308,7 @@ func (x *ConfigRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfigRequest.ProtoReflect.Descriptor instead.
 func (*ConfigRequest) Descriptor() ([]byte, []int) {
-	return file_lbot_proto_config_proto_rawDescGZIP(), []int{3}
+	return file_lbot_proto_config_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ConfigRequest) GetConnectionString() string {
@@ -402,22 +1346,46 @@ func (x *ConfigRequest) GetDebug() bool {
 	return false
 }
 
+func (x *ConfigRequest) GetShardConnectionString() string {
+	if x != nil {
+		return x.ShardConnectionString
+	}
+	return ""
+}
+
+func (x *ConfigRequest) GetParallel() bool {
+	if x != nil {
+		return x.Parallel
+	}
+	return false
+}
+
+func (x *ConfigRequest) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
 type ConfigResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ConnectionString string           `protobuf:"bytes,1,opt,name=connection_string,json=connectionString,proto3" json:"connection_string,omitempty"`
-	Agent            *AgentRequest    `protobuf:"bytes,2,opt,name=agent,proto3" json:"agent,omitempty"`
-	Jobs             []*JobRequest    `protobuf:"bytes,3,rep,name=jobs,proto3" json:"jobs,omitempty"`
-	Schemas          []*SchemaRequest `protobuf:"bytes,4,rep,name=schemas,proto3" json:"schemas,omitempty"`
-	Debug            bool             `protobuf:"varint,5,opt,name=debug,proto3" json:"debug,omitempty"`
+	ConnectionString      string           `protobuf:"bytes,1,opt,name=connection_string,json=connectionString,proto3" json:"connection_string,omitempty"`
+	Agent                 *AgentRequest    `protobuf:"bytes,2,opt,name=agent,proto3" json:"agent,omitempty"`
+	Jobs                  []*JobRequest    `protobuf:"bytes,3,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	Schemas               []*SchemaRequest `protobuf:"bytes,4,rep,name=schemas,proto3" json:"schemas,omitempty"`
+	Debug                 bool             `protobuf:"varint,5,opt,name=debug,proto3" json:"debug,omitempty"`
+	ShardConnectionString string           `protobuf:"bytes,6,opt,name=shard_connection_string,json=shardConnectionString,proto3" json:"shard_connection_string,omitempty"`
+	Parallel              bool             `protobuf:"varint,7,opt,name=parallel,proto3" json:"parallel,omitempty"`
+	Template              string           `protobuf:"bytes,8,opt,name=template,proto3" json:"template,omitempty"`
 }
 
 func (x *ConfigResponse) Reset() {
 	*x = ConfigResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lbot_proto_config_proto_msgTypes[4]
+		mi := &file_lbot_proto_config_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -430,7 +1398,7 @@ func (x *ConfigResponse) String() string {
 func (*ConfigResponse) ProtoMessage() {}
 
 func (x *ConfigResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_lbot_proto_config_proto_msgTypes[4]
+	mi := &file_lbot_proto_config_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -443,7 +1411,7 @@ func (x *ConfigResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfigResponse.ProtoReflect.Descriptor instead.
 func (*ConfigResponse) Descriptor() ([]byte, []int) {
-	return file_lbot_proto_config_proto_rawDescGZIP(), []int{4}
+	return file_lbot_proto_config_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ConfigResponse) GetConnectionString() string {
@@ -481,6 +1449,121 @@ func (x *ConfigResponse) GetDebug() bool {
 	return false
 }
 
+func (x *ConfigResponse) GetShardConnectionString() string {
+	if x != nil {
+		return x.ShardConnectionString
+	}
+	return ""
+}
+
+func (x *ConfigResponse) GetParallel() bool {
+	if x != nil {
+		return x.Parallel
+	}
+	return false
+}
+
+func (x *ConfigResponse) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+type ExportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Credentials bool `protobuf:"varint,1,opt,name=credentials,proto3" json:"credentials,omitempty"` // keep user and password of connection strings
+}
+
+func (x *ExportRequest) Reset() {
+	*x = ExportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lbot_proto_config_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRequest) ProtoMessage() {}
+
+func (x *ExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lbot_proto_config_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRequest.ProtoReflect.Descriptor instead.
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return file_lbot_proto_config_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ExportRequest) GetCredentials() bool {
+	if x != nil {
+		return x.Credentials
+	}
+	return false
+}
+
+type ExportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bundle []byte `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"` // tar.gz archive
+}
+
+func (x *ExportResponse) Reset() {
+	*x = ExportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lbot_proto_config_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportResponse) ProtoMessage() {}
+
+func (x *ExportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lbot_proto_config_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportResponse.ProtoReflect.Descriptor instead.
+func (*ExportResponse) Descriptor() ([]byte, []int) {
+	return file_lbot_proto_config_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ExportResponse) GetBundle() []byte {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
 var File_lbot_proto_config_proto protoreflect.FileDescriptor
 
 var file_lbot_proto_config_proto_rawDesc = []byte{
@@ -489,90 +1572,344 @@ var file_lbot_proto_config_proto_rawDesc = []byte{
 	0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
 	0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f,
 	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70,
-	0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa1, 0x01, 0x0a, 0x0d, 0x53, 0x63, 0x68,
-	0x65, 0x6d, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a,
-	0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x63,
-	0x68, 0x65, 0x6d, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79,
-	0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x61, 0x76, 0x65,
-	0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x73, 0x61, 0x76, 0x65, 0x22, 0xdb, 0x01, 0x0a,
-	0x0c, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
-	0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x10, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
-	0x55, 0x72, 0x6c, 0x12, 0x45, 0x0a, 0x1f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x65,
-	0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x73,
-	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x1c, 0x6d, 0x65,
-	0x74, 0x72, 0x69, 0x63, 0x73, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72,
-	0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x65,
-	0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x70, 0x6f, 0x72,
-	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
-	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x22, 0xfe, 0x02, 0x0a, 0x0a, 0x4a,
-	0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
-	0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63,
-	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a,
-	0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
-	0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x63, 0x65, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x70, 0x61, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x64,
-	0x61, 0x74, 0x61, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
-	0x64, 0x61, 0x74, 0x61, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x74, 0x63,
-	0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x62, 0x61,
-	0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x0c,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x2c, 0x0a,
-	0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x41, 0x6e, 0x79, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0xd4, 0x01, 0x0a, 0x0d,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a,
-	0x11, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x72, 0x69,
-	0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x29, 0x0a, 0x05, 0x61, 0x67,
-	0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05,
-	0x61, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x03, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4a, 0x6f, 0x62, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x12, 0x2e, 0x0a, 0x07,
-	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x52, 0x07, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x73, 0x12, 0x14, 0x0a, 0x05,
-	0x64, 0x65, 0x62, 0x75, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x64, 0x65, 0x62,
-	0x75, 0x67, 0x22, 0xd5, 0x01, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x69,
-	0x6e, 0x67, 0x12, 0x29, 0x0a, 0x05, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x25, 0x0a,
-	0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x04,
-	0x6a, 0x6f, 0x62, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x73, 0x18,
-	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x63,
-	0x68, 0x65, 0x6d, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x73, 0x63, 0x68,
-	0x65, 0x6d, 0x61, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x32, 0x89, 0x01, 0x0a, 0x0d, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x09,
-	0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x15, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70,
+	0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa1, 0x01, 0x0a, 0x0d, 0x53, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x06,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x61, 0x76, 0x65, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x73, 0x61, 0x76, 0x65, 0x22, 0xdb, 0x01, 0x0a, 0x0c, 0x41,
+	0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70,
+	0x6f, 0x72, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x65,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x10, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x55, 0x72,
+	0x6c, 0x12, 0x45, 0x0a, 0x1f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x65, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x1c, 0x6d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x22, 0x55, 0x0a, 0x0a, 0x4a, 0x6f, 0x62, 0x43,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x74, 0x65,
+	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53,
+	0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x22,
+	0xb0, 0x01, 0x0a, 0x0c, 0x53, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x53, 0x74, 0x65, 0x70,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2f, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63,
+	0x74, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x2f, 0x0a, 0x06, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x52, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x22, 0xb1, 0x01, 0x0a, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04,
+	0x6b, 0x65, 0x79, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x70, 0x61, 0x72, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x70,
+	0x61, 0x72, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x72,
+	0x6f, 0x75, 0x6e, 0x64, 0x12, 0x30, 0x0a, 0x14, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f, 0x61,
+	0x66, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x12, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x41, 0x66, 0x74, 0x65, 0x72, 0x53,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x6c, 0x0a, 0x0c, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x16, 0x0a, 0x06,
+	0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6a, 0x69,
+	0x74, 0x74, 0x65, 0x72, 0x22, 0x6e, 0x0a, 0x06, 0x54, 0x61, 0x67, 0x53, 0x65, 0x74, 0x12, 0x2b,
+	0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x67, 0x53, 0x65, 0x74, 0x2e, 0x54, 0x61, 0x67, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x1a, 0x37, 0x0a, 0x09, 0x54,
+	0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0xa8, 0x1a, 0x0a, 0x0a, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62,
+	0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62,
+	0x61, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12,
+	0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x04, 0x70, 0x61, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x62, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a,
+	0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x06, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x2b, 0x0a, 0x11, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72,
+	0x61, 0x6d, 0x5f, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x01,
+	0x52, 0x10, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x42, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x68, 0x69, 0x73,
+	0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x6e, 0x61,
+	0x74, 0x69, 0x76, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x16, 0x0a,
+	0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74,
+	0x65, 0x6e, 0x61, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x76, 0x6f, 0x6c, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x11, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e,
+	0x65, 0x76, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x12, 0x27,
+	0x0a, 0x0f, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e,
+	0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x44,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x73, 0x75,
+	0x6d, 0x65, 0x72, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x13, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0d, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x12, 0x23,
+	0x0a, 0x0d, 0x68, 0x6f, 0x74, 0x5f, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18,
+	0x14, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x68, 0x6f, 0x74, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x16, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x17, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18,
+	0x18, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x73, 0x74, 0x6f, 0x70, 0x5f, 0x77, 0x68, 0x65, 0x6e, 0x18, 0x19, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x73, 0x74, 0x6f, 0x70, 0x57, 0x68, 0x65, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x61, 0x63,
+	0x65, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x70, 0x61, 0x63, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x27,
+	0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e,
+	0x67, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65,
+	0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x61, 0x64, 0x5f,
+	0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x72, 0x65, 0x61, 0x64, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x12, 0x21, 0x0a, 0x0c, 0x68, 0x65, 0x64, 0x67, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x73,
+	0x18, 0x1d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x68, 0x65, 0x64, 0x67, 0x65, 0x64, 0x52, 0x65,
+	0x61, 0x64, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x75, 0x6c, 0x6b, 0x5f, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x75, 0x6c, 0x6b, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78, 0x70,
+	0x6c, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x20, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x12, 0x31, 0x0a, 0x14, 0x64, 0x69, 0x73, 0x74, 0x75, 0x72, 0x62, 0x61,
+	0x6e, 0x63, 0x65, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x21, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x13, 0x64, 0x69, 0x73, 0x74, 0x75, 0x72, 0x62, 0x61, 0x6e, 0x63, 0x65, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x61, 0x63, 0x6b, 0x75,
+	0x70, 0x5f, 0x61, 0x74, 0x18, 0x22, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x61, 0x63, 0x6b,
+	0x75, 0x70, 0x41, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x5f, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x23, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x62,
+	0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a,
+	0x0e, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18,
+	0x24, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x43, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x6d, 0x6f,
+	0x64, 0x65, 0x18, 0x25, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x61, 0x63, 0x68, 0x65, 0x4d,
+	0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x18, 0x26, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x63, 0x68,
+	0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x27, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72,
+	0x6f, 0x77, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x28, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x67, 0x72, 0x6f, 0x77, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x67, 0x72, 0x6f,
+	0x77, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x29, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0c, 0x67, 0x72, 0x6f, 0x77, 0x4d, 0x61, 0x78, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x2a, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x70,
+	0x61, 0x63, 0x65, 0x5f, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x18, 0x2b, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0a, 0x70, 0x61, 0x63, 0x65, 0x4a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x12, 0x2b, 0x0a, 0x11,
+	0x6e, 0x6f, 0x69, 0x73, 0x65, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x2c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x6e, 0x6f, 0x69, 0x73, 0x65, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x5f, 0x6d, 0x61, 0x6e, 0x79, 0x18, 0x2d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x6e, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x61,
+	0x75, 0x73, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x18, 0x2e, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0c, 0x70, 0x61, 0x75, 0x73, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x12,
+	0x2b, 0x0a, 0x11, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73,
+	0x74, 0x65, 0x70, 0x73, 0x18, 0x2f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x65, 0x70, 0x73, 0x12, 0x21, 0x0a, 0x0c,
+	0x72, 0x65, 0x61, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x65, 0x72, 0x6e, 0x18, 0x30, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x63, 0x65, 0x72, 0x6e, 0x12,
+	0x23, 0x0a, 0x0d, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x65, 0x72, 0x6e,
+	0x18, 0x31, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x77, 0x72, 0x69, 0x74, 0x65, 0x43, 0x6f, 0x6e,
+	0x63, 0x65, 0x72, 0x6e, 0x12, 0x2f, 0x0a, 0x13, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x32, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x12, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x48, 0x0a, 0x0d, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x6d, 0x69, 0x78, 0x18, 0x33, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x78, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x0c, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x78, 0x12,
+	0x16, 0x0a, 0x06, 0x75, 0x70, 0x73, 0x65, 0x72, 0x74, 0x18, 0x34, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x75, 0x70, 0x73, 0x65, 0x72, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x72, 0x6f, 0x70, 0x5f,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x18, 0x35, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x64,
+	0x72, 0x6f, 0x70, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x6f,
+	0x74, 0x5f, 0x66, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x36, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0b, 0x68, 0x6f, 0x74, 0x46, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a,
+	0x08, 0x68, 0x6f, 0x74, 0x5f, 0x73, 0x6b, 0x65, 0x77, 0x18, 0x37, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x07, 0x68, 0x6f, 0x74, 0x53, 0x6b, 0x65, 0x77, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x6f, 0x74, 0x5f,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x38, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x74,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x64, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x39, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x75,
+	0x6c, 0x6c, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69,
+	0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x3a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x66,
+	0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x66, 0x69, 0x6c, 0x65, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x3b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b,
+	0x66, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x4d, 0x61, 0x78, 0x12, 0x1f, 0x0a, 0x0b, 0x73,
+	0x6c, 0x61, 0x5f, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x3c, 0x20, 0x03, 0x28, 0x01,
+	0x52, 0x0a, 0x73, 0x6c, 0x61, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x0e,
+	0x64, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x3d,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x69,
+	0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x18, 0x3e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x6d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x12, 0x27, 0x0a, 0x0f,
+	0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x18,
+	0x3f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x45, 0x78,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
+	0x5f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x18, 0x40, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x6d,
+	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x41, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x65, 0x6f, 0x5f, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x18, 0x42, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x65, 0x6f, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x65, 0x6f, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x43,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x65, 0x6f, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x21,
+	0x0a, 0x0c, 0x67, 0x65, 0x6f, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x44,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x67, 0x65, 0x6f, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x2d, 0x0a, 0x12, 0x66, 0x61, 0x6e, 0x6f, 0x75, 0x74, 0x5f, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x45, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x66,
+	0x61, 0x6e, 0x6f, 0x75, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x61, 0x6e, 0x6f, 0x75, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x46,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x61, 0x6e, 0x6f, 0x75, 0x74, 0x4b, 0x65, 0x79, 0x12,
+	0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x5f, 0x6f, 0x6e, 0x18, 0x47, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x6e, 0x12, 0x16,
+	0x0a, 0x06, 0x77, 0x61, 0x72, 0x6d, 0x75, 0x70, 0x18, 0x48, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x77, 0x61, 0x72, 0x6d, 0x75, 0x70, 0x12, 0x25, 0x0a, 0x0e, 0x62, 0x75, 0x72, 0x73, 0x74, 0x5f,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x49, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x62, 0x75, 0x72, 0x73, 0x74, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a,
+	0x0a, 0x62, 0x75, 0x72, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x6c, 0x65, 0x18, 0x4a, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x62, 0x75, 0x72, 0x73, 0x74, 0x49, 0x64, 0x6c, 0x65, 0x12, 0x21, 0x0a, 0x0c,
+	0x72, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x4b, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0b, 0x72, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x63, 0x65, 0x5f, 0x62, 0x75, 0x72, 0x73, 0x74, 0x18, 0x4c, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x70, 0x61, 0x63, 0x65, 0x42, 0x75, 0x72, 0x73, 0x74, 0x12, 0x2b,
+	0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x4d, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4a, 0x6f, 0x62, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x29, 0x0a, 0x05, 0x73,
+	0x74, 0x65, 0x70, 0x73, 0x18, 0x4e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x53, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x53, 0x74, 0x65, 0x70, 0x52,
+	0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x12, 0x36, 0x0a, 0x08, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x18, 0x4f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x08, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x26,
+	0x0a, 0x07, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x18, 0x50, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x07, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x18, 0x51, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52,
+	0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x3f, 0x0a, 0x0d, 0x61, 0x72, 0x72, 0x61, 0x79,
+	0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x52, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0c, 0x61, 0x72, 0x72, 0x61,
+	0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x2b, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74,
+	0x18, 0x53, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52,
+	0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x2f, 0x0a, 0x06, 0x6c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x18,
+	0x54, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x06,
+	0x6c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x12, 0x39, 0x0a, 0x08, 0x62, 0x75, 0x6c, 0x6b, 0x5f, 0x6d,
+	0x69, 0x78, 0x18, 0x55, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x42, 0x75, 0x6c, 0x6b,
+	0x4d, 0x69, 0x78, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x62, 0x75, 0x6c, 0x6b, 0x4d, 0x69,
+	0x78, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x74, 0x75, 0x72, 0x62, 0x61, 0x6e, 0x63, 0x65,
+	0x73, 0x18, 0x56, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x74, 0x75, 0x72, 0x62,
+	0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x3a, 0x0a, 0x0e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f,
+	0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x57, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69,
+	0x6f, 0x6e, 0x52, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x3f, 0x0a, 0x14, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x5f, 0x74, 0x61, 0x67, 0x73, 0x18, 0x58, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x67, 0x53, 0x65, 0x74, 0x52, 0x12,
+	0x72, 0x65, 0x61, 0x64, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x54, 0x61,
+	0x67, 0x73, 0x1a, 0x3f, 0x0a, 0x11, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d,
+	0x69, 0x78, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x1a, 0x3a, 0x0a, 0x0c, 0x42, 0x75, 0x6c, 0x6b, 0x4d, 0x69, 0x78, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0xc4, 0x02, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x29,
+	0x0a, 0x05, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x05, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x04, 0x6a, 0x6f, 0x62,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x04, 0x6a, 0x6f, 0x62, 0x73,
+	0x12, 0x2e, 0x0a, 0x07, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x12, 0x36, 0x0a, 0x17, 0x73, 0x68, 0x61, 0x72, 0x64, 0x5f,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x73, 0x68, 0x61, 0x72, 0x64, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x08, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x65,
+	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65,
+	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x22, 0xc5, 0x02, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x29, 0x0a, 0x05, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x67,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x12, 0x25, 0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
+	0x07, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x62, 0x75,
+	0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x12, 0x36,
+	0x0a, 0x17, 0x73, 0x68, 0x61, 0x72, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x15, 0x73, 0x68, 0x61, 0x72, 0x64, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c,
+	0x65, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c,
+	0x65, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x22, 0x31,
+	0x0a, 0x0d, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x20, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
+	0x73, 0x22, 0x28, 0x0a, 0x0e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x32, 0xc8, 0x01, 0x0a, 0x0d,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3a, 0x0a,
+	0x09, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x09, 0x47, 0x65, 0x74,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x15,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0c, 0x45, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70,
 	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x08, 0x5a, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
 	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
@@ -589,34 +1926,64 @@ func file_lbot_proto_config_proto_rawDescGZIP() []byte {
 	return file_lbot_proto_config_proto_rawDescData
 }
 
-var file_lbot_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_lbot_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_lbot_proto_config_proto_goTypes = []interface{}{
-	(*SchemaRequest)(nil),  // 0: proto.SchemaRequest
-	(*AgentRequest)(nil),   // 1: proto.AgentRequest
-	(*JobRequest)(nil),     // 2: proto.JobRequest
-	(*ConfigRequest)(nil),  // 3: proto.ConfigRequest
-	(*ConfigResponse)(nil), // 4: proto.ConfigResponse
-	(*anypb.Any)(nil),      // 5: google.protobuf.Any
-	(*emptypb.Empty)(nil),  // 6: google.protobuf.Empty
+	(*SchemaRequest)(nil),      // 0: proto.SchemaRequest
+	(*AgentRequest)(nil),       // 1: proto.AgentRequest
+	(*JobCommand)(nil),         // 2: proto.JobCommand
+	(*ScenarioStep)(nil),       // 3: proto.ScenarioStep
+	(*Index)(nil),              // 4: proto.Index
+	(*ClientRegion)(nil),       // 5: proto.ClientRegion
+	(*TagSet)(nil),             // 6: proto.TagSet
+	(*JobRequest)(nil),         // 7: proto.JobRequest
+	(*ConfigRequest)(nil),      // 8: proto.ConfigRequest
+	(*ConfigResponse)(nil),     // 9: proto.ConfigResponse
+	(*ExportRequest)(nil),      // 10: proto.ExportRequest
+	(*ExportResponse)(nil),     // 11: proto.ExportResponse
+	nil,                        // 12: proto.TagSet.TagsEntry
+	nil,                        // 13: proto.JobRequest.OperationMixEntry
+	nil,                        // 14: proto.JobRequest.BulkMixEntry
+	(*anypb.Any)(nil),          // 15: google.protobuf.Any
+	(*structpb.Struct)(nil),    // 16: google.protobuf.Struct
+	(*structpb.ListValue)(nil), // 17: google.protobuf.ListValue
+	(*emptypb.Empty)(nil),      // 18: google.protobuf.Empty
 }
 var file_lbot_proto_config_proto_depIdxs = []int32{
-	5,  // 0: proto.SchemaRequest.schema:type_name -> google.protobuf.Any
-	5,  // 1: proto.JobRequest.filter:type_name -> google.protobuf.Any
-	1,  // 2: proto.ConfigRequest.agent:type_name -> proto.AgentRequest
-	2,  // 3: proto.ConfigRequest.jobs:type_name -> proto.JobRequest
-	0,  // 4: proto.ConfigRequest.schemas:type_name -> proto.SchemaRequest
-	1,  // 5: proto.ConfigResponse.agent:type_name -> proto.AgentRequest
-	2,  // 6: proto.ConfigResponse.jobs:type_name -> proto.JobRequest
-	0,  // 7: proto.ConfigResponse.schemas:type_name -> proto.SchemaRequest
-	3,  // 8: proto.ConfigService.SetConfig:input_type -> proto.ConfigRequest
-	6,  // 9: proto.ConfigService.GetConfig:input_type -> google.protobuf.Empty
-	4,  // 10: proto.ConfigService.SetConfig:output_type -> proto.ConfigResponse
-	4,  // 11: proto.ConfigService.GetConfig:output_type -> proto.ConfigResponse
-	10, // [10:12] is the sub-list for method output_type
-	8,  // [8:10] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	15, // 0: proto.SchemaRequest.schema:type_name -> google.protobuf.Any
+	16, // 1: proto.JobCommand.template:type_name -> google.protobuf.Struct
+	16, // 2: proto.ScenarioStep.filter:type_name -> google.protobuf.Struct
+	16, // 3: proto.ScenarioStep.update:type_name -> google.protobuf.Struct
+	12, // 4: proto.TagSet.tags:type_name -> proto.TagSet.TagsEntry
+	15, // 5: proto.JobRequest.filter:type_name -> google.protobuf.Any
+	13, // 6: proto.JobRequest.operation_mix:type_name -> proto.JobRequest.OperationMixEntry
+	2,  // 7: proto.JobRequest.command:type_name -> proto.JobCommand
+	3,  // 8: proto.JobRequest.steps:type_name -> proto.ScenarioStep
+	17, // 9: proto.JobRequest.pipeline:type_name -> google.protobuf.ListValue
+	4,  // 10: proto.JobRequest.indexes:type_name -> proto.Index
+	16, // 11: proto.JobRequest.update:type_name -> google.protobuf.Struct
+	17, // 12: proto.JobRequest.array_filters:type_name -> google.protobuf.ListValue
+	16, // 13: proto.JobRequest.sort:type_name -> google.protobuf.Struct
+	16, // 14: proto.JobRequest.lookup:type_name -> google.protobuf.Struct
+	14, // 15: proto.JobRequest.bulk_mix:type_name -> proto.JobRequest.BulkMixEntry
+	5,  // 16: proto.JobRequest.client_regions:type_name -> proto.ClientRegion
+	6,  // 17: proto.JobRequest.read_preference_tags:type_name -> proto.TagSet
+	1,  // 18: proto.ConfigRequest.agent:type_name -> proto.AgentRequest
+	7,  // 19: proto.ConfigRequest.jobs:type_name -> proto.JobRequest
+	0,  // 20: proto.ConfigRequest.schemas:type_name -> proto.SchemaRequest
+	1,  // 21: proto.ConfigResponse.agent:type_name -> proto.AgentRequest
+	7,  // 22: proto.ConfigResponse.jobs:type_name -> proto.JobRequest
+	0,  // 23: proto.ConfigResponse.schemas:type_name -> proto.SchemaRequest
+	8,  // 24: proto.ConfigService.SetConfig:input_type -> proto.ConfigRequest
+	18, // 25: proto.ConfigService.GetConfig:input_type -> google.protobuf.Empty
+	10, // 26: proto.ConfigService.ExportConfig:input_type -> proto.ExportRequest
+	9,  // 27: proto.ConfigService.SetConfig:output_type -> proto.ConfigResponse
+	9,  // 28: proto.ConfigService.GetConfig:output_type -> proto.ConfigResponse
+	11, // 29: proto.ConfigService.ExportConfig:output_type -> proto.ExportResponse
+	27, // [27:30] is the sub-list for method output_type
+	24, // [24:27] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_lbot_proto_config_proto_init() }
@@ -650,7 +2017,7 @@ func file_lbot_proto_config_proto_init() {
 			}
 		}
 		file_lbot_proto_config_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobRequest); i {
+			switch v := v.(*JobCommand); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -662,7 +2029,7 @@ func file_lbot_proto_config_proto_init() {
 			}
 		}
 		file_lbot_proto_config_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConfigRequest); i {
+			switch v := v.(*ScenarioStep); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -674,6 +2041,66 @@ func file_lbot_proto_config_proto_init() {
 			}
 		}
 		file_lbot_proto_config_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Index); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lbot_proto_config_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientRegion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lbot_proto_config_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TagSet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lbot_proto_config_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lbot_proto_config_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lbot_proto_config_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ConfigResponse); i {
 			case 0:
 				return &v.state
@@ -685,6 +2112,30 @@ func file_lbot_proto_config_proto_init() {
 				return nil
 			}
 		}
+		file_lbot_proto_config_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lbot_proto_config_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -692,7 +2143,7 @@ func file_lbot_proto_config_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_lbot_proto_config_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,8 +20,9 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	ConfigService_SetConfig_FullMethodName = "/proto.ConfigService/SetConfig"
-	ConfigService_GetConfig_FullMethodName = "/proto.ConfigService/GetConfig"
+	ConfigService_SetConfig_FullMethodName    = "/proto.ConfigService/SetConfig"
+	ConfigService_GetConfig_FullMethodName    = "/proto.ConfigService/GetConfig"
+	ConfigService_ExportConfig_FullMethodName = "/proto.ConfigService/ExportConfig"
 )
 
 // ConfigServiceClient is the client API for ConfigService service.
@@ -30,6 +31,7 @@ const (
 type ConfigServiceClient interface {
 	SetConfig(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
 	GetConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ConfigResponse, error)
+	ExportConfig(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ExportResponse, error)
 }
 
 type configServiceClient struct {
@@ -58,12 +60,22 @@ func (c *configServiceClient) GetConfig(ctx context.Context, in *emptypb.Empty,
 	return out, nil
 }
 
+func (c *configServiceClient) ExportConfig(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ExportResponse, error) {
+	out := new(ExportResponse)
+	err := c.cc.Invoke(ctx, ConfigService_ExportConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ConfigServiceServer is the server API for ConfigService service.
 // All implementations must embed UnimplementedConfigServiceServer
 // for forward compatibility
 type ConfigServiceServer interface {
 	SetConfig(context.Context, *ConfigRequest) (*ConfigResponse, error)
 	GetConfig(context.Context, *emptypb.Empty) (*ConfigResponse, error)
+	ExportConfig(context.Context, *ExportRequest) (*ExportResponse, error)
 	mustEmbedUnimplementedConfigServiceServer()
 }
 
@@ -77,6 +89,9 @@ func (UnimplementedConfigServiceServer) SetConfig(context.Context, *ConfigReques
 func (UnimplementedConfigServiceServer) GetConfig(context.Context, *emptypb.Empty) (*ConfigResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
 }
+func (UnimplementedConfigServiceServer) ExportConfig(context.Context, *ExportRequest) (*ExportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportConfig not implemented")
+}
 func (UnimplementedConfigServiceServer) mustEmbedUnimplementedConfigServiceServer() {}
 
 // UnsafeConfigServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -126,6 +141,24 @@ func _ConfigService_GetConfig_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ConfigService_ExportConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).ExportConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_ExportConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).ExportConfig(ctx, req.(*ExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ConfigService_ServiceDesc is the grpc.ServiceDesc for ConfigService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -141,6 +174,10 @@ var ConfigService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetConfig",
 			Handler:    _ConfigService_GetConfig_Handler,
 		},
+		{
+			MethodName: "ExportConfig",
+			Handler:    _ConfigService_ExportConfig_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "lbot/proto/config.proto",
@@ -0,0 +1,146 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.2
+// source: lbot/proto/history.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	RunHistoryService_ListRuns_FullMethodName = "/proto.RunHistoryService/ListRuns"
+	RunHistoryService_GetRun_FullMethodName   = "/proto.RunHistoryService/GetRun"
+)
+
+// RunHistoryServiceClient is the client API for RunHistoryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RunHistoryServiceClient interface {
+	ListRuns(ctx context.Context, in *ListRunsRequest, opts ...grpc.CallOption) (*ListRunsResponse, error)
+	GetRun(ctx context.Context, in *GetRunRequest, opts ...grpc.CallOption) (*RunSummary, error)
+}
+
+type runHistoryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRunHistoryServiceClient(cc grpc.ClientConnInterface) RunHistoryServiceClient {
+	return &runHistoryServiceClient{cc}
+}
+
+func (c *runHistoryServiceClient) ListRuns(ctx context.Context, in *ListRunsRequest, opts ...grpc.CallOption) (*ListRunsResponse, error) {
+	out := new(ListRunsResponse)
+	err := c.cc.Invoke(ctx, RunHistoryService_ListRuns_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runHistoryServiceClient) GetRun(ctx context.Context, in *GetRunRequest, opts ...grpc.CallOption) (*RunSummary, error) {
+	out := new(RunSummary)
+	err := c.cc.Invoke(ctx, RunHistoryService_GetRun_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RunHistoryServiceServer is the server API for RunHistoryService service.
+// All implementations must embed UnimplementedRunHistoryServiceServer
+// for forward compatibility
+type RunHistoryServiceServer interface {
+	ListRuns(context.Context, *ListRunsRequest) (*ListRunsResponse, error)
+	GetRun(context.Context, *GetRunRequest) (*RunSummary, error)
+	mustEmbedUnimplementedRunHistoryServiceServer()
+}
+
+// UnimplementedRunHistoryServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRunHistoryServiceServer struct {
+}
+
+func (UnimplementedRunHistoryServiceServer) ListRuns(context.Context, *ListRunsRequest) (*ListRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRuns not implemented")
+}
+func (UnimplementedRunHistoryServiceServer) GetRun(context.Context, *GetRunRequest) (*RunSummary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRun not implemented")
+}
+func (UnimplementedRunHistoryServiceServer) mustEmbedUnimplementedRunHistoryServiceServer() {}
+
+// UnsafeRunHistoryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RunHistoryServiceServer will
+// result in compilation errors.
+type UnsafeRunHistoryServiceServer interface {
+	mustEmbedUnimplementedRunHistoryServiceServer()
+}
+
+func RegisterRunHistoryServiceServer(s grpc.ServiceRegistrar, srv RunHistoryServiceServer) {
+	s.RegisterService(&RunHistoryService_ServiceDesc, srv)
+}
+
+func _RunHistoryService_ListRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunHistoryServiceServer).ListRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RunHistoryService_ListRuns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunHistoryServiceServer).ListRuns(ctx, req.(*ListRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RunHistoryService_GetRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunHistoryServiceServer).GetRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RunHistoryService_GetRun_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunHistoryServiceServer).GetRun(ctx, req.(*GetRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RunHistoryService_ServiceDesc is the grpc.ServiceDesc for RunHistoryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RunHistoryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.RunHistoryService",
+	HandlerType: (*RunHistoryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListRuns",
+			Handler:    _RunHistoryService_ListRuns_Handler,
+		},
+		{
+			MethodName: "GetRun",
+			Handler:    _RunHistoryService_GetRun_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "lbot/proto/history.proto",
+}
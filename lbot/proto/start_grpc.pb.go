@@ -11,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -21,6 +22,7 @@ const _ = grpc.SupportPackageIsVersion7
 const (
 	StartProcess_Run_FullMethodName             = "/proto.StartProcess/Run"
 	StartProcess_RunWithProgress_FullMethodName = "/proto.StartProcess/RunWithProgress"
+	StartProcess_Estimate_FullMethodName        = "/proto.StartProcess/Estimate"
 )
 
 // StartProcessClient is the client API for StartProcess service.
@@ -29,6 +31,7 @@ const (
 type StartProcessClient interface {
 	Run(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*StartResponse, error)
 	RunWithProgress(ctx context.Context, in *StartWithProgressRequest, opts ...grpc.CallOption) (StartProcess_RunWithProgressClient, error)
+	Estimate(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Footprint, error)
 }
 
 type startProcessClient struct {
@@ -80,12 +83,22 @@ func (x *startProcessRunWithProgressClient) Recv() (*ProgressResponse, error) {
 	return m, nil
 }
 
+func (c *startProcessClient) Estimate(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Footprint, error) {
+	out := new(Footprint)
+	err := c.cc.Invoke(ctx, StartProcess_Estimate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StartProcessServer is the server API for StartProcess service.
 // All implementations must embed UnimplementedStartProcessServer
 // for forward compatibility
 type StartProcessServer interface {
 	Run(context.Context, *StartRequest) (*StartResponse, error)
 	RunWithProgress(*StartWithProgressRequest, StartProcess_RunWithProgressServer) error
+	Estimate(context.Context, *emptypb.Empty) (*Footprint, error)
 	mustEmbedUnimplementedStartProcessServer()
 }
 
@@ -99,6 +112,9 @@ func (UnimplementedStartProcessServer) Run(context.Context, *StartRequest) (*Sta
 func (UnimplementedStartProcessServer) RunWithProgress(*StartWithProgressRequest, StartProcess_RunWithProgressServer) error {
 	return status.Errorf(codes.Unimplemented, "method RunWithProgress not implemented")
 }
+func (UnimplementedStartProcessServer) Estimate(context.Context, *emptypb.Empty) (*Footprint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Estimate not implemented")
+}
 func (UnimplementedStartProcessServer) mustEmbedUnimplementedStartProcessServer() {}
 
 // UnsafeStartProcessServer may be embedded to opt out of forward compatibility for this service.
@@ -151,6 +167,24 @@ func (x *startProcessRunWithProgressServer) Send(m *ProgressResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _StartProcess_Estimate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StartProcessServer).Estimate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StartProcess_Estimate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StartProcessServer).Estimate(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StartProcess_ServiceDesc is the grpc.ServiceDesc for StartProcess service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -162,6 +196,10 @@ var StartProcess_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Run",
 			Handler:    _StartProcess_Run_Handler,
 		},
+		{
+			MethodName: "Estimate",
+			Handler:    _StartProcess_Estimate_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,133 @@
+package lbot
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/database"
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// storedRun is run kept in history or artifacts dir, size is only known for artifacts
+type storedRun struct {
+	id        string
+	createdAt time.Time
+	size      uint64
+}
+
+// expiredRuns returns runs exceeding agent retention, newest runs are kept first, active runs are never
+// expired but count to max runs and disk usage
+func expiredRuns(runs []storedRun, agent *config.Agent, active map[string]bool, now time.Time) []string {
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].createdAt.After(runs[j].createdAt) })
+
+	expired := make([]string, 0)
+	var kept, size uint64
+	for _, run := range runs {
+		exceeds := (agent.RetentionMaxRuns > 0 && kept >= agent.RetentionMaxRuns) ||
+			(agent.RetentionMaxAge > 0 && now.Sub(run.createdAt) > agent.RetentionMaxAge) ||
+			(agent.RetentionMaxDiskBytes > 0 && size+run.size > agent.RetentionMaxDiskBytes)
+		if exceeds && !active[run.id] {
+			expired = append(expired, run.id)
+			continue
+		}
+		kept++
+		size += run.size
+	}
+	return expired
+}
+
+func hasRetention(agent *config.Agent) bool {
+	return !lo.IsNil(agent) &&
+		(agent.RetentionMaxRuns > 0 || agent.RetentionMaxDiskBytes > 0 || agent.RetentionMaxAge > 0)
+}
+
+// PruneResults removes finished runs exceeding agent retention from artifacts dir, leader prunes also
+// run history (commands, workloads and annotations) shared by agents
+func (l *Lbot) PruneResults(leader bool) {
+	agent := l.Config.Agent
+	if !hasRetention(agent) {
+		return
+	}
+	history, err := l.internalClient.GetStoredRuns(l.ctx)
+	if err != nil {
+		log.Warnf("Pruning results failed: %s", err)
+		return
+	}
+	active := make(map[string]bool)
+	for _, run := range history {
+		if run.Unfinished > 0 {
+			active[run.RunId] = true
+		}
+	}
+	now := time.Now()
+
+	if agent.ArtifactsDir != "" {
+		runs, err := artifactRuns(agent.ArtifactsDir)
+		if err != nil {
+			log.Warnf("Pruning artifacts failed: %s", err)
+		}
+		for _, id := range expiredRuns(runs, agent, active, now) {
+			if err := os.RemoveAll(filepath.Join(agent.ArtifactsDir, id)); err != nil {
+				log.WithField("run_id", id).Warnf("Removing run artifacts failed: %s", err)
+				continue
+			}
+			log.WithField("run_id", id).Info("Run artifacts pruned")
+		}
+	}
+
+	if leader && (agent.RetentionMaxRuns > 0 || agent.RetentionMaxAge > 0) {
+		runs := lo.Map(history, func(run *database.StoredRun, _ int) storedRun {
+			return storedRun{id: run.RunId, createdAt: run.CreatedAt.Time()}
+		})
+		expired := expiredRuns(runs, agent, active, now)
+		if len(expired) == 0 {
+			return
+		}
+		if err := l.internalClient.DeleteRuns(l.ctx, expired); err != nil {
+			log.Warnf("Pruning run history failed: %s", err)
+			return
+		}
+		log.Infof("Pruned %d runs from history", len(expired))
+	}
+}
+
+// artifactRuns returns run directories of artifacts dir with their size, runs are created at time of run id
+func artifactRuns(dir string) ([]storedRun, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	runs := make([]storedRun, 0, len(entries))
+	for _, entry := range entries {
+		id, err := primitive.ObjectIDFromHex(entry.Name())
+		if !entry.IsDir() || err != nil {
+			continue
+		}
+		run := storedRun{id: entry.Name(), createdAt: id.Timestamp()}
+		err = filepath.WalkDir(filepath.Join(dir, entry.Name()), func(_ string, file fs.DirEntry, err error) error {
+			if err != nil || file.IsDir() {
+				return err
+			}
+			info, err := file.Info()
+			if err != nil {
+				return err
+			}
+			run.size += uint64(info.Size())
+			return nil
+		})
+		if err != nil {
+			return runs, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
@@ -0,0 +1,60 @@
+package lbot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/database"
+	"github.com/kuzxnia/loadbot/lbot/schema"
+	"github.com/kuzxnia/loadbot/lbot/worker"
+	log "github.com/sirupsen/logrus"
+)
+
+// compareRouting repeats job directly on shard primary, after job finished through mongos,
+// and logs comparison isolating router overhead
+func (l *Lbot) compareRouting(cfg *config.Config, workload *database.Workload, job config.Job, dataPool schema.DataPool, mongosWorker *worker.Worker) {
+	shardConfig := *cfg
+	shardConfig.ConnectionString = cfg.ShardConnectionString
+	job.Name = job.Name + " (shard)"
+
+	shardWorker, err := worker.NewWorker(l.ctx, &shardConfig, &job, dataPool, l.runningAgents)
+	if err != nil {
+		log.Errorf("Routing comparison for job %s skipped, shard worker initialization error: %s", job.Name, err)
+		return
+	}
+	defer shardWorker.Close()
+	shardWorker.SetWorkload(workload.Id.Hex(), workload.CommandId.Hex())
+	shardWorker.SetBudget(l.budget)
+
+	l.mutext.Lock()
+	l.workers[workload.Id.String()] = shardWorker
+	l.mutext.Unlock()
+
+	shardWorker.InitMetrics()
+	shardWorker.Work(l.changed)
+
+	log.Info(RoutingComparisonReport(mongosWorker.Metrics, shardWorker.Metrics))
+}
+
+func RoutingComparisonReport(mongos *worker.Metrics, shard *worker.Metrics) string {
+	var report strings.Builder
+	report.WriteString("Routing comparison:\n")
+	fmt.Fprintf(&report, "  %-8s %12s %10s %14s %10s\n", "route", "requests", "rps", "avg latency", "errors")
+	for _, route := range []struct {
+		name    string
+		metrics *worker.Metrics
+	}{{"mongos", mongos}, {"shard", shard}} {
+		fmt.Fprintf(
+			&report, "  %-8s %12d %10d %14s %9.2f%%\n",
+			route.name, route.metrics.Requests(), route.metrics.Rps(), route.metrics.AverageLatency(), route.metrics.ErrorRate()*100,
+		)
+	}
+
+	overhead := mongos.AverageLatency() - shard.AverageLatency()
+	fmt.Fprintf(&report, "  router overhead: %s per operation", overhead)
+	if shard.AverageLatency() > 0 {
+		fmt.Fprintf(&report, " (%.1f%%)", float64(overhead)/float64(shard.AverageLatency())*100)
+	}
+	return report.String()
+}
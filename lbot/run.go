@@ -0,0 +1,206 @@
+package lbot
+
+import (
+	"context"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stop reason of job which worker failed to prepare
+const stopReasonPrepareFailed = "prepare_failed"
+
+// run groups commands created by single start, its id identifies workload in stop, progress and watch.
+// Only one run of agent config per tenant can be active, runs of own config (workloads) run alongside
+type run struct {
+	id        string
+	tenant    string
+	own       bool // jobs come from config of start request, not from agent config
+	commands  []primitive.ObjectID
+	startedAt time.Time
+	template  string
+	// aggregated stats of jobs finished on agent
+	jobs      int
+	requests  uint64
+	errors    uint64
+	latencyMs float64 // average latency of jobs weighted by their requests
+	results   []jobResult
+	recorded  bool // summary was added to run history
+	// final progress of jobs whose workers are gone, awaiting clients see them until run is forgotten
+	finished []*proto.ProgressResponse
+}
+
+// jobResult is final stats of job finished on agent
+type jobResult struct {
+	workloadId string
+	name       string
+	jobType    string
+	stopReason string
+	stats      *EventStats
+}
+
+// stats returns aggregated stats of run, rps is computed from run wall time, so jobs running
+// in parallel add up
+func (r *run) stats(now time.Time) *EventStats {
+	stats := &EventStats{Requests: r.requests, Errors: r.errors, DurationSeconds: uint64(now.Sub(r.startedAt).Seconds())}
+	if stats.DurationSeconds > 0 {
+		stats.Rps = r.requests / stats.DurationSeconds
+	}
+	if r.requests > 0 {
+		stats.AverageLatencyMs = r.latencyMs / float64(r.requests)
+	}
+	return stats
+}
+
+func runKey(tenant *config.Tenant) string {
+	if lo.IsNil(tenant) {
+		return ""
+	}
+	return tenant.Name
+}
+
+// registerRun adds run to runs of agent, run of agent config fails with AlreadyExists when tenant run of agent
+// config is still starting or has unfinished commands, finished runs are forgotten. Active run is looked up
+// and new one is stored under one lock, so concurrent starts of tenant can't both register
+func (l *Lbot) registerRun(current *run) error {
+	for {
+		l.mutext.Lock()
+		active, ok := lo.Find(lo.Values(l.runs), func(r *run) bool { return !current.own && !r.own && r.tenant == current.tenant })
+		if !ok {
+			l.runs[current.id] = current
+			l.mutext.Unlock()
+			return nil
+		}
+		commands := active.commands
+		l.mutext.Unlock()
+
+		if len(commands) == 0 {
+			return status.Errorf(codes.AlreadyExists, "run %s is starting, stop it or start with restart", active.id)
+		}
+		unfinished, err := l.internalClient.CountUnfinishedCommands(context.TODO(), commands)
+		if err != nil {
+			return err
+		}
+		if unfinished > 0 {
+			return status.Errorf(codes.AlreadyExists, "run %s is active, stop it or start with restart", active.id)
+		}
+		l.mutext.Lock()
+		// another start could forget it meanwhile
+		if l.runs[active.id] == active {
+			delete(l.runs, active.id)
+		}
+		l.mutext.Unlock()
+	}
+}
+
+// findRun returns run by id, tenant can see only its runs
+func (l *Lbot) findRun(tenant *config.Tenant, runId string) (*run, error) {
+	l.mutext.Lock()
+	defer l.mutext.Unlock()
+	current, ok := l.runs[runId]
+	if !ok || (!lo.IsNil(tenant) && current.tenant != tenant.Name) {
+		return nil, status.Errorf(codes.NotFound, "no run %s started by this agent", runId)
+	}
+	return current, nil
+}
+
+// addJobResult adds stats of job finished on agent to its run
+func (l *Lbot) addJobResult(runId string, result jobResult) {
+	l.mutext.Lock()
+	defer l.mutext.Unlock()
+	current, ok := l.runs[runId]
+	if !ok {
+		return
+	}
+	current.jobs++
+	current.requests += result.stats.Requests
+	current.errors += result.stats.Errors
+	current.latencyMs += result.stats.AverageLatencyMs * float64(result.stats.Requests)
+	current.results = append(current.results, result)
+}
+
+// finishJob keeps final progress of job of run after its worker is removed, caller holds l.mutext
+func (l *Lbot) finishJob(progress *proto.ProgressResponse) {
+	if current, ok := l.runs[progress.RunId]; ok {
+		current.finished = append(current.finished, progress)
+	}
+}
+
+// forgetRun drops runs of tenant, all runs if tenant is nil
+func (l *Lbot) forgetRun(tenant *config.Tenant) {
+	l.mutext.Lock()
+	defer l.mutext.Unlock()
+	if lo.IsNil(tenant) {
+		l.runs = make(map[string]*run)
+		return
+	}
+	l.runs = lo.OmitBy(l.runs, func(_ string, r *run) bool { return r.tenant == tenant.Name })
+}
+
+func (l *Lbot) forgetRunId(runId string) {
+	l.mutext.Lock()
+	defer l.mutext.Unlock()
+	delete(l.runs, runId)
+}
+
+// stopActiveRun stops active run of agent config of tenant, workloads with own config keep running
+func (l *Lbot) stopActiveRun(tenant *config.Tenant) {
+	l.mutext.Lock()
+	active, ok := lo.Find(lo.Values(l.runs), func(r *run) bool { return !r.own && r.tenant == runKey(tenant) })
+	l.mutext.Unlock()
+	if ok {
+		l.CancelRun(tenant, active.id, config.DefaultStopGracePeriod, false)
+	}
+}
+
+// parseWorkloadConfig parses own config of start request, nil when request starts jobs of agent config.
+// Workload without connection string targets agent database, agent target rails apply to it
+func (l *Lbot) parseWorkloadConfig(request *proto.StartRequest) (*config.Config, error) {
+	if request.Config == "" {
+		return nil, nil
+	}
+	if request.Schedule != "" || request.StartAt != "" {
+		return nil, status.Error(codes.InvalidArgument, "workload with own config can't be scheduled")
+	}
+	if request.Restart {
+		return nil, status.Error(codes.InvalidArgument, "workload with own config runs alongside other runs, it can't restart them")
+	}
+	parsed, err := ParseConfig([]byte(request.Config))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid workload config, %s", err)
+	}
+	if parsed.Agent == nil {
+		parsed.Agent = &AgentRequest{}
+	}
+	cfg := NewConfig(parsed)
+	if err = rejectHookCommands(cfg.Jobs); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "config rejected, %s", err)
+	}
+	// agent config is applied only on agent start
+	cfg.Agent = l.Config.Agent
+	if cfg.ConnectionString == "" {
+		cfg.ConnectionString = l.Config.ConnectionString
+	}
+	if err = cfg.Agent.CheckTargets(cfg); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "config rejected, %s", err)
+	}
+	return cfg, nil
+}
+
+// hasActiveRun reports whether run of id (any run of tenant if id is empty) has unfinished commands
+func (l *Lbot) hasActiveRun(tenant *config.Tenant, runId string) bool {
+	l.mutext.Lock()
+	runs := lo.Filter(lo.Values(l.runs), func(r *run, _ int) bool {
+		return (lo.IsNil(tenant) || r.tenant == tenant.Name) && (runId == "" || r.id == runId)
+	})
+	l.mutext.Unlock()
+	return lo.SomeBy(runs, func(r *run) bool {
+		unfinished, err := l.internalClient.CountUnfinishedCommands(l.ctx, r.commands)
+		return err == nil && unfinished > 0
+	})
+}
@@ -0,0 +1,272 @@
+package lbot
+
+import (
+	"context"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/database"
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// startSchedule is when start request runs jobs, cron schedule starts them at every matching time,
+// zero value starts them immediately
+type startSchedule struct {
+	cron *config.Cron
+	at   time.Time
+	// footprint over agent thresholds was confirmed with start request, it's checked again at every start
+	confirmed bool
+}
+
+// parseStartSchedule parses schedule of start request, cron expression and start time are exclusive
+func parseStartSchedule(request *proto.StartRequest, now time.Time) (schedule startSchedule, err error) {
+	if request.Schedule != "" && request.StartAt != "" {
+		return schedule, status.Error(codes.InvalidArgument, "start can't have both schedule and start time")
+	}
+	schedule.confirmed = request.Confirmed
+	if request.Schedule != "" {
+		if schedule.cron, err = config.ParseCron(request.Schedule); err != nil {
+			return schedule, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if schedule.cron.Next(now).IsZero() {
+			return schedule, status.Errorf(codes.InvalidArgument, "cron expression %q never matches", request.Schedule)
+		}
+	}
+	if request.StartAt != "" {
+		if schedule.at, err = time.Parse(time.RFC3339, request.StartAt); err != nil {
+			return schedule, status.Errorf(codes.InvalidArgument, "invalid start time %q, expected RFC 3339 time", request.StartAt)
+		}
+		if !schedule.at.After(now) {
+			return schedule, status.Errorf(codes.InvalidArgument, "start time %s is in the past", request.StartAt)
+		}
+	}
+	return schedule, nil
+}
+
+func (s startSchedule) isSet() bool {
+	return s.cron != nil || !s.at.IsZero()
+}
+
+// next returns start time after now, zero when one-off start already happened
+func (s startSchedule) next(now time.Time) time.Time {
+	if s.cron != nil {
+		return s.cron.Next(now)
+	}
+	if s.at.After(now) {
+		return s.at
+	}
+	return time.Time{}
+}
+
+// scheduledStart is start of tenant jobs waiting for its time
+type scheduledStart struct {
+	tenant *config.Tenant
+	cancel context.CancelFunc
+}
+
+// start runs jobs of tenant now, or schedules them when schedule is set, restart stops active run first,
+// own config starts as workload alongside other runs. Approved is snapshot of agent config approved start
+// runs, nil runs agent config
+func (l *Lbot) start(tenant *config.Tenant, restart bool, schedule startSchedule, own *config.Config, approved *config.Config) (*proto.StartResponse, error) {
+	if own != nil {
+		runId, started, err := l.RunWorkload(tenant, own)
+		return &proto.StartResponse{Jobs: started, RunId: runId}, err
+	}
+	if schedule.isSet() {
+		next, err := l.Schedule(tenant, restart, schedule, approved)
+		if err != nil {
+			return nil, err
+		}
+		return &proto.StartResponse{ScheduledAt: next.Format(time.RFC3339)}, nil
+	}
+	if restart {
+		l.stopActiveRun(tenant)
+	}
+	runId, started, err := l.runConfig(tenant, lo.Ternary(approved != nil, approved, l.Config))
+
+	return &proto.StartResponse{Jobs: started, RunId: runId}, err
+}
+
+// Schedule starts jobs of tenant at times of schedule until it is cancelled, jobs of approved config
+// snapshot, or without it of config current at start time are started, returns time of first start.
+// Schedule is persisted, so agent of the same name restores it after restart
+func (l *Lbot) Schedule(tenant *config.Tenant, restart bool, schedule startSchedule, approved *config.Config) (time.Time, error) {
+	persisted := &database.Schedule{
+		Id:        primitive.NewObjectID(),
+		Agent:     l.agentName(),
+		Tenant:    tenantName(tenant),
+		Restart:   restart,
+		Confirmed: schedule.confirmed,
+		CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
+	}
+	if schedule.cron != nil {
+		persisted.Cron = schedule.cron.String()
+	}
+	if !schedule.at.IsZero() {
+		persisted.At = primitive.NewDateTimeFromTime(schedule.at)
+	}
+	if approved != nil {
+		// tenant tokens are not stored, agent section of restored snapshot is agent config
+		snapshot := *approved
+		snapshot.Agent = nil
+		persisted.Config = &snapshot
+	}
+	if err := l.internalClient.SaveSchedule(l.ctx, persisted); err != nil {
+		return time.Time{}, status.Errorf(codes.Internal, "saving schedule failed: %s", err)
+	}
+	return l.runSchedule(persisted.Id, tenant, restart, schedule, approved), nil
+}
+
+// RestoreSchedules resumes schedules persisted by agent before restart, one-off starts missed while agent
+// was down and schedules of removed tenants are dropped
+func (l *Lbot) RestoreSchedules() error {
+	schedules, err := l.internalClient.GetSchedules(l.ctx, l.agentName())
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, persisted := range schedules {
+		scheduleLog := log.WithField("schedule_id", persisted.Id.Hex())
+		schedule := startSchedule{confirmed: persisted.Confirmed}
+		if persisted.Cron != "" {
+			if schedule.cron, err = config.ParseCron(persisted.Cron); err != nil {
+				scheduleLog.Warnf("Dropping schedule with invalid cron expression: %s", err)
+				l.deleteSchedule(persisted.Id)
+				continue
+			}
+		}
+		if persisted.At != 0 {
+			schedule.at = persisted.At.Time()
+		}
+		var tenant *config.Tenant
+		if persisted.Tenant != "" {
+			if tenant = l.Config.Agent.GetTenant(persisted.Tenant); tenant == nil {
+				scheduleLog.Warnf("Dropping schedule of removed tenant %q", persisted.Tenant)
+				l.deleteSchedule(persisted.Id)
+				continue
+			}
+		}
+		if schedule.next(now).IsZero() {
+			scheduleLog.Warnf("Dropping start of tenant %q missed while agent was down", persisted.Tenant)
+			l.deleteSchedule(persisted.Id)
+			continue
+		}
+		approved := persisted.Config
+		if approved != nil {
+			approved.Agent = l.Config.Agent
+		}
+		next := l.runSchedule(persisted.Id, tenant, persisted.Restart, schedule, approved)
+		scheduleLog.Infof("Restored start of tenant %q, next start at %s", persisted.Tenant, next.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// runSchedule starts scheduled jobs at every time of schedule in background, schedule is deleted once it
+// has no more starts, agent shutdown keeps it persisted
+func (l *Lbot) runSchedule(id primitive.ObjectID, tenant *config.Tenant, restart bool, schedule startSchedule, approved *config.Config) time.Time {
+	ctx, cancel := context.WithCancel(l.ctx)
+	l.mutext.Lock()
+	l.schedules[id.Hex()] = &scheduledStart{tenant: tenant, cancel: cancel}
+	l.mutext.Unlock()
+
+	next := schedule.next(time.Now())
+	log.WithField("schedule_id", id.Hex()).Infof("Start of tenant %q scheduled, first start at %s", runKey(tenant), next.Format(time.RFC3339))
+	go func() {
+		defer l.forgetSchedule(id.Hex())
+		for at := next; !at.IsZero(); at = schedule.next(at) {
+			if !sleepUntil(ctx, at) {
+				return
+			}
+			l.startScheduled(id, tenant, restart, schedule, approved)
+		}
+		l.deleteSchedule(id)
+	}()
+	return next
+}
+
+// startScheduled runs jobs of schedule, footprint and approval gates are checked again as config,
+// agent thresholds or protection could change since start was scheduled
+func (l *Lbot) startScheduled(id primitive.ObjectID, tenant *config.Tenant, restart bool, schedule startSchedule, approved *config.Config) {
+	scheduleLog := log.WithField("schedule_id", id.Hex())
+	scheduleLog.Infof("Starting scheduled run of tenant %q", runKey(tenant))
+	if err := l.checkFootprint(tenant, approved, schedule.confirmed); err != nil {
+		scheduleLog.Warnf("Scheduled start of tenant %q skipped: %s", runKey(tenant), err)
+		l.events.Emit(Event{Type: EventError, Error: err.Error()})
+		return
+	}
+	if approved == nil && l.isProtected() {
+		// config current at start time was never approved
+		approvalId := l.RequestStart(tenant, restart, startSchedule{}, nil)
+		scheduleLog.Warnf("Scheduled start of tenant %q waits for approval %s", runKey(tenant), approvalId)
+		return
+	}
+	if restart {
+		l.stopActiveRun(tenant)
+	}
+	if runId, _, err := l.runConfig(tenant, lo.Ternary(approved != nil, approved, l.Config)); err != nil {
+		scheduleLog.Warnf("Scheduled start of tenant %q failed: %s", runKey(tenant), err)
+		l.events.Emit(Event{Type: EventError, RunId: runId, Error: err.Error()})
+	}
+}
+
+// CancelSchedules cancels scheduled starts of tenant (all if tenant is nil), returns number of cancelled
+func (l *Lbot) CancelSchedules(tenant *config.Tenant) uint64 {
+	l.mutext.Lock()
+	var cancelled []string
+	for id, scheduled := range l.schedules {
+		if !lo.IsNil(tenant) && runKey(scheduled.tenant) != tenant.Name {
+			continue
+		}
+		scheduled.cancel()
+		delete(l.schedules, id)
+		cancelled = append(cancelled, id)
+	}
+	l.mutext.Unlock()
+
+	for _, id := range cancelled {
+		objectId, _ := primitive.ObjectIDFromHex(id)
+		l.deleteSchedule(objectId)
+	}
+	return uint64(len(cancelled))
+}
+
+func (l *Lbot) forgetSchedule(id string) {
+	l.mutext.Lock()
+	defer l.mutext.Unlock()
+	if scheduled, ok := l.schedules[id]; ok {
+		scheduled.cancel()
+		delete(l.schedules, id)
+	}
+}
+
+// deleteSchedule removes persisted schedule, failure only leaves schedule to be dropped on restore
+func (l *Lbot) deleteSchedule(id primitive.ObjectID) {
+	if err := l.internalClient.DeleteSchedule(context.Background(), id); err != nil {
+		log.WithField("schedule_id", id.Hex()).Warnf("Deleting schedule failed: %s", err)
+	}
+}
+
+// agentName identifies agent which restores its schedules after restart
+func (l *Lbot) agentName() string {
+	if l.Config.Agent == nil {
+		return ""
+	}
+	return l.Config.Agent.Name
+}
+
+// sleepUntil waits until time or context cancellation, returns false when cancelled
+func sleepUntil(ctx context.Context, at time.Time) bool {
+	timer := time.NewTimer(time.Until(at))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -1,7 +1,12 @@
 package schema
 
 import (
+	"io"
+	"math/rand"
+
 	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 type DataProvider interface {
@@ -9,6 +14,15 @@ type DataProvider interface {
 	GetSingleItemWithout(string) interface{}
 	GetBatch(uint64) []interface{}
 	GetFilter() interface{}
+	GetUpdate() interface{}
+	GetArrayFilters() []interface{}
+	GetSort() interface{}
+	GetLookup() interface{}
+	GetPipeline() []interface{}
+	GetSearch() interface{}
+	GetGeoPoint() interface{}
+	GetCommand() bson.D
+	GetPayload() io.Reader
 }
 
 func NewDataProvider(job *config.Job, schema *config.Schema) DataProvider {
@@ -17,6 +31,10 @@ func NewDataProvider(job *config.Job, schema *config.Schema) DataProvider {
 	)
 }
 
+// queryValueGenerator generates values of query templates, they are generated the same way with and without job schema,
+// only "#…" placeholders are generated and literal values are kept
+var queryValueGenerator = &StructuralizableDataGenerator{}
+
 // here i need to create pool of items to be taken to insert/update
 // also here is place to store keys which needs to be saved
 
@@ -48,10 +66,62 @@ func (d *LiveDataProvider) GetSingleItemWithout(key string) interface{} {
 }
 
 func (d *LiveDataProvider) GetFilter() interface{} {
-	singleItem, _ := d.dataGenerator.GenerateFromTemplate(d.job.Filter)
+	singleItem, _ := queryValueGenerator.GenerateFromTemplate(d.job.Filter)
 	return singleItem
 }
 
+func (d *LiveDataProvider) GetUpdate() interface{} {
+	update, _ := queryValueGenerator.GenerateFromTemplate(d.job.Update)
+	return update
+}
+
+func (d *LiveDataProvider) GetArrayFilters() []interface{} {
+	arrayFilters, _ := queryValueGenerator.GenerateFromTemplate(d.job.ArrayFilters)
+	result, _ := arrayFilters.([]interface{})
+	return result
+}
+
+func (d *LiveDataProvider) GetSort() interface{} {
+	sort, _ := queryValueGenerator.GenerateFromTemplate(d.job.Sort)
+	return sort
+}
+
+func (d *LiveDataProvider) GetLookup() interface{} {
+	lookup, _ := queryValueGenerator.GenerateFromTemplate(d.job.Lookup)
+	return lookup
+}
+
+func (d *LiveDataProvider) GetPipeline() []interface{} {
+	pipeline, _ := queryValueGenerator.GenerateFromTemplate(d.job.Pipeline)
+	result, _ := pipeline.([]interface{})
+	return result
+}
+
+// GetSearch returns $text search generated from job search template, default single word
+func (d *LiveDataProvider) GetSearch() interface{} {
+	search, _ := queryValueGenerator.GenerateFromTemplate(lo.If(d.job.Search != "", d.job.Search).Else("#word"))
+	return search
+}
+
+// GetGeoPoint returns center of geo query generated from job geo_point template, default point anywhere on earth
+func (d *LiveDataProvider) GetGeoPoint() interface{} {
+	point, _ := queryValueGenerator.GenerateFromTemplate(lo.If(d.job.GeoPoint != "", d.job.GeoPoint).Else("#point"))
+	return point
+}
+
+// GetCommand returns command document generated from job command template, command name is always first key
+func (d *LiveDataProvider) GetCommand() bson.D {
+	generated, _ := queryValueGenerator.GenerateFromTemplate(d.job.Command.Template)
+	fields, _ := generated.(map[string]interface{})
+	command := bson.D{{Key: d.job.Command.Name, Value: fields[d.job.Command.Name]}}
+	for key, value := range fields {
+		if key != d.job.Command.Name {
+			command = append(command, bson.E{Key: key, Value: value})
+		}
+	}
+	return command
+}
+
 func (d *LiveDataProvider) GetBatch(batchSize uint64) []interface{} {
 	batchOfData := make([]interface{}, batchSize)
 
@@ -62,3 +132,12 @@ func (d *LiveDataProvider) GetBatch(batchSize uint64) []interface{} {
 	// todo: add slice
 	return batchOfData
 }
+
+// GetPayload returns stream of file_size random bytes, or random size up to file_size_max
+func (d *LiveDataProvider) GetPayload() io.Reader {
+	size := d.job.FileSize
+	if d.job.FileSizeMax > size {
+		size += uint64(rand.Int63n(int64(d.job.FileSizeMax - size + 1)))
+	}
+	return NewPayloadReader(size)
+}
@@ -0,0 +1,62 @@
+package schema
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// EvolveDocument randomly mutates document shape - adds new field, removes existing one or changes
+// type of existing field, simulates documents written during rolling schema migration,
+// item is always returned (in place mutated), values other than documents are returned unchanged
+func EvolveDocument(item interface{}) interface{} {
+	var document map[string]interface{}
+	switch value := item.(type) {
+	case map[string]interface{}:
+		document = value
+	case bson.M:
+		document = value
+	case *bson.M:
+		if value == nil {
+			return item
+		}
+		document = *value
+	default:
+		return item
+	}
+	if document == nil {
+		return item
+	}
+
+	keys := lo.Without(lo.Keys(document), "_id")
+	if len(keys) == 0 {
+		document[fmt.Sprintf("evolved_%d", rand.Intn(10))] = randStringBytes(8)
+		return item
+	}
+	key := keys[rand.Intn(len(keys))]
+
+	switch rand.Intn(3) {
+	case 0:
+		document[fmt.Sprintf("evolved_%d", rand.Intn(10))] = randStringBytes(8)
+	case 1:
+		delete(document, key)
+	case 2:
+		document[key] = changeType(document[key])
+	}
+	return item
+}
+
+func changeType(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return len(v)
+	case int, int32, int64, float64:
+		return fmt.Sprint(v)
+	case map[string]interface{}:
+		return lo.Values(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -17,7 +17,7 @@ import (
 var (
 	DefaultGeneratorFieldMapper = NewGeneratorFieldMapper()
 	// todo: better validation
-	GeneratorFieldTypes = lo.Keys(DefaultGeneratorFieldMapper.FieldTypeMapper)
+	GeneratorFieldTypes = append(append(append(lo.Keys(DefaultGeneratorFieldMapper.FieldTypeMapper), lo.Keys(ObjectIdGenerators)...), TypedGeneratorNames...), GeoGeneratorNames...)
 )
 
 // todo: add interface
@@ -32,6 +32,9 @@ func NewGeneratorFieldMapper() *GeneratorFieldMapper {
 			"#id":     faker.UUIDDigit,
 			"#string": faker.Word,
 			"#word":   faker.Word,
+			// text
+			"#sentence":  faker.Sentence,
+			"#paragraph": faker.Paragraph,
 			// internet
 			"#email":    faker.Email,
 			"#username": faker.Username,
@@ -52,6 +55,23 @@ func NewGeneratorFieldMapper() *GeneratorFieldMapper {
 func (m *GeneratorFieldMapper) Generate(field string) (result interface{}, err error) {
 	if generate, ok := m.FieldTypeMapper[field]; ok {
 		return generate(), nil
+	} else if generate, ok := ObjectIdGenerators[field]; ok {
+		return generate(), nil
+	} else if generate, ok, err := TypedGenerator(field); ok {
+		if err != nil {
+			return nil, err
+		}
+		return generate(), nil
+	} else if generate, ok, err := LocaleGenerator(field); ok {
+		if err != nil {
+			return nil, err
+		}
+		return generate(), nil
+	} else if generate, ok, err := GeoGenerator(field); ok {
+		if err != nil {
+			return nil, err
+		}
+		return generate(), nil
 	} else {
 		return nil, errors.New("Invalid field mapper, got: " + field)
 	}
@@ -72,7 +92,8 @@ func NewDataGenerator(schema *config.Schema, dataSize int) DataGenerator {
 	if schema != nil {
 		return DataGenerator(
 			&StructuralizableDataGenerator{
-				schema: schema,
+				schema:    schema,
+				objectIds: NewMonotonicObjectId(),
 				// add support for custom byte size
 			},
 		)
@@ -98,6 +119,20 @@ func (g *MeasurableDataGenerator) GenerateFromTemplate(template interface{}) (in
 	case string:
 		return randStringBytes(g.dataSize), nil
 
+	case float64, bool, nil:
+		return value, nil
+
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, nestedTemplate := range value {
+			value, err := g.GenerateFromTemplate(nestedTemplate)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = value
+		}
+		return result, nil
+
 	case map[string]interface{}:
 		result := make(map[string]interface{})
 		for k, nestedTemplate := range value {
@@ -147,7 +182,8 @@ func randStringBytes(n int) string {
 }
 
 type StructuralizableDataGenerator struct {
-	schema *config.Schema
+	schema    *config.Schema
+	objectIds *MonotonicObjectId
 }
 
 func (g *StructuralizableDataGenerator) Generate() (interface{}, error) {
@@ -159,12 +195,33 @@ func (g *StructuralizableDataGenerator) Generate() (interface{}, error) {
 func (g *StructuralizableDataGenerator) GenerateFromTemplate(template interface{}) (interface{}, error) {
 	switch value := template.(type) {
 	case string:
+		if !strings.HasPrefix(value, "#") {
+			// plain value, ex. update operator argument
+			return value, nil
+		}
+		if value == MonotonicObjectIdField {
+			return g.objectIds.Next(), nil
+		}
 		generatedValue, err := DefaultGeneratorFieldMapper.Generate(value)
 		if err != nil {
 			return nil, errors.New("Invalid field mapper, got: " + value)
 		}
 		return generatedValue, nil
 
+	case float64, bool, nil:
+		return value, nil
+
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, nestedTemplate := range value {
+			value, err := g.GenerateFromTemplate(nestedTemplate)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = value
+		}
+		return result, nil
+
 	case map[string]interface{}:
 		result := make(map[string]interface{})
 		for k, nestedTemplate := range value {
@@ -1,11 +1,14 @@
 package schema
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/kuzxnia/loadbot/lbot/config"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func TestGenerateDataFromSchema(t *testing.T) {
@@ -44,3 +47,98 @@ func TestInvalidType(t *testing.T) {
 	assert.Nil(t, result)
 	assert.Error(t, error, "Invalid field mapper, got: #invalid")
 }
+
+func TestEvolveDocument(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		document := map[string]interface{}{"_id": "id", "name": "name", "age": 10}
+
+		result := EvolveDocument(document).(map[string]interface{})
+
+		assert.Contains(t, result, "_id")
+		assert.NotEqual(t, map[string]interface{}{"_id": "id", "name": "name", "age": 10}, result)
+	}
+}
+
+func TestEvolveDocumentGeneratorOutputs(t *testing.T) {
+	schema := config.Schema{Name: "dummy", Schema: map[string]interface{}{"name": "#string", "age": 1.0}}
+	for _, generator := range []DataGenerator{NewDataGenerator(&schema, 100), NewDataGenerator(nil, 100)} {
+		for i := 0; i < 100; i++ {
+			item, error := generator.Generate()
+			assert.Nil(t, error)
+
+			result := EvolveDocument(item)
+
+			assert.NotNil(t, result)
+			assert.IsType(t, item, result)
+		}
+	}
+
+	for _, item := range []interface{}{bson.M{}, &bson.M{"name": "name"}, []interface{}{"name"}, "name", 1.0, true} {
+		assert.IsType(t, item, EvolveDocument(item))
+	}
+	assert.Equal(t, []interface{}{"name"}, EvolveDocument([]interface{}{"name"}))
+	assert.Nil(t, EvolveDocument(nil))
+}
+
+func TestMonotonicObjectId(t *testing.T) {
+	generator := NewMonotonicObjectId()
+
+	previous := generator.Next().(primitive.ObjectID)
+	for i := 0; i < 1000; i++ {
+		next := generator.Next().(primitive.ObjectID)
+		assert.Equal(t, 1, bytes.Compare(next[:], previous[:]))
+		previous = next
+	}
+}
+
+func TestMonotonicObjectIdPerGenerator(t *testing.T) {
+	schema := config.Schema{Name: "dummy", Schema: map[string]interface{}{"_id": MonotonicObjectIdField}}
+	first, second := NewDataGenerator(&schema, 0), NewDataGenerator(&schema, 0)
+
+	ids := map[primitive.ObjectID]bool{}
+	previous := map[DataGenerator]primitive.ObjectID{}
+	for i := 0; i < 1000; i++ {
+		for _, generator := range []DataGenerator{first, second} {
+			document, error := generator.Generate()
+			assert.Nil(t, error)
+			id := document.(map[string]interface{})["_id"].(primitive.ObjectID)
+			last := previous[generator]
+			assert.Equal(t, 1, bytes.Compare(id[:], last[:]))
+			assert.False(t, ids[id])
+			ids[id], previous[generator] = true, id
+		}
+	}
+}
+
+func TestGetPipeline(t *testing.T) {
+	job := &config.Job{Pipeline: []interface{}{
+		map[string]interface{}{"$match": map[string]interface{}{"status": "#word"}},
+		map[string]interface{}{"$group": map[string]interface{}{"_id": "$user", "total": map[string]interface{}{"$sum": 1.0}}},
+	}}
+	schema := &config.Schema{Name: "orders", Schema: map[string]interface{}{"status": "#word"}}
+
+	pipeline := NewDataProvider(job, schema).GetPipeline()
+
+	assert.Len(t, pipeline, 2)
+	status := pipeline[0].(map[string]interface{})["$match"].(map[string]interface{})["status"]
+	assert.NotEqual(t, "#word", status)
+	assert.Equal(t, "$user", pipeline[1].(map[string]interface{})["$group"].(map[string]interface{})["_id"])
+}
+
+func TestQueryTemplatesKeepLiterals(t *testing.T) {
+	job := &config.Job{
+		Filter:   map[string]interface{}{"status": "active", "user": "#word"},
+		Update:   map[string]interface{}{"$set": map[string]interface{}{"status": "blocked"}},
+		Sort:     map[string]interface{}{"created_at": -1.0},
+		Pipeline: []interface{}{map[string]interface{}{"$group": map[string]interface{}{"_id": "$user"}}},
+	}
+
+	// without schema only placeholders are generated as well
+	provider := NewDataProvider(job, nil)
+	filter := provider.GetFilter().(map[string]interface{})
+	assert.Equal(t, "active", filter["status"])
+	assert.NotEqual(t, "#word", filter["user"])
+	assert.Equal(t, job.Update, provider.GetUpdate())
+	assert.Equal(t, job.Sort, provider.GetSort())
+	assert.Equal(t, "$user", provider.GetPipeline()[0].(map[string]interface{})["$group"].(map[string]interface{})["_id"])
+}
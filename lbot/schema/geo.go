@@ -0,0 +1,72 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strconv"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// GeoGeneratorNames are generators of GeoJSON values, bounds argument is optional,
+// ex. #point or #point(14.1,49.0,24.2,54.8) for points within Poland
+var GeoGeneratorNames = []string{"#point"}
+
+// parsed generators by template
+var geoGenerators sync.Map
+
+// GeoGenerator returns generator of GeoJSON template, ok is false when template is not geo generator
+func GeoGenerator(template string) (generate func() interface{}, ok bool, err error) {
+	if cached, found := geoGenerators.Load(template); found {
+		return cached.(func() interface{}), true, nil
+	}
+	name, arguments, err := parseGenerator(template)
+	if !slices.Contains(GeoGeneratorNames, name) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, true, err
+	}
+
+	generate, err = pointGenerator(arguments)
+	if err != nil {
+		return nil, true, fmt.Errorf("Invalid generator %s: %w", template, err)
+	}
+	geoGenerators.Store(template, generate)
+	return generate, true, nil
+}
+
+// pointGenerator generates GeoJSON points within (min longitude, min latitude, max longitude, max latitude)
+// bounds, by default anywhere on earth
+func pointGenerator(arguments []string) (func() interface{}, error) {
+	bounds := []float64{-180, -90, 180, 90}
+	switch len(arguments) {
+	case 0:
+	case 4:
+		for i, argument := range arguments {
+			bound, err := strconv.ParseFloat(argument, 64)
+			if err != nil {
+				return nil, err
+			}
+			bounds[i] = bound
+		}
+	default:
+		return nil, errors.New("expected (min_lng,min_lat,max_lng,max_lat) arguments")
+	}
+	minLng, minLat, maxLng, maxLat := bounds[0], bounds[1], bounds[2], bounds[3]
+	if minLng < -180 || maxLng > 180 || minLat < -90 || maxLat > 90 {
+		return nil, errors.New("longitude must be within [-180, 180] and latitude within [-90, 90]")
+	}
+	if maxLng < minLng || maxLat < minLat {
+		return nil, errors.New("max is lower than min")
+	}
+	return func() interface{} {
+		return bson.D{
+			{Key: "type", Value: "Point"},
+			{Key: "coordinates", Value: bson.A{minLng + rand.Float64()*(maxLng-minLng), minLat + rand.Float64()*(maxLat-minLat)}},
+		}
+	}, nil
+}
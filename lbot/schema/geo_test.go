@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestGeoGenerator(t *testing.T) {
+	generate := func(template string) bson.M {
+		generator, ok, err := GeoGenerator(template)
+		assert.True(t, ok)
+		assert.NoError(t, err)
+		point := bson.M{}
+		for _, element := range generator().(bson.D) {
+			point[element.Key] = element.Value
+		}
+		return point
+	}
+
+	point := generate("#point")
+	assert.Equal(t, "Point", point["type"])
+	assert.Len(t, point["coordinates"], 2)
+
+	for i := 0; i < 100; i++ {
+		coordinates := generate("#point(14.1, 49.0, 24.2, 54.8)")["coordinates"].(bson.A)
+		lng, lat := coordinates[0].(float64), coordinates[1].(float64)
+		assert.True(t, lng >= 14.1 && lng <= 24.2)
+		assert.True(t, lat >= 49.0 && lat <= 54.8)
+	}
+
+	for _, template := range []string{"#point(1,2)", "#point(0,0,200,10)", "#point(10,10,0,0)", "#point(a,0,1,1)"} {
+		_, ok, err := GeoGenerator(template)
+		assert.True(t, ok)
+		assert.Error(t, err, template)
+	}
+	_, ok, _ := GeoGenerator("#word")
+	assert.False(t, ok)
+}
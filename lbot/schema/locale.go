@@ -0,0 +1,123 @@
+package schema
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// LocaleGeneratorNames are textual generators accepting locale argument, ex. #word(ja) or #name(pl),
+// without argument they generate english (ASCII) values
+var LocaleGeneratorNames = []string{"#string", "#word", "#name", "#first_name", "#last_name"}
+
+// Locale holds dictionaries of generated values, diacritics and multi-byte characters surface
+// collation and index size effects hidden by ASCII-only strings
+type Locale struct {
+	Words      []string
+	FirstNames []string
+	LastNames  []string
+	// family name is written before given name without space, ex. 佐藤太郎
+	FamilyNameFirst bool
+}
+
+var Locales = map[string]*Locale{
+	"pl": {
+		Words: []string{
+			"źdźbło", "żółw", "gęś", "łąka", "jabłko", "książka", "miłość", "pieśń", "wiśnia", "ćma",
+			"dźwięk", "róża", "mąka", "chleb", "słońce", "zamek", "rzeka", "góra", "żaba", "część",
+		},
+		FirstNames: []string{
+			"Łukasz", "Paweł", "Michał", "Krzysztof", "Józef", "Wojciech", "Grzegorz", "Tomasz",
+			"Małgorzata", "Agnieszka", "Katarzyna", "Żaneta", "Jadwiga", "Bożena", "Zofia", "Anna",
+		},
+		LastNames: []string{
+			"Nowak", "Kowalski", "Wiśniewski", "Wójcik", "Kowalczyk", "Kamiński", "Lewandowski", "Zieliński",
+			"Szymański", "Woźniak", "Dąbrowski", "Kozłowski", "Jankowski", "Mazur", "Krawczyk", "Żak",
+		},
+	},
+	"de": {
+		Words: []string{
+			"Straße", "Größe", "Fußball", "Bäckerei", "Mädchen", "Brötchen", "Übung", "Schlüssel", "Äpfel", "Haus",
+			"Baum", "Wald", "Flughafen", "Geschwindigkeitsbegrenzung", "Kühlschrank", "Frühstück", "Blume", "Zeitung", "Wörterbuch", "Grüße",
+		},
+		FirstNames: []string{
+			"Jürgen", "Björn", "Günther", "Jörg", "Käthe", "Sören", "Lukas", "Maximilian",
+			"Sophie", "Hannah", "Lena", "Felix", "Paul", "Marie", "Jonas", "Ursula",
+		},
+		LastNames: []string{
+			"Müller", "Schmidt", "Schneider", "Fischer", "Weiß", "Meyer", "Wagner", "Becker",
+			"Schäfer", "Köhler", "Hoffmann", "Schröder", "Krüger", "Groß", "Böhm", "Wolf",
+		},
+	},
+	"ru": {
+		Words: []string{
+			"дом", "книга", "солнце", "река", "молоко", "хлеб", "дерево", "окно", "школа", "друг",
+			"город", "время", "вода", "жизнь", "счастье", "здравствуйте", "пожалуйста", "компьютер", "ёлка", "щука",
+		},
+		FirstNames: []string{"Александр", "Дмитрий", "Сергей", "Андрей", "Алексей", "Наталья", "Елена", "Ольга", "Татьяна", "Юлия"},
+		LastNames:  []string{"Иванов", "Смирнов", "Кузнецов", "Попов", "Васильев", "Петров", "Соколов", "Михайлов", "Новиков", "Фёдоров"},
+	},
+	"ja": {
+		Words: []string{
+			"東京", "桜", "寿司", "猫", "犬", "山", "川", "空", "花火", "図書館",
+			"電車", "新幹線", "ありがとう", "こんにちは", "コンピューター", "データベース", "ラーメン", "時計", "学校", "友達",
+		},
+		FirstNames:      []string{"太郎", "花子", "翔太", "さくら", "陽菜", "蓮", "結衣", "大輔", "美咲", "健太"},
+		LastNames:       []string{"佐藤", "鈴木", "高橋", "田中", "伊藤", "渡辺", "山本", "中村", "小林", "加藤"},
+		FamilyNameFirst: true,
+	},
+	"zh": {
+		Words: []string{
+			"北京", "数据库", "你好", "谢谢", "学校", "朋友", "电脑", "中国", "苹果", "猫",
+			"狗", "山", "水", "火车", "图书馆", "饺子", "长城", "熊猫", "茶", "龙",
+		},
+		FirstNames:      []string{"伟", "芳", "娜", "秀英", "敏", "静", "丽", "强", "磊", "军"},
+		LastNames:       []string{"王", "李", "张", "刘", "陈", "杨", "黄", "赵", "吴", "周"},
+		FamilyNameFirst: true,
+	},
+}
+
+// LocaleGenerator returns generator of localized template like #word(ja), ok is false when template
+// is not localized generator
+func LocaleGenerator(template string) (generate func() interface{}, ok bool, err error) {
+	name, args, found := strings.Cut(template, "(")
+	if !found || !lo.Contains(LocaleGeneratorNames, name) {
+		return nil, false, nil
+	}
+	code := strings.TrimSpace(strings.TrimSuffix(args, ")"))
+	locale, exists := Locales[code]
+	if !strings.HasSuffix(args, ")") || !exists {
+		return nil, true, errors.New("Invalid generator " + template + ", locale must be one of: " + strings.Join(LocaleCodes(), ", "))
+	}
+
+	switch name {
+	case "#first_name":
+		return func() interface{} { return pick(locale.FirstNames) }, true, nil
+	case "#last_name":
+		return func() interface{} { return pick(locale.LastNames) }, true, nil
+	case "#name":
+		return locale.name, true, nil
+	default:
+		return func() interface{} { return pick(locale.Words) }, true, nil
+	}
+}
+
+func (l *Locale) name() interface{} {
+	if l.FamilyNameFirst {
+		return pick(l.LastNames) + pick(l.FirstNames)
+	}
+	return pick(l.FirstNames) + " " + pick(l.LastNames)
+}
+
+func LocaleCodes() []string {
+	codes := lo.Keys(Locales)
+	slices.Sort(codes)
+	return codes
+}
+
+func pick(values []string) string {
+	return values[rand.Intn(len(values))]
+}
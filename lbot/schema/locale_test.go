@@ -0,0 +1,33 @@
+package schema
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocaleGenerators(t *testing.T) {
+	word, err := DefaultGeneratorFieldMapper.Generate("#word(ja)")
+	assert.NoError(t, err)
+	assert.Contains(t, Locales["ja"].Words, word)
+	// multi-byte characters
+	assert.Greater(t, len(word.(string)), utf8.RuneCountInString(word.(string)))
+
+	name, err := DefaultGeneratorFieldMapper.Generate("#name(pl)")
+	assert.NoError(t, err)
+	assert.Contains(t, name, " ")
+
+	lastName, err := DefaultGeneratorFieldMapper.Generate("#last_name( de )")
+	assert.NoError(t, err)
+	assert.Contains(t, Locales["de"].LastNames, lastName)
+
+	_, ok, err := LocaleGenerator("#word(xx)")
+	assert.True(t, ok)
+	assert.ErrorContains(t, err, "de, ja, pl, ru, zh")
+
+	_, ok, _ = LocaleGenerator("#word")
+	assert.False(t, ok)
+	_, ok, _ = LocaleGenerator("#email(pl)")
+	assert.False(t, ok)
+}
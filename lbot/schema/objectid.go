@@ -0,0 +1,59 @@
+package schema
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MonotonicObjectIdField is generator of increasing ObjectIds, scoped to data generator of job
+const MonotonicObjectIdField = "#object_id_monotonic"
+
+// ObjectId generators, inserted documents with increasing _id are appended to the right side of _id index,
+// random ones are spread over whole index
+var ObjectIdGenerators = map[string]func() interface{}{
+	"#object_id":           func() interface{} { return primitive.NewObjectID() },
+	MonotonicObjectIdField: sharedMonotonicObjectId.Next,
+	"#object_id_random":    randomObjectId,
+}
+
+// sharedMonotonicObjectId serves templates generated without data generator, ex. scenario steps
+var sharedMonotonicObjectId = NewMonotonicObjectId()
+
+// MonotonicObjectId generates strictly increasing, time ordered ObjectIds,
+// 4 bytes of seconds timestamp followed by 8 bytes of counter, never goes back even if clock does,
+// counter starts at random value so ids of separate generators do not collide
+type MonotonicObjectId struct {
+	mutex   sync.Mutex
+	seconds uint32
+	counter uint64
+}
+
+func NewMonotonicObjectId() *MonotonicObjectId {
+	var seed [8]byte
+	_, _ = crand.Read(seed[:])
+	// highest bit cleared, leaves room for counter before wrapping
+	return &MonotonicObjectId{counter: binary.BigEndian.Uint64(seed[:]) >> 1}
+}
+
+func (g *MonotonicObjectId) Next() interface{} {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.seconds = max(g.seconds, uint32(time.Now().Unix()))
+	g.counter++
+
+	var id primitive.ObjectID
+	binary.BigEndian.PutUint32(id[0:4], g.seconds)
+	binary.BigEndian.PutUint64(id[4:12], g.counter)
+	return id
+}
+
+func randomObjectId() interface{} {
+	var id primitive.ObjectID
+	_, _ = crand.Read(id[:])
+	return id
+}
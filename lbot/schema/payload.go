@@ -0,0 +1,33 @@
+package schema
+
+import (
+	"io"
+	"math/rand"
+)
+
+// PayloadReader streams pseudo-random bytes generated on read, so payload of any size
+// takes only memory of reader buffer
+type PayloadReader struct {
+	remaining uint64
+	rng       *rand.Rand
+}
+
+func NewPayloadReader(size uint64) *PayloadReader {
+	return &PayloadReader{
+		remaining: size,
+		rng:       rand.New(rand.NewSource(rand.Int63())),
+	}
+}
+
+func (r *PayloadReader) Read(p []byte) (int, error) {
+	if r.remaining == 0 {
+		return 0, io.EOF
+	}
+	n := len(p)
+	if uint64(n) > r.remaining {
+		n = int(r.remaining)
+	}
+	r.rng.Read(p[:n])
+	r.remaining -= uint64(n)
+	return n, nil
+}
@@ -0,0 +1,28 @@
+package schema
+
+import (
+	"io"
+	"testing"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayloadReader(t *testing.T) {
+	written, err := io.Copy(io.Discard, NewPayloadReader(5*1024*1024+3))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5*1024*1024+3), written)
+
+	empty, err := io.ReadAll(NewPayloadReader(0))
+	assert.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestGetPayloadSize(t *testing.T) {
+	provider := NewLiveDataProvider(&config.Job{FileSize: 100, FileSizeMax: 200}, nil)
+	for i := 0; i < 100; i++ {
+		written, _ := io.Copy(io.Discard, provider.GetPayload())
+		assert.GreaterOrEqual(t, written, int64(100))
+		assert.LessOrEqual(t, written, int64(200))
+	}
+}
@@ -0,0 +1,213 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	_ "time/tzdata" // date generator time zones don't depend on zoneinfo of agent image
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const DefaultDecimalScale = 2
+
+// TypedGeneratorNames are generators of explicit BSON types, numbers of template are always doubles,
+// arguments are optional, ex. #int32, #int64(1,1000), #decimal128(0,100,4), #date(2024-01-01,2024-12-31,Europe/Warsaw)
+var TypedGeneratorNames = []string{"#int32", "#int64", "#double", "#decimal128", "#date", "#now"}
+
+// parsed generators by template
+var typedGenerators sync.Map
+
+// TypedGenerator returns generator of typed template, ok is false when template is not typed generator
+func TypedGenerator(template string) (generate func() interface{}, ok bool, err error) {
+	if cached, found := typedGenerators.Load(template); found {
+		return cached.(func() interface{}), true, nil
+	}
+	name, arguments, err := parseGenerator(template)
+	if !slices.Contains(TypedGeneratorNames, name) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, true, err
+	}
+
+	switch name {
+	case "#int32":
+		generate, err = intGenerator(arguments, 32, func(value int64) interface{} { return int32(value) })
+	case "#int64":
+		generate, err = intGenerator(arguments, 64, func(value int64) interface{} { return value })
+	case "#double":
+		generate, err = doubleGenerator(arguments)
+	case "#decimal128":
+		generate, err = decimalGenerator(arguments)
+	case "#date":
+		generate, err = dateGenerator(arguments)
+	case "#now":
+		if len(arguments) > 0 {
+			err = errors.New("#now takes no arguments")
+		}
+		generate = func() interface{} { return primitive.NewDateTimeFromTime(time.Now()) }
+	}
+	if err != nil {
+		return nil, true, fmt.Errorf("Invalid generator %s: %w", template, err)
+	}
+	typedGenerators.Store(template, generate)
+	return generate, true, nil
+}
+
+// parseGenerator splits template into generator name and optional arguments, ex. #int32(1,100)
+func parseGenerator(template string) (name string, arguments []string, err error) {
+	name, args, found := strings.Cut(template, "(")
+	if !found {
+		return name, nil, nil
+	}
+	if !strings.HasSuffix(args, ")") {
+		return name, nil, errors.New("Invalid generator arguments, got: " + template)
+	}
+	if args = strings.TrimSuffix(args, ")"); strings.TrimSpace(args) != "" {
+		arguments = strings.Split(args, ",")
+		for i := range arguments {
+			arguments[i] = strings.TrimSpace(arguments[i])
+		}
+	}
+	return name, arguments, nil
+}
+
+// intGenerator generates integers from [min, max], by default from 0 to max value of type
+func intGenerator(arguments []string, bitSize int, convert func(int64) interface{}) (func() interface{}, error) {
+	low, high := int64(0), int64(math.MaxInt64>>(64-bitSize))
+	switch len(arguments) {
+	case 0:
+	case 2:
+		var err error
+		if low, err = strconv.ParseInt(arguments[0], 10, bitSize); err != nil {
+			return nil, err
+		}
+		if high, err = strconv.ParseInt(arguments[1], 10, bitSize); err != nil {
+			return nil, err
+		}
+		if high < low {
+			return nil, errors.New("max is lower than min")
+		}
+	default:
+		return nil, errors.New("expected (min,max) arguments")
+	}
+	// span of whole int64 range overflows to 0
+	span := uint64(high-low) + 1
+	return func() interface{} {
+		if span == 0 {
+			return convert(int64(rand.Uint64()))
+		}
+		return convert(low + int64(rand.Uint64()%span))
+	}, nil
+}
+
+func floatRange(arguments []string, low float64, high float64) (float64, float64, error) {
+	if len(arguments) == 0 {
+		return low, high, nil
+	}
+	if len(arguments) < 2 {
+		return 0, 0, errors.New("expected (min,max) arguments")
+	}
+	low, err := strconv.ParseFloat(arguments[0], 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	if high, err = strconv.ParseFloat(arguments[1], 64); err != nil {
+		return 0, 0, err
+	}
+	if high < low {
+		return 0, 0, errors.New("max is lower than min")
+	}
+	return low, high, nil
+}
+
+// doubleGenerator generates doubles from [min, max), by default from [0, 1)
+func doubleGenerator(arguments []string) (func() interface{}, error) {
+	if len(arguments) > 2 {
+		return nil, errors.New("expected (min,max) arguments")
+	}
+	low, high, err := floatRange(arguments, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	return func() interface{} { return low + rand.Float64()*(high-low) }, nil
+}
+
+// decimalGenerator generates decimals from [min, max) with scale digits after decimal point,
+// by default from [0, 1000000) with 2 digits
+func decimalGenerator(arguments []string) (func() interface{}, error) {
+	if len(arguments) > 3 {
+		return nil, errors.New("expected (min,max,scale) arguments")
+	}
+	low, high, err := floatRange(arguments, 0, 1000000)
+	if err != nil {
+		return nil, err
+	}
+	scale := DefaultDecimalScale
+	if len(arguments) == 3 {
+		if scale, err = strconv.Atoi(arguments[2]); err != nil || scale < 0 || scale > 34 {
+			return nil, errors.New("scale must be number of digits between 0 and 34")
+		}
+	}
+	return func() interface{} {
+		value, _ := primitive.ParseDecimal128(strconv.FormatFloat(low+rand.Float64()*(high-low), 'f', scale, 64))
+		return value
+	}, nil
+}
+
+// dateGenerator generates dates from [from, to), by default from last year, bounds without offset
+// (ex. 2024-01-01 or 2024-01-01T08:00:00) are local time of time zone (IANA name, default UTC)
+func dateGenerator(arguments []string) (func() interface{}, error) {
+	if len(arguments) == 0 {
+		return func() interface{} {
+			now := time.Now()
+			return randomDate(now.AddDate(-1, 0, 0), now)
+		}, nil
+	}
+	if len(arguments) != 2 && len(arguments) != 3 {
+		return nil, errors.New("expected (from,to,timezone) arguments")
+	}
+	location := time.UTC
+	if len(arguments) == 3 {
+		var err error
+		if location, err = time.LoadLocation(arguments[2]); err != nil {
+			return nil, err
+		}
+	}
+	from, err := parseDate(arguments[0], location)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseDate(arguments[1], location)
+	if err != nil {
+		return nil, err
+	}
+	if !to.After(from) {
+		return nil, errors.New("to is not after from")
+	}
+	return func() interface{} { return randomDate(from, to) }, nil
+}
+
+func parseDate(value string, location *time.Location) (time.Time, error) {
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		return date, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02"} {
+		if date, err := time.ParseInLocation(layout, value, location); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, errors.New("date must be RFC3339, 2006-01-02T15:04:05 or 2006-01-02, got: " + value)
+}
+
+// randomDate returns BSON date (millisecond precision) from [from, to)
+func randomDate(from time.Time, to time.Time) primitive.DateTime {
+	return primitive.NewDateTimeFromTime(from.Add(time.Duration(rand.Int63n(int64(to.Sub(from))))))
+}
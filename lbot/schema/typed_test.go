@@ -0,0 +1,51 @@
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTypedGenerators(t *testing.T) {
+	generate := func(template string) interface{} {
+		generator, ok, err := TypedGenerator(template)
+		assert.True(t, ok)
+		assert.NoError(t, err)
+		return generator()
+	}
+
+	assert.IsType(t, int32(0), generate("#int32"))
+	assert.IsType(t, int64(0), generate("#int64"))
+	assert.IsType(t, float64(0), generate("#double"))
+	assert.IsType(t, primitive.Decimal128{}, generate("#decimal128"))
+	assert.IsType(t, primitive.DateTime(0), generate("#date"))
+	assert.IsType(t, primitive.DateTime(0), generate("#now"))
+
+	for i := 0; i < 100; i++ {
+		value := generate("#int32(-5, 5)").(int32)
+		assert.True(t, value >= -5 && value <= 5)
+		double := generate("#double(10,20)").(float64)
+		assert.True(t, double >= 10 && double < 20)
+	}
+	assert.Equal(t, int64(7), generate("#int64(7,7)"))
+	assert.Regexp(t, `^\d+\.\d{4}$`, generate("#decimal128(0,100,4)").(primitive.Decimal128).String())
+
+	// date only bounds are local midnight of time zone
+	warsaw, _ := time.LoadLocation("Europe/Warsaw")
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, warsaw)
+	for i := 0; i < 100; i++ {
+		date := generate("#date(2024-01-01,2024-01-02,Europe/Warsaw)").(primitive.DateTime).Time()
+		assert.False(t, date.Before(from))
+		assert.True(t, date.Before(from.AddDate(0, 0, 1)))
+	}
+
+	_, ok, _ := TypedGenerator("#word")
+	assert.False(t, ok)
+	for _, template := range []string{"#int32(1)", "#int32(5,1)", "#int32(0,99999999999)", "#double(a,b)", "#decimal128(0,1,40)", "#date(2024-01-02,2024-01-01)", "#date(2024-01-01,2024-01-02,Mars/Base)", "#now(1)", "#int64(1,2"} {
+		_, ok, err := TypedGenerator(template)
+		assert.True(t, ok, template)
+		assert.Error(t, err, template)
+	}
+}
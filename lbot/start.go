@@ -10,6 +10,9 @@ import (
 	"github.com/kuzxnia/loadbot/lbot/proto"
 	"github.com/kuzxnia/loadbot/lbot/worker"
 	"github.com/samber/lo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type StartProcess struct {
@@ -23,9 +26,36 @@ func NewStartProcess(ctx context.Context, lbot *Lbot) *StartProcess {
 }
 
 func (c *StartProcess) Run(ctx context.Context, request *proto.StartRequest) (*proto.StartResponse, error) {
-	err := c.lbot.Run()
+	tenant := TenantFromContext(ctx)
+	schedule, err := parseStartSchedule(request, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	own, err := c.lbot.parseWorkloadConfig(request)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.lbot.checkFootprint(tenant, own, request.Confirmed); err != nil {
+		return nil, err
+	}
+	if c.lbot.isProtected() {
+		return &proto.StartResponse{ApprovalId: c.lbot.RequestStart(tenant, request.Restart, schedule, own)}, nil
+	}
+
+	return c.lbot.start(tenant, request.Restart, schedule, own, nil)
+}
 
-	return &proto.StartResponse{}, err
+func (c *StartProcess) Estimate(ctx context.Context, _ *emptypb.Empty) (*proto.Footprint, error) {
+	tenant := TenantFromContext(ctx)
+	footprint := c.lbot.Footprint(tenant)
+	return &proto.Footprint{
+		Operations:    footprint.Operations,
+		Documents:     footprint.Documents,
+		WrittenBytes:  footprint.WrittenBytes,
+		EgressBytes:   footprint.EgressBytes,
+		UnboundedJobs: footprint.Unbounded,
+		Exceeded:      exceededThresholds(c.lbot.Config.Agent, footprint),
+	}, nil
 }
 
 func (c *StartProcess) RunWithProgress(request *proto.StartWithProgressRequest, srv proto.StartProcess_RunWithProgressServer) error {
@@ -33,14 +63,19 @@ func (c *StartProcess) RunWithProgress(request *proto.StartWithProgressRequest,
 	if err != nil {
 		return err
 	}
+	if c.lbot.isProtected() {
+		return status.Error(codes.FailedPrecondition, "agent is protected, start without progress and approve it with another token")
+	}
+	if err = c.lbot.checkFootprint(TenantFromContext(srv.Context()), nil, request.Confirmed); err != nil {
+		return err
+	}
 
-	var wg sync.WaitGroup
-	wg.Add(2)
+	if _, _, err = c.lbot.Run(TenantFromContext(srv.Context())); err != nil {
+		return err
+	}
 
-	go func() {
-		c.lbot.Run()
-		wg.Done()
-	}()
+	var wg sync.WaitGroup
+	wg.Add(1)
 
 	ticker := time.NewTicker(interval)
 	go func() {
@@ -61,17 +96,7 @@ func (c *StartProcess) RunWithProgress(request *proto.StartWithProgressRequest,
 			}
 			for _, w := range notDoneWorkers {
 				isWorkerFinished := w.IsDone()
-				resp := proto.ProgressResponse{
-					Requests:          w.Metrics.Requests(),
-					Duration:          uint64(w.Metrics.DurationSeconds()),
-					Rps:               w.Metrics.Rps(),
-					ErrorRate:         w.Metrics.ErrorRate(),
-					IsFinished:        isWorkerFinished,
-					JobName:           w.JobName(),
-					RequestOperations: w.RequestedOperations(),
-					RequestDuration:   w.RequestedDurationSeconds(),
-				}
-				if err := srv.Send(&resp); err != nil {
+				if err := srv.Send(NewProgressResponse(w, isWorkerFinished)); err != nil {
 					// todo: handle client not connected
 					log.Printf("Client closed connection")
 					return
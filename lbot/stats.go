@@ -0,0 +1,62 @@
+package lbot
+
+import (
+	"context"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/kuzxnia/loadbot/lbot/worker"
+	"github.com/samber/lo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ResetStats zeroes counters of running jobs of tenant without restarting them, job is job name or all jobs
+// if empty, returned workloads describe measurement window closed by reset
+func (l *Lbot) ResetStats(tenant *config.Tenant, job string) ([]*proto.ResetWorkload, error) {
+	l.mutext.Lock()
+	workers := lo.PickBy(l.workers, func(_ string, w *worker.Worker) bool {
+		return (lo.IsNil(tenant) || w.Tenant() == tenant.Name) && (job == "" || w.JobName() == job) && !w.IsDone()
+	})
+	l.mutext.Unlock()
+	if len(workers) == 0 {
+		if job != "" {
+			return nil, status.Errorf(codes.NotFound, "job %s is not running", job)
+		}
+		return nil, status.Error(codes.FailedPrecondition, "no running workload")
+	}
+
+	reset := make([]*proto.ResetWorkload, 0, len(workers))
+	for _, w := range workers {
+		stats := NewEventStats(w.Metrics)
+		w.ResetMetrics()
+		l.events.Emit(Event{Type: EventStatsReset, RunId: w.RunId(), Job: w.JobName(), Stats: stats})
+		reset = append(reset, &proto.ResetWorkload{
+			WorkloadId: w.WorkloadId(),
+			JobName:    w.JobName(),
+			JobType:    w.JobType(),
+			Requests:   stats.Requests,
+			Errors:     stats.Errors,
+			Duration:   stats.DurationSeconds,
+		})
+	}
+	return reset, nil
+}
+
+type StatsService struct {
+	proto.UnimplementedStatsServiceServer
+	ctx  context.Context
+	lbot *Lbot
+}
+
+func NewStatsService(ctx context.Context, lbot *Lbot) *StatsService {
+	return &StatsService{ctx: ctx, lbot: lbot}
+}
+
+func (s *StatsService) ResetStats(ctx context.Context, request *proto.ResetStatsRequest) (*proto.ResetStatsResponse, error) {
+	reset, err := s.lbot.ResetStats(TenantFromContext(ctx), request.Job)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.ResetStatsResponse{Workloads: reset}, nil
+}
@@ -2,10 +2,18 @@ package lbot
 
 import (
 	"context"
+	"time"
 
+	"github.com/kuzxnia/loadbot/lbot/config"
 	"github.com/kuzxnia/loadbot/lbot/proto"
 )
 
+const (
+	StopStatusDrained             = "drained"               // all in-flight operations finished within grace period
+	StopStatusGracePeriodExceeded = "grace_period_exceeded" // in-flight operations cancelled after grace period
+	StopStatusForced              = "forced"                // in-flight operations cancelled immediately
+)
+
 type StoppingProcess struct {
 	proto.UnimplementedStopProcessServer
 	ctx  context.Context
@@ -18,8 +26,27 @@ func NewStoppingProcess(ctx context.Context, lbot *Lbot) *StoppingProcess {
 
 func (c *StoppingProcess) Run(ctx context.Context, request *proto.StopRequest) (*proto.StopResponse, error) {
 	// validate is configured
+	gracePeriod := config.DefaultStopGracePeriod
+	if request.GracePeriod != "" {
+		var err error
+		if gracePeriod, err = time.ParseDuration(request.GracePeriod); err != nil {
+			return nil, err
+		}
+	}
+	tenant := TenantFromContext(ctx)
+	var cancelledSchedules uint64
+	if request.Schedules {
+		cancelledSchedules = c.lbot.CancelSchedules(tenant)
+	}
+	if request.RunId != "" {
+		stopped, err := c.lbot.CancelRun(tenant, request.RunId, gracePeriod, request.Force)
+		if err != nil {
+			return nil, err
+		}
+		return &proto.StopResponse{Workloads: stopped, CancelledSchedules: cancelledSchedules}, nil
+	}
+	stopped := c.lbot.CancelTenant(tenant, gracePeriod, request.Force)
 
-	go c.lbot.Cancel()
 	// if watch arg - run watch
-	return &proto.StopResponse{}, nil
+	return &proto.StopResponse{Workloads: stopped, CancelledSchedules: cancelledSchedules}, nil
 }
@@ -0,0 +1,62 @@
+package lbot
+
+import (
+	"context"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/worker"
+	"github.com/samber/lo"
+)
+
+type tenantContextKey struct{}
+
+func WithTenant(ctx context.Context, tenant *config.Tenant) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+// TenantFromContext returns tenant authorized for request, nil if agent have no tenants configured
+func TenantFromContext(ctx context.Context) *config.Tenant {
+	tenant, _ := ctx.Value(tenantContextKey{}).(*config.Tenant)
+	return tenant
+}
+
+func tenantName(tenant *config.Tenant) string {
+	if lo.IsNil(tenant) {
+		return ""
+	}
+	return tenant.Name
+}
+
+// ownsJob checks if job belongs to tenant, without tenant (auth disabled) every job is accessible
+func ownsJob(tenant *config.Tenant, job *config.Job) bool {
+	return lo.IsNil(tenant) || job.Tenant == tenant.Name
+}
+
+func ownsWorker(tenant *config.Tenant, w *worker.Worker) bool {
+	return lo.IsNil(tenant) || w.Tenant() == tenant.Name
+}
+
+// applyTenantQuota caps job connections and rate (pace, burst, interval and rate profile) to tenant quota
+func applyTenantQuota(tenant *config.Tenant, job config.Job) config.Job {
+	if lo.IsNil(tenant) {
+		return job
+	}
+	job.Tenant = tenant.Name
+	if tenant.MaxConnections != 0 && job.Connections > tenant.MaxConnections {
+		job.Connections = tenant.MaxConnections
+	}
+	if tenant.MaxPace != 0 && (job.Pace == 0 || job.Pace > tenant.MaxPace) {
+		job.Pace = tenant.MaxPace
+	}
+	if tenant.MaxPace != 0 {
+		job.MaxPace = tenant.MaxPace
+		// burst is issued at once above pace
+		job.PaceBurst = min(job.PaceBurst, tenant.MaxPace)
+		// every connection issues one operation per interval
+		if minInterval := time.Duration(max(1, job.Connections)) * time.Second / time.Duration(tenant.MaxPace); job.PaceInterval > 0 && job.PaceInterval < minInterval {
+			job.PaceInterval = minInterval
+		}
+	}
+	return job
+}
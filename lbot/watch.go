@@ -3,10 +3,16 @@ package lbot
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/kuzxnia/loadbot/lbot/proto"
+	"github.com/kuzxnia/loadbot/lbot/worker"
+	"github.com/samber/lo"
 )
 
+// how often watch checks jobs of watched runs
+const WatchInterval = time.Second
+
 type WatchingRequest struct{}
 
 type WatchingProcess struct {
@@ -19,23 +25,58 @@ func NewWatchingProcess(ctx context.Context, lbot *Lbot) *WatchingProcess {
 	return &WatchingProcess{ctx: ctx, lbot: lbot}
 }
 
+// Run streams starts and stops of jobs of run (all active runs of tenant if run id is empty) until
+// runs finish or client disconnects
 func (w *WatchingProcess) Run(request *proto.WatchRequest, srv proto.WatchProcess_RunServer) error {
-	done := make(chan bool)
-
-	go func() {
-		// for message := range w.lbot.logs {
-		// 	resp := proto.WatchResponse{Message: message}
-
-		// 	if err := srv.Send(&resp); err != nil {
-		// 		// todo: handle client not connected
-		// 		log.Printf("client closed connection, closing channel done")
-		// 		done <- true
-		// 		return
-		// 	}
-		// }
-	}()
-	<-done
-	fmt.Printf("done")
-
-	return nil
+	tenant := TenantFromContext(srv.Context())
+	if request.RunId != "" {
+		if _, err := w.lbot.findRun(tenant, request.RunId); err != nil {
+			return err
+		}
+	}
+	send := func(format string, args ...interface{}) error {
+		return srv.Send(&proto.WatchResponse{Message: fmt.Sprintf(format, args...)})
+	}
+
+	ticker := time.NewTicker(WatchInterval)
+	defer ticker.Stop()
+	// watched workers by workload id
+	watched := map[string]*worker.Worker{}
+	for {
+		running := w.lbot.runWorkers(tenant, request.RunId)
+		for _, wk := range running {
+			if _, ok := watched[wk.WorkloadId()]; !ok && !wk.IsDone() {
+				watched[wk.WorkloadId()] = wk
+				if err := send("run %s: job %s (%s) started", wk.RunId(), wk.JobName(), wk.JobType()); err != nil {
+					return err
+				}
+			}
+		}
+		for id, wk := range watched {
+			if !wk.IsDone() && lo.Contains(running, wk) {
+				continue
+			}
+			delete(watched, id)
+			err := send(
+				"run %s: job %s stopped by %s, %d requests, %d errors, %ds",
+				wk.RunId(), wk.JobName(), lo.Ternary(wk.StopReason() == "", "stop", wk.StopReason()),
+				wk.Metrics.Requests(), wk.Metrics.Errors(), wk.Metrics.DurationSeconds(),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		if len(watched) == 0 && !w.lbot.hasActiveRun(tenant, request.RunId) {
+			if request.RunId != "" {
+				return send("run %s finished", request.RunId)
+			}
+			return send("There are no active runs")
+		}
+
+		select {
+		case <-srv.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
 }
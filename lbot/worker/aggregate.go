@@ -0,0 +1,30 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VictoriaMetrics/metrics"
+)
+
+// AggregateHandler runs analytic pipeline from job config, stage values are generated from templates on every operation
+type AggregateHandler struct {
+	*BaseHandler
+	returned *metrics.Summary
+}
+
+func NewAggregateHandler(handler *BaseHandler) *AggregateHandler {
+	return &AggregateHandler{
+		BaseHandler: handler,
+		returned:    jobSummary(handler.job, fmt.Sprintf(`aggregate_returned_documents{job="%s",run_id="%s"}`, handler.job.Name, handler.job.RunId)),
+	}
+}
+
+func (h *AggregateHandler) Execute(ctx context.Context) error {
+	documents, error := h.client.Aggregate(ctx, h.dataProvider.GetPipeline())
+	if error != nil {
+		return error
+	}
+	h.returned.Update(float64(len(documents)))
+	return nil
+}
@@ -0,0 +1,67 @@
+package worker
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/samber/lo"
+)
+
+// artifact files of job are saved in run directory, ex. <artifacts_dir>/<run_id>/<job>-latency.csv
+const (
+	LatencyLogArtifact = "latency.csv"
+	ReportArtifact     = "report.json"
+)
+
+// ArtifactPath returns path of job artifact in run directory, job name is sanitized to be valid file name
+func ArtifactPath(dir string, job *config.Job, artifact string) string {
+	name := lo.If(job.Name != "", job.Name).Else(job.Type)
+	name = strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ' ' {
+			return '_'
+		}
+		return r
+	}, name)
+	return filepath.Join(dir, job.RunId, name+"-"+artifact)
+}
+
+// LatencyLog writes every operation of job as csv line: start time (unix ms), latency (us) and error flag
+type LatencyLog struct {
+	file   *os.File
+	writer *bufio.Writer
+	mutex  sync.Mutex
+}
+
+func NewLatencyLog(path string) (*LatencyLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	log := &LatencyLog{file: file, writer: bufio.NewWriter(file)}
+	fmt.Fprintln(log.writer, "start_ms,latency_us,error")
+	return log, nil
+}
+
+func (l *LatencyLog) Write(start time.Time, latency time.Duration, failed bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	fmt.Fprintf(l.writer, "%d,%d,%t\n", start.UnixMilli(), latency.Microseconds(), failed)
+}
+
+func (l *LatencyLog) Close() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if err := l.writer.Flush(); err != nil {
+		return err
+	}
+	return l.file.Close()
+}
@@ -0,0 +1,34 @@
+package worker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArtifactPath(t *testing.T) {
+	job := &config.Job{Name: "orders/read all", Type: "read", RunId: "run"}
+	assert.Equal(t, filepath.Join("dir", "run", "orders_read_all-latency.csv"), ArtifactPath("dir", job, LatencyLogArtifact))
+
+	job.Name = ""
+	assert.Equal(t, filepath.Join("dir", "run", "read-report.json"), ArtifactPath("dir", job, ReportArtifact))
+}
+
+func TestLatencyLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run", "job-latency.csv")
+	latencyLog, err := NewLatencyLog(path)
+	assert.NoError(t, err)
+
+	start := time.UnixMilli(1700000000000)
+	latencyLog.Write(start, 1500*time.Microsecond, false)
+	latencyLog.Write(start, 2*time.Millisecond, true)
+	assert.NoError(t, latencyLog.Close())
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "start_ms,latency_us,error\n1700000000000,1500,false\n1700000000000,2000,true\n", string(content))
+}
@@ -0,0 +1,108 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/database"
+	log "github.com/sirupsen/logrus"
+)
+
+// BackupHook simulates backup window during job, at backup_at after start database is fsync locked
+// for backup_duration or backup_command is run, job metrics are snapshotted around window for report
+type BackupHook struct {
+	job              *config.Job
+	db               database.Client
+	metrics          *Metrics
+	connectionString string
+	inProgress       atomic.Bool
+	mutex            sync.Mutex
+	snapshots        []MetricsSnapshot // job start, backup start, backup end
+	err              error
+}
+
+func NewBackupHook(job *config.Job, db database.Client, jobMetrics *Metrics, connectionString string) *BackupHook {
+	hook := &BackupHook{job: job, db: db, metrics: jobMetrics, connectionString: connectionString}
+	jobGauge(job, fmt.Sprintf(`backup_in_progress{job="%s",run_id="%s"}`, job.Name, job.RunId), func() float64 {
+		if hook.inProgress.Load() {
+			return 1
+		}
+		return 0
+	})
+	return hook
+}
+
+// Run waits backup_at and triggers backup, backup is not started when ctx is done earlier
+func (h *BackupHook) Run(ctx context.Context) {
+	h.snapshot()
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(h.job.BackupAt):
+	}
+
+	runLog := log.WithField("run_id", h.job.RunId)
+	runLog.Infof("Job %s backup window started", h.job.Name)
+	h.inProgress.Store(true)
+	h.snapshot()
+	err := h.backup(ctx)
+	h.snapshot()
+	h.inProgress.Store(false)
+
+	h.mutex.Lock()
+	h.err = err
+	h.mutex.Unlock()
+	if err != nil {
+		runLog.Warnf("Job %s backup failed: %s", h.job.Name, err)
+	}
+	runLog.Infof("Job %s backup window finished", h.job.Name)
+}
+
+func (h *BackupHook) backup(ctx context.Context) error {
+	if h.job.BackupCommand != "" {
+		return runHookCommand(ctx, h.job, h.connectionString, h.job.BackupCommand)
+	}
+
+	if err := h.db.FsyncLock(ctx); err != nil {
+		return err
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(h.job.BackupDuration):
+	}
+	// unlock even when job was cancelled, locked node would block writes of every client
+	unlockCtx, cancel := context.WithTimeout(context.Background(), disturbanceCleanupTimeout)
+	defer cancel()
+	return h.db.FsyncUnlock(unlockCtx)
+}
+
+func (h *BackupHook) snapshot() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.snapshots = append(h.snapshots, h.metrics.Snapshot())
+}
+
+// Report compares job throughput and latency before, during and after backup window, empty when backup
+// was not triggered
+func (h *BackupHook) Report() []string {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if len(h.snapshots) < 3 {
+		return nil
+	}
+	end := h.metrics.Snapshot()
+	start, backupStart, backupEnd := h.snapshots[0], h.snapshots[1], h.snapshots[2]
+	report := []string{
+		fmt.Sprintf("before backup: %s", start.Window(backupStart)),
+		fmt.Sprintf("during backup (%s): %s", backupEnd.Time.Sub(backupStart.Time).Round(time.Millisecond), backupStart.Window(backupEnd)),
+		fmt.Sprintf("after backup:  %s", backupEnd.Window(end)),
+	}
+	if h.err != nil {
+		report = append(report, fmt.Sprintf("backup failed: %s", h.err))
+	}
+	return report
+}
@@ -0,0 +1,133 @@
+package worker
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/database"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	BudgetSampleInterval = time.Second
+	// budget usage below which throttling is relaxed, keeps delay from oscillating around the limit
+	BudgetRelaxThreshold = 0.8
+	MinBudgetDelay       = time.Millisecond
+	MaxBudgetDelay       = time.Second
+)
+
+// Budget self-throttles agent, when agent CPU usage or bandwidth sent to database exceeds agent
+// max_cpu_percent or max_network_bytes_per_second every operation of all jobs is delayed,
+// delay is doubled every second budget is exceeded and halved when usage drops
+type Budget struct {
+	maxCpuPercent   float64
+	maxNetworkBytes uint64
+	delay           int64
+	cpuPercent      uint64 // float64 bits, last measured usage
+	networkBytes    uint64
+}
+
+// NewBudget returns agent budget, nil when agent has no limits
+func NewBudget(agent *config.Agent) *Budget {
+	if agent == nil || (agent.MaxCpuPercent == 0 && agent.MaxNetworkBytesPerSecond == 0) {
+		return nil
+	}
+	budget := &Budget{maxCpuPercent: agent.MaxCpuPercent, maxNetworkBytes: agent.MaxNetworkBytesPerSecond}
+	metrics.GetOrCreateGauge("agent_budget_throttle_seconds", func() float64 {
+		return budget.Delay().Seconds()
+	})
+	metrics.GetOrCreateGauge("agent_cpu_percent", func() float64 {
+		return math.Float64frombits(atomic.LoadUint64(&budget.cpuPercent))
+	})
+	metrics.GetOrCreateGauge("agent_network_sent_bytes_per_second", func() float64 {
+		return math.Float64frombits(atomic.LoadUint64(&budget.networkBytes))
+	})
+	return budget
+}
+
+// Run samples agent usage and adjusts throttling delay until ctx is done
+func (b *Budget) Run(ctx context.Context) {
+	ticker := time.NewTicker(BudgetSampleInterval)
+	defer ticker.Stop()
+
+	lastCpu, cpuErr := cpuTime()
+	if cpuErr != nil && b.maxCpuPercent > 0 {
+		log.WithError(cpuErr).Warn("Agent CPU usage is not available, max_cpu_percent is ignored")
+	}
+	lastSent, lastSample := database.SentBytes(), time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			elapsed := now.Sub(lastSample).Seconds()
+			usage, cpuPercent := 0.0, 0.0
+			if cpu, err := cpuTime(); err == nil && cpuErr == nil {
+				cpuPercent = (cpu - lastCpu).Seconds() / elapsed * 100
+				lastCpu = cpu
+				if b.maxCpuPercent > 0 {
+					usage = cpuPercent / b.maxCpuPercent
+				}
+			}
+			sent := database.SentBytes()
+			networkBytes := float64(sent-lastSent) / elapsed
+			lastSent, lastSample = sent, now
+			if b.maxNetworkBytes > 0 {
+				usage = max(usage, networkBytes/float64(b.maxNetworkBytes))
+			}
+			atomic.StoreUint64(&b.cpuPercent, math.Float64bits(cpuPercent))
+			atomic.StoreUint64(&b.networkBytes, math.Float64bits(networkBytes))
+
+			delay := time.Duration(atomic.LoadInt64(&b.delay))
+			next := nextBudgetDelay(delay, usage)
+			if delay == 0 && next > 0 {
+				log.WithField("cpu_percent", cpuPercent).WithField("network_bytes_per_second", networkBytes).
+					Warn("Agent budget exceeded, throttling operations")
+			} else if delay > 0 && next == 0 {
+				log.Info("Agent usage back within budget, throttling stopped")
+			}
+			atomic.StoreInt64(&b.delay, int64(next))
+		}
+	}
+}
+
+// nextBudgetDelay returns throttling delay for measured usage, fraction of budget
+func nextBudgetDelay(delay time.Duration, usage float64) time.Duration {
+	switch {
+	case usage > 1:
+		return min(max(delay*2, MinBudgetDelay), MaxBudgetDelay)
+	case usage < BudgetRelaxThreshold:
+		if delay/2 < MinBudgetDelay {
+			return 0
+		}
+		return delay / 2
+	default:
+		return delay
+	}
+}
+
+// Delay returns current throttling delay of every operation
+func (b *Budget) Delay() time.Duration {
+	if b == nil {
+		return 0
+	}
+	return time.Duration(atomic.LoadInt64(&b.delay))
+}
+
+// Throttle waits current throttling delay before operation is dispatched
+func (b *Budget) Throttle(ctx context.Context) {
+	delay := b.Delay()
+	if delay == 0 {
+		return
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,30 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextBudgetDelay(t *testing.T) {
+	assert.Equal(t, MinBudgetDelay, nextBudgetDelay(0, 1.5))
+	assert.Equal(t, 4*time.Millisecond, nextBudgetDelay(2*time.Millisecond, 1.1))
+	assert.Equal(t, MaxBudgetDelay, nextBudgetDelay(MaxBudgetDelay, 3))
+
+	// within budget but close to it delay is kept
+	assert.Equal(t, 4*time.Millisecond, nextBudgetDelay(4*time.Millisecond, 0.9))
+
+	assert.Equal(t, 2*time.Millisecond, nextBudgetDelay(4*time.Millisecond, 0.5))
+	assert.Equal(t, time.Duration(0), nextBudgetDelay(MinBudgetDelay, 0.5))
+	assert.Equal(t, time.Duration(0), nextBudgetDelay(0, 0))
+}
+
+func TestBudgetWithoutLimits(t *testing.T) {
+	budget := NewBudget(&config.Agent{})
+	assert.Nil(t, budget)
+	assert.Equal(t, time.Duration(0), budget.Delay())
+	budget.Throttle(context.Background())
+}
@@ -0,0 +1,108 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// BulkWriteHandler executes bulks of inserts, or mix of inserts, updates and deletes when job 'bulk_mix' is set,
+// failed operations of partially failed bulks are counted separately from not executed ones
+type BulkWriteHandler struct {
+	*BaseHandler
+	ordered    bool
+	batchSize  uint64
+	operations []string
+	weights    []float64
+	total      float64
+	failed     *metrics.Counter
+	skipped    *metrics.Counter
+	partial    *metrics.Counter
+}
+
+func NewBulkWriteHandler(handler *BaseHandler) *BulkWriteHandler {
+	h := &BulkWriteHandler{
+		BaseHandler: handler,
+		ordered:     handler.job.BulkOrder != config.BulkOrderUnordered,
+		batchSize:   BatchSize(handler.job),
+	}
+	// sorted for deterministic weights order
+	h.operations = lo.Keys(handler.job.BulkMix)
+	sort.Strings(h.operations)
+	for _, operation := range h.operations {
+		h.weights = append(h.weights, handler.job.BulkMix[operation])
+		h.total += handler.job.BulkMix[operation]
+	}
+	labels := fmt.Sprintf(`{job="%s",run_id="%s"}`, handler.job.Name, handler.job.RunId)
+	h.failed = jobCounter(handler.job, "bulk_operations_failed_total"+labels)
+	h.skipped = jobCounter(handler.job, "bulk_operations_skipped_total"+labels)
+	h.partial = jobCounter(handler.job, "bulk_partial_failures_total"+labels)
+	return h
+}
+
+// BatchSize returns number of documents in single bulk_write operation
+func BatchSize(job *config.Job) uint64 {
+	if job.BatchSize == 0 {
+		return DefaultBatchSize
+	}
+	return job.BatchSize
+}
+
+func (h *BulkWriteHandler) Execute(ctx context.Context) error {
+	items := h.dataProvider.GetBatch(h.batchSize)
+	models := make([]mongo.WriteModel, len(items))
+	inserted := make([]interface{}, 0, len(items))
+	for i, item := range items {
+		switch h.nextOperation() {
+		case config.BulkOpUpdate:
+			models[i] = mongo.NewUpdateOneModel().SetFilter(h.dataProvider.GetFilter()).SetUpdate(h.getUpdate())
+		case config.BulkOpDelete:
+			models[i] = mongo.NewDeleteOneModel().SetFilter(h.dataProvider.GetFilter())
+		default:
+			models[i] = mongo.NewInsertOneModel().SetDocument(item)
+			inserted = append(inserted, item)
+		}
+	}
+
+	_, err := h.client.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(h.ordered))
+	var bulkErr mongo.BulkWriteException
+	if errors.As(err, &bulkErr) && len(bulkErr.WriteErrors) > 0 {
+		failed := len(bulkErr.WriteErrors)
+		h.failed.Add(failed)
+		if h.ordered {
+			// ordered bulk stops on first error, following operations are not executed
+			h.skipped.Add(len(models) - bulkErr.WriteErrors[0].Index - 1)
+		}
+		if failed < len(models) {
+			h.partial.Inc()
+		}
+	}
+
+	if err == nil && h.dataPool != nil {
+		h.dataPool.SetBatch(inserted)
+	}
+	return err
+}
+
+// nextOperation picks weighted random operation, inserts only without 'bulk_mix'
+func (h *BulkWriteHandler) nextOperation() string {
+	if h.total == 0 {
+		return config.BulkOpInsert
+	}
+	r := rand.Float64() * h.total
+	for i, weight := range h.weights {
+		if r < weight {
+			return h.operations[i]
+		}
+		r -= weight
+	}
+	return h.operations[len(h.operations)-1]
+}
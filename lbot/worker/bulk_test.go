@@ -0,0 +1,13 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchSize(t *testing.T) {
+	assert.Equal(t, uint64(DefaultBatchSize), BatchSize(&config.Job{Type: string(config.BulkWrite)}))
+	assert.Equal(t, uint64(500), BatchSize(&config.Job{Type: string(config.BulkWrite), BatchSize: 500}))
+}
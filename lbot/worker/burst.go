@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	log "github.com/sirupsen/logrus"
+)
+
+// BurstScheduler runs job in duty cycles, burst_duration at job pace followed by burst_idle with job paused,
+// reproducing batch producers, stats of every burst are kept to see how database recovers between them
+type BurstScheduler struct {
+	job     *config.Job
+	gate    *PauseGate
+	metrics *Metrics
+	mutex   sync.Mutex
+	bursts  []string
+}
+
+func NewBurstScheduler(job *config.Job, gate *PauseGate, jobMetrics *Metrics) *BurstScheduler {
+	return &BurstScheduler{job: job, gate: gate, metrics: jobMetrics}
+}
+
+// Run alternates bursts and idle periods until ctx is done, job is left resumed
+func (s *BurstScheduler) Run(ctx context.Context) {
+	runLog := log.WithField("run_id", s.job.RunId)
+	defer s.gate.Resume(PauseReasonBurst)
+	for burst := 1; ; burst++ {
+		start := s.metrics.Snapshot()
+		if !sleepContext(ctx, s.job.BurstDuration) {
+			s.record(burst, start)
+			return
+		}
+		s.record(burst, start)
+		s.gate.Pause(PauseReasonBurst)
+		runLog.Debugf("Job %s idle after burst %d", s.job.Name, burst)
+		if !sleepContext(ctx, s.job.BurstIdle) {
+			return
+		}
+		s.gate.Resume(PauseReasonBurst)
+	}
+}
+
+func (s *BurstScheduler) record(burst int, start MetricsSnapshot) {
+	window := fmt.Sprintf("burst %d: %s", burst, start.Window(s.metrics.Snapshot()))
+	s.mutex.Lock()
+	s.bursts = append(s.bursts, window)
+	s.mutex.Unlock()
+	log.WithField("run_id", s.job.RunId).Infof("Job %s %s", s.job.Name, window)
+}
+
+// Report returns throughput and latency of every burst
+func (s *BurstScheduler) Report() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string(nil), s.bursts...)
+}
+
+// sleepContext waits duration, returns false when ctx is done earlier
+func sleepContext(ctx context.Context, duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
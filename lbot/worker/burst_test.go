@@ -0,0 +1,36 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBurstScheduler(t *testing.T) {
+	job := &config.Job{Name: "burst", BurstDuration: 40 * time.Millisecond, BurstIdle: 40 * time.Millisecond}
+	gate := &PauseGate{}
+	metrics := NewMetrics(job)
+	metrics.Init()
+	scheduler := NewBurstScheduler(job, gate, metrics)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(done)
+	}()
+
+	assert.False(t, gate.IsPaused())
+	assert.Eventually(t, gate.IsPaused, time.Second, time.Millisecond)
+	assert.Len(t, scheduler.Report(), 1)
+	assert.Contains(t, scheduler.Report()[0], "burst 1: ")
+	assert.Eventually(t, func() bool { return !gate.IsPaused() }, time.Second, time.Millisecond)
+
+	cancel()
+	<-done
+	assert.False(t, gate.IsPaused())
+	assert.Len(t, scheduler.Report(), 2)
+}
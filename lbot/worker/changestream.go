@@ -0,0 +1,104 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const ChangeStreamMaxAwaitTime = time.Second
+
+// ChangeStreamHandler consumes change events of job collection, every connection watches its own stream
+// and every operation waits for single event, so operations rate is event throughput,
+// run it together with write jobs to measure change stream scalability
+type ChangeStreamHandler struct {
+	*BaseHandler
+	streams         chan *mongo.ChangeStream
+	deliveryLatency *metrics.Summary
+	emptyPolls      *metrics.Counter
+}
+
+func NewChangeStreamHandler(handler *BaseHandler) *ChangeStreamHandler {
+	jobLabel := fmt.Sprintf(`{job="%s",run_id="%s"}`, handler.job.Name, handler.job.RunId)
+	return &ChangeStreamHandler{
+		BaseHandler:     handler,
+		streams:         make(chan *mongo.ChangeStream, handler.job.Connections),
+		deliveryLatency: jobSummary(handler.job, "change_stream_delivery_latency_seconds"+jobLabel),
+		emptyPolls:      jobCounter(handler.job, "change_stream_empty_polls_total"+jobLabel),
+	}
+}
+
+func (h *ChangeStreamHandler) Execute(ctx context.Context) error {
+	stream, error := h.stream(ctx)
+	if error != nil {
+		return error
+	}
+	// waiting at most max await time, so job stops when there are no more events
+	if !stream.TryNext(ctx) {
+		if error = stream.Err(); error != nil {
+			// broken stream is reopened by next operation
+			stream.Close(context.Background())
+			return error
+		}
+		// writers are slower than consumers, it's not an operation error and only received events are metered
+		h.emptyPolls.Inc()
+		h.streams <- stream
+		return errNotMetered
+	}
+	receivedAt := time.Now()
+
+	var event struct {
+		OperationType string              `bson:"operationType"`
+		WallTime      primitive.DateTime  `bson:"wallTime"`
+		ClusterTime   primitive.Timestamp `bson:"clusterTime"`
+	}
+	if error = stream.Decode(&event); error == nil {
+		// wallTime has millisecond precision but is reported since MongoDB 6.0, clusterTime only seconds
+		if event.WallTime != 0 {
+			h.deliveryLatency.Update(receivedAt.Sub(event.WallTime.Time()).Seconds())
+		} else if event.ClusterTime.T != 0 {
+			h.deliveryLatency.Update(receivedAt.Sub(time.Unix(int64(event.ClusterTime.T), 0)).Seconds())
+		}
+		jobCounter(h.job, fmt.Sprintf(
+			`change_stream_events_total{job="%s",run_id="%s",operation_type="%s"}`, h.job.Name, h.job.RunId, event.OperationType,
+		)).Inc()
+	}
+	h.streams <- stream
+	return error
+}
+
+// stream returns idle stream or opens new one, there are never more streams than connections
+func (h *ChangeStreamHandler) stream(ctx context.Context) (*mongo.ChangeStream, error) {
+	select {
+	case stream := <-h.streams:
+		return stream, nil
+	default:
+	}
+	pipeline := bson.A{}
+	if len(h.job.Pipeline) > 0 {
+		pipeline = h.dataProvider.GetPipeline()
+	}
+	opts := options.ChangeStream().SetMaxAwaitTime(ChangeStreamMaxAwaitTime)
+	if h.job.FullDocument != "" {
+		opts.SetFullDocument(options.FullDocument(h.job.FullDocument))
+	}
+	return h.client.Watch(ctx, pipeline, opts)
+}
+
+// Close closes idle streams after job finishes
+func (h *ChangeStreamHandler) Close() error {
+	for {
+		select {
+		case stream := <-h.streams:
+			stream.Close(context.Background())
+		default:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/database"
+	"github.com/kuzxnia/loadbot/lbot/schema"
+)
+
+// CollectionTarget is one of job collections with its own handler and stats, so hot collection effects are
+// visible without splitting job
+type CollectionTarget struct {
+	Name            string
+	db              database.Client
+	handler         JobHandler
+	requests        *metrics.Counter
+	requestsError   *metrics.Counter
+	requestDuration *metrics.Summary
+	latencyTotal    atomic.Int64 // nanoseconds, used for average latency
+}
+
+func NewCollectionTargets(job *config.Job, db *database.MongoClient, dataPool schema.DataPool, jobSchema *config.Schema) ([]*CollectionTarget, error) {
+	targets := make([]*CollectionTarget, len(job.Collections))
+	for i, name := range job.Collections {
+		labels := fmt.Sprintf(`{job="%s",run_id="%s",collection="%s"}`, job.Name, job.RunId, name)
+		collection := db.WithCollection(name)
+		handler, err := NewJobHandler(job, collection, dataPool, jobSchema)
+		if err != nil {
+			return nil, err
+		}
+		metricsSet := func(metric string) *metrics.Set {
+			if !isCollectionSampled(job, name) {
+				return unexportedMetrics
+			}
+			return jobMetricsSet(job, metric)
+		}
+		targets[i] = &CollectionTarget{
+			Name:            name,
+			db:              collection,
+			handler:         handler,
+			requests:        metricsSet("collection_requests_total").GetOrCreateCounter("collection_requests_total" + labels),
+			requestsError:   metricsSet("collection_requests_error").GetOrCreateCounter("collection_requests_error" + labels),
+			requestDuration: metricsSet("collection_requests_duration_seconds").GetOrCreateSummary("collection_requests_duration_seconds" + labels),
+		}
+	}
+	return targets, nil
+}
+
+// Meter measures operation on collection, error is returned to be measured also in job metrics
+func (t *CollectionTarget) Meter(operation func() error) error {
+	startTime := time.Now()
+	err := operation()
+	if errors.Is(err, errNotMetered) {
+		return err
+	}
+	t.requestDuration.UpdateDuration(startTime)
+	t.latencyTotal.Add(int64(time.Since(startTime)))
+	t.requests.Inc()
+	if err != nil {
+		t.requestsError.Inc()
+	}
+	return err
+}
+
+func (t *CollectionTarget) Reset() {
+	t.requests.Set(0)
+	t.requestsError.Set(0)
+	t.latencyTotal.Store(0)
+}
+
+// Summary describes collection stats, ex. "users: 1200 requests, 40 rps, avg latency 2ms, 3 errors"
+func (t *CollectionTarget) Summary(duration time.Duration) string {
+	requests := t.requests.Get()
+	var rps float64
+	var latency time.Duration
+	if duration > 0 {
+		rps = float64(requests) / duration.Seconds()
+	}
+	if requests > 0 {
+		latency = time.Duration(uint64(t.latencyTotal.Load()) / requests)
+	}
+	return fmt.Sprintf(
+		"%s: %d requests, %.0f rps, avg latency %s, %d errors",
+		t.Name, requests, rps, latency.Round(time.Microsecond), t.requestsError.Get(),
+	)
+}
+
+// pickTarget chooses collection of next operation uniformly
+func pickTarget(targets []*CollectionTarget) *CollectionTarget {
+	return targets[rand.Intn(len(targets))]
+}
@@ -0,0 +1,14 @@
+package worker
+
+import "context"
+
+// CommandHandler runs command document from job config on job database, values of command are generated
+// from templates on every operation, so admin commands or new server features can be loaded without dedicated handler
+type CommandHandler struct {
+	*BaseHandler
+}
+
+func (h *CommandHandler) Execute(ctx context.Context) error {
+	_, error := h.client.RunCommand(ctx, h.dataProvider.GetCommand())
+	return error
+}
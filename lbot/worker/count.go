@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VictoriaMetrics/metrics"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// CountHandler counts documents matching generated filter, without filter whole collection is counted
+type CountHandler struct {
+	*BaseHandler
+	counted *metrics.Summary
+}
+
+func NewCountHandler(handler *BaseHandler) *CountHandler {
+	return &CountHandler{
+		BaseHandler: handler,
+		counted:     jobSummary(handler.job, fmt.Sprintf(`count_counted_documents{job="%s",run_id="%s"}`, handler.job.Name, handler.job.RunId)),
+	}
+}
+
+func (h *CountHandler) Execute(ctx context.Context) error {
+	count, error := h.client.CountDocuments(ctx, h.filterOrAll())
+	if error != nil {
+		return error
+	}
+	h.counted.Update(float64(count))
+	return nil
+}
+
+// DistinctHandler returns distinct values of distinct_field among documents matching generated filter
+type DistinctHandler struct {
+	*BaseHandler
+	returned *metrics.Summary
+}
+
+func NewDistinctHandler(handler *BaseHandler) *DistinctHandler {
+	return &DistinctHandler{
+		BaseHandler: handler,
+		returned:    jobSummary(handler.job, fmt.Sprintf(`distinct_returned_values{job="%s",run_id="%s"}`, handler.job.Name, handler.job.RunId)),
+	}
+}
+
+func (h *DistinctHandler) Execute(ctx context.Context) error {
+	values, error := h.client.Distinct(ctx, h.job.DistinctField, h.filterOrAll())
+	if error != nil {
+		return error
+	}
+	h.returned.Update(float64(len(values)))
+	return nil
+}
+
+// filterOrAll returns generated filter, or filter matching all documents when job has no filter
+func (h *BaseHandler) filterOrAll() interface{} {
+	if h.job.Filter == nil {
+		return bson.M{}
+	}
+	return h.dataProvider.GetFilter()
+}
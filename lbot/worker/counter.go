@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/kuzxnia/loadbot/lbot/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	DefaultHotDocuments     = 10
+	CounterMaxConflictRetry = 10
+)
+
+// CounterHandler increments small set of hot documents to measure hot-spot contention,
+// operations failed with write conflict are retried and every retry is counted
+type CounterHandler struct {
+	*BaseHandler
+	hotDocuments uint64
+	retries      *metrics.Counter
+}
+
+func NewCounterHandler(handler *BaseHandler) *CounterHandler {
+	hotDocuments := handler.job.HotDocuments
+	if hotDocuments == 0 {
+		hotDocuments = DefaultHotDocuments
+	}
+	return &CounterHandler{
+		BaseHandler:  handler,
+		hotDocuments: hotDocuments,
+		retries:      jobCounter(handler.job, fmt.Sprintf(`counter_write_conflict_retries_total{job="%s",run_id="%s"}`, handler.job.Name, handler.job.RunId)),
+	}
+}
+
+func (h *CounterHandler) Execute(ctx context.Context) (error error) {
+	filter := bson.M{"_id": rand.Uint64() % h.hotDocuments}
+	update := bson.M{"$inc": bson.M{"count": 1}}
+	opts := options.Update().SetUpsert(true)
+
+	for retry := 0; retry <= CounterMaxConflictRetry; retry++ {
+		if retry > 0 {
+			h.retries.Inc()
+		}
+		_, error = h.client.UpdateOne(ctx, filter, update, opts)
+		// concurrent upserts of the same missing document ends with duplicate key error
+		if !database.IsWriteConflict(error) && !mongo.IsDuplicateKeyError(error) {
+			return
+		}
+	}
+	return
+}
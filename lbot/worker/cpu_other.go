@@ -0,0 +1,12 @@
+//go:build !unix
+
+package worker
+
+import (
+	"errors"
+	"time"
+)
+
+func cpuTime() (time.Duration, error) {
+	return 0, errors.New("process CPU time is not supported on this platform")
+}
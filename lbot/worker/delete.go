@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VictoriaMetrics/metrics"
+)
+
+// DeleteHandler removes documents matched by generated filter, first one or all of them with delete_many,
+// models churn of collections together with write jobs
+type DeleteHandler struct {
+	*BaseHandler
+	deleted *metrics.Summary
+}
+
+func NewDeleteHandler(handler *BaseHandler) *DeleteHandler {
+	return &DeleteHandler{
+		BaseHandler: handler,
+		deleted:     jobSummary(handler.job, fmt.Sprintf(`delete_deleted_documents{job="%s",run_id="%s"}`, handler.job.Name, handler.job.RunId)),
+	}
+}
+
+func (h *DeleteHandler) Execute(ctx context.Context) error {
+	filter := h.dataProvider.GetFilter()
+
+	var deleted int64
+	var error error
+	if h.job.DeleteMany {
+		deleted, error = h.client.DeleteMany(ctx, filter)
+	} else {
+		deleted, error = h.client.DeleteOne(ctx, filter)
+	}
+	if error == nil {
+		h.deleted.Update(float64(deleted))
+	}
+	return error
+}
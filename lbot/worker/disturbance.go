@@ -0,0 +1,135 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/database"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	DisturbanceIndexName = "loadbot_disturbance_1"
+	// time given to restore collection after job finished
+	disturbanceCleanupTimeout = 30 * time.Second
+)
+
+// Disturber runs DDL operations on job collection in background while job is running, testing resilience
+// to maintenance operations. Operations are run in turns, index and validator are alternately
+// created and removed, state of collection is restored by Cleanup.
+type Disturber struct {
+	job          *config.Job
+	db           database.Client
+	operations   []string
+	next         int
+	indexCreated bool
+	validatorSet bool
+	original     bson.D // validator options of collection before first validator change
+}
+
+func NewDisturber(job *config.Job, db database.Client) *Disturber {
+	operations := job.Disturbances
+	if len(operations) == 0 {
+		operations = []string{config.DisturbanceCollMod, config.DisturbanceIndex, config.DisturbanceValidator}
+	}
+	return &Disturber{job: job, db: db, operations: operations}
+}
+
+// Run disturbs every disturbance interval until ctx is done, then restores collection
+func (d *Disturber) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.job.DisturbanceInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			d.Cleanup()
+			return
+		case <-ticker.C:
+			d.Disturb(ctx)
+		}
+	}
+}
+
+// Disturb runs next DDL operation
+func (d *Disturber) Disturb(ctx context.Context) {
+	operation := d.operations[d.next%len(d.operations)]
+	d.next++
+
+	labels := fmt.Sprintf(`{job="%s",run_id="%s",operation="%s"}`, d.job.Name, d.job.RunId, operation)
+	jobCounter(d.job, "ddl_disturbances_total"+labels).Inc()
+	if err := d.run(ctx, operation); err != nil && ctx.Err() == nil {
+		jobCounter(d.job, "ddl_disturbances_error"+labels).Inc()
+		log.WithField("run_id", d.job.RunId).Warnf("Job %s disturbance %s failed: %s", d.job.Name, operation, err)
+	}
+}
+
+func (d *Disturber) run(ctx context.Context, operation string) (err error) {
+	switch operation {
+	case config.DisturbanceCollMod:
+		return d.db.CollMod(ctx, nil)
+	case config.DisturbanceIndex:
+		if d.indexCreated {
+			err = d.db.DropIndex(ctx, DisturbanceIndexName)
+		} else {
+			err = d.db.CreateIndex(ctx, bson.D{{Key: "_loadbot_disturbance", Value: 1}}, DisturbanceIndexName)
+		}
+		if err == nil {
+			d.indexCreated = !d.indexCreated
+		}
+		return err
+	case config.DisturbanceValidator:
+		if d.validatorSet {
+			err = d.db.CollMod(ctx, d.original)
+		} else {
+			err = d.setValidator(ctx)
+		}
+		if err == nil {
+			d.validatorSet = !d.validatorSet
+		}
+		return err
+	}
+	return nil
+}
+
+// setValidator replaces validator with permissive one, accepting every document but forcing validator change
+func (d *Disturber) setValidator(ctx context.Context) error {
+	if d.original == nil {
+		options, err := d.db.CollectionOptions(ctx)
+		if err != nil {
+			return err
+		}
+		validator, ok := options["validator"]
+		if !ok {
+			validator = bson.M{}
+		}
+		d.original = bson.D{{Key: "validator", Value: validator}}
+		for _, key := range []string{"validationLevel", "validationAction"} {
+			if value, ok := options[key]; ok {
+				d.original = append(d.original, bson.E{Key: key, Value: value})
+			}
+		}
+	}
+	return d.db.CollMod(ctx, bson.D{
+		{Key: "validator", Value: bson.M{"$jsonSchema": bson.M{"bsonType": "object"}}},
+		{Key: "validationAction", Value: "warn"},
+	})
+}
+
+// Cleanup drops disturbance index and restores original validator
+func (d *Disturber) Cleanup() {
+	ctx, cancel := context.WithTimeout(context.Background(), disturbanceCleanupTimeout)
+	defer cancel()
+	if d.indexCreated {
+		if err := d.run(ctx, config.DisturbanceIndex); err != nil {
+			log.WithField("run_id", d.job.RunId).Warnf("Job %s dropping index %s failed: %s", d.job.Name, DisturbanceIndexName, err)
+		}
+	}
+	if d.validatorSet {
+		if err := d.run(ctx, config.DisturbanceValidator); err != nil {
+			log.WithField("run_id", d.job.RunId).Warnf("Job %s restoring validator failed: %s", d.job.Name, err)
+		}
+	}
+}
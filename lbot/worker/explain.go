@@ -0,0 +1,118 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/database"
+	"github.com/kuzxnia/loadbot/lbot/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+// docs examined ratio change (relative) reported as plan change even when chosen index is the same
+const ExplainRatioChangeThreshold = 10.0
+
+// PlanChange is change of winning plan of sampled filter observed during job
+type PlanChange struct {
+	Time          time.Time
+	PreviousPlan  string
+	Plan          string
+	PreviousRatio float64
+	Ratio         float64
+}
+
+func (c PlanChange) String() string {
+	return fmt.Sprintf(
+		"%s: plan %s -> %s, docs examined per returned %.1f -> %.1f",
+		c.Time.Format(time.TimeOnly), c.PreviousPlan, c.Plan, c.PreviousRatio, c.Ratio,
+	)
+}
+
+// PlanSampler periodically explains sample filter of job, catching plan cache flips during run
+type PlanSampler struct {
+	job          *config.Job
+	db           database.Client
+	dataProvider schema.DataProvider
+	changes      []PlanChange
+	plan         string
+	ratio        float64
+	mutex        sync.Mutex
+	planChanges  *metrics.Counter
+}
+
+func NewPlanSampler(job *config.Job, db database.Client, dataProvider schema.DataProvider) *PlanSampler {
+	labels := fmt.Sprintf(`{job="%s",run_id="%s"}`, job.Name, job.RunId)
+	sampler := &PlanSampler{
+		job:          job,
+		db:           db,
+		dataProvider: dataProvider,
+		planChanges:  jobCounter(job, "explain_plan_changes_total"+labels),
+	}
+	jobGauge(job, "explain_docs_examined_ratio"+labels, func() float64 {
+		sampler.mutex.Lock()
+		defer sampler.mutex.Unlock()
+		return sampler.ratio
+	})
+	return sampler
+}
+
+// Run samples plans every explain interval until ctx is done
+func (s *PlanSampler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.job.ExplainInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			plan, err := s.db.Explain(ctx, s.dataProvider.GetFilter())
+			if err != nil {
+				if ctx.Err() == nil {
+					log.WithField("run_id", s.job.RunId).Warnf("Job %s explain failed: %s", s.job.Name, err)
+				}
+				continue
+			}
+			s.Observe(time.Now(), plan)
+		}
+	}
+}
+
+// Observe records sampled plan, change of chosen indexes/stages or significant change of docs examined ratio is reported
+func (s *PlanSampler) Observe(now time.Time, plan *database.QueryPlan) {
+	summary := PlanSummary(plan)
+	ratio := float64(plan.DocsExamined) / float64(max(plan.Returned, 1))
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.plan != "" && (summary != s.plan || ratioChanged(s.ratio, ratio)) {
+		change := PlanChange{Time: now, PreviousPlan: s.plan, Plan: summary, PreviousRatio: s.ratio, Ratio: ratio}
+		s.changes = append(s.changes, change)
+		s.planChanges.Inc()
+		log.WithField("run_id", s.job.RunId).Warnf("Job %s query plan changed, %s", s.job.Name, change)
+	}
+	s.plan, s.ratio = summary, ratio
+}
+
+func (s *PlanSampler) Changes() []PlanChange {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]PlanChange(nil), s.changes...)
+}
+
+// PlanSummary describes plan by used indexes, ex. "IXSCAN lastname_1", or "COLLSCAN"
+func PlanSummary(plan *database.QueryPlan) string {
+	if plan.IsCollectionScan() || len(plan.Indexes) == 0 {
+		return database.PlanStageCollectionScan
+	}
+	return database.PlanStageIndexScan + " " + strings.Join(plan.Indexes, ",")
+}
+
+func ratioChanged(previous, current float64) bool {
+	low, high := min(previous, current), max(previous, current)
+	return high > 1 && high >= low*ExplainRatioChangeThreshold
+}
@@ -0,0 +1,30 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanSamplerReportsChanges(t *testing.T) {
+	sampler := NewPlanSampler(&config.Job{Name: "explain test"}, nil, nil)
+	now := time.Now()
+
+	sampler.Observe(now, &database.QueryPlan{Indexes: []string{"user_1"}, DocsExamined: 1, Returned: 1})
+	sampler.Observe(now, &database.QueryPlan{Indexes: []string{"user_1"}, DocsExamined: 3, Returned: 1})
+	assert.Empty(t, sampler.Changes())
+
+	// plan cache flip to other index examining much more documents
+	sampler.Observe(now, &database.QueryPlan{Indexes: []string{"created_1"}, DocsExamined: 900, Returned: 1})
+	sampler.Observe(now, &database.QueryPlan{Stages: []string{database.PlanStageCollectionScan}, DocsExamined: 900, Returned: 1})
+
+	changes := sampler.Changes()
+	if assert.Len(t, changes, 2) {
+		assert.Equal(t, "IXSCAN user_1", changes[0].PreviousPlan)
+		assert.Equal(t, "IXSCAN created_1", changes[0].Plan)
+		assert.Equal(t, "COLLSCAN", changes[1].Plan)
+	}
+}
@@ -0,0 +1,201 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	FairnessSampleInterval = time.Second
+	// job is starved when it achieved lower fraction of its pace
+	StarvationRatio = 0.9
+	// fraction of connection time spent waiting for rate limiter below which connections are saturated
+	SaturationWaitRatio = 0.1
+)
+
+// Dispatch counts operations dispatched by job connections, sampled by agent Fairness
+type Dispatch struct {
+	operations  atomic.Uint64
+	busy        atomic.Int64  // nanoseconds connections spent in operations
+	limiterWait atomic.Int64  // nanoseconds connections waited for rate limiter
+	rate        atomic.Uint64 // float64 bits, expected rps of job on this agent, 0 when job has no pace
+}
+
+func (d *Dispatch) SetRate(rate float64) {
+	d.rate.Store(math.Float64bits(rate))
+}
+
+func (d *Dispatch) Rate() float64 {
+	return math.Float64frombits(d.rate.Load())
+}
+
+func (d *Dispatch) Waited(wait time.Duration) {
+	d.limiterWait.Add(int64(wait))
+}
+
+func (d *Dispatch) Done(latency time.Duration) {
+	d.busy.Add(int64(latency))
+	d.operations.Add(1)
+}
+
+type dispatchSample struct {
+	operations  uint64
+	busy        time.Duration
+	limiterWait time.Duration
+}
+
+func (d *Dispatch) sample() dispatchSample {
+	return dispatchSample{
+		operations:  d.operations.Load(),
+		busy:        time.Duration(d.busy.Load()),
+		limiterWait: time.Duration(d.limiterWait.Load()),
+	}
+}
+
+// fairnessStats accumulates intervals job was running (not paused), contended intervals are ones
+// with other jobs running on agent at the same time
+type fairnessStats struct {
+	last                dispatchSample
+	active              time.Duration
+	activeOperations    uint64
+	activeLimiterWait   time.Duration
+	contended           time.Duration
+	contendedOperations uint64
+	contendedBusy       time.Duration
+	othersOperations    uint64 // operations of other jobs within contended intervals
+	othersBusy          time.Duration
+	peers               int // max number of other jobs running at the same time
+}
+
+// Fairness samples dispatch of jobs running in parallel on agent, so share of agent each job received
+// and starved rate limiters are reported when job finishes
+type Fairness struct {
+	mutex sync.Mutex
+	jobs  map[*Worker]*fairnessStats
+}
+
+func NewFairness() *Fairness {
+	return &Fairness{jobs: map[*Worker]*fairnessStats{}}
+}
+
+// Run samples running jobs every FairnessSampleInterval until ctx is done
+func (f *Fairness) Run(ctx context.Context) {
+	ticker := time.NewTicker(FairnessSampleInterval)
+	defer ticker.Stop()
+	last := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			f.Sample(now.Sub(last))
+			last = now
+		}
+	}
+}
+
+func (f *Fairness) Add(w *Worker) {
+	if f == nil {
+		return
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.jobs[w] = &fairnessStats{last: w.dispatch.sample()}
+}
+
+// Remove stops sampling job and returns its fairness report
+func (f *Fairness) Remove(w *Worker) []string {
+	if f == nil {
+		return nil
+	}
+	f.mutex.Lock()
+	stats, ok := f.jobs[w]
+	delete(f.jobs, w)
+	f.mutex.Unlock()
+	if !ok {
+		return nil
+	}
+	return stats.report(w.dispatch.Rate(), w.job.Connections)
+}
+
+// Sample accumulates dispatch of jobs since previous sample, intervals job was paused in are skipped
+func (f *Fairness) Sample(elapsed time.Duration) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	deltas := make(map[*fairnessStats]dispatchSample, len(f.jobs))
+	var total dispatchSample
+	for w, stats := range f.jobs {
+		current := w.dispatch.sample()
+		delta := dispatchSample{
+			operations:  current.operations - stats.last.operations,
+			busy:        current.busy - stats.last.busy,
+			limiterWait: current.limiterWait - stats.last.limiterWait,
+		}
+		stats.last = current
+		if w.IsPaused() {
+			continue
+		}
+		deltas[stats] = delta
+		total.operations += delta.operations
+		total.busy += delta.busy
+	}
+
+	for stats, delta := range deltas {
+		stats.active += elapsed
+		stats.activeOperations += delta.operations
+		stats.activeLimiterWait += delta.limiterWait
+		if len(deltas) > 1 {
+			stats.contended += elapsed
+			stats.contendedOperations += delta.operations
+			stats.contendedBusy += delta.busy
+			stats.othersOperations += total.operations - delta.operations
+			stats.othersBusy += total.busy - delta.busy
+			stats.peers = max(stats.peers, len(deltas)-1)
+		}
+	}
+}
+
+// report describes share of agent job received while running with other jobs and whether its rate limiter
+// starved, ex. "ran 2m0s alongside up to 2 other jobs, received 31.2% of dispatched operations and 45.0% of connection time"
+func (s *fairnessStats) report(rate float64, connections uint64) []string {
+	var report []string
+	if s.contended > 0 {
+		report = append(report, fmt.Sprintf(
+			"ran %s alongside up to %d other jobs, received %.1f%% of dispatched operations and %.1f%% of connection time",
+			s.contended.Round(time.Second), s.peers,
+			share(float64(s.contendedOperations), float64(s.othersOperations)),
+			share(float64(s.contendedBusy), float64(s.othersBusy)),
+		))
+	}
+	if rate > 0 && s.active >= FairnessSampleInterval {
+		achieved := float64(s.activeOperations) / s.active.Seconds()
+		if achieved >= StarvationRatio*rate {
+			return append(report, fmt.Sprintf("rate limiter kept pace, %.0f rps of %.0f rps", achieved, rate))
+		}
+		var waitRatio float64
+		if connectionTime := s.active * time.Duration(connections); connectionTime > 0 {
+			waitRatio = s.activeLimiterWait.Seconds() / connectionTime.Seconds()
+		}
+		line := fmt.Sprintf(
+			"rate limiter starved, %.0f rps of %.0f rps (%.1f%%), connections waited for limiter %.1f%% of time",
+			achieved, rate, achieved/rate*100, waitRatio*100,
+		)
+		if waitRatio < SaturationWaitRatio {
+			line += ", connections are saturated, add connections or lower pace of other jobs"
+		}
+		report = append(report, line)
+	}
+	return report
+}
+
+func share(value float64, others float64) float64 {
+	if value+others == 0 {
+		return 0
+	}
+	return value / (value + others) * 100
+}
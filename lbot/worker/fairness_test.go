@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFairnessReport(t *testing.T) {
+	fairness := NewFairness()
+	fast := &Worker{job: &config.Job{Connections: 10}}
+	fast.dispatch.SetRate(1000)
+	slow := &Worker{job: &config.Job{Connections: 1}}
+	slow.dispatch.SetRate(100)
+	fairness.Add(fast)
+	fairness.Add(slow)
+
+	for i := 0; i < 30; i++ {
+		fast.dispatch.Done(time.Second)
+	}
+	for i := 0; i < 10; i++ {
+		slow.dispatch.Done(time.Second)
+	}
+	fairness.Sample(10 * time.Second)
+
+	assert.Equal(t, []string{
+		"ran 10s alongside up to 1 other jobs, received 75.0% of dispatched operations and 75.0% of connection time",
+		"rate limiter starved, 3 rps of 1000 rps (0.3%), connections waited for limiter 0.0% of time, connections are saturated, add connections or lower pace of other jobs",
+	}, fairness.Remove(fast))
+
+	// paused intervals are not counted
+	slow.gate.Pause(PauseReasonPreempted)
+	fairness.Sample(10 * time.Second)
+	slow.gate.Resume(PauseReasonPreempted)
+	slow.dispatch.Waited(9 * time.Second)
+	for i := 0; i < 100; i++ {
+		slow.dispatch.Done(10 * time.Millisecond)
+	}
+	fairness.Sample(time.Second)
+	assert.Equal(t, []string{
+		"ran 10s alongside up to 1 other jobs, received 25.0% of dispatched operations and 25.0% of connection time",
+		"rate limiter starved, 10 rps of 100 rps (10.0%), connections waited for limiter 81.8% of time",
+	}, fairness.Remove(slow))
+}
@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync/atomic"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/schema"
+)
+
+// applyFanout sets job collections to <collection>_0..N-1 of fan-out write job
+func applyFanout(job *config.Job, jobSchema *config.Schema) {
+	_, prefix, _ := job.Target(jobSchema, 0)
+	job.Collections = make([]string, job.FanoutCollections)
+	for i := range job.Collections {
+		job.Collections[i] = fmt.Sprintf("%s_%d", prefix, i)
+	}
+}
+
+// FanoutHandler inserts generated documents into collection chosen round-robin or by hash of fanout_key,
+// so documents with the same key always land in the same collection
+type FanoutHandler struct {
+	job          *config.Job
+	targets      []*CollectionTarget
+	dataProvider schema.DataProvider
+	dataPool     schema.DataPool
+	next         atomic.Uint64
+}
+
+func NewFanoutHandler(job *config.Job, targets []*CollectionTarget, dataProvider schema.DataProvider, dataPool schema.DataPool) *FanoutHandler {
+	return &FanoutHandler{job: job, targets: targets, dataProvider: dataProvider, dataPool: dataPool}
+}
+
+func (h *FanoutHandler) Execute(ctx context.Context) error {
+	item := h.dataProvider.GetSingleItem()
+	target := h.targets[h.pick(item)]
+	return target.Meter(func() error {
+		_, err := target.db.InsertOne(ctx, item)
+		if err == nil && h.dataPool != nil {
+			h.dataPool.Set(item)
+		}
+		return err
+	})
+}
+
+// pick returns index of document collection, documents without fanout_key field land in the same collection
+func (h *FanoutHandler) pick(item interface{}) int {
+	if h.job.FanoutKey == "" {
+		return int((h.next.Add(1) - 1) % uint64(len(h.targets)))
+	}
+	value, _ := schema.GetFieldFromData(h.job.FanoutKey, item)
+	hash := fnv.New32a()
+	fmt.Fprint(hash, value)
+	return int(hash.Sum32() % uint32(len(h.targets)))
+}
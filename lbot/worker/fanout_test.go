@@ -0,0 +1,41 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyFanout(t *testing.T) {
+	job := &config.Job{Collection: "events", FanoutCollections: 3}
+	applyFanout(job, nil)
+	assert.Equal(t, []string{"events_0", "events_1", "events_2"}, job.Collections)
+
+	job = &config.Job{Schema: "events", FanoutCollections: 2}
+	applyFanout(job, &config.Schema{Database: "app", Collection: "logs"})
+	assert.Equal(t, []string{"logs_0", "logs_1"}, job.Collections)
+}
+
+func TestFanoutPick(t *testing.T) {
+	targets := make([]*CollectionTarget, 3)
+
+	roundRobin := NewFanoutHandler(&config.Job{FanoutCollections: 3}, targets, nil, nil)
+	picked := []int{}
+	for i := 0; i < 4; i++ {
+		picked = append(picked, roundRobin.pick(nil))
+	}
+	assert.Equal(t, []int{0, 1, 2, 0}, picked)
+
+	byKey := NewFanoutHandler(&config.Job{FanoutCollections: 3, FanoutKey: "tenant.id"}, targets, nil, nil)
+	document := map[string]interface{}{"tenant": map[string]interface{}{"id": "acme"}}
+	first := byKey.pick(document)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, first, byKey.pick(document))
+	}
+	spread := map[int]bool{}
+	for i := 0; i < 100; i++ {
+		spread[byKey.pick(map[string]interface{}{"tenant": map[string]interface{}{"id": i}})] = true
+	}
+	assert.Len(t, spread, 3)
+}
@@ -0,0 +1,169 @@
+package worker
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/schema"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// generated samples sizes of documents, filters and updates are averaged from
+const FootprintSamples = 20
+
+// Footprint is estimated volume of job or run, computed from job settings and sizes of generated samples
+type Footprint struct {
+	Operations   uint64
+	Documents    uint64   // inserted documents and uploaded files
+	WrittenBytes uint64   // size of inserted documents and uploaded files
+	EgressBytes  uint64   // documents, filters and updates sent to database, protocol overhead excluded
+	Unbounded    []string // jobs without operations limit or without duration and rate, not estimated
+}
+
+func (f *Footprint) Add(other Footprint) {
+	f.Operations += other.Operations
+	f.Documents += other.Documents
+	f.WrittenBytes += other.WrittenBytes
+	f.EgressBytes += other.EgressBytes
+	f.Unbounded = append(f.Unbounded, other.Unbounded...)
+}
+
+func (f Footprint) String() string {
+	return fmt.Sprintf(
+		"%d operations, %d documents, %s written, %s sent to database",
+		f.Operations, f.Documents, FormatBytes(f.WrittenBytes), FormatBytes(f.EgressBytes),
+	)
+}
+
+// PayloadSizes are average bson sizes of job generated document, filter and update
+type PayloadSizes struct {
+	Document float64
+	Filter   float64
+	Update   float64
+}
+
+// SamplePayloadSizes generates samples of job document, filter and update and averages their sizes,
+// templates which fail to generate count as empty
+func SamplePayloadSizes(job *config.Job, jobSchema *config.Schema, samples int) (sizes PayloadSizes) {
+	generator := schema.NewDataGenerator(jobSchema, int(job.DataSize))
+	for i := 0; i < samples; i++ {
+		if document, err := generator.Generate(); err == nil {
+			sizes.Document += bsonSize(document)
+		}
+		if !lo.IsNil(job.Filter) {
+			if filter, err := generator.GenerateFromTemplate(job.Filter); err == nil {
+				sizes.Filter += bsonSize(filter)
+			}
+		}
+		if !lo.IsNil(job.Update) {
+			if update, err := generator.GenerateFromTemplate(job.Update); err == nil {
+				sizes.Update += bsonSize(update)
+			}
+		}
+	}
+	sizes.Document /= float64(samples)
+	sizes.Filter /= float64(samples)
+	sizes.Update /= float64(samples)
+	return sizes
+}
+
+func bsonSize(value interface{}) float64 {
+	// arrays (update pipelines) can't be top level document
+	content, err := bson.Marshal(bson.M{"v": value})
+	if err != nil {
+		return 0
+	}
+	return float64(len(content))
+}
+
+// EstimateOperations returns number of operations job starts, limited by operations or by rate (pace, pace
+// interval or rate profile) over duration and warm-up, false when job is not limited by any of them
+func EstimateOperations(job *config.Job) (uint64, bool) {
+	seconds := (job.Duration + job.Warmup).Seconds()
+	if job.BurstDuration > 0 {
+		// job is idle between bursts
+		seconds *= job.BurstDuration.Seconds() / (job.BurstDuration + job.BurstIdle).Seconds()
+	}
+	byRate, bounded := 0.0, false
+	if job.Duration > 0 {
+		switch {
+		case len(job.RateProfile) > 0:
+			if profile, err := config.JobRateProfile(job); err == nil {
+				total := profile.Operations(job.Duration + job.Warmup)
+				byRate, bounded = total*seconds/(job.Duration+job.Warmup).Seconds(), true
+			}
+		case job.PaceInterval > 0:
+			// every connection issues one operation per interval
+			byRate, bounded = seconds*float64(max(1, job.Connections))/job.PaceInterval.Seconds(), true
+		case job.Pace > 0:
+			byRate, bounded = seconds*float64(job.Pace), true
+		}
+	}
+	switch {
+	case job.Operations > 0 && bounded:
+		return min(job.Operations, uint64(byRate)), true
+	case job.Operations > 0:
+		return job.Operations, true
+	}
+	return uint64(byRate), bounded
+}
+
+// EstimateFootprint estimates footprint of job from its operations and payload sizes, every operation
+// sends filter and update, writes send and insert documents, bulk writes batch of them
+func EstimateFootprint(job *config.Job, sizes PayloadSizes) (footprint Footprint) {
+	switch config.JobType(job.Type) {
+	case config.Sleep, config.DropCollection, config.CreateIndex:
+		return footprint
+	}
+	operations, bounded := EstimateOperations(job)
+	if !bounded {
+		footprint.Unbounded = []string{job.Name}
+		return footprint
+	}
+	footprint.Operations = operations
+	request := sizes.Filter + sizes.Update
+
+	switch config.JobType(job.Type) {
+	case config.Write:
+		footprint.Documents = operations
+		footprint.WrittenBytes = uint64(float64(operations) * sizes.Document)
+		footprint.EgressBytes = footprint.WrittenBytes
+	case config.BulkWrite:
+		items := float64(operations * BatchSize(job))
+		inserts := items * bulkInsertShare(job)
+		footprint.Documents = uint64(inserts)
+		footprint.WrittenBytes = uint64(inserts * sizes.Document)
+		footprint.EgressBytes = footprint.WrittenBytes + uint64((items-inserts)*request)
+	case config.GridFSUpload:
+		fileSize := float64(job.FileSize+max(job.FileSize, job.FileSizeMax)) / 2
+		footprint.Documents = operations
+		footprint.WrittenBytes = uint64(float64(operations) * fileSize)
+		footprint.EgressBytes = footprint.WrittenBytes
+	default:
+		footprint.EgressBytes = uint64(float64(operations) * request)
+	}
+	return footprint
+}
+
+// bulkInsertShare returns fraction of bulk_write items which are inserts
+func bulkInsertShare(job *config.Job) float64 {
+	if len(job.BulkMix) == 0 {
+		return 1
+	}
+	total := lo.Sum(lo.Values(job.BulkMix))
+	if total == 0 {
+		return 1
+	}
+	return job.BulkMix[config.BulkOpInsert] / total
+}
+
+// FormatBytes formats byte count with decimal unit, ex. 1.5 GB
+func FormatBytes(bytes uint64) string {
+	if bytes < 1000 {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	exponent := min(int(math.Log10(float64(bytes))/3), 6)
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/math.Pow(1000, float64(exponent)), "kMGTPE"[exponent-1])
+}
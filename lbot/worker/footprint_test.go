@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateOperations(t *testing.T) {
+	operations, bounded := EstimateOperations(&config.Job{Pace: 100, Duration: time.Minute})
+	assert.True(t, bounded)
+	assert.Equal(t, uint64(6000), operations)
+
+	// operations limit wins when lower
+	operations, _ = EstimateOperations(&config.Job{Pace: 100, Duration: time.Minute, Operations: 1000})
+	assert.Equal(t, uint64(1000), operations)
+	operations, bounded = EstimateOperations(&config.Job{Operations: 1000})
+	assert.True(t, bounded)
+	assert.Equal(t, uint64(1000), operations)
+
+	operations, _ = EstimateOperations(&config.Job{PaceInterval: 2 * time.Second, Duration: time.Minute})
+	assert.Equal(t, uint64(30), operations)
+	// every connection has own interval
+	operations, _ = EstimateOperations(&config.Job{PaceInterval: 2 * time.Second, Connections: 4, Duration: time.Minute})
+	assert.Equal(t, uint64(120), operations)
+
+	// warm-up runs at pace, bursts are idle half of the time
+	operations, _ = EstimateOperations(&config.Job{Pace: 100, Duration: time.Minute, Warmup: time.Minute, BurstDuration: time.Second, BurstIdle: time.Second})
+	assert.Equal(t, uint64(6000), operations)
+
+	// ramp from 0 to 100 rps
+	operations, _ = EstimateOperations(&config.Job{Duration: 100 * time.Second, RateProfile: []string{"100 over 100s"}})
+	assert.Equal(t, uint64(5000), operations)
+
+	_, bounded = EstimateOperations(&config.Job{Duration: time.Minute})
+	assert.False(t, bounded)
+	_, bounded = EstimateOperations(&config.Job{Pace: 100})
+	assert.False(t, bounded)
+}
+
+func TestEstimateFootprint(t *testing.T) {
+	sizes := PayloadSizes{Document: 1000, Filter: 50, Update: 150}
+
+	footprint := EstimateFootprint(&config.Job{Type: string(config.Write), Operations: 1000}, sizes)
+	assert.Equal(t, Footprint{Operations: 1000, Documents: 1000, WrittenBytes: 1000000, EgressBytes: 1000000}, footprint)
+
+	footprint = EstimateFootprint(&config.Job{
+		Type: string(config.BulkWrite), Operations: 10, BatchSize: 100,
+		BulkMix: map[string]float64{config.BulkOpInsert: 3, config.BulkOpUpdate: 1},
+	}, sizes)
+	assert.Equal(t, Footprint{Operations: 10, Documents: 750, WrittenBytes: 750000, EgressBytes: 750000 + 250*200}, footprint)
+
+	footprint = EstimateFootprint(&config.Job{Type: string(config.Update), Operations: 1000}, sizes)
+	assert.Equal(t, Footprint{Operations: 1000, EgressBytes: 200000}, footprint)
+
+	footprint = EstimateFootprint(&config.Job{Type: string(config.GridFSUpload), Operations: 10, FileSize: 1000, FileSizeMax: 3000}, sizes)
+	assert.Equal(t, Footprint{Operations: 10, Documents: 10, WrittenBytes: 20000, EgressBytes: 20000}, footprint)
+
+	footprint = EstimateFootprint(&config.Job{Name: "reads", Type: string(config.Read), Duration: time.Hour}, sizes)
+	assert.Equal(t, Footprint{Unbounded: []string{"reads"}}, footprint)
+	assert.Equal(t, Footprint{}, EstimateFootprint(&config.Job{Type: string(config.Sleep)}, sizes))
+}
+
+func TestSamplePayloadSizes(t *testing.T) {
+	sizes := SamplePayloadSizes(&config.Job{DataSize: 100}, nil, 5)
+	// "data" string of 100 bytes wrapped in document
+	assert.InDelta(t, 130, sizes.Document, 20)
+	assert.Zero(t, sizes.Filter)
+	assert.Zero(t, sizes.Update)
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "999 B", FormatBytes(999))
+	assert.Equal(t, "1.5 kB", FormatBytes(1500))
+	assert.Equal(t, "2.0 GB", FormatBytes(2000000000))
+}
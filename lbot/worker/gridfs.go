@@ -0,0 +1,164 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GridFSDownloadFiles is number of bucket files downloaded randomly by gridfs_download job
+const GridFSDownloadFiles = 1000
+
+// gridFSBucket is bucket of job collection shared by connections, created on first operation
+// (GridFS API of driver has no context, transfers are bounded by stream deadline and
+// stopped between chunks once operation context is done)
+type gridFSBucket struct {
+	*BaseHandler
+	once        sync.Once
+	bucket      *gridfs.Bucket
+	err         error
+	transferred *metrics.Counter
+}
+
+func newGridFSBucket(handler *BaseHandler) gridFSBucket {
+	return gridFSBucket{
+		BaseHandler: handler,
+		transferred: jobCounter(handler.job, fmt.Sprintf(`gridfs_bytes_total{job="%s",run_id="%s"}`, handler.job.Name, handler.job.RunId)),
+	}
+}
+
+func (b *gridFSBucket) get() (*gridfs.Bucket, error) {
+	b.once.Do(func() {
+		b.bucket, b.err = b.client.GridFSBucket()
+	})
+	return b.bucket, b.err
+}
+
+// GridFSUploadHandler uploads files of file_size pseudo-random bytes streamed from data provider
+type GridFSUploadHandler struct {
+	gridFSBucket
+}
+
+func NewGridFSUploadHandler(handler *BaseHandler) *GridFSUploadHandler {
+	return &GridFSUploadHandler{gridFSBucket: newGridFSBucket(handler)}
+}
+
+func (h *GridFSUploadHandler) Execute(ctx context.Context) error {
+	bucket, error := h.get()
+	if error != nil {
+		return error
+	}
+	stream, error := bucket.OpenUploadStream(primitive.NewObjectID().Hex())
+	if error != nil {
+		return error
+	}
+	if error = stream.SetWriteDeadline(streamDeadline(ctx)); error != nil {
+		return error
+	}
+	payload := &countingReader{ctx: ctx, reader: h.dataProvider.GetPayload()}
+	_, error = io.Copy(stream, payload)
+	h.transferred.Add(int(payload.read))
+	if error != nil {
+		_ = stream.Abort()
+		return error
+	}
+	return stream.Close()
+}
+
+// GridFSDownloadHandler downloads random file of bucket, files are listed on first operation
+// (retried until bucket has files, so it can be started together with upload job)
+type GridFSDownloadHandler struct {
+	gridFSBucket
+	mutex   sync.Mutex
+	fileIds []interface{}
+}
+
+func NewGridFSDownloadHandler(handler *BaseHandler) *GridFSDownloadHandler {
+	return &GridFSDownloadHandler{gridFSBucket: newGridFSBucket(handler)}
+}
+
+func (h *GridFSDownloadHandler) Execute(ctx context.Context) error {
+	bucket, error := h.get()
+	if error != nil {
+		return error
+	}
+	fileId, error := h.randomFile(ctx, bucket)
+	if error != nil {
+		return error
+	}
+	stream, error := bucket.OpenDownloadStream(fileId)
+	if error != nil {
+		return error
+	}
+	defer stream.Close()
+	if error = stream.SetReadDeadline(streamDeadline(ctx)); error != nil {
+		return error
+	}
+	file := &countingReader{ctx: ctx, reader: stream}
+	_, error = io.Copy(io.Discard, file)
+	h.transferred.Add(int(file.read))
+	return error
+}
+
+func (h *GridFSDownloadHandler) randomFile(ctx context.Context, bucket *gridfs.Bucket) (interface{}, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if len(h.fileIds) == 0 {
+		if err := h.listFiles(ctx, bucket); err != nil {
+			return nil, err
+		}
+	}
+	return h.fileIds[rand.Intn(len(h.fileIds))], nil
+}
+
+func (h *GridFSDownloadHandler) listFiles(ctx context.Context, bucket *gridfs.Bucket) error {
+	cursor, err := bucket.FindContext(ctx, bson.M{}, options.GridFSFind().SetLimit(GridFSDownloadFiles))
+	if err != nil {
+		return err
+	}
+	var files []struct {
+		Id interface{} `bson:"_id"`
+	}
+	if err = cursor.All(ctx, &files); err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return errors.New("no files to download in bucket " + h.job.Collection + ", upload them with gridfs_upload job first")
+	}
+	for _, file := range files {
+		h.fileIds = append(h.fileIds, file.Id)
+	}
+	return nil
+}
+
+// streamDeadline is deadline of operation context, zero time (no deadline) when context has none
+func streamDeadline(ctx context.Context) time.Time {
+	deadline, _ := ctx.Deadline()
+	return deadline
+}
+
+// countingReader counts bytes read and fails once context is done, so transfer stops between chunks
+type countingReader struct {
+	ctx    context.Context
+	reader io.Reader
+	read   int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+	return n, err
+}
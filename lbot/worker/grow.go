@@ -0,0 +1,143 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/database"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	DefaultGrowField             = "items"
+	DefaultGrowMaxItems          = 1000
+	DocumentSizeSampleInterval   = 10 * time.Second
+	documentGrowthMaxUpsertRetry = 1
+)
+
+// DocumentGrowthHandler pushes generated items to arrays of hot documents until size cap is reached,
+// after that oldest items are removed, so documents grow over run forcing storage engine to rewrite/move them
+type DocumentGrowthHandler struct {
+	*BaseHandler
+	hotDocuments uint64
+	field        string
+	maxItems     int64
+}
+
+func NewDocumentGrowthHandler(handler *BaseHandler) *DocumentGrowthHandler {
+	hotDocuments := handler.job.HotDocuments
+	if hotDocuments == 0 {
+		hotDocuments = DefaultHotDocuments
+	}
+	field := handler.job.GrowField
+	if field == "" {
+		field = DefaultGrowField
+	}
+	maxItems := handler.job.GrowMaxItems
+	if maxItems == 0 {
+		maxItems = DefaultGrowMaxItems
+	}
+	return &DocumentGrowthHandler{
+		BaseHandler:  handler,
+		hotDocuments: hotDocuments,
+		field:        field,
+		maxItems:     int64(maxItems),
+	}
+}
+
+func (h *DocumentGrowthHandler) Execute(ctx context.Context) (error error) {
+	filter := bson.M{"_id": rand.Uint64() % h.hotDocuments}
+	update := bson.M{"$push": bson.M{h.field: bson.M{
+		"$each":  bson.A{h.dataProvider.GetSingleItem()},
+		"$slice": -h.maxItems,
+	}}}
+	opts := options.Update().SetUpsert(true)
+
+	for retry := 0; retry <= documentGrowthMaxUpsertRetry; retry++ {
+		_, error = h.client.UpdateOne(ctx, filter, update, opts)
+		// concurrent upserts of the same missing document ends with duplicate key error
+		if !mongo.IsDuplicateKeyError(error) {
+			return
+		}
+	}
+	return
+}
+
+type documentSizeSample struct {
+	Time time.Time
+	Size float64
+}
+
+// DocumentSizeTracker samples average document size of job collection during run
+type DocumentSizeTracker struct {
+	job     *config.Job
+	db      database.Client
+	samples []documentSizeSample
+	mutex   sync.Mutex
+}
+
+func NewDocumentSizeTracker(job *config.Job, db database.Client) *DocumentSizeTracker {
+	tracker := &DocumentSizeTracker{job: job, db: db}
+	jobGauge(job, fmt.Sprintf(`document_average_size_bytes{job="%s",run_id="%s"}`, job.Name, job.RunId), func() float64 {
+		tracker.mutex.Lock()
+		defer tracker.mutex.Unlock()
+		if len(tracker.samples) == 0 {
+			return 0
+		}
+		return tracker.samples[len(tracker.samples)-1].Size
+	})
+	return tracker
+}
+
+// Run samples average document size at job start and then every sample interval until ctx is done
+func (t *DocumentSizeTracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(DocumentSizeSampleInterval)
+	defer ticker.Stop()
+	for {
+		size, err := t.db.AverageDocumentSize(ctx)
+		if err == nil {
+			t.Observe(time.Now(), size)
+		} else if ctx.Err() == nil {
+			log.WithField("run_id", t.job.RunId).Warnf("Job %s sampling document size failed: %s", t.job.Name, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *DocumentSizeTracker) Observe(now time.Time, size float64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.samples = append(t.samples, documentSizeSample{Time: now, Size: size})
+}
+
+// Report returns average document size at start and end of run and its peak, nil without samples
+func (t *DocumentSizeTracker) Report() []string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if len(t.samples) == 0 {
+		return nil
+	}
+	first, last, peak := t.samples[0], t.samples[len(t.samples)-1], t.samples[0]
+	for _, sample := range t.samples {
+		if sample.Size > peak.Size {
+			peak = sample
+		}
+	}
+	return []string{
+		fmt.Sprintf("start: %.0f bytes", first.Size),
+		fmt.Sprintf("end: %.0f bytes after %s", last.Size, last.Time.Sub(first.Time).Round(time.Second)),
+		fmt.Sprintf("peak: %.0f bytes at %s", peak.Size, peak.Time.Format(time.TimeOnly)),
+		fmt.Sprintf("samples: %d", len(t.samples)),
+	}
+}
@@ -1,19 +1,26 @@
 package worker
 
 import (
+	"context"
+	"fmt"
+	"math/rand"
 	"time"
 
 	"github.com/kuzxnia/loadbot/lbot/config"
 	"github.com/kuzxnia/loadbot/lbot/database"
 	"github.com/kuzxnia/loadbot/lbot/schema"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+const DefaultBatchSize = 100
+
 type JobHandler interface {
-	Execute() error
+	Execute(context.Context) error
 }
 
-func NewJobHandler(job *config.Job, client database.Client, dataPool schema.DataPool, s *config.Schema) JobHandler {
+// NewJobHandler builds handler of job type, error is returned for config handler can't run with
+func NewJobHandler(job *config.Job, client database.Client, dataPool schema.DataPool, s *config.Schema) (JobHandler, error) {
 	dataProvider := schema.NewDataProvider(job, s)
 	handler := BaseHandler{
 		job:          job,
@@ -24,20 +31,80 @@ func NewJobHandler(job *config.Job, client database.Client, dataPool schema.Data
 
 	switch job.Type {
 	case string(config.Write):
-		return JobHandler(&WriteHandler{BaseHandler: &handler})
+		return JobHandler(&WriteHandler{BaseHandler: &handler}), nil
 	case string(config.Read):
-		return JobHandler(&ReadHandler{BaseHandler: &handler})
+		return JobHandler(&ReadHandler{BaseHandler: &handler}), nil
 	case string(config.Update):
-		return JobHandler(&UpdateHandler{BaseHandler: &handler})
+		return JobHandler(&UpdateHandler{BaseHandler: &handler, hotSpot: NewHotSpot(job)}), nil
 	case string(config.BulkWrite):
-		return JobHandler(&BulkWriteHandler{BaseHandler: &handler})
+		return JobHandler(NewBulkWriteHandler(&handler)), nil
+	case string(config.CreateIndex):
+		return JobHandler(&CreateIndexHandler{BaseHandler: &handler}), nil
 	case string(config.DropCollection):
-		return JobHandler(&DropCollection{BaseHandler: &handler})
+		return JobHandler(&DropCollection{BaseHandler: &handler}), nil
+	case string(config.SchemaEvolution):
+		return JobHandler(&SchemaEvolutionHandler{BaseHandler: &handler}), nil
+	case string(config.FindOneAndUpdate):
+		return JobHandler(&FindOneAndUpdateHandler{BaseHandler: &handler, hotSpot: NewHotSpot(job)}), nil
+	case string(config.FindOneAndDelete):
+		return JobHandler(&FindOneAndDeleteHandler{BaseHandler: &handler}), nil
+	case string(config.FindOneAndReplace):
+		return JobHandler(&FindOneAndReplaceHandler{BaseHandler: &handler}), nil
+	case string(config.Delete):
+		if len(job.Filter) == 0 {
+			return nil, fmt.Errorf("job %s: 'filter' is required for delete job, empty filter would remove whole collection", job.Name)
+		}
+		return JobHandler(NewDeleteHandler(&handler)), nil
+	case string(config.Queue):
+		return JobHandler(NewQueueHandler(&handler)), nil
+	case string(config.Counter):
+		return JobHandler(NewCounterHandler(&handler)), nil
+	case string(config.Paginate):
+		return JobHandler(NewPaginateHandler(&handler)), nil
+	case string(config.Lookup):
+		return JobHandler(NewLookupHandler(&handler)), nil
+	case string(config.Aggregate):
+		return JobHandler(NewAggregateHandler(&handler)), nil
+	case string(config.Count):
+		return JobHandler(NewCountHandler(&handler)), nil
+	case string(config.Distinct):
+		return JobHandler(NewDistinctHandler(&handler)), nil
+	case string(config.TextSearch):
+		return JobHandler(NewTextSearchHandler(&handler)), nil
+	case string(config.GeoNear), string(config.GeoWithin):
+		return JobHandler(NewGeoHandler(&handler)), nil
+	case string(config.Command):
+		if job.Command == nil {
+			return nil, fmt.Errorf("job %s: 'command' is required for command job", job.Name)
+		}
+		return JobHandler(&CommandHandler{BaseHandler: &handler}), nil
+	case string(config.DocumentGrowth):
+		return JobHandler(NewDocumentGrowthHandler(&handler)), nil
+	case string(config.Noise):
+		return JobHandler(&NoiseHandler{BaseHandler: &handler}), nil
+	case string(config.Transaction):
+		return JobHandler(NewTransactionHandler(&handler)), nil
+	case string(config.ChangeStream):
+		return JobHandler(NewChangeStreamHandler(&handler)), nil
+	case string(config.GridFSUpload):
+		return JobHandler(NewGridFSUploadHandler(&handler)), nil
+	case string(config.GridFSDownload):
+		return JobHandler(NewGridFSDownloadHandler(&handler)), nil
+	case string(config.Mixed):
+		mixed, err := NewMixedHandler(job, client, dataPool, s)
+		if err != nil {
+			return nil, err
+		}
+		return JobHandler(mixed), nil
+	case string(config.Scenario):
+		return JobHandler(NewScenarioHandler(&handler, s)), nil
 	case string(config.Sleep):
-		return JobHandler(&SleepHandler{Duration: job.Duration})
+		return JobHandler(&SleepHandler{Duration: job.Duration}), nil
 	default:
-		// todo change
-		panic("Invalid job type: " + job.Type)
+		if factory, ok := pluginHandler(job.Type); ok {
+			return factory(job, client, dataProvider), nil
+		}
+		return nil, fmt.Errorf("job %s: invalid job type %s", job.Name, job.Type)
 	}
 }
 
@@ -52,10 +119,10 @@ type WriteHandler struct {
 	*BaseHandler
 }
 
-func (h *WriteHandler) Execute() error {
+func (h *WriteHandler) Execute(ctx context.Context) error {
 	item := h.dataProvider.GetSingleItem()
 
-	_, error := h.client.InsertOne(item)
+	_, error := h.client.InsertOne(ctx, item)
 
 	if error == nil && h.dataPool != nil {
 		h.dataPool.Set(item)
@@ -63,41 +130,109 @@ func (h *WriteHandler) Execute() error {
 	return error
 }
 
-type BulkWriteHandler struct {
+type ReadHandler struct {
 	*BaseHandler
 }
 
-func (h *BulkWriteHandler) Execute() error {
-	items := h.dataProvider.GetBatch(100)
+func (h *ReadHandler) Execute(ctx context.Context) error {
+	filter := h.dataProvider.GetFilter()
 
-	_, error := h.client.InsertMany(items)
+	_, error := h.client.ReadOne(ctx, filter)
+	return error
+}
 
-	if error == nil && h.dataPool != nil {
-		h.dataPool.SetBatch(items)
+type UpdateHandler struct {
+	*BaseHandler
+	hotSpot *HotSpot
+}
+
+func (h *UpdateHandler) Execute(ctx context.Context) error {
+	filter := h.hotSpot.Filter(h.dataProvider.GetFilter)
+
+	opts := options.Update()
+	if len(h.job.ArrayFilters) > 0 {
+		opts.SetArrayFilters(options.ArrayFilters{Filters: h.dataProvider.GetArrayFilters()})
 	}
+	_, error := h.client.UpdateOne(ctx, filter, h.getUpdate(), opts)
 	return error
 }
 
-type ReadHandler struct {
+// getUpdate returns update generated from job update template,
+// without template all fields are replaced with generated document
+func (h *BaseHandler) getUpdate() interface{} {
+	if len(h.job.Update) == 0 {
+		return bson.M{"$set": h.dataProvider.GetSingleItemWithout("_id")}
+	}
+	return h.dataProvider.GetUpdate()
+}
+
+// replacement returns generated document without _id, documents generated without schema have no _id
+func (h *BaseHandler) replacement() interface{} {
+	if h.job.Schema == "" {
+		return h.dataProvider.GetSingleItem()
+	}
+	return h.dataProvider.GetSingleItemWithout("_id")
+}
+
+type FindOneAndUpdateHandler struct {
+	*BaseHandler
+	hotSpot *HotSpot
+}
+
+func (h *FindOneAndUpdateHandler) Execute(ctx context.Context) error {
+	filter := h.hotSpot.Filter(h.dataProvider.GetFilter)
+
+	opts := options.FindOneAndUpdate()
+	if h.job.ReturnDocument == config.ReturnDocumentAfter {
+		opts.SetReturnDocument(options.After)
+	}
+	if len(h.job.Sort) > 0 {
+		opts.SetSort(h.dataProvider.GetSort())
+	}
+	if len(h.job.ArrayFilters) > 0 {
+		opts.SetArrayFilters(options.ArrayFilters{Filters: h.dataProvider.GetArrayFilters()})
+	}
+	if h.job.Upsert {
+		opts.SetUpsert(true)
+	}
+	_, error := h.client.FindOneAndUpdate(ctx, filter, h.getUpdate(), opts)
+	return error
+}
+
+// FindOneAndReplaceHandler replaces matched document with generated one, _id of matched document is kept
+type FindOneAndReplaceHandler struct {
 	*BaseHandler
 }
 
-func (h *ReadHandler) Execute() error {
+func (h *FindOneAndReplaceHandler) Execute(ctx context.Context) error {
 	filter := h.dataProvider.GetFilter()
 
-	_, error := h.client.ReadOne(filter)
+	opts := options.FindOneAndReplace()
+	if h.job.ReturnDocument == config.ReturnDocumentAfter {
+		opts.SetReturnDocument(options.After)
+	}
+	if len(h.job.Sort) > 0 {
+		opts.SetSort(h.dataProvider.GetSort())
+	}
+	if h.job.Upsert {
+		opts.SetUpsert(true)
+	}
+	_, error := h.client.FindOneAndReplace(ctx, filter, h.replacement(), opts)
 	return error
 }
 
-type UpdateHandler struct {
+type FindOneAndDeleteHandler struct {
 	*BaseHandler
 }
 
-func (h *UpdateHandler) Execute() error {
-	item := h.dataProvider.GetSingleItemWithout("_id")
+func (h *FindOneAndDeleteHandler) Execute(ctx context.Context) error {
 	filter := h.dataProvider.GetFilter()
 
-	_, error := h.client.UpdateOne(filter, bson.M{"$set": item})
+	opts := options.FindOneAndDelete()
+	if len(h.job.Sort) > 0 {
+		opts.SetSort(h.dataProvider.GetSort())
+	}
+	_, error := h.client.FindOneAndDelete(ctx, filter, opts)
 	return error
 }
 
@@ -105,8 +240,26 @@ type DropCollection struct {
 	*BaseHandler
 }
 
-func (h *DropCollection) Execute() error {
-	error := h.client.DropCollection()
+func (h *DropCollection) Execute(ctx context.Context) error {
+	error := h.client.DropCollection(ctx)
+	return error
+}
+
+type SchemaEvolutionHandler struct {
+	*BaseHandler
+}
+
+func (h *SchemaEvolutionHandler) Execute(ctx context.Context) error {
+	item := h.dataProvider.GetSingleItem()
+	if rand.Float64() < h.job.EvolutionRatio {
+		item = schema.EvolveDocument(item)
+	}
+
+	_, error := h.client.InsertOne(ctx, item)
+
+	if error == nil && h.dataPool != nil {
+		h.dataPool.Set(item)
+	}
 	return error
 }
 
@@ -114,7 +267,11 @@ type SleepHandler struct {
 	Duration time.Duration
 }
 
-func (h *SleepHandler) Execute() error {
-	time.Sleep(h.Duration)
-	return nil
+func (h *SleepHandler) Execute(ctx context.Context) error {
+	select {
+	case <-time.After(h.Duration):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
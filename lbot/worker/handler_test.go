@@ -0,0 +1,23 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJobHandlerRejectsInvalidConfig(t *testing.T) {
+	for _, job := range []*config.Job{
+		{Name: "no command", Type: string(config.Command)},
+		{Name: "unknown type", Type: "unknown"},
+		{Name: "delete without filter", Type: string(config.Delete), DeleteMany: true},
+	} {
+		_, err := NewJobHandler(job, nil, nil, nil)
+		assert.Error(t, err, job.Name)
+	}
+
+	handler, err := NewJobHandler(&config.Job{Type: string(config.Command), Command: &config.JobCommand{Name: "ping"}}, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.IsType(t, &CommandHandler{}, handler)
+}
@@ -0,0 +1,26 @@
+package worker
+
+import (
+	"context"
+	"os"
+	"os/exec"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	log "github.com/sirupsen/logrus"
+)
+
+// runHookCommand runs user command with sh -c on agent, connection string and job namespace are passed
+// in LOADBOT_CONNECTION_STRING, LOADBOT_DATABASE and LOADBOT_COLLECTION env variables
+func runHookCommand(ctx context.Context, job *config.Job, connectionString string, hook string) error {
+	command := exec.CommandContext(ctx, "sh", "-c", hook)
+	command.Env = append(os.Environ(),
+		"LOADBOT_CONNECTION_STRING="+connectionString,
+		"LOADBOT_DATABASE="+job.Database,
+		"LOADBOT_COLLECTION="+job.Collection,
+	)
+	output, err := command.CombinedOutput()
+	if len(output) > 0 {
+		log.WithField("run_id", job.RunId).Infof("Job %s command `%s` output:\n%s", job.Name, hook, output)
+	}
+	return err
+}
@@ -0,0 +1,67 @@
+package worker
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// HotSpot concentrates hot_fraction of update operations on hot_documents documents
+// (hot_field from 0 to hot_documents - 1), rest of operations use job filter,
+// with hot_skew documents are picked from zipf distribution, first document is the hottest
+type HotSpot struct {
+	fraction float64
+	field    string
+	upTo     []float64 // cumulative weights, nil for uniform picks
+	size     uint64
+	hits     *metrics.Counter
+}
+
+// NewHotSpot returns nil for jobs without hot_fraction
+func NewHotSpot(job *config.Job) *HotSpot {
+	if job.HotFraction == 0 {
+		return nil
+	}
+	h := &HotSpot{
+		fraction: job.HotFraction,
+		field:    job.HotField,
+		size:     job.HotDocuments,
+		hits:     jobCounter(job, fmt.Sprintf(`update_hot_operations_total{job="%s",run_id="%s"}`, job.Name, job.RunId)),
+	}
+	if h.size == 0 {
+		h.size = DefaultHotDocuments
+	}
+	if job.HotSkew > 0 {
+		h.upTo = make([]float64, h.size)
+		var total float64
+		for i := range h.upTo {
+			total += 1 / math.Pow(float64(i+1), job.HotSkew)
+			h.upTo[i] = total
+		}
+	}
+	return h
+}
+
+// Filter returns filter of hot document or filter generated by fallback, nil safe
+func (h *HotSpot) Filter(fallback func() interface{}) interface{} {
+	if h == nil || rand.Float64() >= h.fraction {
+		return fallback()
+	}
+	h.hits.Inc()
+	return bson.M{h.field: h.pick(rand.Float64())}
+}
+
+// pick returns hot document number drawn for value from [0, 1)
+func (h *HotSpot) pick(draw float64) uint64 {
+	if h.upTo == nil {
+		return uint64(draw * float64(h.size))
+	}
+	draw *= h.upTo[len(h.upTo)-1]
+	i := sort.Search(len(h.upTo), func(i int) bool { return draw < h.upTo[i] })
+	return uint64(min(i, len(h.upTo)-1))
+}
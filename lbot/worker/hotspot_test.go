@@ -0,0 +1,35 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestHotSpotPick(t *testing.T) {
+	uniform := NewHotSpot(&config.Job{Name: "uniform", HotFraction: 0.5, HotDocuments: 4})
+	assert.Equal(t, uint64(0), uniform.pick(0))
+	assert.Equal(t, uint64(1), uniform.pick(0.25))
+	assert.Equal(t, uint64(3), uniform.pick(0.99))
+
+	// weights 1, 1/2, 1/3, 1/4 of total 25/12
+	skewed := NewHotSpot(&config.Job{Name: "skewed", HotFraction: 0.5, HotDocuments: 4, HotSkew: 1})
+	assert.Equal(t, uint64(0), skewed.pick(0))
+	assert.Equal(t, uint64(0), skewed.pick(0.47))
+	assert.Equal(t, uint64(1), skewed.pick(0.49))
+	assert.Equal(t, uint64(2), skewed.pick(0.75))
+	assert.Equal(t, uint64(3), skewed.pick(0.99))
+}
+
+func TestHotSpotFilter(t *testing.T) {
+	fallback := func() interface{} { return bson.M{"cold": true} }
+
+	var disabled *HotSpot
+	assert.Nil(t, NewHotSpot(&config.Job{Name: "disabled"}))
+	assert.Equal(t, bson.M{"cold": true}, disabled.Filter(fallback))
+
+	hot := NewHotSpot(&config.Job{Name: "hot", HotFraction: 1, HotField: "account", HotDocuments: 1})
+	assert.Equal(t, bson.M{"account": uint64(0)}, hot.Filter(fallback))
+}
@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/database"
+	log "github.com/sirupsen/logrus"
+)
+
+// time limit of dropping indexes after job, job context is already cancelled then
+const DropIndexesTimeout = time.Minute
+
+// CreateIndexHandler builds job indexes, with drop_indexes they are dropped after every build,
+// so every operation measures whole index build, ex. while write jobs are running
+type CreateIndexHandler struct {
+	*BaseHandler
+}
+
+func (h *CreateIndexHandler) Execute(ctx context.Context) error {
+	if err := h.client.CreateIndexes(ctx, h.job.Indexes); err != nil {
+		return err
+	}
+	if h.job.DropIndexes {
+		for _, index := range h.job.Indexes {
+			if err := h.client.DropIndex(ctx, index.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// CreateIndexes builds job indexes on every job collection before job starts,
+// create_index job builds them as its operation instead
+func (w *Worker) CreateIndexes() error {
+	if len(w.job.Indexes) == 0 || w.job.Type == string(config.CreateIndex) {
+		return nil
+	}
+	start := time.Now()
+	for _, db := range w.indexedCollections() {
+		if err := db.CreateIndexes(w.ctx, w.job.Indexes); err != nil {
+			return fmt.Errorf("creating indexes failed: %w", err)
+		}
+	}
+	fmt.Printf("Created %d indexes in %s\n", len(w.job.Indexes), time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// DropIndexes drops job indexes after job finished when drop_indexes is set, failures are only logged
+func (w *Worker) DropIndexes() {
+	if !w.job.DropIndexes {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), DropIndexesTimeout)
+	defer cancel()
+	for _, db := range w.indexedCollections() {
+		for _, index := range w.job.Indexes {
+			if err := db.DropIndex(ctx, index.Name); err != nil && !database.IsIndexNotFound(err) {
+				log.WithField("run_id", w.job.RunId).Warnf("Job %s dropping index %s failed: %s", w.job.Name, index.Name, err)
+			}
+		}
+	}
+}
+
+func (w *Worker) indexedCollections() []database.Client {
+	if len(w.connections) > 0 {
+		return uniqueConnectionClients(w.connections)
+	}
+	if len(w.targets) == 0 {
+		return []database.Client{w.db}
+	}
+	clients := make([]database.Client, len(w.targets))
+	for i, target := range w.targets {
+		clients[i] = target.db
+	}
+	return clients
+}
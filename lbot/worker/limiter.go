@@ -1,11 +1,14 @@
 package worker
 
 import (
+	"context"
+	"math/rand"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/benbjohnson/clock"
+	"github.com/kuzxnia/loadbot/lbot/config"
 	"go.uber.org/ratelimit"
 )
 
@@ -22,6 +25,33 @@ func NewLimiter(rate uint64) Limiter {
 	}
 }
 
+// IntervalLimiter issues operations of single connection on fixed clock, one per interval,
+// ticks missed by busy connection are dropped instead of catching up
+type IntervalLimiter struct {
+	ctx    context.Context
+	ticker *time.Ticker
+}
+
+// NewIntervalLimiter returns limiter ticking every interval, waiting for tick ends when ctx is done
+func NewIntervalLimiter(ctx context.Context, interval time.Duration) *IntervalLimiter {
+	return &IntervalLimiter{ctx: ctx, ticker: time.NewTicker(interval)}
+}
+
+func (limiter *IntervalLimiter) Take() {
+	// stopped ticker never ticks again
+	select {
+	case <-limiter.ticker.C:
+	case <-limiter.ctx.Done():
+	}
+}
+
+// SetRate is noop, every connection issues operations with own interval
+func (*IntervalLimiter) SetRate(uint64) {}
+
+func (limiter *IntervalLimiter) Stop() {
+	limiter.ticker.Stop()
+}
+
 type NoLimitLimiter struct{}
 
 func (*NoLimitLimiter) Take()          {}
@@ -119,3 +149,173 @@ func (l *MutableBucketLeakingLimiter) SetRate(rate uint64) {
 	l.clock = clock.New()
 	l.state = 0
 }
+
+// TokenBucketLimiter issues operations at rate on average, bucket holds up to burst tokens
+// so after idle time up to burst operations are issued at once, like real clients catching up
+type TokenBucketLimiter struct {
+	ctx    context.Context
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64 // negative when operations wait for tokens
+	last   time.Time
+	clock  clock.Clock
+}
+
+// NewTokenBucketLimiter returns limiter with full bucket, burst is never lower than 1, waiting for tokens ends
+// when ctx is done
+func NewTokenBucketLimiter(ctx context.Context, rate uint64, burst uint64) *TokenBucketLimiter {
+	c := clock.New()
+	return &TokenBucketLimiter{
+		ctx:    ctx,
+		rate:   float64(max(1, rate)),
+		burst:  float64(max(1, burst)),
+		tokens: float64(max(1, burst)),
+		last:   c.Now(),
+		clock:  c,
+	}
+}
+
+func (l *TokenBucketLimiter) Take() {
+	wait := l.reserve(l.clock.Now())
+	if wait <= 0 {
+		return
+	}
+	// stopped job doesn't wait for tokens reserved far ahead
+	timer := l.clock.Timer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-l.ctx.Done():
+	}
+}
+
+// reserve takes token from bucket, returns time caller has to wait for it
+func (l *TokenBucketLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(now)
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+func (l *TokenBucketLimiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = min(l.burst, l.tokens+elapsed.Seconds()*l.rate)
+		l.last = now
+	}
+}
+
+// SetRate keeps tokens collected so far, new rate applies from now
+func (l *TokenBucketLimiter) SetRate(rate uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(l.clock.Now())
+	l.rate = float64(max(1, rate))
+}
+
+// interval of random pace changes of jittered limiter
+const JitterPeriod = 10 * time.Second
+
+// JitterLimiter changes rate of wrapped limiter every jitter period by random factor from [1-jitter, 1+jitter],
+// jittered rate never exceeds max rate (ex. tenant quota)
+type JitterLimiter struct {
+	limiter  Limiter
+	jitter   float64
+	headroom float64 // max rate as multiple of rate, rate split between agents keeps it, 0 without max rate
+	rate     atomic.Uint64
+	next     atomic.Int64 // unix nanoseconds of next rate change
+}
+
+// NewJitterLimiter jitters rate up to maxRate, 0 for no max rate
+func NewJitterLimiter(limiter Limiter, rate uint64, jitter float64, maxRate uint64) *JitterLimiter {
+	l := &JitterLimiter{limiter: limiter, jitter: jitter}
+	if maxRate > 0 && rate > 0 {
+		l.headroom = float64(maxRate) / float64(rate)
+	}
+	l.SetRate(rate)
+	return l
+}
+
+func (l *JitterLimiter) Take() {
+	now := time.Now().UnixNano()
+	next := l.next.Load()
+	if now >= next && l.next.CompareAndSwap(next, now+int64(JitterPeriod)) {
+		l.limiter.SetRate(l.jittered(l.rate.Load()))
+	}
+	l.limiter.Take()
+}
+
+func (l *JitterLimiter) SetRate(rate uint64) {
+	l.rate.Store(rate)
+	l.next.Store(time.Now().UnixNano() + int64(JitterPeriod))
+	l.limiter.SetRate(l.jittered(rate))
+}
+
+func (l *JitterLimiter) jittered(rate uint64) uint64 {
+	if l.headroom == 0 {
+		return JitteredRate(rate, l.jitter)
+	}
+	return min(JitteredRate(rate, l.jitter), max(1, uint64(float64(rate)*l.headroom)))
+}
+
+// JitteredRate returns rate randomly changed by up to jitter fraction, never lower than 1
+func JitteredRate(rate uint64, jitter float64) uint64 {
+	return max(1, uint64(float64(rate)*(1+jitter*(2*rand.Float64()-1))))
+}
+
+// interval of rate updates of ramp limiter
+const RampPeriod = time.Second
+
+// RampLimiter follows job rate profile, rate of wrapped limiter is updated every ramp period,
+// profile time is counted from first operation
+type RampLimiter struct {
+	limiter  Limiter
+	profile  config.RateProfile
+	agents   atomic.Uint64
+	start    atomic.Int64 // unix nanoseconds of first operation
+	next     atomic.Int64 // unix nanoseconds of next rate update
+	rate     atomic.Uint64
+	onChange func(rate uint64)
+}
+
+// NewRampLimiter returns limiter of profile split between running agents, onChange is called with every new rate
+func NewRampLimiter(profile config.RateProfile, runningAgents uint64, onChange func(rate uint64)) *RampLimiter {
+	l := &RampLimiter{profile: profile, onChange: onChange}
+	l.agents.Store(max(1, runningAgents))
+	l.limiter = NewMutableBucketLeakingLimiter(l.rateAt(0))
+	l.rate.Store(l.rateAt(0))
+	onChange(l.rateAt(0))
+	return l
+}
+
+func (l *RampLimiter) Take() {
+	now := time.Now().UnixNano()
+	l.start.CompareAndSwap(0, now)
+	next := l.next.Load()
+	if now >= next && l.next.CompareAndSwap(next, now+int64(RampPeriod)) {
+		// unchanged rate (hold and step stages) keeps limiter state
+		if rate := l.rateAt(time.Duration(now - l.start.Load())); l.rate.Swap(rate) != rate {
+			l.limiter.SetRate(rate)
+			l.onChange(rate)
+		}
+	}
+	l.limiter.Take()
+}
+
+// SetRate is noop, rate follows profile, see SetAgents
+func (*RampLimiter) SetRate(uint64) {}
+
+// SetAgents splits profile rate between running agents from next rate update
+func (l *RampLimiter) SetAgents(runningAgents uint64) {
+	l.agents.Store(max(1, runningAgents))
+	l.next.Store(0)
+}
+
+// rateAt returns rate of agent after elapsed time of profile, never lower than 1
+func (l *RampLimiter) rateAt(elapsed time.Duration) uint64 {
+	return max(1, l.profile.Rate(elapsed)/l.agents.Load())
+}
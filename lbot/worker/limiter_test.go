@@ -0,0 +1,88 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRampLimiter(t *testing.T) {
+	profile, _ := config.ParseRateProfile(100, []string{"1100 over 10m", "0 over 1m"})
+	var rates []uint64
+	limiter := NewRampLimiter(profile, 2, func(rate uint64) { rates = append(rates, rate) })
+	assert.Equal(t, []uint64{50}, rates)
+	assert.Equal(t, uint64(300), limiter.rateAt(5*time.Minute))
+	// rate never drops to zero
+	assert.Equal(t, uint64(1), limiter.rateAt(time.Hour))
+
+	// unchanged rate is not applied again
+	limiter.Take()
+	assert.Equal(t, []uint64{50}, rates)
+
+	limiter.SetAgents(1)
+	limiter.Take()
+	assert.Equal(t, []uint64{50, 100}, rates)
+}
+
+func TestTokenBucketLimiter(t *testing.T) {
+	limiter := NewTokenBucketLimiter(context.Background(), 10, 3)
+	now := limiter.last
+
+	// full bucket issues burst at once
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, time.Duration(0), limiter.reserve(now))
+	}
+	assert.Equal(t, 100*time.Millisecond, limiter.reserve(now))
+	assert.Equal(t, 200*time.Millisecond, limiter.reserve(now))
+
+	// idle time refills bucket up to burst only
+	now = now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, time.Duration(0), limiter.reserve(now))
+	}
+	assert.Equal(t, 100*time.Millisecond, limiter.reserve(now))
+
+	limiter.SetRate(20)
+	assert.Equal(t, 100*time.Millisecond, limiter.reserve(now))
+
+	// burst is never lower than 1
+	assert.Equal(t, time.Duration(0), NewTokenBucketLimiter(context.Background(), 10, 0).reserve(now.Add(time.Hour)))
+}
+
+func TestTokenBucketLimiterCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	limiter := NewTokenBucketLimiter(ctx, 1, 1)
+	limiter.Take()
+	// next token is reserved far ahead
+	limiter.reserve(limiter.clock.Now().Add(-time.Hour))
+	cancel()
+
+	startTime := time.Now()
+	limiter.Take()
+	assert.Less(t, time.Since(startTime), time.Second)
+}
+
+func TestIntervalLimiterCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	limiter := NewIntervalLimiter(ctx, time.Hour)
+	limiter.ticker.Stop()
+	cancel()
+
+	startTime := time.Now()
+	limiter.Take()
+	assert.Less(t, time.Since(startTime), time.Second)
+}
+
+func TestIntervalLimiterPerConnection(t *testing.T) {
+	job := &config.Job{Name: "interval", Type: string(config.Sleep), Connections: 3, PaceInterval: time.Hour}
+	worker, err := NewWorker(context.Background(), &config.Config{}, job, nil, 1)
+	assert.NoError(t, err)
+	defer worker.Close()
+
+	assert.Len(t, worker.intervals, 3)
+	assert.NotSame(t, worker.intervals[0], worker.intervals[1])
+	assert.InDelta(t, 3/time.Hour.Seconds(), worker.dispatch.Rate(), 1e-9)
+}
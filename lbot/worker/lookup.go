@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VictoriaMetrics/metrics"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const DefaultLookupLimit = 100
+
+// LookupHandler runs join-like aggregation, documents matched by filter are joined with $lookup stage
+type LookupHandler struct {
+	*BaseHandler
+	limit  int64
+	as     string
+	joined *metrics.Summary
+}
+
+func NewLookupHandler(handler *BaseHandler) *LookupHandler {
+	limit := handler.job.Limit
+	if limit == 0 {
+		limit = DefaultLookupLimit
+	}
+	as, _ := handler.job.Lookup["as"].(string)
+	return &LookupHandler{
+		BaseHandler: handler,
+		limit:       int64(limit),
+		as:          as,
+		joined:      jobSummary(handler.job, fmt.Sprintf(`lookup_joined_documents{job="%s",run_id="%s"}`, handler.job.Name, handler.job.RunId)),
+	}
+}
+
+func (h *LookupHandler) Execute(ctx context.Context) error {
+	filter := h.dataProvider.GetFilter()
+	if filter == nil {
+		filter = bson.M{}
+	}
+	pipeline := bson.A{
+		bson.M{"$match": filter},
+		bson.M{"$limit": h.limit},
+		bson.M{"$lookup": h.dataProvider.GetLookup()},
+	}
+
+	documents, error := h.client.Aggregate(ctx, pipeline)
+	if error != nil {
+		return error
+	}
+
+	joined := 0
+	for _, document := range documents {
+		if matched, ok := document[h.as].(bson.A); ok {
+			joined += len(matched)
+		}
+	}
+	h.joined.Update(float64(joined))
+	return nil
+}
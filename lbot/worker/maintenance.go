@@ -0,0 +1,75 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	log "github.com/sirupsen/logrus"
+)
+
+// MaintenanceScheduler pauses job for the time of its daily pause windows, so long soak tests can coexist
+// with nightly maintenance of shared cluster
+type MaintenanceScheduler struct {
+	job     *config.Job
+	gate    *PauseGate
+	windows []config.PauseWindow
+}
+
+// NewMaintenanceScheduler returns scheduler of job pause windows, malformed window is an error, zero window
+// would pause job forever
+func NewMaintenanceScheduler(job *config.Job, gate *PauseGate) (*MaintenanceScheduler, error) {
+	scheduler := &MaintenanceScheduler{job: job, gate: gate}
+	for _, window := range job.PauseWindows {
+		parsed, err := config.ParsePauseWindow(window)
+		if err != nil {
+			return nil, fmt.Errorf("job %s: %w", job.Name, err)
+		}
+		scheduler.windows = append(scheduler.windows, parsed)
+	}
+	return scheduler, nil
+}
+
+// Run pauses or resumes job at every window start or end until ctx is done
+func (s *MaintenanceScheduler) Run(ctx context.Context) {
+	runLog := log.WithField("run_id", s.job.RunId)
+	for {
+		now := time.Now()
+		if s.InWindow(now) {
+			if s.gate.Pause(PauseReasonMaintenance) {
+				runLog.Infof("Job %s paused for maintenance window", s.job.Name)
+			}
+		} else if s.gate.Resume(PauseReasonMaintenance) {
+			runLog.Infof("Job %s resumed after maintenance window", s.job.Name)
+		}
+
+		timer := time.NewTimer(s.NextChange(now).Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+func (s *MaintenanceScheduler) InWindow(t time.Time) bool {
+	for _, window := range s.windows {
+		if window.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextChange returns closest start or end of any window
+func (s *MaintenanceScheduler) NextChange(t time.Time) time.Time {
+	next := s.windows[0].NextChange(t)
+	for _, window := range s.windows[1:] {
+		if change := window.NextChange(t); change.Before(next) {
+			next = change
+		}
+	}
+	return next
+}
@@ -1,52 +1,160 @@
 package worker
 
 import (
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/VictoriaMetrics/metrics"
 	"github.com/google/uuid"
 	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/database"
 )
 
 type Metrics struct {
 	requests        *metrics.Counter
 	requestsError   *metrics.Counter
+	writeConflicts  *metrics.Counter
 	requestDuration *metrics.Summary
-	startTime       time.Time
+	requestLatency  LatencyHistogram
+	latencyTotal    atomic.Int64 // nanoseconds, used for average latency
+	startTime       atomic.Int64 // unix nanoseconds, reset while readers compute duration
+	warmup          time.Duration
+	latencyLog      *LatencyLog // nil when raw latencies are not saved
+	sla             *SLABuckets // nil when job has no sla_buckets
 	// ResponseSize    *metrics.Histogram
 }
 
 func NewMetrics(job *config.Job) *Metrics {
-	jobLabel := fmt.Sprintf(`{job="%s",job_uuid="%s",job_type="%s"}`, job.Name, uuid.New().String(), job.Type)
+	jobLabels := fmt.Sprintf(`job="%s",job_uuid="%s",job_type="%s",run_id="%s"`, job.Name, uuid.New().String(), job.Type, job.RunId)
+	if job.CacheMode != "" {
+		jobLabels += fmt.Sprintf(`,cache="%s"`, job.CacheMode)
+	}
+	jobLabel := "{" + jobLabels + "}"
 
 	return &Metrics{
-		requests:        metrics.NewCounter("requests_total" + jobLabel),
-		requestsError:   metrics.NewCounter("requests_error" + jobLabel),
-		requestDuration: metrics.NewSummary("requests_duration_seconds" + jobLabel),
+		requests:        jobCounter(job, "requests_total"+jobLabel),
+		requestsError:   jobCounter(job, "requests_error"+jobLabel),
+		writeConflicts:  jobCounter(job, "requests_write_conflicts"+jobLabel),
+		requestDuration: jobSummary(job, "requests_duration_seconds"+jobLabel),
+		requestLatency:  NewLatencyHistogram(job, "requests_latency_seconds", jobLabels),
+		sla:             NewSLABuckets(job),
+		warmup:          job.Warmup,
 		// ResponseSize:    metrics.NewHistogram("requests_size"),
 	}
 }
 
+// unexportedMetrics keeps job metrics filtered out by metrics_include, metrics_exclude and metrics_sample,
+// they are still updated for progress and summaries but never written to prometheus
+var unexportedMetrics = metrics.NewSet()
+
+// jobMetricsSet returns set job metric is registered in, name is metric name with labels
+func jobMetricsSet(job *config.Job, name string) *metrics.Set {
+	if isMetricExported(job, name) {
+		return metrics.GetDefaultSet()
+	}
+	return unexportedMetrics
+}
+
+// isMetricExported checks metric name against job metrics_include and metrics_exclude prefixes
+func isMetricExported(job *config.Job, name string) bool {
+	hasPrefix := func(prefix string) bool { return strings.HasPrefix(name, prefix) }
+	if len(job.MetricsInclude) > 0 && !slices.ContainsFunc(job.MetricsInclude, hasPrefix) {
+		return false
+	}
+	return !slices.ContainsFunc(job.MetricsExclude, hasPrefix)
+}
+
+// isCollectionSampled checks if per-collection metrics of collection are exported with job metrics_sample,
+// collection is sampled by its name hash so every agent exports the same collections
+func isCollectionSampled(job *config.Job, collection string) bool {
+	if job.MetricsSample <= 0 || job.MetricsSample >= 1 {
+		return true
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(collection))
+	return float64(hash.Sum32())/(1<<32) < job.MetricsSample
+}
+
+func jobCounter(job *config.Job, name string) *metrics.Counter {
+	return jobMetricsSet(job, name).GetOrCreateCounter(name)
+}
+
+func jobSummary(job *config.Job, name string) *metrics.Summary {
+	return jobMetricsSet(job, name).GetOrCreateSummary(name)
+}
+
+func jobGauge(job *config.Job, name string, f func() float64) *metrics.Gauge {
+	return jobMetricsSet(job, name).GetOrCreateGauge(name, f)
+}
+
+// Init starts measurement window, after warm-up when job has one, operations before start time are not recorded
 func (m *Metrics) Init() {
-	m.startTime = time.Now()
+	m.startTime.Store(time.Now().Add(m.warmup).UnixNano())
 }
 
+// IsWarmingUp checks if job is in warm-up, its operations are not metered
+func (m *Metrics) IsWarmingUp() bool {
+	return time.Now().UnixNano() < m.startTime.Load()
+}
+
+// Reset zeroes job counters and starts new measurement window, summaries keep samples of their sliding window
+// and histograms are not reset
+func (m *Metrics) Reset() {
+	m.requests.Set(0)
+	m.requestsError.Set(0)
+	m.writeConflicts.Set(0)
+	m.latencyTotal.Store(0)
+	if m.sla != nil {
+		m.sla.Reset()
+	}
+	// reset during warm-up doesn't end it
+	if startTime, now := m.startTime.Load(), time.Now().UnixNano(); now >= startTime {
+		m.startTime.CompareAndSwap(startTime, now)
+	}
+}
+
+// errNotMetered is returned by handler when operation had nothing to do, ex. change stream wait without event,
+// such operation is not metered
+var errNotMetered = errors.New("operation is not metered")
+
 func (m *Metrics) Meter(handler func() error) {
 	startTime := time.Now()
 
 	error := handler()
+	if errors.Is(error, errNotMetered) {
+		return
+	}
 
 	// todo: handle size
+	if m.latencyLog != nil {
+		m.latencyLog.Write(startTime, time.Since(startTime), error != nil)
+	}
 	m.requestDuration.UpdateDuration(startTime)
+	m.latencyTotal.Add(int64(time.Since(startTime)))
+	if m.requestLatency != nil {
+		m.requestLatency.UpdateDuration(startTime)
+	}
+	if m.sla != nil {
+		m.sla.Update(time.Since(startTime), error != nil)
+	}
 	m.requests.Inc()
 	if error != nil {
 		m.requestsError.Inc()
+		if database.IsWriteConflict(error) {
+			m.writeConflicts.Inc()
+		}
 	}
 }
 
 func (m *Metrics) Rps() uint64 {
-	duration := time.Since(m.startTime).Seconds()
+	duration := m.Duration().Seconds()
 	if duration == 0 {
 		return 0
 	}
@@ -61,6 +169,126 @@ func (m *Metrics) ErrorRate() float32 {
 	return float32(m.requestsError.Get()) / float32(m.requests.Get())
 }
 
+func (m *Metrics) AverageLatency() time.Duration {
+	requests := m.requests.Get()
+	if requests == 0 {
+		return 0
+	}
+	return time.Duration(uint64(m.latencyTotal.Load()) / requests)
+}
+
+// MetricsSnapshot is state of job counters at given time, difference of two snapshots describes time window
+type MetricsSnapshot struct {
+	Time         time.Time
+	Requests     uint64
+	Errors       uint64
+	LatencyTotal time.Duration
+}
+
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	return MetricsSnapshot{
+		Time:         time.Now(),
+		Requests:     m.requests.Get(),
+		Errors:       m.requestsError.Get(),
+		LatencyTotal: time.Duration(m.latencyTotal.Load()),
+	}
+}
+
+// Window describes requests between snapshots, ex. "120 rps, avg latency 3ms, 0 errors"
+func (s MetricsSnapshot) Window(end MetricsSnapshot) string {
+	requests := end.Requests - s.Requests
+	var rps float64
+	if seconds := end.Time.Sub(s.Time).Seconds(); seconds > 0 {
+		rps = float64(requests) / seconds
+	}
+	var latency time.Duration
+	if requests > 0 {
+		latency = (end.LatencyTotal - s.LatencyTotal) / time.Duration(requests)
+	}
+	return fmt.Sprintf("%.0f rps, avg latency %s, %d errors", rps, latency.Round(time.Microsecond), end.Errors-s.Errors)
+}
+
+// SLAReport returns fraction of operations in every SLA bucket, nil when job has no sla_buckets
+func (m *Metrics) SLAReport() []string {
+	if m.sla == nil {
+		return nil
+	}
+	return m.sla.Report()
+}
+
+func (m *Metrics) Errors() uint64 {
+	return m.requestsError.Get()
+}
+
+func (m *Metrics) WriteConflicts() uint64 {
+	return m.writeConflicts.Get()
+}
+
+func (m *Metrics) Duration() time.Duration {
+	return max(0, time.Since(time.Unix(0, m.startTime.Load())))
+}
+
 func (m *Metrics) DurationSeconds() uint64 {
-	return uint64(time.Since(m.startTime).Round(time.Second).Seconds())
+	return uint64(m.Duration().Round(time.Second).Seconds())
+}
+
+type LatencyHistogram interface {
+	UpdateDuration(time.Time)
+}
+
+// NewLatencyHistogram returns histogram configured in job, nil if job have no histogram
+func NewLatencyHistogram(job *config.Job, name string, labels string) LatencyHistogram {
+	if job.NativeHistogram {
+		// victoria metrics histogram with log-scaled vmrange buckets
+		return LatencyHistogram(jobMetricsSet(job, name).NewHistogram(name + "{" + labels + "}"))
+	} else if len(job.HistogramBuckets) > 0 {
+		return LatencyHistogram(NewBucketHistogram(jobMetricsSet(job, name), name, labels, job.HistogramBuckets))
+	}
+	return nil
+}
+
+// BucketHistogram is prometheus histogram with fixed, user defined bucket boundaries (in seconds)
+type BucketHistogram struct {
+	upperBounds []float64
+	buckets     []*metrics.Counter
+	sum         *metrics.FloatCounter
+	count       *metrics.Counter
+}
+
+func NewBucketHistogram(set *metrics.Set, name string, labels string, upperBounds []float64) *BucketHistogram {
+	upperBounds = slices.Clone(upperBounds)
+	sort.Float64s(upperBounds)
+	// duplicated bound would register the same bucket counter twice
+	upperBounds = slices.Compact(upperBounds)
+
+	histogram := &BucketHistogram{
+		upperBounds: upperBounds,
+		buckets:     make([]*metrics.Counter, len(upperBounds)+1),
+		sum:         set.NewFloatCounter(fmt.Sprintf("%s_sum{%s}", name, labels)),
+		count:       set.NewCounter(fmt.Sprintf("%s_count{%s}", name, labels)),
+	}
+	for i, upperBound := range upperBounds {
+		histogram.buckets[i] = set.NewCounter(
+			fmt.Sprintf(`%s_bucket{%s,le="%s"}`, name, labels, strconv.FormatFloat(upperBound, 'g', -1, 64)),
+		)
+	}
+	histogram.buckets[len(upperBounds)] = set.NewCounter(fmt.Sprintf(`%s_bucket{%s,le="+Inf"}`, name, labels))
+
+	return histogram
+}
+
+func (h *BucketHistogram) Update(value float64) {
+	// buckets are cumulative
+	for i, upperBound := range h.upperBounds {
+		if value <= upperBound {
+			h.buckets[i].Inc()
+		}
+	}
+	h.buckets[len(h.upperBounds)].Inc()
+	h.sum.Add(value)
+	h.count.Inc()
+}
+
+func (h *BucketHistogram) UpdateDuration(startTime time.Time) {
+	h.Update(time.Since(startTime).Seconds())
 }
@@ -0,0 +1,108 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsMetricExported(t *testing.T) {
+	assert.True(t, isMetricExported(&config.Job{}, `requests_total{job="all"}`))
+
+	job := &config.Job{MetricsInclude: []string{"requests_", "sla_"}, MetricsExclude: []string{"requests_write_conflicts"}}
+	assert.True(t, isMetricExported(job, `requests_total{job="filtered"}`))
+	assert.True(t, isMetricExported(job, `sla_operations_total{job="filtered",bucket="breach"}`))
+	assert.False(t, isMetricExported(job, `requests_write_conflicts{job="filtered"}`))
+	assert.False(t, isMetricExported(job, `collection_requests_total{job="filtered"}`))
+}
+
+func TestIsCollectionSampled(t *testing.T) {
+	assert.True(t, isCollectionSampled(&config.Job{}, "tenant_1"))
+
+	job := &config.Job{MetricsSample: 0.1}
+	sampled := 0
+	for i := 0; i < 1000; i++ {
+		if isCollectionSampled(job, fmt.Sprintf("tenant_%d", i)) {
+			sampled++
+		}
+	}
+	assert.InDelta(t, 100, sampled, 40)
+	// sampling is stable across calls and agents
+	assert.Equal(t, isCollectionSampled(job, "tenant_7"), isCollectionSampled(job, "tenant_7"))
+}
+
+func TestMetricsReset(t *testing.T) {
+	metrics := NewMetrics(&config.Job{Name: "reset", SlaBuckets: []float64{0.1}})
+	metrics.Init()
+	for i := 0; i < 3; i++ {
+		metrics.Meter(func() error { return errors.New("failed") })
+	}
+	assert.Equal(t, uint64(3), metrics.Requests())
+	assert.Equal(t, uint64(3), metrics.Errors())
+
+	metrics.Reset()
+	assert.Equal(t, uint64(0), metrics.Requests())
+	assert.Equal(t, uint64(0), metrics.Errors())
+	assert.Equal(t, time.Duration(0), metrics.AverageLatency())
+	assert.Less(t, metrics.Duration(), time.Second)
+	assert.Contains(t, metrics.SLAReport(), "breach: 0.00% (0 operations)")
+
+	metrics.Meter(func() error { return nil })
+	assert.Equal(t, uint64(1), metrics.Requests())
+	assert.Equal(t, uint64(0), metrics.Errors())
+}
+
+func TestMetricsNotMetered(t *testing.T) {
+	metrics := NewMetrics(&config.Job{Name: "not_metered"})
+	metrics.Init()
+	metrics.Meter(func() error { return errNotMetered })
+	metrics.Meter(func() error { return nil })
+	assert.Equal(t, uint64(1), metrics.Requests())
+	assert.Equal(t, uint64(0), metrics.Errors())
+}
+
+func TestMetricsResetWhileReading(t *testing.T) {
+	metrics := NewMetrics(&config.Job{Name: "reset_while_reading"})
+	metrics.Init()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			metrics.Reset()
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		assert.False(t, metrics.IsWarmingUp())
+		assert.GreaterOrEqual(t, metrics.Duration(), time.Duration(0))
+	}
+	<-done
+}
+
+func TestMetricsWarmup(t *testing.T) {
+	metrics := NewMetrics(&config.Job{Name: "warmup", Warmup: time.Hour})
+	metrics.Init()
+	assert.True(t, metrics.IsWarmingUp())
+	assert.Equal(t, time.Duration(0), metrics.Duration())
+	assert.Equal(t, uint64(0), metrics.DurationSeconds())
+	assert.Equal(t, uint64(0), metrics.Rps())
+
+	// reset doesn't end warm-up
+	metrics.Reset()
+	assert.True(t, metrics.IsWarmingUp())
+
+	metrics = NewMetrics(&config.Job{Name: "no warmup"})
+	metrics.Init()
+	assert.False(t, metrics.IsWarmingUp())
+}
+
+func TestBucketHistogramDuplicatedBounds(t *testing.T) {
+	// set panics when the same counter is registered twice
+	histogram := NewBucketHistogram(metrics.NewSet(), "latency", `job="duplicated"`, []float64{0.5, 0.1, 0.5})
+	assert.Equal(t, []float64{0.1, 0.5}, histogram.upperBounds)
+	assert.Len(t, histogram.buckets, 3)
+}
@@ -0,0 +1,69 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/database"
+	"github.com/kuzxnia/loadbot/lbot/schema"
+	"github.com/samber/lo"
+)
+
+// MixedHandler picks operation of every iteration from operation_mix weighted distribution,
+// ex. 70% reads, 20% updates and 10% inserts are modeled by single job
+type MixedHandler struct {
+	operations []*mixedOperation
+	total      float64
+}
+
+type mixedOperation struct {
+	name     string
+	upTo     float64 // cumulative weight
+	handler  JobHandler
+	executed *metrics.Counter
+}
+
+func NewMixedHandler(job *config.Job, client database.Client, dataPool schema.DataPool, s *config.Schema) (*MixedHandler, error) {
+	if err := job.ValidateOperationMix(); err != nil {
+		return nil, fmt.Errorf("job %s: %w", job.Name, err)
+	}
+	h := &MixedHandler{}
+	// sorted for stable distribution between runs
+	for _, name := range lo.Keys(job.OperationMix) {
+		h.operations = append(h.operations, &mixedOperation{name: name})
+	}
+	sort.Slice(h.operations, func(i, j int) bool { return h.operations[i].name < h.operations[j].name })
+
+	for _, operation := range h.operations {
+		// operations share job name and run id, their handler metrics are reported under mixed job
+		operationJob := *job
+		operationJob.Type = operation.name
+		h.total += job.OperationMix[operation.name]
+		operation.upTo = h.total
+		handler, err := NewJobHandler(&operationJob, client, dataPool, s)
+		if err != nil {
+			return nil, err
+		}
+		operation.handler = handler
+		operation.executed = jobCounter(job, fmt.Sprintf(
+			`mixed_operations_total{job="%s",run_id="%s",operation="%s"}`, job.Name, job.RunId, operation.name,
+		))
+	}
+	return h, nil
+}
+
+func (h *MixedHandler) Execute(ctx context.Context) error {
+	operation := h.pick(rand.Float64() * h.total)
+	operation.executed.Inc()
+	return operation.handler.Execute(ctx)
+}
+
+// pick returns operation drawn for value from [0, total)
+func (h *MixedHandler) pick(draw float64) *mixedOperation {
+	i := sort.Search(len(h.operations), func(i int) bool { return draw < h.operations[i].upTo })
+	return h.operations[min(i, len(h.operations)-1)]
+}
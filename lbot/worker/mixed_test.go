@@ -0,0 +1,41 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMixedHandlerPick(t *testing.T) {
+	job := &config.Job{
+		Name:         "mixed",
+		Type:         string(config.Mixed),
+		Database:     "db",
+		Collection:   "col",
+		OperationMix: map[string]float64{"read": 70, "update": 20, "write": 10},
+	}
+	h, err := NewMixedHandler(job, nil, nil, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 100.0, h.total)
+	assert.Equal(t, "read", h.pick(0).name)
+	assert.Equal(t, "read", h.pick(69.9).name)
+	assert.Equal(t, "update", h.pick(70).name)
+	assert.Equal(t, "update", h.pick(89.9).name)
+	assert.Equal(t, "write", h.pick(90).name)
+	assert.Equal(t, "write", h.pick(99.99).name)
+	assert.IsType(t, &UpdateHandler{}, h.pick(75).handler)
+}
+
+func TestNewMixedHandlerRejectsInvalidMix(t *testing.T) {
+	for name, mix := range map[string]map[string]float64{
+		"empty":   {},
+		"nested":  {"mixed": 1},
+		"unknown": {"upsert": 1},
+		"zero":    {"read": 0},
+	} {
+		_, err := NewMixedHandler(&config.Job{Name: name, Type: string(config.Mixed), OperationMix: mix}, nil, nil, nil)
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	DefaultNoisePace        = 10
+	DefaultNoisePaceJitter  = 0.2
+	DefaultNoiseCollections = 10
+	// operation mix of noise job, rest of operations are updates
+	NoiseReadRatio   = 0.6
+	NoiseInsertRatio = 0.25
+)
+
+// NoiseHandler keeps cluster realistically busy, every operation is random read, insert or update
+type NoiseHandler struct {
+	*BaseHandler
+}
+
+func (h *NoiseHandler) Execute(ctx context.Context) (err error) {
+	switch draw := rand.Float64(); {
+	case draw < NoiseReadRatio:
+		_, err = h.client.ReadOne(ctx, h.filter())
+	case draw < NoiseReadRatio+NoiseInsertRatio:
+		_, err = h.client.InsertOne(ctx, h.dataProvider.GetSingleItem())
+	default:
+		_, err = h.client.UpdateOne(ctx, h.filter(), bson.M{"$set": bson.M{"noise_updated_at": time.Now()}})
+	}
+	return
+}
+
+func (h *NoiseHandler) filter() interface{} {
+	if h.job.Filter == nil {
+		return bson.M{}
+	}
+	return h.dataProvider.GetFilter()
+}
+
+// applyNoiseDefaults sets low randomized pace and spreads noise job over collections named after job collection,
+// ex. noise_0, noise_1, ... when collections are not set
+func applyNoiseDefaults(job *config.Job) {
+	if job.Pace == 0 && job.PaceInterval == 0 {
+		job.Pace = DefaultNoisePace
+	}
+	if job.PaceJitter == 0 {
+		job.PaceJitter = DefaultNoisePaceJitter
+	}
+	if len(job.Collections) == 0 {
+		count := job.NoiseCollections
+		if count == 0 {
+			count = DefaultNoiseCollections
+		}
+		job.Collections = make([]string, count)
+		for i := range job.Collections {
+			job.Collections[i] = fmt.Sprintf("%s_%d", job.Collection, i)
+		}
+	}
+}
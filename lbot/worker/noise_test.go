@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJitteredRate(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		rate := JitteredRate(100, 0.2)
+		assert.GreaterOrEqual(t, rate, uint64(80))
+		assert.LessOrEqual(t, rate, uint64(120))
+	}
+	assert.Equal(t, uint64(1), JitteredRate(0, 0.5))
+}
+
+func TestApplyNoiseDefaults(t *testing.T) {
+	job := &config.Job{Type: string(config.Noise), Collection: "noise", NoiseCollections: 3}
+	applyNoiseDefaults(job)
+
+	assert.Equal(t, uint64(DefaultNoisePace), job.Pace)
+	assert.Equal(t, DefaultNoisePaceJitter, job.PaceJitter)
+	assert.Equal(t, []string{"noise_0", "noise_1", "noise_2"}, job.Collections)
+
+	job = &config.Job{Type: string(config.Noise), Pace: 50, Collections: []string{"a", "b"}}
+	applyNoiseDefaults(job)
+	assert.Equal(t, uint64(50), job.Pace)
+	assert.Equal(t, []string{"a", "b"}, job.Collections)
+}
+
+type rateRecorder struct{ rates []uint64 }
+
+func (r *rateRecorder) Take()               {}
+func (r *rateRecorder) SetRate(rate uint64) { r.rates = append(r.rates, rate) }
+
+func TestJitterLimiterTenantQuota(t *testing.T) {
+	// tenant max_pace 100 caps requested pace 500 of noise job
+	job := &config.Job{Type: string(config.Noise), Pace: 100, MaxPace: 100, PaceJitter: 0.5}
+	recorder := &rateRecorder{}
+	limiter := NewJitterLimiter(recorder, job.Pace/2, job.PaceJitter, job.MaxPace/2)
+	for i := 0; i < 100; i++ {
+		limiter.SetRate(job.Pace / 2)
+	}
+	assert.Less(t, lo.Min(recorder.rates), uint64(50))
+	assert.LessOrEqual(t, lo.Max(recorder.rates), uint64(50))
+
+	recorder = &rateRecorder{}
+	limiter = NewJitterLimiter(recorder, 100, 0.5, 0)
+	for i := 0; i < 100; i++ {
+		limiter.SetRate(100)
+	}
+	assert.Greater(t, lo.Max(recorder.rates), uint64(100))
+}
@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	DefaultPageSize = 100
+	DefaultPages    = 10
+)
+
+// PaginateHandler scans result set page by page, single operation is whole scan,
+// pages are sorted by _id and fetched with skip/limit or with range on last seen _id
+type PaginateHandler struct {
+	*BaseHandler
+	pagination string
+	pageSize   int64
+	pages      int64
+	fetched    *metrics.Counter
+}
+
+func NewPaginateHandler(handler *BaseHandler) *PaginateHandler {
+	pagination, pageSize, pages := handler.job.Pagination, handler.job.PageSize, handler.job.Pages
+	if pagination == "" {
+		pagination = config.PaginationRange
+	}
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+	if pages == 0 {
+		pages = DefaultPages
+	}
+	return &PaginateHandler{
+		BaseHandler: handler,
+		pagination:  pagination,
+		pageSize:    int64(pageSize),
+		pages:       int64(pages),
+		fetched: jobCounter(handler.job,
+			fmt.Sprintf(`pagination_pages_total{job="%s",run_id="%s",pagination="%s"}`, handler.job.Name, handler.job.RunId, pagination),
+		),
+	}
+}
+
+func (h *PaginateHandler) Execute(ctx context.Context) error {
+	filter := h.dataProvider.GetFilter()
+	if filter == nil {
+		filter = bson.M{}
+	}
+
+	var lastId interface{}
+	for page := int64(0); page < h.pages; page++ {
+		opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(h.pageSize)
+		pageFilter := filter
+
+		if h.pagination == config.PaginationSkip {
+			opts.SetSkip(page * h.pageSize)
+		} else if lastId != nil {
+			pageFilter = bson.M{"$and": bson.A{filter, bson.M{"_id": bson.M{"$gt": lastId}}}}
+		}
+
+		documents, error := h.client.Find(ctx, pageFilter, opts)
+		if error != nil {
+			return error
+		}
+		h.fetched.Inc()
+
+		if int64(len(documents)) < h.pageSize {
+			return nil
+		}
+		lastId = documents[len(documents)-1]["_id"]
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"context"
+	"sync"
+)
+
+// reasons job is paused for, job is resumed when all of them are gone
+const (
+	PauseReasonPreempted   = "preempted"
+	PauseReasonMaintenance = "maintenance"
+	PauseReasonBurst       = "burst"
+)
+
+// PauseGate blocks job operations while job is paused, ex. preempted by higher priority workload
+// or during maintenance window and between bursts
+type PauseGate struct {
+	mutex   sync.Mutex
+	reasons map[string]bool
+	resumed chan struct{}
+}
+
+// Pause closes gate for reason, returns false if job was already paused for it
+func (g *PauseGate) Pause(reason string) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.reasons[reason] {
+		return false
+	}
+	if len(g.reasons) == 0 {
+		g.reasons = map[string]bool{}
+		g.resumed = make(chan struct{})
+	}
+	g.reasons[reason] = true
+	return true
+}
+
+// Resume removes pause reason, gate is opened releasing waiting operations when no reason is left,
+// returns false if job was not paused for reason
+func (g *PauseGate) Resume(reason string) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.reasons[reason] {
+		return false
+	}
+	delete(g.reasons, reason)
+	if len(g.reasons) == 0 {
+		close(g.resumed)
+	}
+	return true
+}
+
+// ResumeAll opens gate regardless of pause reasons
+func (g *PauseGate) ResumeAll() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if len(g.reasons) > 0 {
+		g.reasons = nil
+		close(g.resumed)
+	}
+}
+
+func (g *PauseGate) IsPaused() bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return len(g.reasons) > 0
+}
+
+// Wait blocks until gate is opened or ctx is done
+func (g *PauseGate) Wait(ctx context.Context) {
+	g.mutex.Lock()
+	if len(g.reasons) == 0 {
+		g.mutex.Unlock()
+		return
+	}
+	resumed := g.resumed
+	g.mutex.Unlock()
+
+	select {
+	case <-resumed:
+	case <-ctx.Done():
+	}
+}
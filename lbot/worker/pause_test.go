@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPauseGate(t *testing.T) {
+	gate := &PauseGate{}
+	gate.Wait(context.Background())
+	assert.False(t, gate.Resume(PauseReasonPreempted))
+
+	assert.True(t, gate.Pause(PauseReasonPreempted))
+	assert.False(t, gate.Pause(PauseReasonPreempted))
+	assert.True(t, gate.Pause(PauseReasonMaintenance))
+	assert.True(t, gate.IsPaused())
+
+	released := make(chan struct{})
+	go func() {
+		gate.Wait(context.Background())
+		close(released)
+	}()
+	assert.True(t, gate.Resume(PauseReasonPreempted))
+	select {
+	case <-released:
+		t.Fatal("operation passed gate paused for maintenance")
+	case <-time.After(10 * time.Millisecond):
+	}
+	assert.True(t, gate.Resume(PauseReasonMaintenance))
+	<-released
+	assert.False(t, gate.IsPaused())
+
+	gate.Pause(PauseReasonPreempted)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	gate.Wait(ctx)
+
+	gate.ResumeAll()
+	assert.False(t, gate.IsPaused())
+	gate.Wait(context.Background())
+}
+
+func TestMaintenanceSchedulerMalformedWindow(t *testing.T) {
+	var gate PauseGate
+	_, err := NewMaintenanceScheduler(&config.Job{Name: "soak", PauseWindows: []string{"02:00-02:30", "2am"}}, &gate)
+	assert.ErrorContains(t, err, `pause window "2am"`)
+
+	scheduler, err := NewMaintenanceScheduler(&config.Job{Name: "soak", PauseWindows: []string{"02:00-02:30"}}, &gate)
+	assert.NoError(t, err)
+	assert.True(t, scheduler.InWindow(time.Date(2024, 5, 2, 2, 10, 0, 0, time.UTC)))
+}
+
+func TestNewWorkerUnwindsOnError(t *testing.T) {
+	job := &config.Job{
+		Name: "unwind", Type: string(config.Write), Database: "db", Collection: "c", Connections: 1, RunId: "run",
+		Pace: 100, RateProfile: []string{"hold 1m"}, PauseWindows: []string{"2am"},
+	}
+
+	_, err := NewWorker(context.Background(), &config.Config{}, job, nil, 1)
+	assert.Error(t, err)
+
+	names := metrics.GetDefaultSet().ListMetricNames()
+	assert.NotContains(t, names, `job_target_rps{job="unwind",run_id="run"}`)
+	assert.NotContains(t, names, `job_paused{job="unwind",run_id="run"}`)
+}
@@ -0,0 +1,75 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"plugin"
+	"sync"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/database"
+	"github.com/kuzxnia/loadbot/lbot/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+// PluginRegisterSymbol is function every agent plugin exports, it registers plugin job types with RegisterJobType:
+//
+//	func Register() error {
+//		return worker.RegisterJobType("refresh_cache", NewRefreshCacheHandler)
+//	}
+const PluginRegisterSymbol = "Register"
+
+// HandlerFactory builds handler of plugin job type, called for every job (and job collection) started by agent,
+// handler is executed with job scheduling, metrics and reports like built-in ones
+type HandlerFactory func(job *config.Job, client database.Client, dataProvider schema.DataProvider) JobHandler
+
+var (
+	pluginHandlersMutex sync.RWMutex
+	pluginHandlers      = map[string]HandlerFactory{}
+)
+
+// RegisterJobType registers handler of custom job type, built-in job types can't be overridden
+func RegisterJobType(jobType string, factory HandlerFactory) error {
+	if jobType == "" || factory == nil {
+		return errors.New("job type and handler factory are required")
+	}
+	if config.IsJobType(jobType) {
+		return fmt.Errorf("job type %s is already registered", jobType)
+	}
+	pluginHandlersMutex.Lock()
+	defer pluginHandlersMutex.Unlock()
+	pluginHandlers[jobType] = factory
+	config.RegisterPluginJobType(jobType)
+	return nil
+}
+
+func pluginHandler(jobType string) (HandlerFactory, bool) {
+	pluginHandlersMutex.RLock()
+	defer pluginHandlersMutex.RUnlock()
+	factory, ok := pluginHandlers[jobType]
+	return factory, ok
+}
+
+// LoadPlugins opens Go plugins (built with -buildmode=plugin against the same loadbot version) and calls
+// their Register function
+func LoadPlugins(paths []string) error {
+	for _, path := range paths {
+		p, err := plugin.Open(path)
+		if err != nil {
+			return fmt.Errorf("loading plugin %s failed: %w", path, err)
+		}
+		symbol, err := p.Lookup(PluginRegisterSymbol)
+		if err != nil {
+			return fmt.Errorf("plugin %s has no %s function: %w", path, PluginRegisterSymbol, err)
+		}
+		register, ok := symbol.(func() error)
+		if !ok {
+			return fmt.Errorf("plugin %s %s is not func() error", path, PluginRegisterSymbol)
+		}
+		if err = register(); err != nil {
+			return fmt.Errorf("registering plugin %s failed: %w", path, err)
+		}
+		log.Infof("Loaded plugin %s", path)
+	}
+	return nil
+}
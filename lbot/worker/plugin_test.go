@@ -0,0 +1,34 @@
+package worker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/database"
+	"github.com/kuzxnia/loadbot/lbot/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type pluginTestHandler struct{ job *config.Job }
+
+func (h *pluginTestHandler) Execute(context.Context) error { return nil }
+
+func TestRegisterJobType(t *testing.T) {
+	factory := func(job *config.Job, _ database.Client, _ schema.DataProvider) JobHandler {
+		return &pluginTestHandler{job: job}
+	}
+	assert.False(t, config.IsJobType("refresh_cache"))
+	assert.Nil(t, RegisterJobType("refresh_cache", factory))
+	assert.True(t, config.IsJobType("refresh_cache"))
+
+	job := &config.Job{Type: "refresh_cache"}
+	handler, err := NewJobHandler(job, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, job, handler.(*pluginTestHandler).job)
+
+	// built-in and already registered types can't be overridden
+	assert.Error(t, RegisterJobType(string(config.Write), factory))
+	assert.Error(t, RegisterJobType("refresh_cache", factory))
+	assert.Error(t, LoadPlugins([]string{"/nonexistent/plugin.so"}))
+}
@@ -8,40 +8,78 @@ import (
 	"github.com/kuzxnia/loadbot/lbot/config"
 )
 
+const (
+	StopReasonDuration              = "duration"
+	StopReasonOperations            = "operations"
+	StopReasonDurationAndOperations = "duration_and_operations"
+	StopReasonCancelled             = "cancelled"
+)
+
 type JobPool interface {
 	SpawnJob() bool
 	MarkJobDone()
 	Cancel()
 	GetRequestsStarted() uint64
 	GetRequestsDone() uint64
+	StopReason() string
 }
 
 func NewJobPool(cfg *config.Job) JobPool {
 	// todo: refactor this, add tracing
+	// warm-up is not counted to job duration
+	duration := cfg.Duration + cfg.Warmup
 	if cfg.Duration == 0 && cfg.Operations == 0 {
 		return JobPool(NewNoLimitTimerJobPool())
+	} else if cfg.Duration != 0 && cfg.Operations != 0 {
+		return JobPool(NewCombinedJobPool(duration, cfg.Operations, cfg.StopWhen == config.StopWhenAll))
 	} else if cfg.Duration != 0 {
-		return JobPool(NewTimerJobPool(cfg.Duration))
+		return JobPool(NewTimerJobPool(duration))
 	} else {
 		return JobPool(NewDeductionJobPool(cfg.Operations))
 	}
 }
 
+// poolStop closes pool once, remembering which condition stopped it
+type poolStop struct {
+	done   chan struct{}
+	close  sync.Once
+	reason atomic.Value
+}
+
+func newPoolStop() poolStop {
+	return poolStop{done: make(chan struct{})}
+}
+
+func (s *poolStop) stop(reason string) {
+	s.close.Do(func() {
+		s.reason.Store(reason)
+		close(s.done)
+	})
+}
+
+func (s *poolStop) Cancel() {
+	s.stop(StopReasonCancelled)
+}
+
+// StopReason returns condition which stopped pool, empty if pool is still running
+func (s *poolStop) StopReason() string {
+	reason, _ := s.reason.Load().(string)
+	return reason
+}
+
 type deductionJobPool struct {
+	poolStop
 	requestsStarted uint64
 	requestsDone    uint64
 	requestsNumber  uint64
-
-	done  chan struct{}
-	close sync.Once
 }
 
 func NewDeductionJobPool(requestsNumber uint64) JobPool {
 	pool := &deductionJobPool{
+		poolStop:        newPoolStop(),
 		requestsStarted: 0,
 		requestsDone:    0,
 		requestsNumber:  requestsNumber,
-		done:            make(chan struct{}),
 	}
 	return JobPool(pool)
 }
@@ -60,14 +98,10 @@ func (w *deductionJobPool) SpawnJob() bool {
 func (w *deductionJobPool) MarkJobDone() {
 	requestsDone := atomic.AddUint64(&w.requestsDone, 1)
 	if requestsDone == w.requestsNumber {
-		w.close.Do(func() { close(w.done) })
+		w.stop(StopReasonOperations)
 	}
 }
 
-func (w *deductionJobPool) Cancel() {
-	w.close.Do(func() { close(w.done) })
-}
-
 func (w *deductionJobPool) GetRequestsStarted() uint64 {
 	return atomic.LoadUint64(&w.requestsStarted)
 }
@@ -77,12 +111,10 @@ func (w *deductionJobPool) GetRequestsDone() uint64 {
 }
 
 type timerJobPool struct {
+	poolStop
 	duration        time.Duration
 	requestsStarted uint64
 	requestsDone    uint64
-
-	done  chan struct{}
-	close sync.Once
 }
 
 func NewTimerJobPool(duration time.Duration) JobPool {
@@ -91,14 +123,14 @@ func NewTimerJobPool(duration time.Duration) JobPool {
 	}
 
 	pool := &timerJobPool{
+		poolStop:        newPoolStop(),
 		requestsStarted: 0,
 		requestsDone:    0,
 		duration:        duration,
-		done:            make(chan struct{}),
 	}
 	go func() {
 		time.AfterFunc(duration, func() {
-			pool.Cancel()
+			pool.stop(StopReasonDuration)
 		})
 	}()
 	return JobPool(pool)
@@ -118,10 +150,6 @@ func (w *timerJobPool) MarkJobDone() {
 	atomic.AddUint64(&w.requestsDone, 1)
 }
 
-func (w *timerJobPool) Cancel() {
-	w.close.Do(func() { close(w.done) })
-}
-
 func (w *timerJobPool) GetRequestsStarted() uint64 {
 	return atomic.LoadUint64(&w.requestsStarted)
 }
@@ -130,18 +158,82 @@ func (w *timerJobPool) GetRequestsDone() uint64 {
 	return atomic.LoadUint64(&w.requestsDone)
 }
 
-type noLimitTimerJobPool struct {
+// combinedJobPool stops when duration elapses or operations are done, whichever comes first,
+// with requireAll it stops only when both conditions are met
+type combinedJobPool struct {
+	poolStop
+	requireAll      bool
 	requestsStarted uint64
 	requestsDone    uint64
+	requestsNumber  uint64
+	durationReached atomic.Bool
+}
+
+func NewCombinedJobPool(duration time.Duration, requestsNumber uint64, requireAll bool) JobPool {
+	if duration < 0 {
+		panic("duration must be positive")
+	}
+
+	pool := &combinedJobPool{
+		poolStop:       newPoolStop(),
+		requireAll:     requireAll,
+		requestsNumber: requestsNumber,
+	}
+	time.AfterFunc(duration, func() {
+		pool.durationReached.Store(true)
+		if !pool.requireAll {
+			pool.stop(StopReasonDuration)
+		} else if atomic.LoadUint64(&pool.requestsDone) >= pool.requestsNumber {
+			pool.stop(StopReasonDurationAndOperations)
+		}
+	})
+	return JobPool(pool)
+}
+
+func (w *combinedJobPool) SpawnJob() bool {
+	select {
+	case <-w.done:
+		return false
+	default:
+		requestsStarted := atomic.AddUint64(&w.requestsStarted, 1)
+		if !w.requireAll || w.durationReached.Load() {
+			return requestsStarted <= w.requestsNumber
+		}
+		// operations number is only lower bound until duration elapses
+		return true
+	}
+}
 
-	done  chan struct{}
-	close sync.Once
+func (w *combinedJobPool) MarkJobDone() {
+	requestsDone := atomic.AddUint64(&w.requestsDone, 1)
+	if requestsDone < w.requestsNumber {
+		return
+	}
+	if !w.requireAll {
+		w.stop(StopReasonOperations)
+	} else if w.durationReached.Load() {
+		w.stop(StopReasonDurationAndOperations)
+	}
+}
+
+func (w *combinedJobPool) GetRequestsStarted() uint64 {
+	return atomic.LoadUint64(&w.requestsStarted)
+}
+
+func (w *combinedJobPool) GetRequestsDone() uint64 {
+	return atomic.LoadUint64(&w.requestsDone)
+}
+
+type noLimitTimerJobPool struct {
+	poolStop
+	requestsStarted uint64
+	requestsDone    uint64
 }
 
 func NewNoLimitTimerJobPool() JobPool {
 	pool := &noLimitTimerJobPool{
+		poolStop:     newPoolStop(),
 		requestsDone: 0,
-		done:         make(chan struct{}),
 	}
 	return JobPool(pool)
 }
@@ -160,10 +252,6 @@ func (w *noLimitTimerJobPool) MarkJobDone() {
 	atomic.AddUint64(&w.requestsDone, 1)
 }
 
-func (w *noLimitTimerJobPool) Cancel() {
-	w.close.Do(func() { close(w.done) })
-}
-
 func (w *noLimitTimerJobPool) GetRequestsStarted() uint64 {
 	return atomic.LoadUint64(&w.requestsStarted)
 }
@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func drainPool(pool JobPool) {
+	for pool.SpawnJob() {
+		pool.MarkJobDone()
+	}
+}
+
+func TestCombinedJobPoolStopsOnOperationsFirst(t *testing.T) {
+	pool := NewCombinedJobPool(time.Hour, 10, false)
+
+	drainPool(pool)
+
+	assert.Equal(t, uint64(10), pool.GetRequestsDone())
+	assert.Equal(t, StopReasonOperations, pool.StopReason())
+}
+
+func TestCombinedJobPoolStopsOnDurationFirst(t *testing.T) {
+	pool := NewCombinedJobPool(10*time.Millisecond, 1000, false)
+
+	for pool.SpawnJob() {
+		time.Sleep(time.Millisecond)
+		pool.MarkJobDone()
+	}
+
+	assert.Less(t, pool.GetRequestsDone(), uint64(1000))
+	assert.Equal(t, StopReasonDuration, pool.StopReason())
+}
+
+func TestCombinedJobPoolRequiresBoth(t *testing.T) {
+	pool := NewCombinedJobPool(10*time.Millisecond, 10, true)
+	startTime := time.Now()
+
+	for pool.SpawnJob() {
+		time.Sleep(time.Millisecond)
+		pool.MarkJobDone()
+	}
+
+	assert.GreaterOrEqual(t, time.Since(startTime), 10*time.Millisecond)
+	assert.GreaterOrEqual(t, pool.GetRequestsDone(), uint64(10))
+	assert.Equal(t, StopReasonDurationAndOperations, pool.StopReason())
+}
+
+func TestJobPoolCancel(t *testing.T) {
+	pool := NewNoLimitTimerJobPool()
+	assert.Empty(t, pool.StopReason())
+
+	pool.Cancel()
+
+	assert.False(t, pool.SpawnJob())
+	assert.Equal(t, StopReasonCancelled, pool.StopReason())
+}
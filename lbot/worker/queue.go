@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	DefaultConsumerRatio = 0.5
+
+	QueueStatusNew     = "new"
+	QueueStatusClaimed = "claimed"
+)
+
+// QueueHandler simulates message queue on collection, producers insert messages
+// and consumers claim oldest new message with findOneAndUpdate on status field
+type QueueHandler struct {
+	*BaseHandler
+	consumerRatio float64
+	claimLatency  *metrics.Summary
+	emptyClaims   *metrics.Counter
+}
+
+func NewQueueHandler(handler *BaseHandler) *QueueHandler {
+	jobLabel := fmt.Sprintf(`{job="%s",run_id="%s"}`, handler.job.Name, handler.job.RunId)
+	consumerRatio := handler.job.ConsumerRatio
+	if consumerRatio == 0 {
+		consumerRatio = DefaultConsumerRatio
+	}
+	return &QueueHandler{
+		BaseHandler:   handler,
+		consumerRatio: consumerRatio,
+		claimLatency:  jobSummary(handler.job, "queue_claim_latency_seconds"+jobLabel),
+		emptyClaims:   jobCounter(handler.job, "queue_empty_claims_total"+jobLabel),
+	}
+}
+
+func (h *QueueHandler) Execute(ctx context.Context) error {
+	if rand.Float64() < h.consumerRatio {
+		return h.consume(ctx)
+	}
+	return h.produce(ctx)
+}
+
+func (h *QueueHandler) produce(ctx context.Context) error {
+	_, error := h.client.InsertOne(ctx, bson.M{
+		"status":      QueueStatusNew,
+		"enqueued_at": time.Now(),
+		"payload":     h.dataProvider.GetSingleItem(),
+	})
+	return error
+}
+
+func (h *QueueHandler) consume(ctx context.Context) error {
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "enqueued_at", Value: 1}}).
+		SetProjection(bson.M{"enqueued_at": 1})
+
+	claimedAt := time.Now()
+	message, error := h.client.FindOneAndUpdate(ctx,
+		bson.M{"status": QueueStatusNew},
+		bson.M{"$set": bson.M{"status": QueueStatusClaimed, "claimed_at": claimedAt}},
+		opts,
+	)
+	if error == mongo.ErrNoDocuments {
+		// consumers are faster than producers, it's not an operation error
+		h.emptyClaims.Inc()
+		return nil
+	} else if error != nil {
+		return error
+	}
+
+	if enqueuedAt, ok := message["enqueued_at"].(primitive.DateTime); ok {
+		h.claimLatency.Update(claimedAt.Sub(enqueuedAt.Time()).Seconds())
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package worker
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/samber/lo"
+)
+
+// AssignClientRegions returns client region of every job connection, regions get connections proportionally
+// to their weights and at least one each, nil when job has no client regions
+func AssignClientRegions(regions []*config.ClientRegion, connections uint64) []*config.ClientRegion {
+	if len(regions) == 0 || connections < uint64(len(regions)) {
+		return nil
+	}
+	var total float64
+	for _, region := range regions {
+		total += regionWeight(region)
+	}
+	// connections are split with largest remainder method, regions left without connection take one
+	// from region with most connections
+	counts := make([]uint64, len(regions))
+	remainders := make([]float64, len(regions))
+	assigned := uint64(0)
+	for i, region := range regions {
+		share := float64(connections) * regionWeight(region) / total
+		counts[i] = uint64(share)
+		remainders[i] = share - float64(counts[i])
+		assigned += counts[i]
+	}
+	for ; assigned < connections; assigned++ {
+		largest := 0
+		for i := range remainders {
+			if remainders[i] > remainders[largest] {
+				largest = i
+			}
+		}
+		counts[largest]++
+		remainders[largest] = -1
+	}
+	for i := range counts {
+		if counts[i] == 0 {
+			counts[lo.MaxBy(lo.Range(len(counts)), func(a, b int) bool { return counts[a] > counts[b] })]--
+			counts[i] = 1
+		}
+	}
+
+	assignment := make([]*config.ClientRegion, 0, connections)
+	for i, region := range regions {
+		for c := uint64(0); c < counts[i]; c++ {
+			assignment = append(assignment, region)
+		}
+	}
+	return assignment
+}
+
+func regionWeight(region *config.ClientRegion) float64 {
+	if region.Weight == 0 {
+		return 1
+	}
+	return region.Weight
+}
+
+// NetworkDelay returns simulated round trip of single operation of region, latency randomly changed by up to jitter
+func NetworkDelay(region *config.ClientRegion) time.Duration {
+	if region == nil {
+		return 0
+	}
+	delay := region.Latency.Duration
+	if region.Jitter.Duration > 0 {
+		delay += time.Duration(float64(region.Jitter.Duration) * (2*rand.Float64() - 1))
+	}
+	return max(0, delay)
+}
+
+// SimulateNetwork delays operation of connection from region by its network round trip
+func SimulateNetwork(ctx context.Context, region *config.ClientRegion) {
+	delay := NetworkDelay(region)
+	if delay == 0 {
+		return
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
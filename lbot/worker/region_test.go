@@ -0,0 +1,41 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssignClientRegions(t *testing.T) {
+	eu := &config.ClientRegion{Name: "eu", Weight: 3}
+	us := &config.ClientRegion{Name: "us"}
+	ap := &config.ClientRegion{Name: "ap"}
+
+	assignment := AssignClientRegions([]*config.ClientRegion{eu, us, ap}, 10)
+	assert.Len(t, assignment, 10)
+	counts := map[string]int{}
+	for _, region := range assignment {
+		counts[region.Name]++
+	}
+	assert.Equal(t, map[string]int{"eu": 6, "us": 2, "ap": 2}, counts)
+
+	// every region gets connection regardless of weight
+	assignment = AssignClientRegions([]*config.ClientRegion{eu, us, ap}, 3)
+	assert.Equal(t, []*config.ClientRegion{eu, us, ap}, assignment)
+
+	assert.Nil(t, AssignClientRegions(nil, 10))
+}
+
+func TestNetworkDelay(t *testing.T) {
+	assert.Equal(t, time.Duration(0), NetworkDelay(nil))
+	assert.Equal(t, 80*time.Millisecond, NetworkDelay(&config.ClientRegion{Latency: config.Duration{Duration: 80 * time.Millisecond}}))
+
+	region := &config.ClientRegion{Latency: config.Duration{Duration: 80 * time.Millisecond}, Jitter: config.Duration{Duration: 10 * time.Millisecond}}
+	for i := 0; i < 100; i++ {
+		delay := NetworkDelay(region)
+		assert.GreaterOrEqual(t, delay, 70*time.Millisecond)
+		assert.LessOrEqual(t, delay, 90*time.Millisecond)
+	}
+}
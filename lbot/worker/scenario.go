@@ -0,0 +1,210 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/schema"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ScenarioHandler runs steps of scenario job in order as single operation, so user flows (ex. sign up,
+// read profile back, update it) are modeled instead of isolated operations, documents inserted and read
+// by steps are shared with following steps through "@step.field" values of filter and update
+type ScenarioHandler struct {
+	steps []*scenarioStep
+}
+
+type scenarioStep struct {
+	*BaseHandler
+	step       *config.ScenarioStep
+	references []string // steps whose documents are used by this one
+	executed   *metrics.Counter
+	errors     *metrics.Counter
+	duration   *metrics.Summary
+}
+
+func NewScenarioHandler(handler *BaseHandler, s *config.Schema) *ScenarioHandler {
+	h := &ScenarioHandler{}
+	for _, step := range handler.job.Steps {
+		// steps share job name and run id, their templates replace job filter and update
+		stepJob := *handler.job
+		stepJob.Filter = step.Filter
+		stepJob.Update = step.Update
+		labels := fmt.Sprintf(`{job="%s",run_id="%s",step="%s"}`, handler.job.Name, handler.job.RunId, step.Name)
+		h.steps = append(h.steps, &scenarioStep{
+			BaseHandler: &BaseHandler{
+				job:          &stepJob,
+				client:       handler.client,
+				dataProvider: schema.NewDataProvider(&stepJob, s),
+				dataPool:     handler.dataPool,
+			},
+			step:       step,
+			references: lo.Uniq(append(config.ScenarioReferences(step.Filter), config.ScenarioReferences(step.Update)...)),
+			executed:   jobCounter(handler.job, "scenario_steps_total"+labels),
+			errors:     jobCounter(handler.job, "scenario_step_errors_total"+labels),
+			duration:   jobSummary(handler.job, "scenario_step_duration_seconds"+labels),
+		})
+	}
+	return h
+}
+
+func (h *ScenarioHandler) Execute(ctx context.Context) error {
+	documents := map[string]interface{}{}
+	for _, step := range h.steps {
+		if !step.runs(rand.Float64(), documents) {
+			continue
+		}
+		start := time.Now()
+		document, err := step.execute(ctx, documents)
+		step.duration.UpdateDuration(start)
+		step.executed.Inc()
+		if err != nil {
+			step.errors.Inc()
+			return fmt.Errorf("scenario step %s failed: %w", step.step.Name, err)
+		}
+		if document != nil {
+			documents[step.step.Name] = document
+		}
+	}
+	return nil
+}
+
+// runs checks if step is drawn with its weight, steps using documents of skipped steps are skipped as well
+func (s *scenarioStep) runs(draw float64, documents map[string]interface{}) bool {
+	if s.step.Weight > 0 && draw >= s.step.Weight {
+		return false
+	}
+	return lo.EveryBy(s.references, func(step string) bool { return documents[step] != nil })
+}
+
+// execute runs step operation, returns document inserted or read by step
+func (s *scenarioStep) execute(ctx context.Context, documents map[string]interface{}) (interface{}, error) {
+	switch s.step.Type {
+	case config.ScenarioStepInsert:
+		item := withObjectId(s.dataProvider.GetSingleItem())
+		if _, err := s.client.InsertOne(ctx, item); err != nil {
+			return nil, err
+		}
+		if s.dataPool != nil {
+			s.dataPool.Set(item)
+		}
+		return item, nil
+	case config.ScenarioStepRead:
+		filter, err := resolveScenarioReferences(s.step.Filter, documents)
+		if err != nil {
+			return nil, err
+		}
+		found, err := s.client.Find(ctx, filter, options.Find().SetLimit(1))
+		if err != nil {
+			return nil, err
+		}
+		if len(found) == 0 {
+			return nil, mongo.ErrNoDocuments
+		}
+		return found[0], nil
+	case config.ScenarioStepUpdate:
+		filter, err := resolveScenarioReferences(s.step.Filter, documents)
+		if err != nil {
+			return nil, err
+		}
+		update := s.getUpdate()
+		if len(s.step.Update) > 0 {
+			if update, err = resolveScenarioReferences(s.step.Update, documents); err != nil {
+				return nil, err
+			}
+		}
+		_, err = s.client.UpdateOne(ctx, filter, update)
+		return nil, err
+	case config.ScenarioStepDelete:
+		filter, err := resolveScenarioReferences(s.step.Filter, documents)
+		if err != nil {
+			return nil, err
+		}
+		_, err = s.client.DeleteOne(ctx, filter)
+		return nil, err
+	default:
+		return nil, fmt.Errorf("invalid scenario step type: %s", s.step.Type)
+	}
+}
+
+// withObjectId sets _id of generated document when schema doesn't generate it, so following steps can reference it
+func withObjectId(item interface{}) interface{} {
+	if document, ok := item.(*bson.M); ok {
+		item = map[string]interface{}(*document)
+	}
+	if document, ok := item.(map[string]interface{}); ok {
+		if _, ok := document["_id"]; !ok {
+			document["_id"] = primitive.NewObjectID()
+		}
+	}
+	return item
+}
+
+// resolveScenarioReferences replaces "@step.field" values of step template with fields of step documents and
+// generates its "#…" placeholders, references are resolved before generation so they never get generated
+func resolveScenarioReferences(template interface{}, documents map[string]interface{}) (interface{}, error) {
+	switch value := template.(type) {
+	case string:
+		if reference, ok := config.ParseScenarioReference(value); ok {
+			return scenarioField(documents[reference.Step], reference.Field)
+		}
+		if strings.HasPrefix(value, "#") {
+			return schema.DefaultGeneratorFieldMapper.Generate(value)
+		}
+		return value, nil
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, nested := range value {
+			resolved, err := resolveScenarioReferences(nested, documents)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = resolved
+		}
+		return result, nil
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for key, nested := range value {
+			resolved, err := resolveScenarioReferences(nested, documents)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = resolved
+		}
+		return result, nil
+	default:
+		return value, nil
+	}
+}
+
+// scenarioField returns field of document under dotted path, documents are generated maps or read bson.M
+func scenarioField(document interface{}, path string) (interface{}, error) {
+	value := document
+	for _, key := range strings.Split(path, ".") {
+		var fields map[string]interface{}
+		switch typed := value.(type) {
+		case map[string]interface{}:
+			fields = typed
+		case bson.M:
+			fields = typed
+		case *bson.M:
+			fields = *typed
+		}
+		field, ok := fields[key]
+		if !ok {
+			return nil, fmt.Errorf("document has no field %s", path)
+		}
+		value = field
+	}
+	return value, nil
+}
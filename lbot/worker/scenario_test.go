@@ -0,0 +1,60 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestResolveScenarioReferences(t *testing.T) {
+	documents := map[string]interface{}{
+		"signup":  map[string]interface{}{"_id": 7, "profile": map[string]interface{}{"email": "a@b.c"}},
+		"profile": bson.M{"visits": int32(3)},
+	}
+	template := map[string]interface{}{
+		"_id":    "@signup._id",
+		"email":  "@signup.profile.email",
+		"visits": map[string]interface{}{"$gte": "@profile.visits"},
+		"tags":   []interface{}{"@signup._id", "plain"},
+	}
+
+	resolved, err := resolveScenarioReferences(template, documents)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"_id":    7,
+		"email":  "a@b.c",
+		"visits": map[string]interface{}{"$gte": int32(3)},
+		"tags":   []interface{}{7, "plain"},
+	}, resolved)
+
+	// placeholders are generated after references are resolved
+	resolved, err = resolveScenarioReferences(map[string]interface{}{"_id": "@signup._id", "name": "#word"}, documents)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, resolved.(map[string]interface{})["_id"])
+	assert.NotEqual(t, "#word", resolved.(map[string]interface{})["name"])
+
+	_, err = resolveScenarioReferences(map[string]interface{}{"x": "@signup.missing"}, documents)
+	assert.ErrorContains(t, err, "document has no field missing")
+}
+
+func TestScenarioStepRuns(t *testing.T) {
+	step := &scenarioStep{step: &config.ScenarioStep{Name: "update", Weight: 0.3}, references: []string{"read"}}
+	documents := map[string]interface{}{"read": bson.M{"_id": 1}}
+
+	assert.True(t, step.runs(0.1, documents))
+	assert.False(t, step.runs(0.3, documents))
+	assert.False(t, step.runs(0.1, map[string]interface{}{}), "referenced step was skipped")
+
+	step.step.Weight = 0
+	assert.True(t, step.runs(0.99, documents), "steps without weight always run")
+}
+
+func TestWithObjectId(t *testing.T) {
+	item := withObjectId(&bson.M{"data": "x"}).(map[string]interface{})
+	assert.NotNil(t, item["_id"])
+
+	item = withObjectId(map[string]interface{}{"_id": 1}).(map[string]interface{})
+	assert.Equal(t, 1, item["_id"])
+}
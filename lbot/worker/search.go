@@ -0,0 +1,112 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	DefaultSearchLimit = 100
+	DefaultGeoDistance = 1000    // meters
+	earthRadius        = 6378100 // meters, used by $centerSphere radius in radians
+)
+
+// TextSearchHandler runs $text query with generated search, results are sorted by text score like in search
+// applications, requires text index on collection
+type TextSearchHandler struct {
+	*BaseHandler
+	returned *metrics.Summary
+}
+
+func NewTextSearchHandler(handler *BaseHandler) *TextSearchHandler {
+	return &TextSearchHandler{
+		BaseHandler: handler,
+		returned:    jobSummary(handler.job, fmt.Sprintf(`text_search_returned_documents{job="%s",run_id="%s"}`, handler.job.Name, handler.job.RunId)),
+	}
+}
+
+func (h *TextSearchHandler) Execute(ctx context.Context) error {
+	filter := h.searchFilter()
+	filter["$text"] = bson.M{"$search": h.dataProvider.GetSearch()}
+	score := bson.M{"score": bson.M{"$meta": "textScore"}}
+	opts := options.Find().SetProjection(score).SetSort(score).SetLimit(h.searchLimit())
+
+	documents, error := h.client.Find(ctx, filter, opts)
+	if error != nil {
+		return error
+	}
+	h.returned.Update(float64(len(documents)))
+	return nil
+}
+
+// GeoHandler runs $near (nearest documents first) or $geoWithin (documents within circle) query around
+// generated point on geo_field, $near requires 2dsphere index
+type GeoHandler struct {
+	*BaseHandler
+	distance float64
+	returned *metrics.Summary
+}
+
+func NewGeoHandler(handler *BaseHandler) *GeoHandler {
+	distance := handler.job.GeoDistance
+	if distance == 0 {
+		distance = DefaultGeoDistance
+	}
+	return &GeoHandler{
+		BaseHandler: handler,
+		distance:    distance,
+		returned:    jobSummary(handler.job, fmt.Sprintf(`geo_returned_documents{job="%s",run_id="%s",job_type="%s"}`, handler.job.Name, handler.job.RunId, handler.job.Type)),
+	}
+}
+
+func (h *GeoHandler) Execute(ctx context.Context) error {
+	point := h.dataProvider.GetGeoPoint()
+
+	filter := h.searchFilter()
+	if h.job.Type == string(config.GeoNear) {
+		filter[h.job.GeoField] = bson.M{"$near": bson.M{"$geometry": point, "$maxDistance": h.distance}}
+	} else {
+		var coordinates interface{}
+		if geometry, ok := point.(bson.D); ok {
+			for _, element := range geometry {
+				if element.Key == "coordinates" {
+					coordinates = element.Value
+				}
+			}
+		}
+		filter[h.job.GeoField] = bson.M{"$geoWithin": bson.M{"$centerSphere": bson.A{coordinates, h.distance / earthRadius}}}
+	}
+
+	documents, error := h.client.Find(ctx, filter, options.Find().SetLimit(h.searchLimit()))
+	if error != nil {
+		return error
+	}
+	h.returned.Update(float64(len(documents)))
+	return nil
+}
+
+// searchFilter returns generated job filter, search and geo conditions are added to it
+func (h *BaseHandler) searchFilter() bson.M {
+	filter := bson.M{}
+	if h.job.Filter == nil {
+		return filter
+	}
+	if generated, ok := h.dataProvider.GetFilter().(map[string]interface{}); ok {
+		for key, value := range generated {
+			filter[key] = value
+		}
+	}
+	return filter
+}
+
+func (h *BaseHandler) searchLimit() int64 {
+	if h.job.Limit == 0 {
+		return DefaultSearchLimit
+	}
+	return int64(h.job.Limit)
+}
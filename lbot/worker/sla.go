@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/kuzxnia/loadbot/lbot/config"
+)
+
+const SLABreach = "breach"
+
+// SLABuckets counts operations by the lowest sla_buckets threshold their latency is within,
+// operations above all thresholds and failed operations are counted as breach
+type SLABuckets struct {
+	upperBounds []time.Duration
+	names       []string
+	counters    []*metrics.Counter // non-cumulative, last one is breach
+}
+
+// NewSLABuckets returns nil for jobs without sla_buckets
+func NewSLABuckets(job *config.Job) *SLABuckets {
+	if len(job.SlaBuckets) == 0 {
+		return nil
+	}
+	buckets := &SLABuckets{}
+	for _, bound := range job.SlaBuckets {
+		upperBound := time.Duration(bound * float64(time.Second))
+		buckets.upperBounds = append(buckets.upperBounds, upperBound)
+		buckets.names = append(buckets.names, "<"+upperBound.String())
+	}
+	buckets.names = append(buckets.names, SLABreach)
+	for _, name := range buckets.names {
+		buckets.counters = append(buckets.counters, jobCounter(job,
+			fmt.Sprintf(`sla_operations_total{job="%s",run_id="%s",bucket="%s"}`, job.Name, job.RunId, name),
+		))
+	}
+	return buckets
+}
+
+func (b *SLABuckets) Update(latency time.Duration, failed bool) {
+	b.counters[b.bucket(latency, failed)].Inc()
+}
+
+func (b *SLABuckets) Reset() {
+	for _, counter := range b.counters {
+		counter.Set(0)
+	}
+}
+
+func (b *SLABuckets) bucket(latency time.Duration, failed bool) int {
+	if !failed {
+		for i, upperBound := range b.upperBounds {
+			if latency < upperBound {
+				return i
+			}
+		}
+	}
+	return len(b.upperBounds)
+}
+
+// Report returns fraction of operations in every bucket, ex. "<10ms: 92.41% (9241 operations)"
+func (b *SLABuckets) Report() []string {
+	var total uint64
+	for _, counter := range b.counters {
+		total += counter.Get()
+	}
+	report := make([]string, 0, len(b.counters))
+	for i, counter := range b.counters {
+		var fraction float64
+		if total > 0 {
+			fraction = float64(counter.Get()) / float64(total) * 100
+		}
+		report = append(report, fmt.Sprintf("%s: %.2f%% (%d operations)", b.names[i], fraction, counter.Get()))
+	}
+	return report
+}
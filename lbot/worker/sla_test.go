@@ -0,0 +1,30 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSLABuckets(t *testing.T) {
+	assert.Nil(t, NewSLABuckets(&config.Job{Name: "no sla"}))
+
+	buckets := NewSLABuckets(&config.Job{Name: "sla", RunId: "sla-test", SlaBuckets: []float64{0.01, 0.05, 0.2}})
+	assert.Equal(t, 0, buckets.bucket(5*time.Millisecond, false))
+	assert.Equal(t, 1, buckets.bucket(10*time.Millisecond, false))
+	assert.Equal(t, 2, buckets.bucket(199*time.Millisecond, false))
+	assert.Equal(t, 3, buckets.bucket(time.Second, false))
+	assert.Equal(t, 3, buckets.bucket(time.Millisecond, true))
+
+	for _, latency := range []time.Duration{time.Millisecond, 2 * time.Millisecond, 3 * time.Millisecond, 20 * time.Millisecond} {
+		buckets.Update(latency, false)
+	}
+	assert.Equal(t, []string{
+		"<10ms: 75.00% (3 operations)",
+		"<50ms: 25.00% (1 operations)",
+		"<200ms: 0.00% (0 operations)",
+		"breach: 0.00% (0 operations)",
+	}, buckets.Report())
+}
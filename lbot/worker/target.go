@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/database"
+	"github.com/kuzxnia/loadbot/lbot/schema"
+)
+
+// ConnectionTarget is namespace of single job connection, used when job database or collection is template,
+// ex. "tenant_{{.WorkerId}}" for collection-per-customer patterns
+type ConnectionTarget struct {
+	Namespace string
+	db        database.Client
+	handler   JobHandler
+}
+
+// NewConnectionTargets renders job target for every connection, connections with equal namespace share it
+func NewConnectionTargets(job *config.Job, db *database.MongoClient, dataPool schema.DataPool, jobSchema *config.Schema) ([]*ConnectionTarget, error) {
+	targets := make([]*ConnectionTarget, job.Connections)
+	for i := range targets {
+		databaseName, collectionName, err := job.Target(jobSchema, i)
+		if err != nil {
+			return nil, err
+		}
+		client := db.WithNamespace(databaseName, collectionName)
+		handler, err := NewJobHandler(job, client, dataPool, jobSchema)
+		if err != nil {
+			return nil, err
+		}
+		targets[i] = &ConnectionTarget{
+			Namespace: databaseName + "." + collectionName,
+			db:        client,
+			handler:   handler,
+		}
+	}
+	return targets, nil
+}
+
+// uniqueConnectionClients returns one client per distinct namespace of connections
+func uniqueConnectionClients(connections []*ConnectionTarget) []database.Client {
+	seen := make(map[string]bool, len(connections))
+	clients := make([]database.Client, 0, len(connections))
+	for _, target := range connections {
+		if !seen[target.Namespace] {
+			seen[target.Namespace] = true
+			clients = append(clients, target.db)
+		}
+	}
+	return clients
+}
@@ -0,0 +1,16 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniqueConnectionClients(t *testing.T) {
+	connections := []*ConnectionTarget{
+		{Namespace: "shop.tenant_0"},
+		{Namespace: "shop.tenant_1"},
+		{Namespace: "shop.tenant_0"},
+	}
+	assert.Len(t, uniqueConnectionClients(connections), 2)
+}
@@ -0,0 +1,97 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/database"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const DefaultTransactionRetries = 3
+
+// TransactionHandler runs transaction_steps in single multi-document transaction,
+// transactions aborted with transient error are retried up to transaction_retries times
+type TransactionHandler struct {
+	*BaseHandler
+	opts       *options.TransactionOptions
+	maxRetries int
+	commits    *metrics.Counter
+	aborts     *metrics.Counter
+	retries    *metrics.Counter
+}
+
+func NewTransactionHandler(handler *BaseHandler) *TransactionHandler {
+	maxRetries := DefaultTransactionRetries
+	if handler.job.TransactionRetries > 0 {
+		maxRetries = int(handler.job.TransactionRetries)
+	}
+	labels := fmt.Sprintf(`{job="%s",run_id="%s"}`, handler.job.Name, handler.job.RunId)
+	return &TransactionHandler{
+		BaseHandler: handler,
+		opts:        database.TransactionOptions(handler.job),
+		maxRetries:  maxRetries,
+		commits:     jobCounter(handler.job, "transaction_commits_total"+labels),
+		aborts:      jobCounter(handler.job, "transaction_aborts_total"+labels),
+		retries:     jobCounter(handler.job, "transaction_retries_total"+labels),
+	}
+}
+
+func (h *TransactionHandler) Execute(ctx context.Context) error {
+	var inserted []interface{}
+	result, error := h.client.Transaction(ctx, h.opts, h.maxRetries, func(ctx context.Context) error {
+		inserted = inserted[:0]
+		for _, step := range h.job.TransactionSteps {
+			item, err := h.executeStep(ctx, step)
+			if err != nil {
+				return err
+			}
+			if item != nil {
+				inserted = append(inserted, item)
+			}
+		}
+		return nil
+	})
+
+	// every retry means previous attempt was aborted
+	h.retries.Add(result.Retries)
+	h.aborts.Add(result.Retries)
+	if error != nil {
+		h.aborts.Inc()
+		return error
+	}
+	h.commits.Inc()
+
+	if h.dataPool != nil {
+		for _, item := range inserted {
+			h.dataPool.Set(item)
+		}
+	}
+	return nil
+}
+
+// executeStep runs single transaction operation, returns inserted document,
+// read not matching any document doesn't abort transaction
+func (h *TransactionHandler) executeStep(ctx context.Context, step string) (interface{}, error) {
+	switch step {
+	case config.TransactionStepInsert:
+		item := h.dataProvider.GetSingleItem()
+		_, err := h.client.InsertOne(ctx, item)
+		return item, err
+	case config.TransactionStepUpdate:
+		_, err := h.client.UpdateOne(ctx, h.dataProvider.GetFilter(), h.getUpdate())
+		return nil, err
+	case config.TransactionStepRead:
+		_, err := h.client.ReadOne(ctx, h.dataProvider.GetFilter())
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = nil
+		}
+		return nil, err
+	default:
+		return nil, fmt.Errorf("invalid transaction step: %s", step)
+	}
+}
@@ -2,44 +2,122 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/kuzxnia/loadbot/lbot/config"
+	"github.com/kuzxnia/loadbot/lbot/cost"
 	"github.com/kuzxnia/loadbot/lbot/database"
 	"github.com/kuzxnia/loadbot/lbot/schema"
 	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
+// BackgroundTask runs alongside job operations until ctx is done, ex. explain sampling or DDL disturbance
+type BackgroundTask interface {
+	Run(context.Context)
+}
+
+// number of generated filters explained before job with expected index starts
+const ExplainSamples = 5
+
 // todo: split this function to setup and to starting workers
 type Worker struct {
 	Metrics     *Metrics
 	ctx         context.Context
+	cancel      context.CancelFunc
+	inFlight    int64
 	cfg         *config.Config
 	job         *config.Job
 	wg          sync.WaitGroup
 	db          database.Client
 	handler     JobHandler
 	rateLimiter Limiter
+	ramp        *RampLimiter // set when job has rate profile
 	pool        JobPool
 	dataPool    schema.DataPool
-	ticker      *time.Ticker
+	intervals   []*IntervalLimiter // limiter of every connection, set when job has pace_interval
 	done        bool
+	workloadId  string
+	commandId   string
+	sampler     *PlanSampler
+	disturber   *Disturber
+	background  sync.WaitGroup
+	backup      *BackupHook
+	targets     []*CollectionTarget // set when job targets multiple collections
+	connections []*ConnectionTarget // set when job database or collection is template
+	sizeTracker *DocumentSizeTracker
+	gate        PauseGate
+	regions     []*config.ClientRegion // client region of every connection, nil without client regions
+	maintenance *MaintenanceScheduler
+	burst       *BurstScheduler
+	budget      *Budget // agent budget, nil if agent has no limits
+	dispatch    Dispatch
+	gauges      []string  // job gauges registered by worker
+	fairness    *Fairness // agent fairness sampling, nil if not set
+	fairReport  []string  // set when job finishes
 }
 
-func NewWorker(ctx context.Context, cfg *config.Config, job *config.Job, dataPool schema.DataPool, runningAgents uint64) (*Worker, error) {
-	// todo: check errors
+func NewWorker(ctx context.Context, cfg *config.Config, job *config.Job, dataPool schema.DataPool, runningAgents uint64) (_ *Worker, err error) {
 	worker := new(Worker)
-	worker.ctx = ctx
+	// every operation is derived from run-scoped context, cancelled on stop
+	worker.ctx, worker.cancel = context.WithCancel(ctx)
+	defer func() {
+		if err != nil {
+			worker.discard()
+		}
+	}()
 	worker.cfg = cfg
 	worker.job = job
+	// malformed window fails before limiters, gauges and database connection are set up
+	if len(job.PauseWindows) > 0 {
+		if worker.maintenance, err = NewMaintenanceScheduler(job, &worker.gate); err != nil {
+			return nil, err
+		}
+	}
+	if job.Type == string(config.Noise) {
+		applyNoiseDefaults(job)
+	}
 	worker.wg.Add(int(job.Connections))
 	worker.pool = NewJobPool(job)
-	worker.rateLimiter = NewLimiter(job.Pace / runningAgents)
+	if job.PaceInterval > 0 {
+		// every connection issues operations on its own interval
+		worker.intervals = make([]*IntervalLimiter, job.Connections)
+		for i := range worker.intervals {
+			worker.intervals[i] = NewIntervalLimiter(worker.ctx, job.PaceInterval)
+		}
+		worker.rateLimiter = NewNoLimitLimiter()
+		worker.dispatch.SetRate(float64(job.Connections) / job.PaceInterval.Seconds())
+	} else if len(job.RateProfile) > 0 {
+		// validated with config
+		profile, _ := config.JobRateProfile(job)
+		worker.ramp = NewRampLimiter(profile, runningAgents, func(rate uint64) { worker.dispatch.SetRate(float64(rate)) })
+		worker.rateLimiter = worker.ramp
+		worker.jobGauge(fmt.Sprintf(`job_target_rps{job="%s",run_id="%s"}`, job.Name, job.RunId), worker.dispatch.Rate)
+	} else {
+		worker.rateLimiter = NewLimiter(job.Pace / runningAgents)
+		if job.PaceBurst > 0 && job.Pace > 0 {
+			worker.rateLimiter = NewTokenBucketLimiter(worker.ctx, job.Pace/runningAgents, job.PaceBurst/runningAgents)
+		}
+		if job.PaceJitter > 0 && job.Pace > 0 {
+			worker.rateLimiter = NewJitterLimiter(worker.rateLimiter, job.Pace/runningAgents, job.PaceJitter, job.MaxPace/runningAgents)
+		}
+		worker.dispatch.SetRate(float64(job.Pace / runningAgents))
+	}
 	worker.Metrics = NewMetrics(job)
+	worker.jobGauge(fmt.Sprintf(`job_paused{job="%s",run_id="%s"}`, job.Name, job.RunId), func() float64 {
+		return lo.Ternary(worker.gate.IsPaused(), 1.0, 0.0)
+	})
 	worker.done = false
 	jobSchema := cfg.GetSchema(job.Schema)
+	if job.FanoutCollections > 0 {
+		applyFanout(job, jobSchema)
+	}
 	// introduce no db worker
 	if job.Type != string(config.Sleep) {
 		db, err := database.NewMongoClient(cfg.ConnectionString, job, jobSchema)
@@ -47,61 +125,337 @@ func NewWorker(ctx context.Context, cfg *config.Config, job *config.Job, dataPoo
 			return nil, err
 		}
 		worker.db = db
+		if len(job.Collections) > 0 {
+			if worker.targets, err = NewCollectionTargets(job, db, dataPool, jobSchema); err != nil {
+				return nil, err
+			}
+			// pre-run steps and background tasks operate on first collection
+			worker.db = db.WithCollection(job.Collections[0])
+		}
+		if job.IsTargetTemplated() {
+			// pre-run steps and background tasks operate on target of first connection
+			if worker.connections, err = NewConnectionTargets(job, db, dataPool, jobSchema); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	worker.dataPool = dataPool
-	worker.handler = NewJobHandler(job, worker.db, dataPool, jobSchema)
+	handler, err := NewJobHandler(job, worker.db, dataPool, jobSchema)
+	if err != nil {
+		return nil, err
+	}
+	worker.handler = handler
+	if job.FanoutCollections > 0 {
+		// fan-out handler meters collection targets itself
+		worker.handler = NewFanoutHandler(job, worker.targets, schema.NewDataProvider(job, jobSchema), dataPool)
+	}
+	if job.ExplainInterval > 0 && worker.db != nil {
+		worker.sampler = NewPlanSampler(job, worker.db, schema.NewDataProvider(job, jobSchema))
+	}
+	if job.DisturbanceInterval > 0 && worker.db != nil {
+		worker.disturber = NewDisturber(job, worker.db)
+	}
+	if job.BackupAt > 0 && worker.db != nil {
+		worker.backup = NewBackupHook(job, worker.db, worker.Metrics, cfg.ConnectionString)
+	}
+	if cfg.Agent != nil && cfg.Agent.ArtifactsDir != "" && job.Type != string(config.Sleep) {
+		latencyLog, err := NewLatencyLog(ArtifactPath(cfg.Agent.ArtifactsDir, job, LatencyLogArtifact))
+		if err != nil {
+			return nil, fmt.Errorf("creating latency log failed: %w", err)
+		}
+		worker.Metrics.latencyLog = latencyLog
+	}
+	if job.BurstDuration > 0 {
+		worker.burst = NewBurstScheduler(job, &worker.gate, worker.Metrics)
+	}
+	if job.Type == string(config.DocumentGrowth) {
+		worker.sizeTracker = NewDocumentSizeTracker(job, worker.db)
+	}
+	worker.regions = AssignClientRegions(job.ClientRegions, job.Connections)
 	return worker, nil
 }
 
 func (w *Worker) Work(agents chan uint64) {
 	fmt.Printf("Starting job: %s\n", lo.If(w.job.Name != "", w.job.Name).Else(w.job.Type))
+	if w.job.Warmup > 0 {
+		fmt.Printf("Warming up for %s, stats are recorded after warm-up\n", w.job.Warmup)
+	}
+	for _, region := range w.job.ClientRegions {
+		connections := lo.Count(w.regions, region)
+		fmt.Printf("Client region %s: %d connections, latency %s ± %s\n", region.Name, connections, region.Latency, region.Jitter)
+	}
 	// something wrong with context propagation change this
 	go func() {
 		for {
 			runningAgents := <-agents
+			if w.ramp != nil {
+				w.ramp.SetAgents(runningAgents)
+				continue
+			}
 			rate := w.job.Pace / runningAgents
 			fmt.Println("new rps rate: ", rate)
 			w.rateLimiter.SetRate(rate)
+			if w.job.PaceInterval == 0 {
+				w.dispatch.SetRate(float64(rate))
+			}
 		}
 	}()
 
+	// background tasks run for the time of job, db is disconnected only after they finish
+	background, stopBackground := context.WithCancel(w.ctx)
+	defer func() {
+		stopBackground()
+		w.background.Wait()
+	}()
+	for _, task := range []BackgroundTask{w.sampler, w.disturber, w.backup, w.sizeTracker, w.maintenance, w.burst} {
+		if !lo.IsNil(task) {
+			w.background.Add(1)
+			go func(task BackgroundTask) {
+				defer w.background.Done()
+				task.Run(background)
+			}(task)
+		}
+	}
+
+	w.fairness.Add(w)
 	for i := 0; i < int(w.job.Connections); i++ {
+		handler := w.handler
+		if len(w.connections) > 0 {
+			handler = w.connections[i].handler
+		}
+		var region *config.ClientRegion
+		if len(w.regions) > 0 {
+			region = w.regions[i]
+		}
+		limiter := w.rateLimiter
+		if len(w.intervals) > 0 {
+			limiter = w.intervals[i]
+		}
 		go func() {
 			defer w.wg.Done()
-			for w.pool.SpawnJob() {
-				w.rateLimiter.Take()
+			for {
+				ok, warmingUp := w.spawn()
+				if !ok {
+					return
+				}
+				w.gate.Wait(w.ctx)
+				w.budget.Throttle(w.ctx)
+				waitStart := time.Now()
+				limiter.Take()
+				w.dispatch.Waited(time.Since(waitStart))
+				// simulated network round trip is not part of measured operation latency
+				SimulateNetwork(w.ctx, region)
+				if w.ctx.Err() != nil {
+					return
+				}
 				// perform operation
+				atomic.AddInt64(&w.inFlight, 1)
+				start := time.Now()
+				if warmingUp {
+					// warm-up operations are not metered
+					warmupHandler := handler
+					if len(w.targets) > 0 && w.job.FanoutCollections == 0 {
+						warmupHandler = pickTarget(w.targets).handler
+					}
+					w.execute(warmupHandler)
+				} else if len(w.targets) > 0 && w.job.FanoutCollections == 0 {
+					target := pickTarget(w.targets)
+					w.Metrics.Meter(func() error { return target.Meter(func() error { return w.execute(target.handler) }) })
+				} else {
+					w.Metrics.Meter(func() error { return w.execute(handler) })
+				}
+				w.dispatch.Done(time.Since(start))
+				atomic.AddInt64(&w.inFlight, -1)
 
-				w.Metrics.Meter(w.handler.Execute)
-
-				w.pool.MarkJobDone()
+				if !warmingUp {
+					w.pool.MarkJobDone()
+				}
 			}
 		}()
 	}
 	w.wg.Wait()
+	w.fairReport = w.fairness.Remove(w)
+	w.closeHandlers()
 	w.done = true
 }
 
+// spawn starts next operation of connection, warm-up operations don't count to job operations
+func (w *Worker) spawn() (ok bool, warmingUp bool) {
+	if w.Metrics.IsWarmingUp() {
+		return w.pool.StopReason() == "", true
+	}
+	return w.pool.SpawnJob(), false
+}
+
+// execute runs single operation of handler, failed operations are logged on debug level
+func (w *Worker) execute(handler JobHandler) error {
+	err := handler.Execute(w.ctx)
+	if err != nil && !errors.Is(err, errNotMetered) && w.ctx.Err() == nil && log.IsLevelEnabled(log.DebugLevel) {
+		log.WithField("run_id", w.job.RunId).Debugf("Job %s operation failed: %s", w.job.Name, err)
+	}
+	return err
+}
+
+// closeHandlers releases resources held by handlers between operations, ex. change streams
+func (w *Worker) closeHandlers() {
+	handlers := []JobHandler{w.handler}
+	for _, target := range w.targets {
+		handlers = append(handlers, target.handler)
+	}
+	for _, target := range w.connections {
+		handlers = append(handlers, target.handler)
+	}
+	for _, handler := range handlers {
+		if closer, ok := handler.(io.Closer); ok {
+			closer.Close()
+		}
+	}
+}
+
+// Prepare runs pre-run steps of job, index build, cache preparation and expected index verification
+func (w *Worker) Prepare() error {
+	if err := w.CreateIndexes(); err != nil {
+		return err
+	}
+	if err := w.PrepareCache(); err != nil {
+		return err
+	}
+	return w.VerifyIndex()
+}
+
+// PrepareCache warms cache reading whole job collection, or runs cache command clearing it (cold cache),
+// comparing results of warm and cold runs is meaningless, mode is added to job metrics labels
+func (w *Worker) PrepareCache() error {
+	switch w.job.CacheMode {
+	case config.CacheModeWarm:
+		start := time.Now()
+		documents, err := w.db.Touch(w.ctx)
+		if err != nil {
+			return fmt.Errorf("warming cache failed: %w", err)
+		}
+		fmt.Printf("Warmed cache with %d documents in %s\n", documents, time.Since(start).Round(time.Millisecond))
+	case config.CacheModeCold:
+		if err := runHookCommand(w.ctx, w.job, w.cfg.ConnectionString, w.job.CacheCommand); err != nil {
+			return fmt.Errorf("cache command failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// VerifyIndex explains sample generated filters and fails when any of them is not served by job expected index,
+// so collection scan is detected before it invalidates whole benchmark
+func (w *Worker) VerifyIndex() error {
+	if w.job.ExpectedIndex == "" {
+		return nil
+	}
+	dataProvider := schema.NewDataProvider(w.job, w.cfg.GetSchema(w.job.Schema))
+	for i := 0; i < ExplainSamples; i++ {
+		filter := dataProvider.GetFilter()
+		plan, err := w.db.Explain(w.ctx, filter)
+		if err != nil {
+			return fmt.Errorf("explaining filter %v failed: %w", filter, err)
+		}
+		if !plan.UsesIndex(w.job.ExpectedIndex) {
+			return fmt.Errorf(
+				"filter %v is not served by index %s, plan stages: %v, indexes: %v",
+				filter, w.job.ExpectedIndex, plan.Stages, plan.Indexes,
+			)
+		}
+	}
+	return nil
+}
+
 func (w *Worker) InitMetrics() {
 	w.Metrics.Init()
 }
 
+// ResetMetrics starts new measurement window of running job, operations count and duration limits are not affected
+func (w *Worker) ResetMetrics() {
+	w.Metrics.Reset()
+	for _, target := range w.targets {
+		target.Reset()
+	}
+}
+
 // todo: fix wrong place invalid
 func (w *Worker) ExtendCopySavedFieldsToDataPool() {
-	if w.dataPool != nil && (w.job.Type == string(config.Write) || w.job.Type == string(config.BulkWrite)) {
+	writes := w.job.Type == string(config.Write) || w.job.Type == string(config.BulkWrite) || w.job.Type == string(config.SchemaEvolution) ||
+		(w.job.Type == string(config.Mixed) && w.job.OperationMix[string(config.Write)] > 0)
+	if w.dataPool != nil && writes {
 		w.dataPool.ExtendGeneratorMapperFields(schema.DefaultGeneratorFieldMapper)
 	}
 }
 
+// Usage returns measured workload footprint, written bytes are approximated with size of sample document
+func (w *Worker) Usage() cost.Usage {
+	usage := cost.Usage{
+		Operations: w.Metrics.Requests(),
+		Duration:   w.Metrics.Duration(),
+	}
+
+	var documentsPerOperation uint64
+	switch w.job.Type {
+	case string(config.Write), string(config.SchemaEvolution):
+		documentsPerOperation = 1
+	case string(config.BulkWrite):
+		documentsPerOperation = BatchSize(w.job)
+	default:
+		return usage
+	}
+	sample, err := bson.Marshal(schema.NewDataProvider(w.job, w.cfg.GetSchema(w.job.Schema)).GetSingleItem())
+	if err == nil {
+		usage.BytesWritten = usage.Operations * documentsPerOperation * uint64(len(sample))
+	}
+	return usage
+}
+
 func (w *Worker) Summary() {
 	// w.Report.Summary(nil)
 }
 
-func (w *Worker) Cancel() {
+// Cancel stops spawning new operations and waits grace period for in-flight ones,
+// operations still running after grace period are cancelled and returned as abandoned
+func (w *Worker) Cancel(gracePeriod time.Duration) (abandoned uint64) {
 	fmt.Printf("Task canceled\n")
 	w.pool.Cancel()
+	// paused operations are released to notice cancellation
+	w.gate.ResumeAll()
+
+	finished := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+	case <-time.After(gracePeriod):
+		abandoned = uint64(atomic.LoadInt64(&w.inFlight))
+		w.cancel()
+		<-finished
+	}
+	w.cancel()
 	w.Close()
+	return abandoned
+}
+
+// Preempt stops spawning operations until Resume, in-flight operations are finished,
+// paused time counts to job duration
+func (w *Worker) Preempt() bool {
+	return w.gate.Pause(PauseReasonPreempted)
+}
+
+func (w *Worker) Resume() bool {
+	return w.gate.Resume(PauseReasonPreempted)
+}
+
+func (w *Worker) IsPaused() bool {
+	return w.gate.IsPaused()
+}
+
+func (w *Worker) Priority() uint64 {
+	return w.job.Priority
 }
 
 func (w *Worker) IsDone() bool {
@@ -110,11 +464,40 @@ func (w *Worker) IsDone() bool {
 
 func (w *Worker) Close() {
 	w.done = true
+	// cancelled worker, wait for background tasks cleanup before disconnecting
+	w.background.Wait()
 	if w.job.Type != string(config.Sleep) {
 		w.db.Disconnect()
 	}
-	if w.ticker != nil {
-		w.ticker.Stop()
+	for _, interval := range w.intervals {
+		interval.Stop()
+	}
+	if w.Metrics.latencyLog != nil {
+		if err := w.Metrics.latencyLog.Close(); err != nil {
+			log.WithField("run_id", w.job.RunId).Warnf("Job %s saving latency log failed: %s", w.job.Name, err)
+		}
+		// cancelled worker is closed twice
+		w.Metrics.latencyLog = nil
+	}
+}
+
+// jobGauge registers job gauge of worker, unregistered when worker fails to start
+func (w *Worker) jobGauge(name string, f func() float64) {
+	jobGauge(w.job, name, f)
+	w.gauges = append(w.gauges, name)
+}
+
+// discard releases resources of worker which failed to start
+func (w *Worker) discard() {
+	w.cancel()
+	for _, interval := range w.intervals {
+		interval.Stop()
+	}
+	if w.db != nil {
+		w.db.Disconnect()
+	}
+	for _, name := range w.gauges {
+		jobMetricsSet(w.job, name).UnregisterMetric(name)
 	}
 }
 
@@ -122,6 +505,117 @@ func (w *Worker) JobName() string {
 	return w.job.Name
 }
 
+// StopReason returns condition which finished job, empty while job is running
+func (w *Worker) StopReason() string {
+	return w.pool.StopReason()
+}
+
+// Leaks returns cursors and sessions left open by job handler, nil for jobs without database
+func (w *Worker) Leaks() *database.Leaks {
+	if w.db == nil {
+		return nil
+	}
+	leaks := w.db.Leaks()
+	return &leaks
+}
+
+// PlanChanges returns query plan changes observed by explain sampling, nil when sampling is disabled
+func (w *Worker) PlanChanges() []PlanChange {
+	if w.sampler == nil {
+		return nil
+	}
+	return w.sampler.Changes()
+}
+
+// CollectionReport returns stats of every job collection, nil when job targets single collection
+func (w *Worker) CollectionReport() []string {
+	if len(w.targets) == 0 {
+		return nil
+	}
+	report := make([]string, 0, len(w.targets))
+	for _, target := range w.targets {
+		report = append(report, target.Summary(w.Metrics.Duration()))
+	}
+	return report
+}
+
+// BackupReport returns job metrics around backup window, nil when backup was not triggered
+func (w *Worker) BackupReport() []string {
+	if w.backup == nil {
+		return nil
+	}
+	return w.backup.Report()
+}
+
+// DocumentSizeReport returns average document size over run, nil for jobs other than document growth
+func (w *Worker) DocumentSizeReport() []string {
+	if w.sizeTracker == nil {
+		return nil
+	}
+	return w.sizeTracker.Report()
+}
+
+// BurstReport returns stats of every burst, nil for jobs without bursts
+func (w *Worker) BurstReport() []string {
+	if w.burst == nil {
+		return nil
+	}
+	return w.burst.Report()
+}
+
+// FairnessReport returns share of agent job received while running in parallel with other jobs and
+// rate limiter starvation, nil until job finishes
+func (w *Worker) FairnessReport() []string {
+	return w.fairReport
+}
+
+// Topology captures cluster snapshot with job client, nil for jobs without database
+func (w *Worker) Topology() (*database.Topology, error) {
+	if w.db == nil {
+		return nil, nil
+	}
+	return w.db.Topology(w.ctx)
+}
+
+func (w *Worker) IsCancelled() bool {
+	return w.pool.StopReason() == StopReasonCancelled
+}
+
+func (w *Worker) JobType() string {
+	return w.job.Type
+}
+
+func (w *Worker) SetWorkload(workloadId string, commandId string) {
+	w.workloadId = workloadId
+	w.commandId = commandId
+}
+
+// SetBudget makes worker operations throttled by agent budget
+func (w *Worker) SetBudget(budget *Budget) {
+	w.budget = budget
+}
+
+// SetFairness makes worker dispatch sampled by agent fairness
+func (w *Worker) SetFairness(fairness *Fairness) {
+	w.fairness = fairness
+}
+
+func (w *Worker) WorkloadId() string {
+	return w.workloadId
+}
+
+func (w *Worker) CommandId() string {
+	return w.commandId
+}
+
+func (w *Worker) RunId() string {
+	return w.job.RunId
+}
+
+func (w *Worker) Tenant() string {
+	return w.job.Tenant
+}
+
 func (w *Worker) RequestedOperations() uint64 {
 	return w.job.Operations
 }